@@ -0,0 +1,272 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// common holds the flags of every command.
+type common struct {
+	venue   string
+	output  string
+	config  string
+	baseURL string
+	timeout time.Duration
+	debug   bool
+}
+
+func newFlagSet(name, arguments string, stderr io.Writer) (*flag.FlagSet, *common) {
+	fs := flag.NewFlagSet("nexapi "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	c := &common{}
+	fs.StringVar(&c.venue, "venue", os.Getenv("NEXAPI_VENUE"), "venue: "+strings.Join(venueNames(), ", ")+" (default $NEXAPI_VENUE)")
+	fs.StringVar(&c.output, "o", "table", "output format: table or json")
+	fs.StringVar(&c.config, "config", "", "config file (default $NEXAPI_CONFIG or nexapi/config.json in the user config directory)")
+	fs.StringVar(&c.baseURL, "base-url", "", "REST base URL of the venue, e.g. a testnet")
+	fs.DurationVar(&c.timeout, "timeout", 10*time.Second, "timeout of a request")
+	fs.BoolVar(&c.debug, "debug", false, "log the requests to stderr")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: nexapi %s [flags] %s\n\nFlags:\n", name, arguments)
+		fs.PrintDefaults()
+	}
+
+	return fs, c
+}
+
+// parse parses the flags wherever they appear among the arguments and returns the arguments.
+func parse(fs *flag.FlagSet, c *common, args []string) ([]string, error) {
+	var ret []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, errFlags
+		}
+
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		ret = append(ret, args[0])
+		args = args[1:]
+	}
+
+	if c.output != "table" && c.output != "json" {
+		return nil, usageError(fmt.Sprintf("unknown output format %q, use table or json", c.output))
+	}
+
+	return ret, nil
+}
+
+// exec opens the venue, calls fn and writes its result.
+func (c *common) exec(stdout, stderr io.Writer, fn func(v venue) (*result, error)) error {
+	v, err := openVenue(c, stderr)
+	if err != nil {
+		return err
+	}
+
+	res, err := fn(v)
+	if err != nil {
+		return err
+	}
+
+	return res.write(stdout, c.output)
+}
+
+func oneArg(args []string, name string) (string, error) {
+	if len(args) != 1 {
+		return "", usageError("expected one " + name + " argument")
+	}
+
+	return args[0], nil
+}
+
+func runTicker(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs, c := newFlagSet("ticker", "SYMBOL", stderr)
+	args, err := parse(fs, c, args)
+	if err != nil {
+		return err
+	}
+
+	symbol, err := oneArg(args, "SYMBOL")
+	if err != nil {
+		return err
+	}
+
+	return c.exec(stdout, stderr, func(v venue) (*result, error) {
+		return v.Ticker(ctx, symbol)
+	})
+}
+
+func runBook(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs, c := newFlagSet("book", "SYMBOL", stderr)
+	depth := fs.Int("depth", 20, "number of levels per side")
+	args, err := parse(fs, c, args)
+	if err != nil {
+		return err
+	}
+
+	symbol, err := oneArg(args, "SYMBOL")
+	if err != nil {
+		return err
+	}
+	if *depth <= 0 {
+		return usageError("-depth must be positive")
+	}
+
+	return c.exec(stdout, stderr, func(v venue) (*result, error) {
+		return v.Book(ctx, symbol, *depth)
+	})
+}
+
+func runKlines(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs, c := newFlagSet("klines", "SYMBOL", stderr)
+	interval := fs.String("interval", "1m", "candle interval: "+strings.Join(intervalNames(), ", "))
+	limit := fs.Int("limit", 100, "number of candles")
+	args, err := parse(fs, c, args)
+	if err != nil {
+		return err
+	}
+
+	symbol, err := oneArg(args, "SYMBOL")
+	if err != nil {
+		return err
+	}
+	if _, ok := intervals[*interval]; !ok {
+		return usageError(fmt.Sprintf("unknown interval %q, use one of %s", *interval, strings.Join(intervalNames(), ", ")))
+	}
+	if *limit <= 0 {
+		return usageError("-limit must be positive")
+	}
+
+	return c.exec(stdout, stderr, func(v venue) (*result, error) {
+		return v.Klines(ctx, symbol, *interval, *limit)
+	})
+}
+
+func runBalance(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs, c := newFlagSet("balance", "", stderr)
+	currency := fs.String("currency", "", "only show this currency")
+	args, err := parse(fs, c, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) != 0 {
+		return usageError("balance takes no argument")
+	}
+
+	return c.exec(stdout, stderr, func(v venue) (*result, error) {
+		return v.Balance(ctx, *currency)
+	})
+}
+
+func runPositions(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs, c := newFlagSet("positions", "[SYMBOL]", stderr)
+	args, err := parse(fs, c, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 1 {
+		return usageError("expected at most one SYMBOL argument")
+	}
+	var symbol string
+	if len(args) == 1 {
+		symbol = args[0]
+	}
+
+	return c.exec(stdout, stderr, func(v venue) (*result, error) {
+		return v.Positions(ctx, symbol)
+	})
+}
+
+func runOrder(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		return usageError("expected place, cancel or get")
+	}
+
+	switch args[0] {
+	case "place":
+		return runPlaceOrder(ctx, args[1:], stdout, stderr)
+	case "cancel", "get":
+		return runOrderID(ctx, args[0], args[1:], stdout, stderr)
+	default:
+		return usageError(fmt.Sprintf("unknown order command %q, use place, cancel or get", args[0]))
+	}
+}
+
+func runPlaceOrder(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs, c := newFlagSet("order place", "", stderr)
+	o := &orderRequest{}
+	fs.StringVar(&o.Symbol, "symbol", "", "symbol (required)")
+	fs.StringVar(&o.Side, "side", "", "buy or sell (required)")
+	fs.StringVar(&o.Type, "type", "limit", "limit or market")
+	fs.StringVar(&o.Qty, "qty", "", "quantity, in contracts for the futures venues (required)")
+	fs.StringVar(&o.Price, "price", "", "limit price")
+	fs.StringVar(&o.ClientID, "client-id", "", "client order id")
+	fs.StringVar(&o.Margin, "margin", "", "margin mode of a derivative: cross or isolated")
+	fs.IntVar(&o.Leverage, "leverage", 0, "leverage of a derivative order")
+	fs.BoolVar(&o.ReduceOnly, "reduce-only", false, "only reduce the position of a derivative")
+	args, err := parse(fs, c, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) != 0 {
+		return usageError("order place takes no argument, use the flags")
+	}
+	if err := o.validate(); err != nil {
+		return err
+	}
+
+	return c.exec(stdout, stderr, func(v venue) (*result, error) {
+		return v.PlaceOrder(ctx, o)
+	})
+}
+
+func runOrderID(ctx context.Context, name string, args []string, stdout, stderr io.Writer) error {
+	fs, c := newFlagSet("order "+name, "ORDER_ID", stderr)
+	symbol := fs.String("symbol", "", "symbol, required by mexc, okx and kucoin")
+	args, err := parse(fs, c, args)
+	if err != nil {
+		return err
+	}
+
+	id, err := oneArg(args, "ORDER_ID")
+	if err != nil {
+		return err
+	}
+
+	return c.exec(stdout, stderr, func(v venue) (*result, error) {
+		if name == "cancel" {
+			return v.CancelOrder(ctx, *symbol, id)
+		}
+		return v.GetOrder(ctx, *symbol, id)
+	})
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// credentials of an exchange account, the spot and the futures venues of an
+// exchange share them.
+type credentials struct {
+	Key        string `json:"key"`
+	Secret     string `json:"secret"`
+	Passphrase string `json:"passphrase,omitempty"`
+	// KeyVersion is the KuCoin API key version, the default is 2
+	KeyVersion string `json:"key_version,omitempty"`
+	// Demo sends the OKX requests to the demo trading
+	Demo bool `json:"demo,omitempty"`
+}
+
+func envName(exchange, field string) string {
+	return "NEXAPI_" + strings.ToUpper(exchange) + "_" + field
+}
+
+// loadCredentials reads the exchange section of the config file, then overrides
+// its fields with the environment variables. The config file is path, $NEXAPI_CONFIG
+// or nexapi/config.json in the user config directory, only the latter may not exist.
+func loadCredentials(path, exchange string) (*credentials, error) {
+	if path == "" {
+		path = os.Getenv("NEXAPI_CONFIG")
+	}
+	optional := false
+	if path == "" {
+		if dir, err := os.UserConfigDir(); err == nil {
+			path = filepath.Join(dir, "nexapi", "config.json")
+			optional = true
+		}
+	}
+
+	creds := &credentials{}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			var file map[string]*credentials
+			if err := json.Unmarshal(data, &file); err != nil {
+				return nil, fmt.Errorf("config %s: %w", path, err)
+			}
+			if c := file[exchange]; c != nil {
+				creds = c
+			}
+		case errors.Is(err, fs.ErrNotExist) && optional:
+		default:
+			return nil, err
+		}
+	}
+
+	for field, value := range map[string]*string{
+		"KEY":         &creds.Key,
+		"SECRET":      &creds.Secret,
+		"PASSPHRASE":  &creds.Passphrase,
+		"KEY_VERSION": &creds.KeyVersion,
+	} {
+		if v, ok := os.LookupEnv(envName(exchange, field)); ok {
+			*value = v
+		}
+	}
+
+	return creds, nil
+}
@@ -0,0 +1,416 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	futuresaccount "github.com/rluisr/nexapi/kucoin/futures/account"
+	futuresaccounttypes "github.com/rluisr/nexapi/kucoin/futures/account/types"
+	futuresmd "github.com/rluisr/nexapi/kucoin/futures/marketdata"
+	futuresmdtypes "github.com/rluisr/nexapi/kucoin/futures/marketdata/types"
+	"github.com/rluisr/nexapi/kucoin/rest/account"
+	accounttypes "github.com/rluisr/nexapi/kucoin/rest/account/types"
+	"github.com/rluisr/nexapi/kucoin/rest/hftrade"
+	hftradetypes "github.com/rluisr/nexapi/kucoin/rest/hftrade/types"
+	kucoinutils "github.com/rluisr/nexapi/kucoin/rest/utils"
+	"github.com/rluisr/nexapi/utils/candle"
+)
+
+// kucoinKeyVersion returns the API key version of the credentials, the default is 2.
+func kucoinKeyVersion(creds *credentials) string {
+	if creds.KeyVersion != "" {
+		return creds.KeyVersion
+	}
+
+	return kucoinutils.ApiKeyVersionV2
+}
+
+// kucoinClientOid returns the client order id of the request, a futures order
+// needs one.
+func kucoinClientOid(o *orderRequest) string {
+	if o.ClientID != "" {
+		return o.ClientID
+	}
+
+	return "nexapi" + strconv.FormatInt(time.Now().UnixNano(), 10)
+}
+
+// kucoinSpot trades the spot HF account, KuCoin spot market data is not supported.
+type kucoinSpot struct {
+	unsupported
+	cfg *venueConfig
+}
+
+func newKucoinSpot(cfg *venueConfig) (venue, error) {
+	return &kucoinSpot{unsupported: unsupported(cfg.name), cfg: cfg}, nil
+}
+
+func (k *kucoinSpot) account() (*account.AccountClient, error) {
+	if err := k.cfg.require(true); err != nil {
+		return nil, err
+	}
+
+	return account.NewAccountClient(&account.AccountClientCfg{
+		BaseURL:    k.cfg.url(kucoinutils.SpotBaseURL),
+		HTTPClient: k.cfg.client,
+		Key:        k.cfg.creds.Key,
+		KeyVersion: kucoinKeyVersion(k.cfg.creds),
+		Secret:     k.cfg.creds.Secret,
+		Passphrase: k.cfg.creds.Passphrase,
+		Debug:      k.cfg.debug,
+		Logger:     k.cfg.logger,
+	})
+}
+
+func (k *kucoinSpot) trade() (*hftrade.HFTradeClient, error) {
+	if err := k.cfg.require(true); err != nil {
+		return nil, err
+	}
+
+	return hftrade.NewHFTradeClient(&hftrade.HFTradeClientCfg{
+		BaseURL:    k.cfg.url(kucoinutils.SpotBaseURL),
+		HTTPClient: k.cfg.client,
+		Key:        k.cfg.creds.Key,
+		KeyVersion: kucoinKeyVersion(k.cfg.creds),
+		Secret:     k.cfg.creds.Secret,
+		Passphrase: k.cfg.creds.Passphrase,
+		Debug:      k.cfg.debug,
+		Logger:     k.cfg.logger,
+	})
+}
+
+func (k *kucoinSpot) Balance(ctx context.Context, currency string) (*result, error) {
+	cli, err := k.account()
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := cli.GetAccountList(ctx, accounttypes.GetAccountListParam{Currency: strings.ToUpper(currency)})
+	if err != nil {
+		return nil, err
+	}
+
+	// a currency has one account per account type
+	res := newResult(accounts, []string{"ASSET", "ACCOUNT", "AVAILABLE", "FROZEN", "TOTAL"})
+	for _, a := range accounts {
+		res.add(a.Currency, a.Type, a.Available, a.Holds, a.Balance)
+	}
+
+	return res, nil
+}
+
+func (k *kucoinSpot) PlaceOrder(ctx context.Context, o *orderRequest) (*result, error) {
+	if o.Margin != "" || o.Leverage != 0 || o.ReduceOnly {
+		return nil, usageError(k.cfg.name + " does not support -margin, -leverage and -reduce-only")
+	}
+
+	cli, err := k.trade()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := cli.PlaceOrder(ctx, hftradetypes.PlaceOrderParam{
+		ClientOid: o.ClientID,
+		Symbol:    o.Symbol,
+		Type:      o.Type,
+		Side:      o.Side,
+		Price:     o.Price,
+		Size:      o.Qty,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := newResult(resp, orderHeader)
+	res.add(resp.OrderID, resp.ClientOid, o.Symbol, o.Side, o.Type, o.Price, o.Qty, "", "", "")
+
+	return res, nil
+}
+
+func (k *kucoinSpot) CancelOrder(ctx context.Context, symbol, id string) (*result, error) {
+	if err := k.requireSymbol(symbol); err != nil {
+		return nil, err
+	}
+
+	cli, err := k.trade()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := cli.CancelOrder(ctx, hftradetypes.CancelOrderParam{OrderID: id, Symbol: symbol})
+	if err != nil {
+		return nil, err
+	}
+
+	res := newResult(resp, orderHeader)
+	res.add(resp.OrderID, "", symbol, "", "", "", "", "", "canceled", "")
+
+	return res, nil
+}
+
+func (k *kucoinSpot) GetOrder(ctx context.Context, symbol, id string) (*result, error) {
+	if err := k.requireSymbol(symbol); err != nil {
+		return nil, err
+	}
+
+	cli, err := k.trade()
+	if err != nil {
+		return nil, err
+	}
+
+	o, err := cli.GetOrder(ctx, hftradetypes.GetOrderParam{OrderID: id, Symbol: symbol})
+	if err != nil {
+		return nil, err
+	}
+
+	status := "done"
+	if o.Active {
+		status = "active"
+	}
+	res := newResult(o, orderHeader)
+	res.add(o.ID, o.ClientOid, o.Symbol, o.Side, o.Type, o.Price, o.Size, o.DealSize, status, formatMillis(o.CreatedAt))
+
+	return res, nil
+}
+
+type kucoinFutures struct {
+	unsupported
+	cfg *venueConfig
+	md  *futuresmd.FuturesMarketDataClient
+}
+
+func newKucoinFutures(cfg *venueConfig) (venue, error) {
+	md, err := futuresmd.NewFuturesMarketDataClient(&futuresmd.FuturesMarketDataClientCfg{
+		BaseURL:    cfg.url(kucoinutils.FuturesBaseURL),
+		HTTPClient: cfg.client,
+		Debug:      cfg.debug,
+		Logger:     cfg.logger,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &kucoinFutures{unsupported: unsupported(cfg.name), cfg: cfg, md: md}, nil
+}
+
+func (k *kucoinFutures) account() (*futuresaccount.FuturesAccountClient, error) {
+	if err := k.cfg.require(true); err != nil {
+		return nil, err
+	}
+
+	return futuresaccount.NewFuturesAccountClient(&futuresaccount.FuturesAccountClientCfg{
+		BaseURL:    k.cfg.url(kucoinutils.FuturesBaseURL),
+		HTTPClient: k.cfg.client,
+		Key:        k.cfg.creds.Key,
+		KeyVersion: kucoinKeyVersion(k.cfg.creds),
+		Secret:     k.cfg.creds.Secret,
+		Passphrase: k.cfg.creds.Passphrase,
+		Debug:      k.cfg.debug,
+		Logger:     k.cfg.logger,
+	})
+}
+
+func (k *kucoinFutures) Ticker(ctx context.Context, symbol string) (*result, error) {
+	t, err := k.md.GetTicker(ctx, futuresmdtypes.GetTickerParam{Symbol: symbol})
+	if err != nil {
+		return nil, err
+	}
+
+	// the ticker has no volume, its time is in nanoseconds
+	res := newResult(t, tickerHeader)
+	res.add(t.Symbol, t.Price, t.BestBidPrice, t.BestAskPrice, "", formatTime(time.Unix(0, t.Ts)))
+
+	return res, nil
+}
+
+func (k *kucoinFutures) Book(ctx context.Context, symbol string, depth int) (*result, error) {
+	// the partial order book has 20 or 100 levels
+	levels := 20
+	if depth > levels {
+		levels = 100
+	}
+
+	ob, err := k.md.GetPartOrderBook(ctx, futuresmdtypes.GetPartOrderBookParam{Symbol: symbol, Depth: levels})
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := ob.Book()
+	if err != nil {
+		return nil, err
+	}
+
+	return bookResult(ob, b, depth), nil
+}
+
+func (k *kucoinFutures) Klines(ctx context.Context, symbol, interval string, limit int) (*result, error) {
+	d := intervals[interval]
+	klines, err := k.md.GetKlines(ctx, futuresmdtypes.GetKlinesParam{
+		Symbol:      symbol,
+		Granularity: int(d / time.Minute),
+		From:        time.Now().Add(-time.Duration(limit) * d).UnixMilli(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	candles := make([]*candle.Candle, 0, len(klines))
+	for _, kl := range klines {
+		openTime := time.UnixMilli(kl.Time)
+		candles = append(candles, &candle.Candle{
+			OpenTime: openTime,
+			Interval: d,
+			Open:     kl.Open,
+			High:     kl.High,
+			Low:      kl.Low,
+			Close:    kl.Close,
+			Volume:   kl.Volume,
+			Closed:   !now.Before(openTime.Add(d)),
+		})
+	}
+
+	return candlesResult(klines, candles, limit), nil
+}
+
+func (k *kucoinFutures) Balance(ctx context.Context, currency string) (*result, error) {
+	if currency == "" {
+		currency = "USDT"
+	}
+
+	cli, err := k.account()
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := cli.GetAccountOverview(ctx, futuresaccounttypes.GetAccountOverviewParam{Currency: strings.ToUpper(currency)})
+	if err != nil {
+		return nil, err
+	}
+
+	res := newResult(a, balanceHeader)
+	res.add(a.Currency, formatFloat(a.AvailableBalance), formatFloat(a.PositionMargin+a.OrderMargin+a.FrozenFunds), formatFloat(a.AccountEquity))
+
+	return res, nil
+}
+
+func (k *kucoinFutures) Positions(ctx context.Context, symbol string) (*result, error) {
+	cli, err := k.account()
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := cli.GetPositions(ctx, futuresaccounttypes.GetPositionsParam{})
+	if err != nil {
+		return nil, err
+	}
+
+	open := make([]*futuresaccounttypes.Position, 0, len(positions))
+	res := newResult(nil, positionHeader)
+	for _, p := range positions {
+		if !p.IsOpen || (symbol != "" && p.Symbol != symbol) {
+			continue
+		}
+
+		side, size := "long", p.CurrentQty
+		if size < 0 {
+			side, size = "short", -size
+		}
+		open = append(open, p)
+		res.add(p.Symbol, side, strconv.FormatInt(size, 10), formatFloat(p.AvgEntryPrice), formatFloat(p.MarkPrice), formatFloat(p.UnrealisedPnl), formatFloat(p.LiquidationPrice), formatFloat(p.RealLeverage))
+	}
+	res.raw = open
+
+	return res, nil
+}
+
+func (k *kucoinFutures) PlaceOrder(ctx context.Context, o *orderRequest) (*result, error) {
+	size, err := strconv.ParseInt(o.Qty, 10, 64)
+	if err != nil {
+		return nil, usageError(k.cfg.name + " -qty is a number of lots: " + o.Qty)
+	}
+
+	param := futuresaccounttypes.PlaceOrderParam{
+		ClientOid:  kucoinClientOid(o),
+		Side:       o.Side,
+		Symbol:     o.Symbol,
+		Type:       o.Type,
+		ReduceOnly: o.ReduceOnly,
+		MarginMode: strings.ToUpper(o.Margin),
+		Price:      o.Price,
+		Size:       size,
+	}
+	if o.Leverage != 0 {
+		param.Leverage = strconv.Itoa(o.Leverage)
+	}
+
+	cli, err := k.account()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := cli.PlaceOrder(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newResult(resp, orderHeader)
+	res.add(resp.OrderID, resp.ClientOid, o.Symbol, o.Side, o.Type, o.Price, o.Qty, "", "", "")
+
+	return res, nil
+}
+
+func (k *kucoinFutures) CancelOrder(ctx context.Context, _, id string) (*result, error) {
+	cli, err := k.account()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := cli.CancelOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newResult(resp, orderHeader)
+	for _, id := range resp.CancelledOrderIDs {
+		res.add(id, "", "", "", "", "", "", "", "canceled", "")
+	}
+
+	return res, nil
+}
+
+func (k *kucoinFutures) GetOrder(ctx context.Context, _, id string) (*result, error) {
+	cli, err := k.account()
+	if err != nil {
+		return nil, err
+	}
+
+	o, err := cli.GetOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newResult(o, orderHeader)
+	res.add(o.ID, o.ClientOid, o.Symbol, o.Side, o.Type, o.Price, strconv.FormatInt(o.Size, 10), strconv.FormatInt(o.FilledSize, 10), o.Status, formatMillis(o.CreatedAt))
+
+	return res, nil
+}
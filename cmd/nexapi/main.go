@@ -0,0 +1,143 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Nexapi queries the market data, the balances, the positions and the orders of
+// the supported venues from the command line.
+//
+// Usage:
+//
+//	nexapi <command> [flags] [arguments]
+//
+// The commands are:
+//
+//	ticker SYMBOL                 last price and best bid and ask
+//	book [-depth N] SYMBOL        order book
+//	klines [-interval 1m] SYMBOL  candles
+//	balance                       account balances
+//	positions [SYMBOL]            open positions
+//	order place                   place an order
+//	order cancel ORDER_ID         cancel an order
+//	order get ORDER_ID            order details
+//
+// Every command takes -venue (mexc, mexc-contract, okx, kucoin or kucoin-futures,
+// the default is $NEXAPI_VENUE), -o (table or json), -config, -base-url, -timeout
+// and -debug. The flags may follow the arguments. The json output is the response
+// of the venue as is, the table output only shows the common columns.
+//
+// The credentials are read from the config file, a JSON object keyed by exchange:
+//
+//	{"okx": {"key": "...", "secret": "...", "passphrase": "..."}}
+//
+// The file is $NEXAPI_CONFIG or nexapi/config.json in the user config directory.
+// The NEXAPI_<EXCHANGE>_KEY, _SECRET, _PASSPHRASE and _KEY_VERSION environment
+// variables override it, e.g. NEXAPI_OKX_KEY. The spot and the futures venues of
+// an exchange share the credentials.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+)
+
+// errFlags is returned once the flag package reported the error and the usage.
+var errFlags = errors.New("invalid flags")
+
+// A usageError is a missing or invalid argument, the exit code is 2.
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, args []string, stdout, stderr io.Writer) error
+}
+
+var commands = []*command{
+	{"ticker", "last price and best bid and ask", runTicker},
+	{"book", "order book", runBook},
+	{"klines", "candles", runKlines},
+	{"balance", "account balances", runBalance},
+	{"positions", "open positions", runPositions},
+	{"order", "place, cancel or get an order", runOrder},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command line and returns the exit code.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+
+	name := args[0]
+	if name == "help" || name == "-h" || name == "-help" || name == "--help" {
+		usage(stdout)
+		return 0
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+
+		err := cmd.run(ctx, args[1:], stdout, stderr)
+		var uerr usageError
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.Is(err, errFlags):
+			return 2
+		case errors.As(err, &uerr):
+			fmt.Fprintf(stderr, "nexapi %s: %s\n", name, uerr)
+			return 2
+		default:
+			fmt.Fprintf(stderr, "nexapi %s: %s\n", name, err)
+			return 1
+		}
+	}
+
+	fmt.Fprintf(stderr, "nexapi: unknown command %q\n", name)
+	usage(stderr)
+	return 2
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: nexapi <command> [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'nexapi <command> -h' for the flags of a command.")
+}
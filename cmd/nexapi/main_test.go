@@ -0,0 +1,217 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rluisr/nexapi/kucoin/kucointest"
+	"github.com/rluisr/nexapi/mexc/contract/contracttest"
+	"github.com/rluisr/nexapi/mexc/spot/spottest"
+	"github.com/rluisr/nexapi/okx/okxtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// testConfig isolates the test from the environment, config is the content of the
+// config file.
+func testConfig(t *testing.T, config string) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("NEXAPI_CONFIG", path)
+	t.Setenv("NEXAPI_VENUE", "")
+
+	for _, exchange := range []string{"mexc", "okx", "kucoin"} {
+		for _, field := range []string{"KEY", "SECRET", "PASSPHRASE", "KEY_VERSION"} {
+			t.Setenv(envName(exchange, field), "")
+			os.Unsetenv(envName(exchange, field))
+		}
+	}
+}
+
+func testRun(t *testing.T, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, &stdout, &stderr)
+
+	return code, stdout.String(), stderr.String()
+}
+
+func TestTicker(t *testing.T) {
+	testConfig(t, "{}")
+	srv := spottest.NewServer()
+	t.Cleanup(srv.Close)
+
+	srv.Handle(http.MethodGet, "/api/v3/ticker/24hr", `{"symbol":"BTCUSDT","lastPrice":"42100","bidPrice":"42099.9","askPrice":"42100.1","volume":"1234.5","closeTime":1704153600000}`)
+
+	// the flags may follow the arguments
+	code, stdout, stderr := testRun(t, "ticker", "BTCUSDT", "-venue", "mexc", "-base-url", srv.URL)
+	assert.Equal(t, 0, code, stderr)
+	assert.Equal(t, "BTCUSDT", srv.LastRequest().Query.Get("symbol"))
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	assert.Len(t, lines, 2)
+	assert.Equal(t, []string{"SYMBOL", "LAST", "BID", "ASK", "VOLUME", "TIME"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"BTCUSDT", "42100", "42099.9", "42100.1", "1234.5", "2024-01-02T00:00:00.000Z"}, strings.Fields(lines[1]))
+}
+
+func TestBook(t *testing.T) {
+	testConfig(t, "{}")
+	srv := okxtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	srv.Handle(http.MethodGet, "/api/v5/market/books", `{"code":"0","msg":"","data":[{"asks":[["42001","2","0","1"],["42002","3","0","1"]],"bids":[["42000","1","0","1"],["41999","4","0","1"]],"ts":"1704067200000"}]}`)
+
+	code, stdout, stderr := testRun(t, "book", "-venue", "okx", "-base-url", srv.URL, "-depth", "1", "BTC-USDT")
+	assert.Equal(t, 0, code, stderr)
+	assert.Equal(t, "1", srv.LastRequest().Query.Get("sz"))
+	assert.Equal(t, "SIDE  PRICE  SIZE\nask   42001  2\nbid   42000  1\n", stdout)
+
+	// the json output is the response of the venue
+	code, stdout, stderr = testRun(t, "book", "-venue", "okx", "-base-url", srv.URL, "-o", "json", "BTC-USDT")
+	assert.Equal(t, 0, code, stderr)
+
+	var ob struct {
+		Asks [][]string `json:"asks"`
+		TS   string     `json:"ts"`
+	}
+	assert.Nil(t, json.Unmarshal([]byte(stdout), &ob))
+	assert.Equal(t, "42002", ob.Asks[1][0])
+	assert.Equal(t, "1704067200000", ob.TS)
+}
+
+func TestKlines(t *testing.T) {
+	testConfig(t, "{}")
+	srv := okxtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	// newest first
+	srv.Handle(http.MethodGet, "/api/v5/market/candles", `{"code":"0","msg":"","data":[["1704070800000","2","4","1","3","10","20","30","0"],["1704067200000","1","2","0.5","2","5","10","15","1"]]}`)
+
+	code, stdout, stderr := testRun(t, "klines", "-venue", "okx", "-base-url", srv.URL, "-interval", "1h", "-limit", "2", "BTC-USDT")
+	assert.Equal(t, 0, code, stderr)
+	assert.Equal(t, "1H", srv.LastRequest().Query.Get("bar"))
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, []string{"2024-01-01T00:00:00.000Z", "1", "2", "0.5", "2", "5"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"2024-01-01T01:00:00.000Z", "2", "4", "1", "3", "10"}, strings.Fields(lines[2]))
+
+	code, _, stderr = testRun(t, "klines", "-venue", "okx", "-interval", "2m", "BTC-USDT")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "unknown interval")
+}
+
+func TestCredentials(t *testing.T) {
+	testConfig(t, `{"kucoin":{"key":"`+kucointest.Key+`","secret":"wrong","passphrase":"`+kucointest.Passphrase+`"}}`)
+	srv := kucointest.NewServer()
+	t.Cleanup(srv.Close)
+
+	srv.Handle(http.MethodGet, "/api/v1/accounts", `{"code":"200000","data":[{"id":"1","currency":"USDT","type":"trade","balance":"100","available":"90","holds":"10"}]}`)
+
+	// the environment overrides the config file
+	t.Setenv("NEXAPI_KUCOIN_SECRET", kucointest.Secret)
+	t.Setenv("NEXAPI_VENUE", "kucoin")
+
+	code, stdout, stderr := testRun(t, "balance", "-base-url", srv.URL)
+	assert.Equal(t, 0, code, stderr)
+	assert.True(t, srv.LastRequest().Signed)
+	assert.Equal(t, []string{"USDT", "trade", "90", "10", "100"}, strings.Fields(strings.Split(stdout, "\n")[1]))
+
+	t.Setenv("NEXAPI_KUCOIN_SECRET", "")
+	code, _, stderr = testRun(t, "balance", "-base-url", srv.URL)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "NEXAPI_KUCOIN_SECRET")
+}
+
+func TestOrder(t *testing.T) {
+	testConfig(t, `{"mexc":{"key":"`+contracttest.Key+`","secret":"`+contracttest.Secret+`"}}`)
+	srv := contracttest.NewServer()
+	t.Cleanup(srv.Close)
+
+	srv.Handle(http.MethodPost, "/api/v1/private/order/submit", `{"success":true,"code":0,"data":12345}`)
+	srv.Handle(http.MethodPost, "/api/v1/private/order/cancel", `{"success":true,"code":0,"data":[{"orderId":12345,"errorCode":0,"errorMsg":"success"}]}`)
+	srv.Handle(http.MethodGet, "/api/v1/private/order/get/12345", `{"success":true,"code":0,"data":{"orderId":"12345","symbol":"BTC_USDT","price":42000,"vol":2,"side":4,"orderType":1,"dealVol":1,"state":2,"createTime":1704067200000}}`)
+
+	code, stdout, stderr := testRun(t, "order", "place", "-venue", "mexc-contract", "-base-url", srv.URL,
+		"-symbol", "BTC_USDT", "-side", "sell", "-qty", "2", "-price", "42000", "-reduce-only")
+	assert.Equal(t, 0, code, stderr)
+	assert.True(t, strings.HasPrefix(strings.Split(stdout, "\n")[1], "12345 "))
+
+	var body map[string]any
+	assert.Nil(t, srv.LastRequest().JSON(&body))
+	assert.Equal(t, float64(4), body["side"])
+	assert.Equal(t, float64(1), body["type"])
+	assert.Equal(t, float64(2), body["openType"])
+
+	code, stdout, stderr = testRun(t, "order", "get", "-venue", "mexc-contract", "-base-url", srv.URL, "12345")
+	assert.Equal(t, 0, code, stderr)
+	assert.Equal(t, []string{"12345", "BTC_USDT", "close", "long", "limit", "42000", "2", "1", "uncompleted", "2024-01-01T00:00:00.000Z"}, strings.Fields(strings.Split(stdout, "\n")[1]))
+
+	code, stdout, stderr = testRun(t, "order", "cancel", "12345", "-venue", "mexc-contract", "-base-url", srv.URL)
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "cancelled")
+
+	srv.Handle(http.MethodPost, "/api/v1/private/order/cancel", `{"success":true,"code":0,"data":[{"orderId":12345,"errorCode":2041,"errorMsg":"order state cannot be cancelled"}]}`)
+	code, _, stderr = testRun(t, "order", "cancel", "12345", "-venue", "mexc-contract", "-base-url", srv.URL)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "order state cannot be cancelled")
+
+	for _, r := range srv.Requests() {
+		assert.True(t, r.Signed)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	testConfig(t, "{}")
+
+	tests := []struct {
+		args   []string
+		code   int
+		stderr string
+	}{
+		{nil, 2, "Usage: nexapi"},
+		{[]string{"quote"}, 2, "unknown command"},
+		{[]string{"ticker", "BTCUSDT"}, 2, "-venue is required"},
+		{[]string{"ticker", "-venue", "binance", "BTCUSDT"}, 2, "unknown venue"},
+		{[]string{"ticker", "-venue", "mexc"}, 2, "expected one SYMBOL argument"},
+		{[]string{"ticker", "-venue", "mexc", "-o", "csv", "BTCUSDT"}, 2, "unknown output format"},
+		{[]string{"ticker", "-venue", "kucoin", "BTC-USDT"}, 1, "kucoin does not support ticker"},
+		{[]string{"positions", "-venue", "mexc"}, 1, "mexc does not support positions"},
+		{[]string{"order", "cancel", "-venue", "okx", "1"}, 2, "okx needs -symbol"},
+		{[]string{"order", "place", "-venue", "okx", "-symbol", "BTC-USDT", "-side", "buy", "-qty", "1"}, 2, "-price is required"},
+		{[]string{"book", "-depth"}, 2, "flag needs an argument"},
+	}
+
+	for _, tt := range tests {
+		code, _, stderr := testRun(t, tt.args...)
+		assert.Equal(t, tt.code, code, tt.args)
+		assert.Contains(t, stderr, tt.stderr, tt.args)
+	}
+
+	code, stdout, _ := testRun(t, "help")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "positions")
+}
@@ -0,0 +1,524 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	contractaccount "github.com/rluisr/nexapi/mexc/contract/account"
+	contractaccounttypes "github.com/rluisr/nexapi/mexc/contract/account/types"
+	contractmd "github.com/rluisr/nexapi/mexc/contract/marketdata"
+	contractmdtypes "github.com/rluisr/nexapi/mexc/contract/marketdata/types"
+	contractutils "github.com/rluisr/nexapi/mexc/contract/utils"
+	spotmd "github.com/rluisr/nexapi/mexc/spot/marketdata"
+	spotmdtypes "github.com/rluisr/nexapi/mexc/spot/marketdata/types"
+	"github.com/rluisr/nexapi/mexc/spot/spotaccount"
+	spotaccounttypes "github.com/rluisr/nexapi/mexc/spot/spotaccount/types"
+	spotutils "github.com/rluisr/nexapi/mexc/spot/utils"
+	"github.com/rluisr/nexapi/utils/candle"
+	"github.com/rluisr/nexapi/utils/decimal"
+)
+
+var mexcSpotIntervals = map[string]spotutils.KlineInterval{
+	"1m":  spotutils.Minute1,
+	"5m":  spotutils.Minute5,
+	"15m": spotutils.Minute15,
+	"30m": spotutils.Minute30,
+	"1h":  spotutils.Minute60,
+	"4h":  spotutils.Hour4,
+	"1d":  spotutils.Day1,
+}
+
+type mexcSpot struct {
+	unsupported
+	cfg *venueConfig
+	md  *spotmd.SpotMarketDataClient
+}
+
+func newMexcSpot(cfg *venueConfig) (venue, error) {
+	md, err := spotmd.NewSpotMarketDataClient(&spotutils.SpotClientCfg{
+		BaseURL:    cfg.url(spotutils.BaseURL),
+		HTTPClient: cfg.client,
+		Debug:      cfg.debug,
+		Logger:     cfg.logger,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &mexcSpot{unsupported: unsupported(cfg.name), cfg: cfg, md: md}, nil
+}
+
+func (m *mexcSpot) account() (*spotaccount.SpotAccountClient, error) {
+	if err := m.cfg.require(false); err != nil {
+		return nil, err
+	}
+
+	return spotaccount.NewSpotAccountClient(&spotaccount.SpotAccountClientCfg{
+		BaseURL:    m.cfg.url(spotutils.BaseURL),
+		HTTPClient: m.cfg.client,
+		Key:        m.cfg.creds.Key,
+		Secret:     m.cfg.creds.Secret,
+		Debug:      m.cfg.debug,
+		Logger:     m.cfg.logger,
+	})
+}
+
+func (m *mexcSpot) Ticker(ctx context.Context, symbol string) (*result, error) {
+	t, err := m.md.GetTickerForSymbol(ctx, spotmdtypes.GetTickerForSymbolParam{Symbol: symbol})
+	if err != nil {
+		return nil, err
+	}
+
+	res := newResult(t, tickerHeader)
+	res.add(t.Symbol, t.LastPrice, t.BidPrice, t.AskPrice, t.Volume, formatMillis(t.CloseTime))
+
+	return res, nil
+}
+
+func (m *mexcSpot) Book(ctx context.Context, symbol string, depth int) (*result, error) {
+	ob, err := m.md.GetOrderbook(ctx, spotmdtypes.GetOrderbookParams{Symbol: symbol, Limit: depth})
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := ob.Book()
+	if err != nil {
+		return nil, err
+	}
+
+	return bookResult(ob, b, depth), nil
+}
+
+func (m *mexcSpot) Klines(ctx context.Context, symbol, interval string, limit int) (*result, error) {
+	klines, err := m.md.GetKlines(ctx, spotmdtypes.GetKlineParam{
+		Symbol:   symbol,
+		Interval: mexcSpotIntervals[interval],
+		Limit:    limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	candles := make([]*candle.Candle, 0, len(klines))
+	for _, k := range klines {
+		c, err := k.Candle(now)
+		if err != nil {
+			return nil, err
+		}
+		candles = append(candles, c)
+	}
+
+	return candlesResult(klines, candles, limit), nil
+}
+
+func (m *mexcSpot) Balance(ctx context.Context, currency string) (*result, error) {
+	acc, err := m.account()
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := acc.GetAccountInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make([]spotaccounttypes.Balance, 0, len(info.Balances))
+	res := newResult(nil, balanceHeader)
+	for _, b := range info.Balances {
+		if currency != "" && !strings.EqualFold(b.Asset, currency) {
+			continue
+		}
+
+		free, err := b.FreeDecimal()
+		if err != nil {
+			return nil, err
+		}
+		locked, err := b.LockedDecimal()
+		if err != nil {
+			return nil, err
+		}
+
+		balances = append(balances, b)
+		res.add(b.Asset, b.Free, b.Locked, free.Add(locked).String())
+	}
+	res.raw = balances
+
+	return res, nil
+}
+
+func (m *mexcSpot) PlaceOrder(ctx context.Context, o *orderRequest) (*result, error) {
+	if o.ClientID != "" || o.Margin != "" || o.Leverage != 0 || o.ReduceOnly {
+		return nil, usageError(m.cfg.name + " only supports -symbol, -side, -type, -qty and -price")
+	}
+
+	acc, err := m.account()
+	if err != nil {
+		return nil, err
+	}
+
+	param := spotaccounttypes.CreateOrderParam{
+		Symbol: o.Symbol,
+		Side:   strings.ToUpper(o.Side),
+		Type:   strings.ToUpper(o.Type),
+	}
+	if param.Quantity, err = decimal.NewFromString(o.Qty); err != nil {
+		return nil, usageError("invalid -qty: " + o.Qty)
+	}
+	if param.Price, err = decimal.Parse(o.Price); err != nil {
+		return nil, usageError("invalid -price: " + o.Price)
+	}
+
+	resp, err := acc.CreateOrder(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newResult(resp, orderHeader)
+	res.add(resp.OrderID, "", resp.Symbol, resp.Side, resp.Type, resp.Price, resp.OrigQty, "", "", formatMillis(resp.TransactTime))
+
+	return res, nil
+}
+
+func (m *mexcSpot) GetOrder(ctx context.Context, symbol, id string) (*result, error) {
+	if err := m.requireSymbol(symbol); err != nil {
+		return nil, err
+	}
+
+	acc, err := m.account()
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := acc.QueryOrder(ctx, spotaccounttypes.QueryOrderParam{Symbol: symbol, OrderID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	res := newResult(order, orderHeader)
+	res.add(order.OrderID, order.ClientOrderID, order.Symbol, order.Side, order.Type, order.Price, order.OrigQty, order.ExecutedQty, order.Status, formatMillis(order.Time))
+
+	return res, nil
+}
+
+var mexcContractIntervals = map[string]contractutils.KlineInterval{
+	"1m":  contractutils.Minute1,
+	"5m":  contractutils.Minute5,
+	"15m": contractutils.Minute15,
+	"30m": contractutils.Minute30,
+	"1h":  contractutils.Minute60,
+	"4h":  contractutils.Hour4,
+	"1d":  contractutils.Day1,
+}
+
+var (
+	mexcContractSides = map[int]string{
+		contractaccounttypes.OpenLong:   "open long",
+		contractaccounttypes.CloseShort: "close short",
+		contractaccounttypes.OpenShort:  "open short",
+		contractaccounttypes.CloseLong:  "close long",
+	}
+	mexcContractTypes = map[int]string{
+		contractaccounttypes.LimitOrder:             "limit",
+		contractaccounttypes.PostOnlyMaker:          "post only",
+		contractaccounttypes.TransactOrCancel:       "ioc",
+		contractaccounttypes.TransactAllOrCancelAll: "fok",
+		contractaccounttypes.MarketOrder:            "market",
+		contractaccounttypes.ConvertToCurrentPrice:  "convert",
+	}
+	mexcContractStates = map[int]string{
+		contractaccounttypes.OrderUninformed:  "uninformed",
+		contractaccounttypes.OrderUncompleted: "uncompleted",
+		contractaccounttypes.OrderCompleted:   "completed",
+		contractaccounttypes.OrderCancelled:   "cancelled",
+		contractaccounttypes.OrderInvalid:     "invalid",
+	}
+)
+
+type mexcContract struct {
+	unsupported
+	cfg *venueConfig
+	md  *contractmd.ContractMarketDataClient
+}
+
+func newMexcContract(cfg *venueConfig) (venue, error) {
+	md, err := contractmd.NewContractMarketDataClient(&contractutils.ContractClientCfg{
+		BaseURL:    cfg.url(contractutils.BaseURL),
+		HTTPClient: cfg.client,
+		Debug:      cfg.debug,
+		Logger:     cfg.logger,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &mexcContract{unsupported: unsupported(cfg.name), cfg: cfg, md: md}, nil
+}
+
+func (m *mexcContract) account() (*contractaccount.ContractAccountClient, error) {
+	if err := m.cfg.require(false); err != nil {
+		return nil, err
+	}
+
+	return contractaccount.NewContractAccountClient(&contractutils.ContractClientCfg{
+		BaseURL:    m.cfg.url(contractutils.BaseURL),
+		HTTPClient: m.cfg.client,
+		Key:        m.cfg.creds.Key,
+		Secret:     m.cfg.creds.Secret,
+		Debug:      m.cfg.debug,
+		Logger:     m.cfg.logger,
+	})
+}
+
+func (m *mexcContract) Ticker(ctx context.Context, symbol string) (*result, error) {
+	resp, err := m.md.GetTickerForSymbol(ctx, contractmdtypes.GetTickerForSymbolParam{Symbol: symbol})
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Err(); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, fmt.Errorf("no ticker for %s", symbol)
+	}
+
+	t := resp.Data
+	res := newResult(t, tickerHeader)
+	res.add(t.Symbol, formatFloat(t.LastPrice), formatFloat(t.Bid1), formatFloat(t.Ask1), formatFloat(t.Volume24), formatMillis(t.Timestamp))
+
+	return res, nil
+}
+
+func (m *mexcContract) Book(ctx context.Context, symbol string, depth int) (*result, error) {
+	resp, err := m.md.GetDepth(ctx, contractmdtypes.GetDepthParam{Symbol: symbol, Limit: depth})
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Err(); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, fmt.Errorf("no order book for %s", symbol)
+	}
+
+	b, err := resp.Data.Book()
+	if err != nil {
+		return nil, err
+	}
+
+	return bookResult(resp.Data, b, depth), nil
+}
+
+func (m *mexcContract) Klines(ctx context.Context, symbol, interval string, limit int) (*result, error) {
+	d := intervals[interval]
+	resp, err := m.md.GetKlines(ctx, contractmdtypes.GetKlineParam{
+		Symbol:   symbol,
+		Interval: mexcContractIntervals[interval],
+		Start:    time.Now().Add(-time.Duration(limit) * d).Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Err(); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, fmt.Errorf("no klines for %s", symbol)
+	}
+
+	klines, err := resp.Data.Klines()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	candles := make([]*candle.Candle, 0, len(klines))
+	for _, k := range klines {
+		candles = append(candles, k.Candle(d, now))
+	}
+
+	return candlesResult(resp.Data, candles, limit), nil
+}
+
+func (m *mexcContract) Balance(ctx context.Context, currency string) (*result, error) {
+	acc, err := m.account()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := acc.GetAccountAssets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Err(); err != nil {
+		return nil, err
+	}
+
+	assets := make([]*contractaccounttypes.ContractAsset, 0, len(resp.Data))
+	res := newResult(nil, balanceHeader)
+	for _, a := range resp.Data {
+		if currency != "" && !strings.EqualFold(a.Currency, currency) {
+			continue
+		}
+
+		assets = append(assets, a)
+		res.add(a.Currency, formatFloat(a.AvailableBalance), formatFloat(a.FrozenBalance), formatFloat(a.Equity))
+	}
+	res.raw = assets
+
+	return res, nil
+}
+
+func (m *mexcContract) Positions(ctx context.Context, symbol string) (*result, error) {
+	acc, err := m.account()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := acc.GetOpenPositions(ctx, contractaccounttypes.GetOpenPositionsParams{Symbol: symbol})
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Err(); err != nil {
+		return nil, err
+	}
+
+	res := newResult(resp.Data, positionHeader)
+	for _, p := range resp.Data {
+		side := "long"
+		if p.PositionType == 2 {
+			side = "short"
+		}
+		res.add(p.Symbol, side, p.HoldVol.String(), p.HoldAvgPrice.String(), "", "", p.LiquidatePrice.String(), strconv.Itoa(p.Leverage))
+	}
+
+	return res, nil
+}
+
+func (m *mexcContract) PlaceOrder(ctx context.Context, o *orderRequest) (*result, error) {
+	param := contractaccounttypes.NewOrderParam{
+		Symbol:      o.Symbol,
+		Leverage:    o.Leverage,
+		Type:        contractaccounttypes.LimitOrder,
+		OpenType:    contractaccounttypes.CrossMargin,
+		ExternalOid: o.ClientID,
+	}
+
+	// the side opens a position unless the order is reduce only
+	switch {
+	case o.Side == "buy" && o.ReduceOnly:
+		param.Side = contractaccounttypes.CloseShort
+	case o.Side == "buy":
+		param.Side = contractaccounttypes.OpenLong
+	case o.ReduceOnly:
+		param.Side = contractaccounttypes.CloseLong
+	default:
+		param.Side = contractaccounttypes.OpenShort
+	}
+	if o.Type == "market" {
+		param.Type = contractaccounttypes.MarketOrder
+	}
+	if o.Margin == "isolated" {
+		param.OpenType = contractaccounttypes.IsolatedMargin
+	}
+
+	var err error
+	if param.Vol, err = decimal.NewFromString(o.Qty); err != nil {
+		return nil, usageError("invalid -qty: " + o.Qty)
+	}
+	if param.Price, err = decimal.Parse(o.Price); err != nil {
+		return nil, usageError("invalid -price: " + o.Price)
+	}
+
+	acc, err := m.account()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := acc.SubmitOrder(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Err(); err != nil {
+		return nil, err
+	}
+
+	res := newResult(resp, orderHeader)
+	res.add(strconv.FormatInt(resp.Data, 10), o.ClientID, o.Symbol, mexcContractSides[param.Side], o.Type, o.Price, o.Qty, "", "", "")
+
+	return res, nil
+}
+
+func (m *mexcContract) CancelOrder(ctx context.Context, _, id string) (*result, error) {
+	orderID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, usageError("invalid ORDER_ID: " + id)
+	}
+
+	acc, err := m.account()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := acc.CancelOrders(ctx, []int64{orderID})
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Err(); err != nil {
+		return nil, err
+	}
+
+	res := newResult(resp, orderHeader)
+	for _, r := range resp.Data {
+		if r.ErrorCode != 0 {
+			return nil, fmt.Errorf("[API]Failure: code=%d message=%s", r.ErrorCode, r.ErrorMsg)
+		}
+		res.add(strconv.FormatInt(r.OrderId, 10), "", "", "", "", "", "", "", "cancelled", "")
+	}
+
+	return res, nil
+}
+
+func (m *mexcContract) GetOrder(ctx context.Context, _, id string) (*result, error) {
+	acc, err := m.account()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := acc.GetOrderByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Err(); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, fmt.Errorf("order %s not found", id)
+	}
+
+	o := resp.Data
+	res := newResult(o, orderHeader)
+	res.add(o.OrderId, o.ExternalOid, o.Symbol, mexcContractSides[o.Side], mexcContractTypes[o.OrderType], formatFloat(o.Price), formatFloat(o.Vol), formatFloat(o.DealVol), mexcContractStates[o.State], formatMillis(o.CreateTime))
+
+	return res, nil
+}
@@ -0,0 +1,342 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rluisr/nexapi/okx/orderbookaccount"
+	orderbookaccounttypes "github.com/rluisr/nexapi/okx/orderbookaccount/types"
+	"github.com/rluisr/nexapi/okx/publicdata"
+	publicdatatypes "github.com/rluisr/nexapi/okx/publicdata/types"
+	"github.com/rluisr/nexapi/okx/tradingaccount"
+	tradingaccounttypes "github.com/rluisr/nexapi/okx/tradingaccount/types"
+	okxutils "github.com/rluisr/nexapi/okx/utils"
+	"github.com/rluisr/nexapi/utils/candle"
+)
+
+var okxBars = map[string]string{
+	"1m":  "1m",
+	"5m":  "5m",
+	"15m": "15m",
+	"30m": "30m",
+	"1h":  "1H",
+	"4h":  "4H",
+	"1d":  "1Dutc",
+}
+
+// okxInstType guesses the instrument type from the instrument id:
+// BTC-USDT, BTC-USDT-SWAP, BTC-USD-240329 and BTC-USD-240329-50000-C.
+func okxInstType(instID string) publicdatatypes.InstrumentType {
+	parts := strings.Split(instID, "-")
+	switch {
+	case strings.HasSuffix(instID, "-SWAP"):
+		return publicdatatypes.Swap
+	case len(parts) == 3:
+		return publicdatatypes.Futures
+	case len(parts) == 5:
+		return publicdatatypes.Option
+	default:
+		return publicdatatypes.Spot
+	}
+}
+
+type okx struct {
+	unsupported
+	cfg *venueConfig
+	pub *publicdata.PublicDataClient
+}
+
+func newOKX(cfg *venueConfig) (venue, error) {
+	pub, err := publicdata.NewPublicDataClient(&okxutils.OKXRestClientCfg{
+		BaseURL:    cfg.url(okxutils.RestURL),
+		HTTPClient: cfg.client,
+		Debug:      cfg.debug,
+		Logger:     cfg.logger,
+		IsDemo:     cfg.creds.Demo,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &okx{unsupported: unsupported(cfg.name), cfg: cfg, pub: pub}, nil
+}
+
+func (o *okx) trading() (*tradingaccount.TradingAccountClient, error) {
+	if err := o.cfg.require(true); err != nil {
+		return nil, err
+	}
+
+	return tradingaccount.NewTradingAccountClient(&tradingaccount.TradingAccountClientCfg{
+		BaseURL:    o.cfg.url(okxutils.RestURL),
+		HTTPClient: o.cfg.client,
+		Key:        o.cfg.creds.Key,
+		Secret:     o.cfg.creds.Secret,
+		Passphrase: o.cfg.creds.Passphrase,
+		Debug:      o.cfg.debug,
+		IsDemo:     o.cfg.creds.Demo,
+		Logger:     o.cfg.logger,
+	})
+}
+
+func (o *okx) orders() (*orderbookaccount.OrderBookAccountClient, error) {
+	if err := o.cfg.require(true); err != nil {
+		return nil, err
+	}
+
+	return orderbookaccount.NewOrderBookAccountClient(&orderbookaccount.OrderBookAccountClientCfg{
+		BaseURL:    o.cfg.url(okxutils.RestURL),
+		HTTPClient: o.cfg.client,
+		Key:        o.cfg.creds.Key,
+		Secret:     o.cfg.creds.Secret,
+		Passphrase: o.cfg.creds.Passphrase,
+		Debug:      o.cfg.debug,
+		IsDemo:     o.cfg.creds.Demo,
+		Logger:     o.cfg.logger,
+	})
+}
+
+func (o *okx) Ticker(ctx context.Context, symbol string) (*result, error) {
+	resp, err := o.pub.GetMarketTickers(ctx, publicdatatypes.GetMarketTickersParam{InstType: okxInstType(symbol)})
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, t := range resp.Data {
+		if t.InstID != symbol {
+			continue
+		}
+
+		res := newResult(t, tickerHeader)
+		res.add(t.InstID, t.Last, t.BidPx, t.AskPx, t.Vol24h, formatMillisString(t.TS))
+		return res, nil
+	}
+
+	return nil, fmt.Errorf("no ticker for %s", symbol)
+}
+
+func (o *okx) Book(ctx context.Context, symbol string, depth int) (*result, error) {
+	resp, err := o.pub.GetOrderBook(ctx, publicdatatypes.GetOrderBookParam{InstID: symbol, Sz: strconv.Itoa(depth)})
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Err(); err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("no order book for %s", symbol)
+	}
+
+	b, err := resp.Data[0].Book()
+	if err != nil {
+		return nil, err
+	}
+
+	return bookResult(resp.Data[0], b, depth), nil
+}
+
+func (o *okx) Klines(ctx context.Context, symbol, interval string, limit int) (*result, error) {
+	resp, err := o.pub.GetCandles(ctx, publicdatatypes.GetCandlesParam{
+		InstID: symbol,
+		Bar:    okxBars[interval],
+		Limit:  strconv.Itoa(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Err(); err != nil {
+		return nil, err
+	}
+
+	candles := make([]*candle.Candle, 0, len(resp.Data))
+	for _, c := range resp.Data {
+		cc, err := c.Candle(intervals[interval])
+		if err != nil {
+			return nil, err
+		}
+		candles = append(candles, cc)
+	}
+
+	return candlesResult(resp.Data, candles, limit), nil
+}
+
+func (o *okx) Balance(ctx context.Context, currency string) (*result, error) {
+	cli, err := o.trading()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := cli.GetBalance(ctx, tradingaccounttypes.GetBalanceParam{Currency: strings.ToUpper(currency)})
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Err(); err != nil {
+		return nil, err
+	}
+
+	res := newResult(resp.Data, balanceHeader)
+	for _, acc := range resp.Data {
+		for _, d := range acc.Details {
+			res.add(d.Ccy, d.AvailBal, d.FrozenBal, d.Eq)
+		}
+	}
+
+	return res, nil
+}
+
+func (o *okx) Positions(ctx context.Context, symbol string) (*result, error) {
+	cli, err := o.trading()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := cli.GetPositions(ctx, tradingaccounttypes.GetPositionsParam{InstId: symbol})
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Err(); err != nil {
+		return nil, err
+	}
+
+	res := newResult(resp.Data, positionHeader)
+	for _, p := range resp.Data {
+		// the size of a net position is negative when it is short
+		side, size := p.PosSide, p.Pos
+		if side == "net" || side == "" {
+			side = "long"
+			if strings.HasPrefix(size, "-") {
+				side, size = "short", size[1:]
+			}
+		}
+		res.add(p.InstId, side, size, p.AvgPx, p.MarkPx, p.UPL, p.LiqPx, p.Lever)
+	}
+
+	return res, nil
+}
+
+// okxOrderErr returns the error of the response or of its first order.
+func okxOrderErr(resp okxutils.Response, results []*orderbookaccounttypes.OrderResult) error {
+	if len(results) > 0 && results[0].SCode != "0" {
+		return fmt.Errorf("[API]Failure: code=%s msg=%s", results[0].SCode, results[0].SMsg)
+	}
+
+	return resp.Err()
+}
+
+func (o *okx) PlaceOrder(ctx context.Context, req *orderRequest) (*result, error) {
+	param := orderbookaccounttypes.PlaceOrderParam{
+		InstId:     req.Symbol,
+		TdMode:     okxutils.Cash,
+		ClOrdId:    req.ClientID,
+		Side:       req.Side,
+		OrdType:    req.Type,
+		Sz:         req.Qty,
+		Px:         req.Price,
+		ReduceOnly: req.ReduceOnly,
+	}
+	switch {
+	case req.Margin != "":
+		param.TdMode = req.Margin
+	case okxInstType(req.Symbol) != publicdatatypes.Spot:
+		param.TdMode = okxutils.Cross
+	}
+	if req.Leverage != 0 {
+		return nil, usageError(o.cfg.name + " does not support -leverage, the leverage is set on the account")
+	}
+
+	cli, err := o.orders()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := cli.PlaceOrder(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+	if err := okxOrderErr(resp.Response, resp.Data); err != nil {
+		return nil, err
+	}
+
+	res := newResult(resp.Data, orderHeader)
+	for _, r := range resp.Data {
+		res.add(r.OrdID, r.ClOrdID, req.Symbol, req.Side, req.Type, req.Price, req.Qty, "", "", "")
+	}
+
+	return res, nil
+}
+
+func (o *okx) CancelOrder(ctx context.Context, symbol, id string) (*result, error) {
+	if err := o.requireSymbol(symbol); err != nil {
+		return nil, err
+	}
+
+	cli, err := o.orders()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := cli.CancelOrder(ctx, orderbookaccounttypes.CancelOrderParam{InstId: symbol, OrdId: id})
+	if err != nil {
+		return nil, err
+	}
+	if err := okxOrderErr(resp.Response, resp.Data); err != nil {
+		return nil, err
+	}
+
+	res := newResult(resp.Data, orderHeader)
+	for _, r := range resp.Data {
+		res.add(r.OrdID, r.ClOrdID, symbol, "", "", "", "", "", "canceled", "")
+	}
+
+	return res, nil
+}
+
+func (o *okx) GetOrder(ctx context.Context, symbol, id string) (*result, error) {
+	if err := o.requireSymbol(symbol); err != nil {
+		return nil, err
+	}
+
+	cli, err := o.orders()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := cli.GetOrder(ctx, orderbookaccounttypes.GetOrderParam{InstId: symbol, OrdId: id})
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Err(); err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("order %s not found", id)
+	}
+
+	res := newResult(resp.Data[0], orderHeader)
+	for _, r := range resp.Data {
+		res.add(r.OrdID, r.ClOrdID, r.InstID, r.Side, r.OrdType, r.Px, r.Sz, r.AccFillSz, r.State, formatMillisString(r.CTime))
+	}
+
+	return res, nil
+}
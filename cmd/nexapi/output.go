@@ -0,0 +1,143 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rluisr/nexapi/utils/book"
+	"github.com/rluisr/nexapi/utils/candle"
+)
+
+// The table headers shared by the venues.
+var (
+	tickerHeader   = []string{"SYMBOL", "LAST", "BID", "ASK", "VOLUME", "TIME"}
+	bookHeader     = []string{"SIDE", "PRICE", "SIZE"}
+	klineHeader    = []string{"TIME", "OPEN", "HIGH", "LOW", "CLOSE", "VOLUME"}
+	balanceHeader  = []string{"ASSET", "AVAILABLE", "FROZEN", "TOTAL"}
+	positionHeader = []string{"SYMBOL", "SIDE", "SIZE", "ENTRY", "MARK", "PNL", "LIQUIDATION", "LEVERAGE"}
+	orderHeader    = []string{"ID", "CLIENT_ID", "SYMBOL", "SIDE", "TYPE", "PRICE", "QTY", "FILLED", "STATUS", "TIME"}
+)
+
+// A result is written as raw, the response of the venue, in json and as rows in table.
+type result struct {
+	raw    any
+	header []string
+	rows   [][]string
+}
+
+func newResult(raw any, header []string) *result {
+	return &result{raw: raw, header: header}
+}
+
+func (r *result) add(cells ...string) {
+	r.rows = append(r.rows, cells)
+}
+
+func (r *result) write(w io.Writer, format string) error {
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r.raw)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(r.header, "\t"))
+	for _, row := range r.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+func formatMillis(ms int64) string {
+	if ms == 0 {
+		return ""
+	}
+
+	return formatTime(time.UnixMilli(ms))
+}
+
+// formatMillisString formats a timestamp in milliseconds sent as a string.
+func formatMillisString(ms string) string {
+	v, err := strconv.ParseInt(ms, 10, 64)
+	if err != nil {
+		return ms
+	}
+
+	return formatMillis(v)
+}
+
+// bookResult lists the asks from the highest price down to the bids, depth levels per side.
+func bookResult(raw any, b *book.Book, depth int) *result {
+	res := newResult(raw, bookHeader)
+
+	asks := b.Levels(book.Ask)
+	if len(asks) > depth {
+		asks = asks[:depth]
+	}
+	for i := len(asks) - 1; i >= 0; i-- {
+		res.add("ask", asks[i].Price.String(), asks[i].Size.String())
+	}
+
+	bids := b.Levels(book.Bid)
+	if len(bids) > depth {
+		bids = bids[:depth]
+	}
+	for _, l := range bids {
+		res.add("bid", l.Price.String(), l.Size.String())
+	}
+
+	return res
+}
+
+// candlesResult lists the last limit candles by ascending time.
+func candlesResult(raw any, candles []*candle.Candle, limit int) *result {
+	sort.Slice(candles, func(i, j int) bool {
+		return candles[i].OpenTime.Before(candles[j].OpenTime)
+	})
+	if len(candles) > limit {
+		candles = candles[len(candles)-limit:]
+	}
+
+	res := newResult(raw, klineHeader)
+	for _, c := range candles {
+		res.add(formatTime(c.OpenTime), formatFloat(c.Open), formatFloat(c.High), formatFloat(c.Low), formatFloat(c.Close), formatFloat(c.Volume))
+	}
+
+	return res
+}
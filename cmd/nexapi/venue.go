@@ -0,0 +1,238 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// A venue runs the commands against one exchange API, the methods a venue does
+// not support return an error.
+type venue interface {
+	Ticker(ctx context.Context, symbol string) (*result, error)
+	Book(ctx context.Context, symbol string, depth int) (*result, error)
+	Klines(ctx context.Context, symbol, interval string, limit int) (*result, error)
+	Balance(ctx context.Context, currency string) (*result, error)
+	Positions(ctx context.Context, symbol string) (*result, error)
+	PlaceOrder(ctx context.Context, o *orderRequest) (*result, error)
+	CancelOrder(ctx context.Context, symbol, id string) (*result, error)
+	GetOrder(ctx context.Context, symbol, id string) (*result, error)
+}
+
+// venueConfig is what a venue needs to create its clients.
+type venueConfig struct {
+	name string
+	// exchange is the config file section and the environment variable prefix of the credentials
+	exchange string
+	baseURL  string
+	creds    *credentials
+	client   *http.Client
+	debug    bool
+	logger   *slog.Logger
+}
+
+// url returns the base URL given by -base-url or def.
+func (c *venueConfig) url(def string) string {
+	if c.baseURL != "" {
+		return c.baseURL
+	}
+
+	return def
+}
+
+// require checks the credentials of the private endpoints.
+func (c *venueConfig) require(passphrase bool) error {
+	missing := c.creds.Key == "" || c.creds.Secret == "" || (passphrase && c.creds.Passphrase == "")
+	if !missing {
+		return nil
+	}
+
+	vars := envName(c.exchange, "KEY") + " and " + envName(c.exchange, "SECRET")
+	if passphrase {
+		vars = envName(c.exchange, "KEY") + ", " + envName(c.exchange, "SECRET") + " and " + envName(c.exchange, "PASSPHRASE")
+	}
+
+	return fmt.Errorf("%s needs API credentials, set %s or the %q section of the config file", c.name, vars, c.exchange)
+}
+
+type venueInfo struct {
+	exchange string
+	open     func(cfg *venueConfig) (venue, error)
+}
+
+var venues = map[string]venueInfo{
+	"mexc":           {"mexc", newMexcSpot},
+	"mexc-contract":  {"mexc", newMexcContract},
+	"okx":            {"okx", newOKX},
+	"kucoin":         {"kucoin", newKucoinSpot},
+	"kucoin-futures": {"kucoin", newKucoinFutures},
+}
+
+func venueNames() []string {
+	names := make([]string, 0, len(venues))
+	for name := range venues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func openVenue(c *common, stderr io.Writer) (venue, error) {
+	if c.venue == "" {
+		return nil, usageError("-venue is required")
+	}
+
+	info, ok := venues[c.venue]
+	if !ok {
+		return nil, usageError(fmt.Sprintf("unknown venue %q", c.venue))
+	}
+
+	creds, err := loadCredentials(c.config, info.exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &venueConfig{
+		name:     c.venue,
+		exchange: info.exchange,
+		baseURL:  c.baseURL,
+		creds:    creds,
+		client:   &http.Client{Timeout: c.timeout},
+		debug:    c.debug,
+	}
+	if c.debug {
+		cfg.logger = slog.New(slog.NewTextHandler(stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+
+	return info.open(cfg)
+}
+
+// unsupported is embedded by the venues, it implements every method with an error.
+type unsupported string
+
+func (u unsupported) err(what string) error {
+	return fmt.Errorf("%s does not support %s", string(u), what)
+}
+
+func (u unsupported) Ticker(context.Context, string) (*result, error) {
+	return nil, u.err("ticker")
+}
+
+func (u unsupported) Book(context.Context, string, int) (*result, error) {
+	return nil, u.err("book")
+}
+
+func (u unsupported) Klines(context.Context, string, string, int) (*result, error) {
+	return nil, u.err("klines")
+}
+
+func (u unsupported) Balance(context.Context, string) (*result, error) {
+	return nil, u.err("balance")
+}
+
+func (u unsupported) Positions(context.Context, string) (*result, error) {
+	return nil, u.err("positions")
+}
+
+func (u unsupported) PlaceOrder(context.Context, *orderRequest) (*result, error) {
+	return nil, u.err("order place")
+}
+
+func (u unsupported) CancelOrder(context.Context, string, string) (*result, error) {
+	return nil, u.err("order cancel")
+}
+
+func (u unsupported) GetOrder(context.Context, string, string) (*result, error) {
+	return nil, u.err("order get")
+}
+
+// requireSymbol is used by the venues that need the symbol of an order.
+func (u unsupported) requireSymbol(symbol string) error {
+	if symbol == "" {
+		return usageError(string(u) + " needs -symbol")
+	}
+
+	return nil
+}
+
+// intervals are the candle intervals of the klines command, every venue maps them
+// to its own values.
+var intervals = map[string]time.Duration{
+	"1m":  time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"30m": 30 * time.Minute,
+	"1h":  time.Hour,
+	"4h":  4 * time.Hour,
+	"1d":  24 * time.Hour,
+}
+
+func intervalNames() []string {
+	names := make([]string, 0, len(intervals))
+	for name := range intervals {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return intervals[names[i]] < intervals[names[j]]
+	})
+
+	return names
+}
+
+// An orderRequest is the order of the order place command.
+type orderRequest struct {
+	Symbol     string
+	Side       string
+	Type       string
+	Qty        string
+	Price      string
+	ClientID   string
+	Margin     string
+	Leverage   int
+	ReduceOnly bool
+}
+
+func (o *orderRequest) validate() error {
+	switch {
+	case o.Symbol == "":
+		return usageError("-symbol is required")
+	case o.Side != "buy" && o.Side != "sell":
+		return usageError("-side must be buy or sell")
+	case o.Type != "limit" && o.Type != "market":
+		return usageError("-type must be limit or market")
+	case o.Qty == "":
+		return usageError("-qty is required")
+	case o.Type == "limit" && o.Price == "":
+		return usageError("-price is required for a limit order")
+	case o.Type == "market" && o.Price != "":
+		return usageError("-price is not allowed for a market order")
+	case o.Margin != "" && o.Margin != "cross" && o.Margin != "isolated":
+		return usageError("-margin must be cross or isolated")
+	case o.Leverage < 0:
+		return usageError("-leverage must be positive")
+	}
+
+	return nil
+}
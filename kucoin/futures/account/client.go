@@ -0,0 +1,817 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/rluisr/nexapi/kucoin/futures/account/types"
+	"github.com/rluisr/nexapi/kucoin/rest/utils"
+)
+
+type FuturesAccountClient struct {
+	cli *utils.KucoinClient
+
+	// validate struct fields
+	validate *validator.Validate
+}
+
+type FuturesAccountClientCfg struct {
+	Debug bool
+	// Logger
+	Logger *slog.Logger
+
+	BaseURL    string `validate:"required"`
+	HTTPClient *http.Client
+	Key        string `validate:"required"`
+	KeyVersion string `validate:"required"`
+	Secret     string `validate:"required"`
+	Passphrase string `validate:"required"`
+}
+
+func NewFuturesAccountClient(cfg *FuturesAccountClientCfg) (*FuturesAccountClient, error) {
+	validator := validator.New()
+
+	err := validator.Struct(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := utils.NewKucoinRestClient(&utils.KucoinClientCfg{
+		Debug:      cfg.Debug,
+		Logger:     cfg.Logger,
+		BaseURL:    cfg.BaseURL,
+		HTTPClient: cfg.HTTPClient,
+		Key:        cfg.Key,
+		KeyVersion: cfg.KeyVersion,
+		Secret:     cfg.Secret,
+		Passphrase: cfg.Passphrase,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &FuturesAccountClient{
+		cli:      cli,
+		validate: validator,
+	}, nil
+}
+
+// GetAccountOverview gets the account overview of the futures account.
+func (f *FuturesAccountClient) GetAccountOverview(ctx context.Context, param types.GetAccountOverviewParam) (*types.AccountOverview, error) {
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    "/api/v1/account-overview",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := f.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := f.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.AccountOverview
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetPosition gets the position details of a specified symbol.
+func (f *FuturesAccountClient) GetPosition(ctx context.Context, param types.GetPositionParam) (*types.Position, error) {
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    "/api/v1/position",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := f.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := f.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.Position
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetPositions gets the position details of all symbols.
+func (f *FuturesAccountClient) GetPositions(ctx context.Context, param types.GetPositionsParam) ([]*types.Position, error) {
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    "/api/v1/positions",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := f.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := f.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret []*types.Position
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// SetAutoDepositStatus enables or disables auto deposit margin of an isolated position.
+func (f *FuturesAccountClient) SetAutoDepositStatus(ctx context.Context, param types.SetAutoDepositStatusParam) (bool, error) {
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    "/api/v1/position/margin/auto-deposit-status",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return false, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := f.validate.Struct(param)
+		if err != nil {
+			return false, err
+		}
+
+		h, err := f.cli.GenSignature(req)
+		if err != nil {
+			return false, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return false, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return false, errors.New(resp.Error())
+	}
+
+	var ret bool
+	if err := ar.ReadData(&ret); err != nil {
+		return false, err
+	}
+
+	return ret, nil
+}
+
+// AddMargin adds margin manually to an isolated position.
+func (f *FuturesAccountClient) AddMargin(ctx context.Context, param types.AddMarginParam) (*types.Position, error) {
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    "/api/v1/position/margin/deposit-margin",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := f.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := f.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.Position
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetMarginMode gets the margin mode of a symbol.
+func (f *FuturesAccountClient) GetMarginMode(ctx context.Context, param types.GetMarginModeParam) (*types.MarginModeResult, error) {
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    "/api/v2/position/getMarginMode",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := f.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := f.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.MarginModeResult
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// ChangeMarginMode modifies the margin mode of a symbol, it can not be changed when the symbol has positions or open orders.
+func (f *FuturesAccountClient) ChangeMarginMode(ctx context.Context, param types.ChangeMarginModeParam) (*types.MarginModeResult, error) {
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    "/api/v2/position/changeMarginMode",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := f.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := f.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.MarginModeResult
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetCrossLeverage gets the leverage of a symbol in cross margin mode.
+func (f *FuturesAccountClient) GetCrossLeverage(ctx context.Context, param types.GetCrossLeverageParam) (*types.CrossLeverage, error) {
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    "/api/v2/getCrossUserLeverage",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := f.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := f.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.CrossLeverage
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// ChangeCrossLeverage modifies the leverage of a symbol in cross margin mode.
+func (f *FuturesAccountClient) ChangeCrossLeverage(ctx context.Context, param types.ChangeCrossLeverageParam) (bool, error) {
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    "/api/v2/changeCrossUserLeverage",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return false, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := f.validate.Struct(param)
+		if err != nil {
+			return false, err
+		}
+
+		h, err := f.cli.GenSignature(req)
+		if err != nil {
+			return false, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return false, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return false, errors.New(resp.Error())
+	}
+
+	var ret bool
+	if err := ar.ReadData(&ret); err != nil {
+		return false, err
+	}
+
+	return ret, nil
+}
+
+// PlaceOrder places an order in the futures trading system.
+func (f *FuturesAccountClient) PlaceOrder(ctx context.Context, param types.PlaceOrderParam) (*types.PlaceOrderResult, error) {
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    "/api/v1/orders",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := f.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := f.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.PlaceOrderResult
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// CancelOrder cancels an order by the order id.
+func (f *FuturesAccountClient) CancelOrder(ctx context.Context, orderID string) (*types.CancelOrdersResult, error) {
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/orders/%s", orderID),
+		Method:  http.MethodDelete,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		h, err := f.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.CancelOrdersResult
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// CancelOrderByClientOid cancels an order by the client order id.
+func (f *FuturesAccountClient) CancelOrderByClientOid(ctx context.Context, param types.CancelOrderByClientOidParam) (*types.CancelOrderByClientOidResult, error) {
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/orders/client-order/%s", param.ClientOid),
+		Method:  http.MethodDelete,
+		Query:   param,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := f.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := f.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.CancelOrderByClientOidResult
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// CancelAllOrders cancels all open limit orders, stop orders are not included.
+func (f *FuturesAccountClient) CancelAllOrders(ctx context.Context, param types.CancelAllOrdersParam) (*types.CancelOrdersResult, error) {
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    "/api/v1/orders",
+		Method:  http.MethodDelete,
+		Query:   param,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := f.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := f.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.CancelOrdersResult
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetOrder gets a single order by the order id.
+func (f *FuturesAccountClient) GetOrder(ctx context.Context, orderID string) (*types.Order, error) {
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/orders/%s", orderID),
+		Method:  http.MethodGet,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		h, err := f.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.Order
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetOrders lists the orders of the current account with pagination.
+func (f *FuturesAccountClient) GetOrders(ctx context.Context, param types.GetOrdersParam) ([]*types.Order, *utils.PaginationModel, error) {
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    "/api/v1/orders",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return nil, nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := f.validate.Struct(param)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		h, err := f.cli.GenSignature(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, nil, errors.New(resp.Error())
+	}
+
+	var ret []*types.Order
+	page, err := ar.ReadPaginationData(&ret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ret, page, nil
+}
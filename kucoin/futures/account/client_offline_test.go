@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package account
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/rluisr/nexapi/kucoin/futures/account/types"
+	"github.com/rluisr/nexapi/kucoin/kucointest"
+	"github.com/rluisr/nexapi/utils/fakeserver"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNewFakeFuturesAccountClient(t *testing.T, secret string) (*FuturesAccountClient, *fakeserver.Server) {
+	srv := kucointest.NewServer()
+	t.Cleanup(srv.Close)
+
+	cli, err := NewFuturesAccountClient(&FuturesAccountClientCfg{
+		BaseURL:    srv.URL,
+		Key:        kucointest.Key,
+		KeyVersion: kucointest.KeyVersion,
+		Secret:     secret,
+		Passphrase: kucointest.Passphrase,
+	})
+	if err != nil {
+		t.Fatalf("Could not create kucoin futures client, %s", err)
+	}
+
+	return cli, srv
+}
+
+func TestFakeEndpoints(t *testing.T) {
+	cli, srv := testNewFakeFuturesAccountClient(t, kucointest.Secret)
+
+	srv.Handle(http.MethodGet, "/api/v1/orders", kucointest.EmptyPage)
+
+	fakeserver.RunEndpoints(t, srv, true, []fakeserver.Endpoint{
+		{Name: "GetAccountOverview", Method: http.MethodGet, Path: "/api/v1/account-overview", Call: func(ctx context.Context) (any, error) {
+			return cli.GetAccountOverview(ctx, types.GetAccountOverviewParam{Currency: "USDT"})
+		}},
+		{Name: "GetPosition", Method: http.MethodGet, Path: "/api/v1/position", Call: func(ctx context.Context) (any, error) {
+			return cli.GetPosition(ctx, types.GetPositionParam{Symbol: "XBTUSDTM"})
+		}},
+		{Name: "GetPositions", Method: http.MethodGet, Path: "/api/v1/positions", Call: func(ctx context.Context) (any, error) {
+			return cli.GetPositions(ctx, types.GetPositionsParam{})
+		}},
+		{Name: "SetAutoDepositStatus", Method: http.MethodPost, Path: "/api/v1/position/margin/auto-deposit-status", Call: func(ctx context.Context) (any, error) {
+			return cli.SetAutoDepositStatus(ctx, types.SetAutoDepositStatusParam{Symbol: "XBTUSDTM", Status: true})
+		}},
+		{Name: "AddMargin", Method: http.MethodPost, Path: "/api/v1/position/margin/deposit-margin", Call: func(ctx context.Context) (any, error) {
+			return cli.AddMargin(ctx, types.AddMarginParam{Symbol: "XBTUSDTM", Margin: 10, BizNo: "b1"})
+		}},
+		{Name: "GetMarginMode", Method: http.MethodGet, Path: "/api/v2/position/getMarginMode", Call: func(ctx context.Context) (any, error) {
+			return cli.GetMarginMode(ctx, types.GetMarginModeParam{Symbol: "XBTUSDTM"})
+		}},
+		{Name: "ChangeMarginMode", Method: http.MethodPost, Path: "/api/v2/position/changeMarginMode", Call: func(ctx context.Context) (any, error) {
+			return cli.ChangeMarginMode(ctx, types.ChangeMarginModeParam{Symbol: "XBTUSDTM", MarginMode: types.CrossMode})
+		}},
+		{Name: "GetCrossLeverage", Method: http.MethodGet, Path: "/api/v2/getCrossUserLeverage", Call: func(ctx context.Context) (any, error) {
+			return cli.GetCrossLeverage(ctx, types.GetCrossLeverageParam{Symbol: "XBTUSDTM"})
+		}},
+		{Name: "ChangeCrossLeverage", Method: http.MethodPost, Path: "/api/v2/changeCrossUserLeverage", Call: func(ctx context.Context) (any, error) {
+			return cli.ChangeCrossLeverage(ctx, types.ChangeCrossLeverageParam{Symbol: "XBTUSDTM", Leverage: "5"})
+		}},
+		{Name: "PlaceOrder", Method: http.MethodPost, Path: "/api/v1/orders", Call: func(ctx context.Context) (any, error) {
+			return cli.PlaceOrder(ctx, types.PlaceOrderParam{ClientOid: "c1", Side: "buy", Symbol: "XBTUSDTM", Leverage: "5", Price: "42000", Size: 1})
+		}},
+		{Name: "CancelOrder", Method: http.MethodDelete, Path: "/api/v1/orders/o1", Call: func(ctx context.Context) (any, error) {
+			return cli.CancelOrder(ctx, "o1")
+		}},
+		{Name: "CancelOrderByClientOid", Method: http.MethodDelete, Path: "/api/v1/orders/client-order/c1", Call: func(ctx context.Context) (any, error) {
+			return cli.CancelOrderByClientOid(ctx, types.CancelOrderByClientOidParam{ClientOid: "c1", Symbol: "XBTUSDTM"})
+		}},
+		{Name: "CancelAllOrders", Method: http.MethodDelete, Path: "/api/v1/orders", Call: func(ctx context.Context) (any, error) {
+			return cli.CancelAllOrders(ctx, types.CancelAllOrdersParam{Symbol: "XBTUSDTM"})
+		}},
+		{Name: "GetOrder", Method: http.MethodGet, Path: "/api/v1/orders/o1", Call: func(ctx context.Context) (any, error) {
+			return cli.GetOrder(ctx, "o1")
+		}},
+		{Name: "GetOrders", Method: http.MethodGet, Path: "/api/v1/orders", Call: func(ctx context.Context) (any, error) {
+			_, _, err := cli.GetOrders(ctx, types.GetOrdersParam{Status: "active"})
+			return nil, err
+		}},
+	})
+}
+
+func TestFakeGetPosition(t *testing.T) {
+	cli, srv := testNewFakeFuturesAccountClient(t, kucointest.Secret)
+
+	srv.Handle(http.MethodGet, "/api/v1/position", `{"code":"200000","data":{"id":"p1","symbol":"XBTUSDTM","crossMode":true,"leverage":5,"marginMode":"CROSS","currentQty":3}}`)
+
+	pos, err := cli.GetPosition(context.Background(), types.GetPositionParam{Symbol: "XBTUSDTM"})
+	assert.Nil(t, err)
+	assert.Equal(t, "p1", pos.ID)
+	assert.True(t, pos.CrossMode)
+	assert.Equal(t, float64(5), pos.Leverage)
+	assert.Equal(t, "XBTUSDTM", srv.LastRequest().Query.Get("symbol"))
+}
+
+func TestFakeGetOrders(t *testing.T) {
+	cli, srv := testNewFakeFuturesAccountClient(t, kucointest.Secret)
+
+	srv.Handle(http.MethodGet, "/api/v1/orders", `{"code":"200000","data":{"currentPage":1,"pageSize":50,"totalNum":1,"totalPage":1,"items":[{"id":"o1","symbol":"XBTUSDTM","side":"sell","price":"43000","size":2,"dealSize":1}]}}`)
+
+	orders, page, err := cli.GetOrders(context.Background(), types.GetOrdersParam{Status: "active", Symbol: "XBTUSDTM"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), page.TotalNum)
+	assert.Len(t, orders, 1)
+	assert.Equal(t, int64(1), orders[0].DealSize)
+
+	q := srv.LastRequest().Query
+	assert.Equal(t, "active", q.Get("status"))
+	assert.Equal(t, "XBTUSDTM", q.Get("symbol"))
+}
+
+func TestFakeInvalidSignature(t *testing.T) {
+	cli, _ := testNewFakeFuturesAccountClient(t, "wrong-secret")
+
+	_, err := cli.GetAccountOverview(context.Background(), types.GetAccountOverviewParam{})
+	kucointest.AssertInvalidSignature(t, err)
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package account
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/rluisr/nexapi/kucoin/futures/account/types"
+	"github.com/rluisr/nexapi/kucoin/rest/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNewFuturesAccountClient(t *testing.T) *FuturesAccountClient {
+	cli, err := NewFuturesAccountClient(&FuturesAccountClientCfg{
+		BaseURL:    utils.FuturesBaseURL,
+		Key:        os.Getenv("KUCOIN_KEY"),
+		KeyVersion: utils.ApiKeyVersionV2,
+		Secret:     os.Getenv("KUCOIN_SECRET"),
+		Passphrase: os.Getenv("KUCOIN_PASS"),
+		Debug:      true,
+	})
+
+	if err != nil {
+		t.Fatalf("Could not create kucoin futures client, %s", err)
+	}
+
+	return cli
+}
+
+func TestGetAccountOverview(t *testing.T) {
+	cli := testNewFuturesAccountClient(t)
+
+	_, err := cli.GetAccountOverview(context.TODO(), types.GetAccountOverviewParam{
+		Currency: "USDT",
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetPositions(t *testing.T) {
+	cli := testNewFuturesAccountClient(t)
+
+	_, err := cli.GetPositions(context.TODO(), types.GetPositionsParam{})
+	assert.Nil(t, err)
+}
+
+func TestGetOrders(t *testing.T) {
+	cli := testNewFuturesAccountClient(t)
+
+	_, _, err := cli.GetOrders(context.TODO(), types.GetOrdersParam{
+		Status: "done",
+		PaginationParam: utils.PaginationParam{
+			CurrentPage: 1,
+			PageSize:    10,
+		},
+	})
+	assert.Nil(t, err)
+}
+
+// TestPlaceAndCancelOrder places a limit order far from the market and cancels it,
+// it only runs with KUCOIN_ORDER=1.
+func TestPlaceAndCancelOrder(t *testing.T) {
+	if os.Getenv("KUCOIN_ORDER") != "1" {
+		t.Skip("set KUCOIN_ORDER=1 to place orders on the live API")
+	}
+
+	cli := testNewFuturesAccountClient(t)
+
+	order, err := cli.PlaceOrder(context.TODO(), types.PlaceOrderParam{
+		ClientOid:  "nexapi-test-order",
+		Side:       "buy",
+		Symbol:     "XBTUSDTM",
+		Leverage:   "1",
+		Type:       "limit",
+		Price:      "10000",
+		Size:       1,
+		MarginMode: types.IsolatedMode,
+	})
+	assert.Nil(t, err)
+	if err != nil {
+		return
+	}
+
+	_, err = cli.CancelOrder(context.TODO(), order.OrderID)
+	assert.Nil(t, err)
+}
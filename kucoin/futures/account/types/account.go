@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+type GetAccountOverviewParam struct {
+	Currency string `url:"currency,omitempty"` // Currency, including XBT, USDT, default is XBT
+}
+
+// An AccountOverview represents the futures account.
+type AccountOverview struct {
+	AccountEquity    float64 `json:"accountEquity"` // Account equity = marginBalance + Unrealised PNL
+	UnrealisedPNL    float64 `json:"unrealisedPNL"`
+	MarginBalance    float64 `json:"marginBalance"` // Margin balance = positionMargin + orderMargin + frozenFunds + availableBalance - unrealisedPNL
+	PositionMargin   float64 `json:"positionMargin"`
+	OrderMargin      float64 `json:"orderMargin"`
+	FrozenFunds      float64 `json:"frozenFunds"` // Frozen funds for withdrawal and out-transfer
+	AvailableBalance float64 `json:"availableBalance"`
+	Currency         string  `json:"currency"`
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import "github.com/rluisr/nexapi/kucoin/rest/utils"
+
+type PlaceOrderParam struct {
+	ClientOid     string     `json:"clientOid" validate:"required"`
+	Side          string     `json:"side" validate:"required,oneof=buy sell"`
+	Symbol        string     `json:"symbol" validate:"required"`
+	Leverage      string     `json:"leverage,omitempty"`                                     // Leverage of the order, required for isolated margin
+	Type          string     `json:"type,omitempty" validate:"omitempty,oneof=limit market"` // The default is limit
+	Remark        string     `json:"remark,omitempty"`
+	Stop          string     `json:"stop,omitempty" validate:"omitempty,oneof=down up"` // down: triggers when the price reaches or goes below the stopPrice, up: triggers when the price reaches or goes above the stopPrice
+	StopPriceType string     `json:"stopPriceType,omitempty" validate:"omitempty,oneof=TP IP MP"`
+	StopPrice     string     `json:"stopPrice,omitempty"`
+	ReduceOnly    bool       `json:"reduceOnly,omitempty"`
+	CloseOrder    bool       `json:"closeOrder,omitempty"`
+	ForceHold     bool       `json:"forceHold,omitempty"`
+	Stp           string     `json:"stp,omitempty" validate:"omitempty,oneof=CN CO CB"` // self trade prevention
+	MarginMode    MarginMode `json:"marginMode,omitempty" validate:"omitempty,oneof=ISOLATED CROSS"`
+	Price         string     `json:"price,omitempty"`
+	Size          int64      `json:"size,omitempty"` // Order size (Lot), must be a positive integer
+	TimeInForce   string     `json:"timeInForce,omitempty" validate:"omitempty,oneof=GTC IOC"`
+	PostOnly      bool       `json:"postOnly,omitempty"`
+	Hidden        bool       `json:"hidden,omitempty"`
+	Iceberg       bool       `json:"iceberg,omitempty"`
+	VisibleSize   string     `json:"visibleSize,omitempty"`
+}
+
+type PlaceOrderResult struct {
+	OrderID   string `json:"orderId"`
+	ClientOid string `json:"clientOid"`
+}
+
+type CancelOrdersResult struct {
+	CancelledOrderIDs []string `json:"cancelledOrderIds"`
+}
+
+type CancelOrderByClientOidParam struct {
+	ClientOid string `url:"-" validate:"required"`
+	Symbol    string `url:"symbol" validate:"required"`
+}
+
+type CancelOrderByClientOidResult struct {
+	ClientOid string `json:"clientOid"`
+}
+
+type CancelAllOrdersParam struct {
+	Symbol string `url:"symbol,omitempty"` // Cancel all limit orders for a specific contract only
+}
+
+type GetOrdersParam struct {
+	Status  string `url:"status,omitempty" validate:"omitempty,oneof=active done"`
+	Symbol  string `url:"symbol,omitempty"`
+	Side    string `url:"side,omitempty" validate:"omitempty,oneof=buy sell"`
+	Type    string `url:"type,omitempty" validate:"omitempty,oneof=limit market limit_stop market_stop"`
+	StartAt int64  `url:"startAt,omitempty"` // Start time (milisecond)
+	EndAt   int64  `url:"endAt,omitempty"`   // End time (milisecond)
+	utils.PaginationParam
+}
+
+// An Order represents a futures order.
+type Order struct {
+	ID             string  `json:"id"`
+	Symbol         string  `json:"symbol"`
+	Type           string  `json:"type"`
+	Side           string  `json:"side"`
+	Price          string  `json:"price"`
+	Size           int64   `json:"size"`
+	Value          string  `json:"value"`
+	DealValue      string  `json:"dealValue"`
+	DealSize       int64   `json:"dealSize"`
+	Stp            string  `json:"stp"`
+	Stop           string  `json:"stop"`
+	StopPriceType  string  `json:"stopPriceType"`
+	StopTriggered  bool    `json:"stopTriggered"`
+	StopPrice      string  `json:"stopPrice"`
+	TimeInForce    string  `json:"timeInForce"`
+	PostOnly       bool    `json:"postOnly"`
+	Hidden         bool    `json:"hidden"`
+	Iceberg        bool    `json:"iceberg"`
+	Leverage       string  `json:"leverage"`
+	ForceHold      bool    `json:"forceHold"`
+	CloseOrder     bool    `json:"closeOrder"`
+	VisibleSize    float64 `json:"visibleSize"`
+	ClientOid      string  `json:"clientOid"`
+	Remark         string  `json:"remark"`
+	Tags           string  `json:"tags"`
+	IsActive       bool    `json:"isActive"`
+	CancelExist    bool    `json:"cancelExist"`
+	CreatedAt      int64   `json:"createdAt"`
+	UpdatedAt      int64   `json:"updatedAt"`
+	EndAt          int64   `json:"endAt"`
+	OrderTime      int64   `json:"orderTime"` // nanosecond
+	SettleCurrency string  `json:"settleCurrency"`
+	MarginMode     string  `json:"marginMode"`
+	Status         string  `json:"status"` // active, done
+	FilledSize     int64   `json:"filledSize"`
+	FilledValue    string  `json:"filledValue"`
+	ReduceOnly     bool    `json:"reduceOnly"`
+}
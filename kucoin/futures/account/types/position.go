@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+type GetPositionParam struct {
+	Symbol string `url:"symbol" validate:"required"`
+}
+
+type GetPositionsParam struct {
+	Currency string `url:"currency,omitempty"`
+}
+
+// A Position represents a futures position.
+// doc: https://www.kucoin.com/docs/rest/futures-trading/positions/get-position-details
+type Position struct {
+	ID                string  `json:"id"`
+	Symbol            string  `json:"symbol"`
+	AutoDeposit       bool    `json:"autoDeposit"`
+	MaintMarginReq    float64 `json:"maintMarginReq"`
+	RiskLimit         float64 `json:"riskLimit"`
+	RealLeverage      float64 `json:"realLeverage"`
+	Leverage          float64 `json:"leverage"`
+	CrossMode         bool    `json:"crossMode"`
+	MarginMode        string  `json:"marginMode"`
+	PositionSide      string  `json:"positionSide"`
+	DelevPercentage   float64 `json:"delevPercentage"`
+	OpeningTimestamp  int64   `json:"openingTimestamp"`
+	CurrentTimestamp  int64   `json:"currentTimestamp"`
+	CurrentQty        int64   `json:"currentQty"`
+	CurrentCost       float64 `json:"currentCost"`
+	CurrentComm       float64 `json:"currentComm"`
+	UnrealisedCost    float64 `json:"unrealisedCost"`
+	RealisedGrossCost float64 `json:"realisedGrossCost"`
+	RealisedCost      float64 `json:"realisedCost"`
+	IsOpen            bool    `json:"isOpen"`
+	MarkPrice         float64 `json:"markPrice"`
+	MarkValue         float64 `json:"markValue"`
+	PosCost           float64 `json:"posCost"`
+	PosCross          float64 `json:"posCross"`
+	PosInit           float64 `json:"posInit"`
+	PosComm           float64 `json:"posComm"`
+	PosLoss           float64 `json:"posLoss"`
+	PosMargin         float64 `json:"posMargin"`
+	PosMaint          float64 `json:"posMaint"`
+	MaintMargin       float64 `json:"maintMargin"`
+	RealisedGrossPnl  float64 `json:"realisedGrossPnl"`
+	RealisedPnl       float64 `json:"realisedPnl"`
+	UnrealisedPnl     float64 `json:"unrealisedPnl"`
+	UnrealisedPnlPcnt float64 `json:"unrealisedPnlPcnt"`
+	UnrealisedRoePcnt float64 `json:"unrealisedRoePcnt"`
+	AvgEntryPrice     float64 `json:"avgEntryPrice"`
+	LiquidationPrice  float64 `json:"liquidationPrice"`
+	BankruptPrice     float64 `json:"bankruptPrice"`
+	SettleCurrency    string  `json:"settleCurrency"`
+	RiskLimitLevel    int64   `json:"riskLimitLevel"`
+}
+
+type SetAutoDepositStatusParam struct {
+	Symbol string `json:"symbol" validate:"required"`
+	Status bool   `json:"status"`
+}
+
+type AddMarginParam struct {
+	Symbol string  `json:"symbol" validate:"required"`
+	Margin float64 `json:"margin" validate:"required,gt=0"`
+	BizNo  string  `json:"bizNo" validate:"required"` // A unique ID generated by the user, to ensure the operation is processed by the system only once
+}
+
+type MarginMode = string
+
+const (
+	IsolatedMode MarginMode = "ISOLATED"
+	CrossMode    MarginMode = "CROSS"
+)
+
+type GetMarginModeParam struct {
+	Symbol string `url:"symbol" validate:"required"`
+}
+
+type ChangeMarginModeParam struct {
+	Symbol     string     `json:"symbol" validate:"required"`
+	MarginMode MarginMode `json:"marginMode" validate:"required,oneof=ISOLATED CROSS"`
+}
+
+type MarginModeResult struct {
+	Symbol     string `json:"symbol"`
+	MarginMode string `json:"marginMode"`
+}
+
+type GetCrossLeverageParam struct {
+	Symbol string `url:"symbol" validate:"required"`
+}
+
+type ChangeCrossLeverageParam struct {
+	Symbol   string `json:"symbol" validate:"required"`
+	Leverage string `json:"leverage" validate:"required"`
+}
+
+type CrossLeverage struct {
+	Symbol   string `json:"symbol"`
+	Leverage string `json:"leverage"`
+}
@@ -0,0 +1,456 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package marketdata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/rluisr/nexapi/kucoin/futures/marketdata/types"
+	"github.com/rluisr/nexapi/kucoin/rest/utils"
+)
+
+type FuturesMarketDataClient struct {
+	cli *utils.KucoinClient
+
+	// validate struct fields
+	validate *validator.Validate
+}
+
+type FuturesMarketDataClientCfg struct {
+	Debug bool
+	// Logger
+	Logger *slog.Logger
+
+	BaseURL    string `validate:"required"`
+	HTTPClient *http.Client
+}
+
+func NewFuturesMarketDataClient(cfg *FuturesMarketDataClientCfg) (*FuturesMarketDataClient, error) {
+	validator := validator.New()
+
+	err := validator.Struct(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := utils.NewKucoinRestClient(&utils.KucoinClientCfg{
+		Debug:      cfg.Debug,
+		Logger:     cfg.Logger,
+		BaseURL:    cfg.BaseURL,
+		HTTPClient: cfg.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &FuturesMarketDataClient{
+		cli:      cli,
+		validate: validator,
+	}, nil
+}
+
+// GetServerTime gets the API server time, Unix timestamp in milliseconds.
+func (f *FuturesMarketDataClient) GetServerTime(ctx context.Context) (int64, error) {
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    "/api/v1/timestamp",
+		Method:  http.MethodGet,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return 0, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return 0, errors.New(resp.Error())
+	}
+
+	var ret int64
+	if err := ar.ReadData(&ret); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
+// GetActiveContracts lists all the open contracts.
+func (f *FuturesMarketDataClient) GetActiveContracts(ctx context.Context) ([]*types.Contract, error) {
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    "/api/v1/contracts/active",
+		Method:  http.MethodGet,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret []*types.Contract
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// GetContract gets the detail of a contract.
+func (f *FuturesMarketDataClient) GetContract(ctx context.Context, param types.GetContractParam) (*types.Contract, error) {
+	err := f.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/contracts/%s", param.Symbol),
+		Method:  http.MethodGet,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.Contract
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetTicker gets the real-time ticker 1.0 of a contract, including the last traded price and the best bid/ask.
+func (f *FuturesMarketDataClient) GetTicker(ctx context.Context, param types.GetTickerParam) (*types.Ticker, error) {
+	err := f.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    "/api/v1/ticker",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.Ticker
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetFullOrderBook gets a snapshot of aggregated open orders for a symbol.
+func (f *FuturesMarketDataClient) GetFullOrderBook(ctx context.Context, param types.GetOrderBookParam) (*types.OrderBook, error) {
+	err := f.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    "/api/v1/level2/snapshot",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.OrderBook
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetPartOrderBook gets the top 20 or 100 levels of the order book for a symbol.
+func (f *FuturesMarketDataClient) GetPartOrderBook(ctx context.Context, param types.GetPartOrderBookParam) (*types.OrderBook, error) {
+	err := f.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/level2/depth%d", param.Depth),
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.OrderBook
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetTradeHistory lists the latest 100 trades for a symbol.
+func (f *FuturesMarketDataClient) GetTradeHistory(ctx context.Context, param types.GetTradeHistoryParam) ([]*types.Trade, error) {
+	err := f.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    "/api/v1/trade/history",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret []*types.Trade
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// GetKlines gets the klines of a symbol, the maximum size per request is 200.
+func (f *FuturesMarketDataClient) GetKlines(ctx context.Context, param types.GetKlinesParam) ([]*types.Kline, error) {
+	err := f.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    "/api/v1/kline/query",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret []*types.Kline
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// GetCurrentFundingRate gets the current funding rate of a symbol.
+func (f *FuturesMarketDataClient) GetCurrentFundingRate(ctx context.Context, param types.GetCurrentFundingRateParam) (*types.FundingRate, error) {
+	err := f.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/funding-rate/%s/current", param.Symbol),
+		Method:  http.MethodGet,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.FundingRate
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetFundingRateHistory gets the public funding history of a symbol within a time range.
+func (f *FuturesMarketDataClient) GetFundingRateHistory(ctx context.Context, param types.GetFundingRateHistoryParam) ([]*types.FundingRateHistory, error) {
+	err := f.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: f.cli.GetBaseURL(),
+		Path:    "/api/v1/contract/funding-rates",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := f.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := f.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret []*types.FundingRateHistory
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package marketdata
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/rluisr/nexapi/kucoin/futures/marketdata/types"
+	"github.com/rluisr/nexapi/kucoin/kucointest"
+	"github.com/rluisr/nexapi/utils/fakeserver"
+	"github.com/rluisr/nexapi/utils/replay"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNewFakeFuturesMarketDataClient(t *testing.T) (*FuturesMarketDataClient, *fakeserver.Server) {
+	srv := kucointest.NewServer()
+	t.Cleanup(srv.Close)
+
+	cli, err := NewFuturesMarketDataClient(&FuturesMarketDataClientCfg{
+		BaseURL: srv.URL,
+	})
+	if err != nil {
+		t.Fatalf("Could not create kucoin futures client, %s", err)
+	}
+
+	return cli, srv
+}
+
+func TestFakeEndpoints(t *testing.T) {
+	cli, srv := testNewFakeFuturesMarketDataClient(t)
+
+	fakeserver.RunEndpoints(t, srv, false, []fakeserver.Endpoint{
+		{Name: "GetServerTime", Method: http.MethodGet, Path: "/api/v1/timestamp", Call: func(ctx context.Context) (any, error) {
+			return cli.GetServerTime(ctx)
+		}},
+		{Name: "GetActiveContracts", Method: http.MethodGet, Path: "/api/v1/contracts/active", Call: func(ctx context.Context) (any, error) {
+			return cli.GetActiveContracts(ctx)
+		}},
+		{Name: "GetContract", Method: http.MethodGet, Path: "/api/v1/contracts/XBTUSDTM", Call: func(ctx context.Context) (any, error) {
+			return cli.GetContract(ctx, types.GetContractParam{Symbol: "XBTUSDTM"})
+		}},
+		{Name: "GetTicker", Method: http.MethodGet, Path: "/api/v1/ticker", Call: func(ctx context.Context) (any, error) {
+			return cli.GetTicker(ctx, types.GetTickerParam{Symbol: "XBTUSDTM"})
+		}},
+		{Name: "GetFullOrderBook", Method: http.MethodGet, Path: "/api/v1/level2/snapshot", Call: func(ctx context.Context) (any, error) {
+			return cli.GetFullOrderBook(ctx, types.GetOrderBookParam{Symbol: "XBTUSDTM"})
+		}},
+		{Name: "GetPartOrderBook", Method: http.MethodGet, Path: "/api/v1/level2/depth20", Call: func(ctx context.Context) (any, error) {
+			return cli.GetPartOrderBook(ctx, types.GetPartOrderBookParam{Symbol: "XBTUSDTM", Depth: 20})
+		}},
+		{Name: "GetTradeHistory", Method: http.MethodGet, Path: "/api/v1/trade/history", Call: func(ctx context.Context) (any, error) {
+			return cli.GetTradeHistory(ctx, types.GetTradeHistoryParam{Symbol: "XBTUSDTM"})
+		}},
+		{Name: "GetKlines", Method: http.MethodGet, Path: "/api/v1/kline/query", Call: func(ctx context.Context) (any, error) {
+			return cli.GetKlines(ctx, types.GetKlinesParam{Symbol: "XBTUSDTM", Granularity: types.Hour1})
+		}},
+		{Name: "GetCurrentFundingRate", Method: http.MethodGet, Path: "/api/v1/funding-rate/XBTUSDTM/current", Call: func(ctx context.Context) (any, error) {
+			return cli.GetCurrentFundingRate(ctx, types.GetCurrentFundingRateParam{Symbol: "XBTUSDTM"})
+		}},
+		{Name: "GetFundingRateHistory", Method: http.MethodGet, Path: "/api/v1/contract/funding-rates", Call: func(ctx context.Context) (any, error) {
+			return cli.GetFundingRateHistory(ctx, types.GetFundingRateHistoryParam{Symbol: "XBTUSDTM", From: 1704067200000, To: 1704096000000})
+		}},
+	})
+}
+
+func TestFakeGetPartOrderBook(t *testing.T) {
+	cli, srv := testNewFakeFuturesMarketDataClient(t)
+
+	srv.Handle(http.MethodGet, "/api/v1/level2/depth20", `{"code":"200000","data":{"symbol":"XBTUSDTM","sequence":100,"asks":[[42001,5],[42000.5,2]],"bids":[[42000,3],[41999,1]],"ts":1704067200000000000}}`)
+
+	ob, err := cli.GetPartOrderBook(context.Background(), types.GetPartOrderBookParam{Symbol: "XBTUSDTM", Depth: 20})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(100), ob.Sequence)
+
+	b, err := ob.Book()
+	assert.Nil(t, err)
+	ask, ok := b.BestAsk()
+	assert.True(t, ok)
+	assert.Equal(t, "42000.5", ask.Price.String())
+	spread, ok := b.Spread()
+	assert.True(t, ok)
+	assert.Equal(t, "0.5", spread.String())
+
+	// only the depths of 20 and 100 are served
+	n := len(srv.Requests())
+	_, err = cli.GetPartOrderBook(context.Background(), types.GetPartOrderBookParam{Symbol: "XBTUSDTM", Depth: 50})
+	assert.NotNil(t, err)
+	assert.Len(t, srv.Requests(), n)
+}
+
+func TestFakeGetKlines(t *testing.T) {
+	cli, srv := testNewFakeFuturesMarketDataClient(t)
+
+	srv.Handle(http.MethodGet, "/api/v1/kline/query", `{"code":"200000","data":[[1704067200000,42000,42100,41900,42050,120],[1704070800000,42050,42200,42000,42150,80]]}`)
+
+	klines, err := cli.GetKlines(context.Background(), types.GetKlinesParam{Symbol: "XBTUSDTM", Granularity: types.Hour1, From: 1704067200000})
+	assert.Nil(t, err)
+	assert.Len(t, klines, 2)
+	assert.Equal(t, int64(1704070800000), klines[1].Time)
+	assert.Equal(t, 42150.0, klines[1].Close)
+
+	q := srv.LastRequest().Query
+	assert.Equal(t, "60", q.Get("granularity"))
+	assert.Equal(t, "1704067200000", q.Get("from"))
+
+	// a malformed kline fails the decoding
+	srv.Handle(http.MethodGet, "/api/v1/kline/query", `{"code":"200000","data":[[1704067200000,42000]]}`)
+	_, err = cli.GetKlines(context.Background(), types.GetKlinesParam{Symbol: "XBTUSDTM", Granularity: types.Hour1})
+	assert.ErrorContains(t, err, "unknown kline value")
+}
+
+func TestFakeErrors(t *testing.T) {
+	cli, srv := testNewFakeFuturesMarketDataClient(t)
+
+	srv.Fail(http.MethodGet, "/api/v1/timestamp", fakeserver.Response{Status: http.StatusTooManyRequests, Body: `{"code":"429000","msg":"Too Many Requests"}`})
+
+	_, err := cli.GetServerTime(context.Background())
+	assert.ErrorContains(t, err, "respond code=429")
+
+	// the failure is served once
+	srv.Handle(http.MethodGet, "/api/v1/timestamp", `{"code":"200000","data":1704067200000}`)
+	ts, err := cli.GetServerTime(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1704067200000), ts)
+}
+
+func TestFakeRecordReplay(t *testing.T) {
+	srv := kucointest.NewServer()
+	defer srv.Close()
+
+	srv.Handle(http.MethodGet, "/api/v1/ticker", `{"code":"200000","data":{"symbol":"XBTUSDTM","sequence":1,"price":"42000","bestBidPrice":"41999","bestAskPrice":"42001"}}`)
+
+	var buf bytes.Buffer
+	rec := replay.NewRecorder(&buf, nil)
+
+	cli, err := NewFuturesMarketDataClient(&FuturesMarketDataClientCfg{BaseURL: srv.URL, HTTPClient: rec.Client()})
+	assert.Nil(t, err)
+
+	recorded, err := cli.GetTicker(context.Background(), types.GetTickerParam{Symbol: "XBTUSDTM"})
+	assert.Nil(t, err)
+	assert.Nil(t, rec.Err())
+
+	// the replay needs no server
+	player, err := replay.LoadPlayer(&buf, nil)
+	assert.Nil(t, err)
+
+	cli, err = NewFuturesMarketDataClient(&FuturesMarketDataClientCfg{BaseURL: "https://api-futures.example.com", HTTPClient: player.Client()})
+	assert.Nil(t, err)
+
+	replayed, err := cli.GetTicker(context.Background(), types.GetTickerParam{Symbol: "XBTUSDTM"})
+	assert.Nil(t, err)
+	assert.Equal(t, recorded, replayed)
+	assert.Equal(t, 0, player.Remaining())
+
+	_, err = cli.GetTicker(context.Background(), types.GetTickerParam{Symbol: "XBTUSDTM"})
+	assert.ErrorContains(t, err, "no recorded response")
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package marketdata
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rluisr/nexapi/kucoin/futures/marketdata/types"
+	"github.com/rluisr/nexapi/kucoin/rest/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNewFuturesMarketDataClient(t *testing.T) *FuturesMarketDataClient {
+	cli, err := NewFuturesMarketDataClient(&FuturesMarketDataClientCfg{
+		BaseURL: utils.FuturesBaseURL,
+		Debug:   true,
+	})
+
+	if err != nil {
+		t.Fatalf("Could not create kucoin futures client, %s", err)
+	}
+
+	return cli
+}
+
+func TestGetServerTime(t *testing.T) {
+	cli := testNewFuturesMarketDataClient(t)
+
+	_, err := cli.GetServerTime(context.TODO())
+	assert.Nil(t, err)
+}
+
+func TestGetActiveContracts(t *testing.T) {
+	cli := testNewFuturesMarketDataClient(t)
+
+	_, err := cli.GetActiveContracts(context.TODO())
+	assert.Nil(t, err)
+}
+
+func TestGetContract(t *testing.T) {
+	cli := testNewFuturesMarketDataClient(t)
+
+	_, err := cli.GetContract(context.TODO(), types.GetContractParam{
+		Symbol: "XBTUSDTM",
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetTicker(t *testing.T) {
+	cli := testNewFuturesMarketDataClient(t)
+
+	_, err := cli.GetTicker(context.TODO(), types.GetTickerParam{
+		Symbol: "XBTUSDTM",
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetPartOrderBook(t *testing.T) {
+	cli := testNewFuturesMarketDataClient(t)
+
+	_, err := cli.GetPartOrderBook(context.TODO(), types.GetPartOrderBookParam{
+		Symbol: "XBTUSDTM",
+		Depth:  20,
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetKlines(t *testing.T) {
+	cli := testNewFuturesMarketDataClient(t)
+
+	_, err := cli.GetKlines(context.TODO(), types.GetKlinesParam{
+		Symbol:      "XBTUSDTM",
+		Granularity: types.Hour1,
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetCurrentFundingRate(t *testing.T) {
+	cli := testNewFuturesMarketDataClient(t)
+
+	_, err := cli.GetCurrentFundingRate(context.TODO(), types.GetCurrentFundingRateParam{
+		Symbol: "XBTUSDTM",
+	})
+	assert.Nil(t, err)
+}
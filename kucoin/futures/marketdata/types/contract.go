@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+type GetContractParam struct {
+	Symbol string `validate:"required"`
+}
+
+// A Contract represents a futures contract.
+// doc: https://www.kucoin.com/docs/rest/futures-trading/market-data/get-symbol-detail
+type Contract struct {
+	Symbol                  string   `json:"symbol"`
+	RootSymbol              string   `json:"rootSymbol"`
+	Type                    string   `json:"type"` // FFWCSX: perpetual contract, FFICSX: futures contract
+	FirstOpenDate           int64    `json:"firstOpenDate"`
+	ExpireDate              int64    `json:"expireDate"`
+	SettleDate              int64    `json:"settleDate"`
+	BaseCurrency            string   `json:"baseCurrency"`
+	QuoteCurrency           string   `json:"quoteCurrency"`
+	SettleCurrency          string   `json:"settleCurrency"`
+	MaxOrderQty             int64    `json:"maxOrderQty"`
+	MaxPrice                float64  `json:"maxPrice"`
+	LotSize                 float64  `json:"lotSize"`
+	TickSize                float64  `json:"tickSize"`
+	IndexPriceTickSize      float64  `json:"indexPriceTickSize"`
+	Multiplier              float64  `json:"multiplier"`
+	InitialMargin           float64  `json:"initialMargin"`
+	MaintainMargin          float64  `json:"maintainMargin"`
+	MaxRiskLimit            int64    `json:"maxRiskLimit"`
+	MinRiskLimit            int64    `json:"minRiskLimit"`
+	RiskStep                int64    `json:"riskStep"`
+	MakerFeeRate            float64  `json:"makerFeeRate"`
+	TakerFeeRate            float64  `json:"takerFeeRate"`
+	TakerFixFee             float64  `json:"takerFixFee"`
+	MakerFixFee             float64  `json:"makerFixFee"`
+	SettlementFee           float64  `json:"settlementFee"`
+	IsDeleverage            bool     `json:"isDeleverage"`
+	IsQuanto                bool     `json:"isQuanto"`
+	IsInverse               bool     `json:"isInverse"`
+	MarkMethod              string   `json:"markMethod"`
+	FairMethod              string   `json:"fairMethod"`
+	FundingBaseSymbol       string   `json:"fundingBaseSymbol"`
+	FundingQuoteSymbol      string   `json:"fundingQuoteSymbol"`
+	FundingRateSymbol       string   `json:"fundingRateSymbol"`
+	IndexSymbol             string   `json:"indexSymbol"`
+	SettlementSymbol        string   `json:"settlementSymbol"`
+	Status                  string   `json:"status"`
+	FundingFeeRate          float64  `json:"fundingFeeRate"`
+	PredictedFundingFeeRate float64  `json:"predictedFundingFeeRate"`
+	OpenInterest            string   `json:"openInterest"`
+	TurnoverOf24H           float64  `json:"turnoverOf24h"`
+	VolumeOf24H             float64  `json:"volumeOf24h"`
+	MarkPrice               float64  `json:"markPrice"`
+	IndexPrice              float64  `json:"indexPrice"`
+	LastTradePrice          float64  `json:"lastTradePrice"`
+	NextFundingRateTime     int64    `json:"nextFundingRateTime"`
+	MaxLeverage             int64    `json:"maxLeverage"`
+	SourceExchanges         []string `json:"sourceExchanges"`
+	LowPrice                float64  `json:"lowPrice"`
+	HighPrice               float64  `json:"highPrice"`
+	PriceChgPct             float64  `json:"priceChgPct"`
+	PriceChg                float64  `json:"priceChg"`
+}
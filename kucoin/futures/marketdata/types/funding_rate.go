@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+type GetCurrentFundingRateParam struct {
+	Symbol string `validate:"required"`
+}
+
+type FundingRate struct {
+	Symbol         string  `json:"symbol"`
+	Granularity    int64   `json:"granularity"` // Granularity (milisecond)
+	TimePoint      int64   `json:"timePoint"`
+	Value          float64 `json:"value"`
+	PredictedValue float64 `json:"predictedValue"`
+}
+
+type GetFundingRateHistoryParam struct {
+	Symbol string `url:"symbol" validate:"required"`
+	From   int64  `url:"from" validate:"required"` // Begin time (milisecond)
+	To     int64  `url:"to" validate:"required"`   // End time (milisecond)
+}
+
+type FundingRateHistory struct {
+	Symbol      string  `json:"symbol"`
+	FundingRate float64 `json:"fundingRate"`
+	Timepoint   int64   `json:"timepoint"`
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Granularity is the kline type in minutes.
+type Granularity = int
+
+const (
+	Minute1  Granularity = 1
+	Minute5  Granularity = 5
+	Minute15 Granularity = 15
+	Minute30 Granularity = 30
+	Hour1    Granularity = 60
+	Hour2    Granularity = 120
+	Hour4    Granularity = 240
+	Hour8    Granularity = 480
+	Hour12   Granularity = 720
+	Day1     Granularity = 1440
+	Week1    Granularity = 10080
+)
+
+type GetKlinesParam struct {
+	Symbol      string      `url:"symbol" validate:"required"`
+	Granularity Granularity `url:"granularity" validate:"required,oneof=1 5 15 30 60 120 240 480 720 1440 10080"`
+	From        int64       `url:"from,omitempty"` // Start time (milisecond)
+	To          int64       `url:"to,omitempty"`   // End time (milisecond)
+}
+
+// Kline is decoded from [time, open, high, low, close, volume].
+type Kline struct {
+	Time   int64
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64
+}
+
+func (k *Kline) UnmarshalJSON(data []byte) error {
+	var arr []float64
+	if err := json.Unmarshal(data, &arr); err != nil {
+		return err
+	}
+
+	if len(arr) < 6 {
+		return fmt.Errorf("unknown kline value: %s", string(data))
+	}
+
+	k.Time = int64(arr[0])
+	k.Open, k.High, k.Low, k.Close, k.Volume = arr[1], arr[2], arr[3], arr[4], arr[5]
+
+	return nil
+}
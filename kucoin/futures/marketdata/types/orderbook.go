@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import "github.com/rluisr/nexapi/utils/book"
+
+type GetOrderBookParam struct {
+	Symbol string `url:"symbol" validate:"required"`
+}
+
+type GetPartOrderBookParam struct {
+	Symbol string `url:"symbol" validate:"required"`
+	Depth  int    `url:"-" validate:"required,oneof=20 100"`
+}
+
+// An OrderBook levels are [price, size].
+type OrderBook struct {
+	Symbol   string      `json:"symbol"`
+	Sequence int64       `json:"sequence"`
+	Asks     [][]float64 `json:"asks"`
+	Bids     [][]float64 `json:"bids"`
+	Ts       int64       `json:"ts"` // nanosecond
+}
+
+// Book returns the typed and sorted levels of the order book.
+func (o *OrderBook) Book() (*book.Book, error) {
+	return book.FromFloats(o.Bids, o.Asks)
+}
+
+func (o *OrderBook) BidLevels() ([]book.PriceLevel, error) {
+	return book.FloatLevels(o.Bids)
+}
+
+func (o *OrderBook) AskLevels() ([]book.PriceLevel, error) {
+	return book.FloatLevels(o.Asks)
+}
@@ -0,0 +1,37 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+type GetTickerParam struct {
+	Symbol string `url:"symbol" validate:"required"`
+}
+
+// A Ticker represents the real-time ticker 1.0 of a contract.
+type Ticker struct {
+	Sequence     int64  `json:"sequence"`
+	Symbol       string `json:"symbol"`
+	Side         string `json:"side"`
+	Size         int64  `json:"size"`
+	Price        string `json:"price"`
+	BestBidSize  int64  `json:"bestBidSize"`
+	BestBidPrice string `json:"bestBidPrice"`
+	BestAskPrice string `json:"bestAskPrice"`
+	BestAskSize  int64  `json:"bestAskSize"`
+	TradeID      string `json:"tradeId"`
+	Ts           int64  `json:"ts"` // Filled time - nanosecond
+}
@@ -0,0 +1,33 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+type GetTradeHistoryParam struct {
+	Symbol string `url:"symbol" validate:"required"`
+}
+
+type Trade struct {
+	Sequence     int64  `json:"sequence"`
+	TradeID      string `json:"tradeId"`
+	TakerOrderID string `json:"takerOrderId"`
+	MakerOrderID string `json:"makerOrderId"`
+	Price        string `json:"price"`
+	Size         int64  `json:"size"`
+	Side         string `json:"side"` // Side of liquidity taker
+	Ts           int64  `json:"ts"`   // nanosecond
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package kucointest provides a fake KuCoin server for offline tests.
+package kucointest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/rluisr/nexapi/utils/fakeserver"
+	"github.com/stretchr/testify/assert"
+)
+
+// The credentials accepted by the server.
+const (
+	Key        = "kucoin-test-key"
+	Secret     = "kucoin-test-secret"
+	Passphrase = "kucoin-test-passphrase"
+	KeyVersion = "2"
+)
+
+// Success is the response envelope returned for the routes without fixture.
+const Success = `{"code":"200000","data":null}`
+
+// EmptyPage is the response envelope of a paginated endpoint without items.
+const EmptyPage = `{"code":"200000","data":{"currentPage":1,"pageSize":50,"totalNum":0,"totalPage":0,"items":[]}}`
+
+// InvalidSignCode is the error code of the requests rejected by Verify.
+const InvalidSignCode = "400005"
+
+// NewServer starts a server that checks the signed requests with Key, Secret and Passphrase.
+func NewServer() *fakeserver.Server {
+	return fakeserver.New(fakeserver.Config{
+		Verify: Verify,
+		Unauthorized: fakeserver.Response{
+			Status: http.StatusUnauthorized,
+			Body:   `{"code":"` + InvalidSignCode + `","msg":"%s"}`,
+		},
+		Default: fakeserver.Response{Status: http.StatusOK, Body: Success},
+	})
+}
+
+// Verify checks the KC-API-* headers. The signature is the base64 HMAC-SHA256 of the
+// timestamp, the method, the request uri and the body, the passphrase of a v2 key is
+// signed with the secret as well.
+// A request without KC-API-KEY header is not signed.
+func Verify(r *http.Request, body []byte) (bool, error) {
+	key := r.Header.Get("KC-API-KEY")
+	if key == "" {
+		return false, nil
+	}
+
+	if key != Key {
+		return true, errors.New("invalid KC-API-KEY")
+	}
+
+	if r.Header.Get("KC-API-KEY-VERSION") != KeyVersion {
+		return true, errors.New("invalid KC-API-KEY-VERSION")
+	}
+
+	if !hmac.Equal([]byte(sign(Passphrase)), []byte(r.Header.Get("KC-API-PASSPHRASE"))) {
+		return true, errors.New("invalid KC-API-PASSPHRASE")
+	}
+
+	timestamp := r.Header.Get("KC-API-TIMESTAMP")
+	if _, err := strconv.ParseInt(timestamp, 10, 64); err != nil {
+		return true, errors.New("invalid KC-API-TIMESTAMP")
+	}
+
+	expected := sign(timestamp + r.Method + r.URL.RequestURI() + string(body))
+	if !hmac.Equal([]byte(expected), []byte(r.Header.Get("KC-API-SIGN"))) {
+		return true, errors.New("invalid KC-API-SIGN")
+	}
+
+	return true, nil
+}
+
+func sign(plain string) string {
+	h := hmac.New(sha256.New, []byte(Secret))
+	h.Write([]byte(plain))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// AssertInvalidSignature checks that err reports a request rejected by Verify.
+func AssertInvalidSignature(t *testing.T, err error) {
+	t.Helper()
+	assert.ErrorContains(t, err, InvalidSignCode)
+}
@@ -20,12 +20,14 @@ package account
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 
 	"github.com/go-playground/validator"
 	"github.com/rluisr/nexapi/kucoin/rest/account/types"
 	"github.com/rluisr/nexapi/kucoin/rest/utils"
+	"github.com/rluisr/nexapi/utils/pagination"
 )
 
 type AccountClient struct {
@@ -41,6 +43,7 @@ type AccountClientCfg struct {
 	Logger *slog.Logger
 
 	BaseURL    string `validate:"required"`
+	HTTPClient *http.Client
 	Key        string `validate:"required"`
 	KeyVersion string `validate:"required"`
 	Secret     string `validate:"required"`
@@ -59,6 +62,7 @@ func NewAccountClient(cfg *AccountClientCfg) (*AccountClient, error) {
 		Debug:      cfg.Debug,
 		Logger:     cfg.Logger,
 		BaseURL:    cfg.BaseURL,
+		HTTPClient: cfg.HTTPClient,
 		Key:        cfg.Key,
 		KeyVersion: cfg.KeyVersion,
 		Secret:     cfg.Secret,
@@ -122,3 +126,676 @@ func (a *AccountClient) GetAccountList(ctx context.Context, param types.GetAccou
 
 	return ret, nil
 }
+
+// GetAccountDetail gets the information of a single account.
+func (a *AccountClient) GetAccountDetail(ctx context.Context, param types.GetAccountDetailParam) (*types.AccountDetail, error) {
+	req := utils.HTTPRequest{
+		BaseURL: a.cli.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/accounts/%s", param.AccountID),
+		Method:  http.MethodGet,
+	}
+
+	{
+		headers, err := a.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := a.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := a.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := a.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.AccountDetail
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetLedgers gets the account ledgers of all the currencies in the last 24 hours by default, the query range can not exceed 24 hours.
+func (a *AccountClient) GetLedgers(ctx context.Context, param types.GetLedgersParam) ([]*types.Ledger, *utils.PaginationModel, error) {
+	req := utils.HTTPRequest{
+		BaseURL: a.cli.GetBaseURL(),
+		Path:    "/api/v1/accounts/ledgers",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := a.cli.GetHeaders()
+		if err != nil {
+			return nil, nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := a.validate.Struct(param)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		h, err := a.cli.GenSignature(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := a.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, nil, errors.New(resp.Error())
+	}
+
+	var ret []*types.Ledger
+	page, err := ar.ReadPaginationData(&ret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ret, page, nil
+}
+
+// InnerTransfer transfers funds between the accounts of the same user, e.g. from main to trade.
+func (a *AccountClient) InnerTransfer(ctx context.Context, param types.InnerTransferParam) (*types.TransferResult, error) {
+	req := utils.HTTPRequest{
+		BaseURL: a.cli.GetBaseURL(),
+		Path:    "/api/v2/accounts/inner-transfer",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := a.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := a.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := a.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := a.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.TransferResult
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetSubAccountBalance gets the account info of a sub-user specified by the subUserId.
+func (a *AccountClient) GetSubAccountBalance(ctx context.Context, param types.GetSubAccountBalanceParam) (*types.SubAccountBalance, error) {
+	req := utils.HTTPRequest{
+		BaseURL: a.cli.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/sub-accounts/%s", param.SubUserID),
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := a.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := a.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := a.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := a.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.SubAccountBalance
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetSubAccountBalances gets the account info of all sub-users with pagination.
+func (a *AccountClient) GetSubAccountBalances(ctx context.Context, param types.GetSubAccountBalancesParam) ([]*types.SubAccountBalance, *utils.PaginationModel, error) {
+	req := utils.HTTPRequest{
+		BaseURL: a.cli.GetBaseURL(),
+		Path:    "/api/v2/sub-accounts",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := a.cli.GetHeaders()
+		if err != nil {
+			return nil, nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := a.validate.Struct(param)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		h, err := a.cli.GenSignature(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := a.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, nil, errors.New(resp.Error())
+	}
+
+	var ret []*types.SubAccountBalance
+	page, err := ar.ReadPaginationData(&ret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ret, page, nil
+}
+
+// SubTransfer transfers funds between the master user and a sub-user.
+func (a *AccountClient) SubTransfer(ctx context.Context, param types.SubTransferParam) (*types.TransferResult, error) {
+	req := utils.HTTPRequest{
+		BaseURL: a.cli.GetBaseURL(),
+		Path:    "/api/v2/accounts/sub-transfer",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := a.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := a.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := a.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := a.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.TransferResult
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// CreateDepositAddress creates a deposit address for a currency.
+func (a *AccountClient) CreateDepositAddress(ctx context.Context, param types.CreateDepositAddressParam) (*types.DepositAddress, error) {
+	req := utils.HTTPRequest{
+		BaseURL: a.cli.GetBaseURL(),
+		Path:    "/api/v1/deposit-addresses",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := a.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := a.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := a.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := a.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.DepositAddress
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetDepositAddresses gets all the deposit addresses of a currency.
+func (a *AccountClient) GetDepositAddresses(ctx context.Context, param types.GetDepositAddressesParam) ([]*types.DepositAddress, error) {
+	req := utils.HTTPRequest{
+		BaseURL: a.cli.GetBaseURL(),
+		Path:    "/api/v2/deposit-addresses",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := a.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := a.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := a.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := a.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret []*types.DepositAddress
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// GetDeposits gets the deposit records with pagination.
+func (a *AccountClient) GetDeposits(ctx context.Context, param types.GetDepositsParam) ([]*types.Deposit, *utils.PaginationModel, error) {
+	req := utils.HTTPRequest{
+		BaseURL: a.cli.GetBaseURL(),
+		Path:    "/api/v1/deposits",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := a.cli.GetHeaders()
+		if err != nil {
+			return nil, nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := a.validate.Struct(param)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		h, err := a.cli.GenSignature(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := a.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, nil, errors.New(resp.Error())
+	}
+
+	var ret []*types.Deposit
+	page, err := ar.ReadPaginationData(&ret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ret, page, nil
+}
+
+// GetWithdrawals gets the withdrawal records with pagination.
+func (a *AccountClient) GetWithdrawals(ctx context.Context, param types.GetWithdrawalsParam) ([]*types.Withdrawal, *utils.PaginationModel, error) {
+	req := utils.HTTPRequest{
+		BaseURL: a.cli.GetBaseURL(),
+		Path:    "/api/v1/withdrawals",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := a.cli.GetHeaders()
+		if err != nil {
+			return nil, nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := a.validate.Struct(param)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		h, err := a.cli.GenSignature(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := a.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, nil, errors.New(resp.Error())
+	}
+
+	var ret []*types.Withdrawal
+	page, err := ar.ReadPaginationData(&ret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ret, page, nil
+}
+
+// GetWithdrawalQuotas gets the withdrawal quotas of a currency.
+func (a *AccountClient) GetWithdrawalQuotas(ctx context.Context, param types.GetWithdrawalQuotasParam) (*types.WithdrawalQuotas, error) {
+	req := utils.HTTPRequest{
+		BaseURL: a.cli.GetBaseURL(),
+		Path:    "/api/v1/withdrawals/quotas",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := a.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := a.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := a.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := a.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.WithdrawalQuotas
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// ApplyWithdrawal applies a withdrawal.
+func (a *AccountClient) ApplyWithdrawal(ctx context.Context, param types.ApplyWithdrawalParam) (*types.ApplyWithdrawalResult, error) {
+	req := utils.HTTPRequest{
+		BaseURL: a.cli.GetBaseURL(),
+		Path:    "/api/v1/withdrawals",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := a.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := a.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := a.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := a.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.ApplyWithdrawalResult
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// CancelWithdrawal cancels a withdrawal, only withdrawals in PROCESSING status can be cancelled.
+func (a *AccountClient) CancelWithdrawal(ctx context.Context, withdrawalID string) error {
+	req := utils.HTTPRequest{
+		BaseURL: a.cli.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/withdrawals/%s", withdrawalID),
+		Method:  http.MethodDelete,
+	}
+
+	{
+		headers, err := a.cli.GetHeaders()
+		if err != nil {
+			return err
+		}
+		req.Headers = headers
+	}
+
+	{
+		h, err := a.cli.GenSignature(req)
+		if err != nil {
+			return err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := a.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return errors.New(resp.Error())
+	}
+
+	return ar.ReadData(nil)
+}
+
+// IterateLedgers walks all the pages of GetLedgers.
+func (a *AccountClient) IterateLedgers(param types.GetLedgersParam, pageSize int64) *pagination.Iterator[utils.PaginationParam, *types.Ledger] {
+	return utils.NewPageIterator(func(ctx context.Context, p utils.PaginationParam) ([]*types.Ledger, *utils.PaginationModel, error) {
+		param.PaginationParam = p
+		return a.GetLedgers(ctx, param)
+	}, pageSize)
+}
+
+// IterateSubAccountBalances walks all the pages of GetSubAccountBalances.
+func (a *AccountClient) IterateSubAccountBalances(pageSize int64) *pagination.Iterator[utils.PaginationParam, *types.SubAccountBalance] {
+	return utils.NewPageIterator(func(ctx context.Context, p utils.PaginationParam) ([]*types.SubAccountBalance, *utils.PaginationModel, error) {
+		return a.GetSubAccountBalances(ctx, types.GetSubAccountBalancesParam{PaginationParam: p})
+	}, pageSize)
+}
+
+// IterateDeposits walks all the pages of GetDeposits.
+func (a *AccountClient) IterateDeposits(param types.GetDepositsParam, pageSize int64) *pagination.Iterator[utils.PaginationParam, *types.Deposit] {
+	return utils.NewPageIterator(func(ctx context.Context, p utils.PaginationParam) ([]*types.Deposit, *utils.PaginationModel, error) {
+		param.PaginationParam = p
+		return a.GetDeposits(ctx, param)
+	}, pageSize)
+}
+
+// IterateWithdrawals walks all the pages of GetWithdrawals.
+func (a *AccountClient) IterateWithdrawals(param types.GetWithdrawalsParam, pageSize int64) *pagination.Iterator[utils.PaginationParam, *types.Withdrawal] {
+	return utils.NewPageIterator(func(ctx context.Context, p utils.PaginationParam) ([]*types.Withdrawal, *utils.PaginationModel, error) {
+		param.PaginationParam = p
+		return a.GetWithdrawals(ctx, param)
+	}, pageSize)
+}
@@ -0,0 +1,215 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package account
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/rluisr/nexapi/kucoin/kucointest"
+	"github.com/rluisr/nexapi/kucoin/rest/account/types"
+	"github.com/rluisr/nexapi/utils/fakeserver"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNewFakeAccountClient(t *testing.T, secret string) (*AccountClient, *fakeserver.Server) {
+	srv := kucointest.NewServer()
+	t.Cleanup(srv.Close)
+
+	cli, err := NewAccountClient(&AccountClientCfg{
+		BaseURL:    srv.URL,
+		Key:        kucointest.Key,
+		KeyVersion: kucointest.KeyVersion,
+		Secret:     secret,
+		Passphrase: kucointest.Passphrase,
+	})
+	if err != nil {
+		t.Fatalf("Could not create kucoin client, %s", err)
+	}
+
+	return cli, srv
+}
+
+func TestFakeEndpoints(t *testing.T) {
+	cli, srv := testNewFakeAccountClient(t, kucointest.Secret)
+
+	for _, path := range []string{"/api/v1/accounts/ledgers", "/api/v2/sub-accounts", "/api/v1/deposits", "/api/v1/withdrawals"} {
+		srv.Handle(http.MethodGet, path, kucointest.EmptyPage)
+	}
+
+	fakeserver.RunEndpoints(t, srv, true, []fakeserver.Endpoint{
+		{Name: "GetAccountList", Method: http.MethodGet, Path: "/api/v1/accounts", Call: func(ctx context.Context) (any, error) {
+			return cli.GetAccountList(ctx, types.GetAccountListParam{})
+		}},
+		{Name: "GetAccountDetail", Method: http.MethodGet, Path: "/api/v1/accounts/5bd6e9286d99522a52e458de", Call: func(ctx context.Context) (any, error) {
+			return cli.GetAccountDetail(ctx, types.GetAccountDetailParam{AccountID: "5bd6e9286d99522a52e458de"})
+		}},
+		{Name: "GetLedgers", Method: http.MethodGet, Path: "/api/v1/accounts/ledgers", Call: func(ctx context.Context) (any, error) {
+			_, _, err := cli.GetLedgers(ctx, types.GetLedgersParam{})
+			return nil, err
+		}},
+		{Name: "InnerTransfer", Method: http.MethodPost, Path: "/api/v2/accounts/inner-transfer", Call: func(ctx context.Context) (any, error) {
+			return cli.InnerTransfer(ctx, types.InnerTransferParam{ClientOid: "t1", Currency: "USDT", From: types.MainAccount, To: types.TradeAccount, Amount: "10"})
+		}},
+		{Name: "GetSubAccountBalance", Method: http.MethodGet, Path: "/api/v1/sub-accounts/sub1", Call: func(ctx context.Context) (any, error) {
+			return cli.GetSubAccountBalance(ctx, types.GetSubAccountBalanceParam{SubUserID: "sub1"})
+		}},
+		{Name: "GetSubAccountBalances", Method: http.MethodGet, Path: "/api/v2/sub-accounts", Call: func(ctx context.Context) (any, error) {
+			_, _, err := cli.GetSubAccountBalances(ctx, types.GetSubAccountBalancesParam{})
+			return nil, err
+		}},
+		{Name: "SubTransfer", Method: http.MethodPost, Path: "/api/v2/accounts/sub-transfer", Call: func(ctx context.Context) (any, error) {
+			return cli.SubTransfer(ctx, types.SubTransferParam{ClientOid: "t1", Currency: "USDT", Amount: "10", Direction: "OUT", SubUserID: "sub1"})
+		}},
+		{Name: "CreateDepositAddress", Method: http.MethodPost, Path: "/api/v1/deposit-addresses", Call: func(ctx context.Context) (any, error) {
+			return cli.CreateDepositAddress(ctx, types.CreateDepositAddressParam{Currency: "USDT"})
+		}},
+		{Name: "GetDepositAddresses", Method: http.MethodGet, Path: "/api/v2/deposit-addresses", Call: func(ctx context.Context) (any, error) {
+			return cli.GetDepositAddresses(ctx, types.GetDepositAddressesParam{Currency: "USDT"})
+		}},
+		{Name: "GetDeposits", Method: http.MethodGet, Path: "/api/v1/deposits", Call: func(ctx context.Context) (any, error) {
+			_, _, err := cli.GetDeposits(ctx, types.GetDepositsParam{})
+			return nil, err
+		}},
+		{Name: "GetWithdrawals", Method: http.MethodGet, Path: "/api/v1/withdrawals", Call: func(ctx context.Context) (any, error) {
+			_, _, err := cli.GetWithdrawals(ctx, types.GetWithdrawalsParam{})
+			return nil, err
+		}},
+		{Name: "GetWithdrawalQuotas", Method: http.MethodGet, Path: "/api/v1/withdrawals/quotas", Call: func(ctx context.Context) (any, error) {
+			return cli.GetWithdrawalQuotas(ctx, types.GetWithdrawalQuotasParam{Currency: "USDT"})
+		}},
+		{Name: "ApplyWithdrawal", Method: http.MethodPost, Path: "/api/v1/withdrawals", Call: func(ctx context.Context) (any, error) {
+			return cli.ApplyWithdrawal(ctx, types.ApplyWithdrawalParam{Currency: "USDT", Address: "addr", Amount: "10"})
+		}},
+		{Name: "CancelWithdrawal", Method: http.MethodDelete, Path: "/api/v1/withdrawals/w1", Call: func(ctx context.Context) (any, error) {
+			return nil, cli.CancelWithdrawal(ctx, "w1")
+		}},
+	})
+}
+
+func TestFakeGetAccountList(t *testing.T) {
+	cli, srv := testNewFakeAccountClient(t, kucointest.Secret)
+
+	srv.Handle(http.MethodGet, "/api/v1/accounts", `{"code":"200000","data":[{"id":"a1","currency":"USDT","type":"trade","balance":"100.5","available":"90","holds":"10.5"}]}`)
+
+	accounts, err := cli.GetAccountList(context.Background(), types.GetAccountListParam{Currency: "USDT", Type: types.TradeAccount})
+	assert.Nil(t, err)
+	assert.Len(t, accounts, 1)
+	assert.Equal(t, "100.5", accounts[0].Balance)
+
+	q := srv.LastRequest().Query
+	assert.Equal(t, "USDT", q.Get("currency"))
+	assert.Equal(t, "trade", q.Get("type"))
+}
+
+func TestFakeInnerTransfer(t *testing.T) {
+	cli, srv := testNewFakeAccountClient(t, kucointest.Secret)
+
+	srv.Handle(http.MethodPost, "/api/v2/accounts/inner-transfer", `{"code":"200000","data":{"orderId":"5bd6e9286d99522a52e458de"}}`)
+
+	ret, err := cli.InnerTransfer(context.Background(), types.InnerTransferParam{ClientOid: "t1", Currency: "USDT", From: types.MainAccount, To: types.TradeHFAccount, Amount: "10"})
+	assert.Nil(t, err)
+	assert.Equal(t, "5bd6e9286d99522a52e458de", ret.OrderID)
+
+	var body types.InnerTransferParam
+	assert.Nil(t, srv.LastRequest().JSON(&body))
+	assert.Equal(t, types.TradeHFAccount, body.To)
+
+	// an API error code is reported with the message
+	srv.Handle(http.MethodPost, "/api/v2/accounts/inner-transfer", `{"code":"200004","msg":"Balance insufficient!"}`)
+	_, err = cli.InnerTransfer(context.Background(), types.InnerTransferParam{ClientOid: "t2", Currency: "USDT", From: types.MainAccount, To: types.TradeHFAccount, Amount: "10"})
+	assert.ErrorContains(t, err, "Balance insufficient!")
+}
+
+func TestFakeIterateLedgers(t *testing.T) {
+	cli, srv := testNewFakeAccountClient(t, kucointest.Secret)
+
+	pages := map[string]string{
+		"1": `{"code":"200000","data":{"currentPage":1,"pageSize":2,"totalNum":3,"totalPage":2,"items":[{"id":"l1"},{"id":"l2"}]}}`,
+		"2": `{"code":"200000","data":{"currentPage":2,"pageSize":2,"totalNum":3,"totalPage":2,"items":[{"id":"l3"}]}}`,
+	}
+	srv.HandleFunc(http.MethodGet, "/api/v1/accounts/ledgers", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(pages[r.URL.Query().Get("currentPage")]))
+	})
+
+	ledgers, err := cli.IterateLedgers(types.GetLedgersParam{Currency: "USDT"}, 2).All(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, ledgers, 3)
+	assert.Equal(t, "l3", ledgers[2].ID)
+
+	assert.Len(t, srv.Requests(), 2)
+	q := srv.LastRequest().Query
+	assert.Equal(t, "2", q.Get("pageSize"))
+	assert.Equal(t, "USDT", q.Get("currency"))
+}
+
+func TestFakeIterators(t *testing.T) {
+	cli, srv := testNewFakeAccountClient(t, kucointest.Secret)
+	ctx := context.Background()
+
+	// 3 items in pages of 2, the items are the same object with one key per type
+	item := func(n int) string {
+		return fmt.Sprintf(`{"id":"i%d","address":"i%d","subUserId":"i%d"}`, n, n, n)
+	}
+	pages := map[string]string{
+		"1": `{"code":"200000","data":{"currentPage":1,"pageSize":2,"totalNum":3,"totalPage":2,"items":[` + item(1) + `,` + item(2) + `]}}`,
+		"2": `{"code":"200000","data":{"currentPage":2,"pageSize":2,"totalNum":3,"totalPage":2,"items":[` + item(3) + `]}}`,
+	}
+	paginate := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(pages[r.URL.Query().Get("currentPage")]))
+	}
+	for _, path := range []string{"/api/v1/deposits", "/api/v1/withdrawals", "/api/v2/sub-accounts"} {
+		srv.HandleFunc(http.MethodGet, path, paginate)
+	}
+
+	deposits, err := cli.IterateDeposits(types.GetDepositsParam{Currency: "USDT"}, 2).All(ctx)
+	assert.Nil(t, err)
+	if assert.Len(t, deposits, 3) {
+		assert.Equal(t, "i3", deposits[2].Address)
+	}
+	assert.Equal(t, "USDT", srv.LastRequest().Query.Get("currency"))
+
+	withdrawals, err := cli.IterateWithdrawals(types.GetWithdrawalsParam{Currency: "USDT"}, 2).All(ctx)
+	assert.Nil(t, err)
+	if assert.Len(t, withdrawals, 3) {
+		assert.Equal(t, "i3", withdrawals[2].ID)
+	}
+	assert.Equal(t, "USDT", srv.LastRequest().Query.Get("currency"))
+
+	balances, err := cli.IterateSubAccountBalances(2).All(ctx)
+	assert.Nil(t, err)
+	if assert.Len(t, balances, 3) {
+		assert.Equal(t, "i3", balances[2].SubUserID)
+	}
+
+	// 3 iterators of 2 pages
+	assert.Len(t, srv.Requests(), 6)
+	for _, r := range srv.Requests() {
+		assert.Equal(t, "2", r.Query.Get("pageSize"))
+		assert.True(t, r.Signed)
+	}
+}
+
+func TestFakeInvalidSignature(t *testing.T) {
+	cli, _ := testNewFakeAccountClient(t, "wrong-secret")
+
+	_, err := cli.GetAccountList(context.Background(), types.GetAccountListParam{})
+	kucointest.AssertInvalidSignature(t, err)
+}
@@ -51,3 +51,63 @@ func TestGetAccountList(t *testing.T) {
 
 	assert.Nil(t, err)
 }
+
+func TestGetLedgers(t *testing.T) {
+	cli := testNewAccountClient(t)
+
+	_, _, err := cli.GetLedgers(context.TODO(), types.GetLedgersParam{
+		Currency: "USDT",
+	})
+	assert.Nil(t, err)
+}
+
+func TestIterateLedgers(t *testing.T) {
+	cli := testNewAccountClient(t)
+
+	it := cli.IterateLedgers(types.GetLedgersParam{
+		Currency: "USDT",
+	}, 50)
+	for it.Next(context.TODO()) {
+		assert.NotEmpty(t, it.Item().ID)
+	}
+	assert.Nil(t, it.Err())
+}
+
+func TestGetSubAccountBalances(t *testing.T) {
+	cli := testNewAccountClient(t)
+
+	_, _, err := cli.GetSubAccountBalances(context.TODO(), types.GetSubAccountBalancesParam{})
+	assert.Nil(t, err)
+}
+
+func TestGetDepositAddresses(t *testing.T) {
+	cli := testNewAccountClient(t)
+
+	_, err := cli.GetDepositAddresses(context.TODO(), types.GetDepositAddressesParam{
+		Currency: "USDT",
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetDeposits(t *testing.T) {
+	cli := testNewAccountClient(t)
+
+	_, _, err := cli.GetDeposits(context.TODO(), types.GetDepositsParam{})
+	assert.Nil(t, err)
+}
+
+func TestGetWithdrawals(t *testing.T) {
+	cli := testNewAccountClient(t)
+
+	_, _, err := cli.GetWithdrawals(context.TODO(), types.GetWithdrawalsParam{})
+	assert.Nil(t, err)
+}
+
+func TestGetWithdrawalQuotas(t *testing.T) {
+	cli := testNewAccountClient(t)
+
+	_, err := cli.GetWithdrawalQuotas(context.TODO(), types.GetWithdrawalQuotasParam{
+		Currency: "USDT",
+	})
+	assert.Nil(t, err)
+}
@@ -17,6 +17,11 @@
 
 package types
 
+import (
+	"github.com/rluisr/nexapi/kucoin/rest/utils"
+	"github.com/rluisr/nexapi/utils/decimal"
+)
+
 type GetAccountListParam struct {
 	Currency string `url:"currency,omitempty" validate:"omitempty"`
 	Type     string `url:"type,omitempty" validate:"omitempty"`
@@ -31,3 +36,62 @@ type AccountModel struct {
 	Available string `json:"available"`
 	Holds     string `json:"holds"`
 }
+
+type GetAccountDetailParam struct {
+	AccountID string `validate:"required"`
+}
+
+// An AccountDetail represents the detail of an account.
+type AccountDetail struct {
+	Currency  string `json:"currency"`
+	Balance   string `json:"balance"`
+	Available string `json:"available"`
+	Holds     string `json:"holds"`
+}
+
+type GetLedgersParam struct {
+	Currency  string `url:"currency,omitempty"`                                    // Currency (you can choose more than one currency), e.g. BTC,ETH
+	Direction string `url:"direction,omitempty" validate:"omitempty,oneof=in out"` // Direction: in, out
+	BizType   string `url:"bizType,omitempty"`                                     // Business type, e.g. DEPOSIT, WITHDRAW, TRANSFER, SUB_TRANSFER, TRADE_EXCHANGE, MARGIN_EXCHANGE, KUCOIN_BONUS
+	StartAt   int64  `url:"startAt,omitempty"`                                     // Start time (milisecond)
+	EndAt     int64  `url:"endAt,omitempty"`                                       // End time (milisecond)
+	utils.PaginationParam
+}
+
+// A Ledger represents an account ledger entry.
+type Ledger struct {
+	ID          string `json:"id"`
+	Currency    string `json:"currency"`
+	Amount      string `json:"amount"`
+	Fee         string `json:"fee"`
+	Balance     string `json:"balance"`
+	AccountType string `json:"accountType"` // Account type: MAIN, TRADE, MARGIN, CONTRACT
+	BizType     string `json:"bizType"`
+	Direction   string `json:"direction"`
+	CreatedAt   int64  `json:"createdAt"`
+	Context     string `json:"context"`
+}
+
+func (a *AccountModel) BalanceDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(a.Balance)
+}
+
+func (a *AccountModel) AvailableDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(a.Available)
+}
+
+func (a *AccountModel) HoldsDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(a.Holds)
+}
+
+func (a *AccountDetail) BalanceDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(a.Balance)
+}
+
+func (a *AccountDetail) AvailableDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(a.Available)
+}
+
+func (a *AccountDetail) HoldsDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(a.Holds)
+}
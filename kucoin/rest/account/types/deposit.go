@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import "github.com/rluisr/nexapi/kucoin/rest/utils"
+
+type CreateDepositAddressParam struct {
+	Currency string `json:"currency" validate:"required"`
+	Chain    string `json:"chain,omitempty"` // The chain name of currency, e.g. ERC20, TRC20. The default is the default chain of the currency
+}
+
+type GetDepositAddressesParam struct {
+	Currency string `url:"currency" validate:"required"`
+}
+
+// A DepositAddress represents a deposit address of a currency.
+type DepositAddress struct {
+	Address         string `json:"address"`
+	Memo            string `json:"memo"`
+	Chain           string `json:"chain"`
+	ContractAddress string `json:"contractAddress"`
+}
+
+type GetDepositsParam struct {
+	Currency string `url:"currency,omitempty"`
+	StartAt  int64  `url:"startAt,omitempty"`                                                      // Start time (milisecond)
+	EndAt    int64  `url:"endAt,omitempty"`                                                        // End time (milisecond)
+	Status   string `url:"status,omitempty" validate:"omitempty,oneof=PROCESSING SUCCESS FAILURE"` // Status: PROCESSING, SUCCESS, FAILURE
+	utils.PaginationParam
+}
+
+// A Deposit represents a deposit record.
+type Deposit struct {
+	Currency   string `json:"currency"`
+	Chain      string `json:"chain"`
+	Status     string `json:"status"`
+	Address    string `json:"address"`
+	Memo       string `json:"memo"`
+	IsInner    bool   `json:"isInner"`
+	Amount     string `json:"amount"`
+	Fee        string `json:"fee"`
+	WalletTxID string `json:"walletTxId"`
+	CreatedAt  int64  `json:"createdAt"`
+	UpdatedAt  int64  `json:"updatedAt"`
+	Remark     string `json:"remark"`
+}
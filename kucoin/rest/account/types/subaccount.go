@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import "github.com/rluisr/nexapi/kucoin/rest/utils"
+
+type GetSubAccountBalanceParam struct {
+	SubUserID         string `url:"-" validate:"required"`
+	IncludeBaseAmount bool   `url:"includeBaseAmount"` // Whether to return the assets with balance 0
+}
+
+type GetSubAccountBalancesParam struct {
+	utils.PaginationParam
+}
+
+// A SubAccountBalance represents the balances of a sub-account.
+type SubAccountBalance struct {
+	SubUserID      string             `json:"subUserId"`
+	SubName        string             `json:"subName"`
+	MainAccounts   []*SubAccountAsset `json:"mainAccounts"`
+	TradeAccounts  []*SubAccountAsset `json:"tradeAccounts"`
+	MarginAccounts []*SubAccountAsset `json:"marginAccounts"`
+}
+
+type SubAccountAsset struct {
+	Currency          string `json:"currency"`
+	Balance           string `json:"balance"`
+	Available         string `json:"available"`
+	Holds             string `json:"holds"`
+	BaseCurrency      string `json:"baseCurrency"`
+	BaseCurrencyPrice string `json:"baseCurrencyPrice"`
+	BaseAmount        string `json:"baseAmount"`
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+// AccountType is the type of an account.
+type AccountType = string
+
+const (
+	MainAccount       AccountType = "main"
+	TradeAccount      AccountType = "trade"
+	MarginAccount     AccountType = "margin"
+	IsolatedAccount   AccountType = "isolated"
+	TradeHFAccount    AccountType = "trade_hf"
+	MarginV2Account   AccountType = "margin_v2"
+	IsolatedV2Account AccountType = "isolated_v2"
+	ContractAccount   AccountType = "contract"
+)
+
+type InnerTransferParam struct {
+	ClientOid string      `json:"clientOid" validate:"required"` // Unique order id created by users to identify their orders, e.g. UUID, with a maximum length of 128 bits
+	Currency  string      `json:"currency" validate:"required"`
+	From      AccountType `json:"from" validate:"required"`
+	To        AccountType `json:"to" validate:"required"`
+	Amount    string      `json:"amount" validate:"required"`
+	FromTag   string      `json:"fromTag,omitempty"` // Trading pair, required when the payment account type is isolated, e.g. BTC-USDT
+	ToTag     string      `json:"toTag,omitempty"`   // Trading pair, required when the receiving account type is isolated, e.g. BTC-USDT
+}
+
+type TransferResult struct {
+	OrderID string `json:"orderId"`
+}
+
+type SubTransferParam struct {
+	ClientOid      string `json:"clientOid" validate:"required"`
+	Currency       string `json:"currency" validate:"required"`
+	Amount         string `json:"amount" validate:"required"`
+	Direction      string `json:"direction" validate:"required,oneof=OUT IN"`                                     // OUT: the master user to sub user, IN: the sub user to the master user
+	AccountType    string `json:"accountType,omitempty" validate:"omitempty,oneof=MAIN TRADE MARGIN CONTRACT"`    // Account type of the master user, the default is MAIN
+	SubAccountType string `json:"subAccountType,omitempty" validate:"omitempty,oneof=MAIN TRADE MARGIN CONTRACT"` // Account type of the sub user, the default is MAIN
+	SubUserID      string `json:"subUserId" validate:"required"`
+}
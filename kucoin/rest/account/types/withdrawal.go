@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import "github.com/rluisr/nexapi/kucoin/rest/utils"
+
+type GetWithdrawalsParam struct {
+	Currency string `url:"currency,omitempty"`
+	Status   string `url:"status,omitempty" validate:"omitempty,oneof=PROCESSING WALLET_PROCESSING SUCCESS FAILURE"`
+	StartAt  int64  `url:"startAt,omitempty"` // Start time (milisecond)
+	EndAt    int64  `url:"endAt,omitempty"`   // End time (milisecond)
+	utils.PaginationParam
+}
+
+// A Withdrawal represents a withdrawal record.
+type Withdrawal struct {
+	ID         string `json:"id"`
+	Address    string `json:"address"`
+	Memo       string `json:"memo"`
+	Currency   string `json:"currency"`
+	Chain      string `json:"chain"`
+	Amount     string `json:"amount"`
+	Fee        string `json:"fee"`
+	WalletTxID string `json:"walletTxId"`
+	IsInner    bool   `json:"isInner"`
+	Status     string `json:"status"`
+	Remark     string `json:"remark"`
+	CreatedAt  int64  `json:"createdAt"`
+	UpdatedAt  int64  `json:"updatedAt"`
+}
+
+type GetWithdrawalQuotasParam struct {
+	Currency string `url:"currency" validate:"required"`
+	Chain    string `url:"chain,omitempty"`
+}
+
+type WithdrawalQuotas struct {
+	Currency                 string `json:"currency"`
+	LimitBTCAmount           string `json:"limitBTCAmount"`
+	UsedBTCAmount            string `json:"usedBTCAmount"`
+	QuotaCurrency            string `json:"quotaCurrency"`
+	LimitQuotaCurrencyAmount string `json:"limitQuotaCurrencyAmount"`
+	UsedQuotaCurrencyAmount  string `json:"usedQuotaCurrencyAmount"`
+	RemainAmount             string `json:"remainAmount"`
+	AvailableAmount          string `json:"availableAmount"`
+	WithdrawMinFee           string `json:"withdrawMinFee"`
+	InnerWithdrawMinFee      string `json:"innerWithdrawMinFee"`
+	WithdrawMinSize          string `json:"withdrawMinSize"`
+	IsWithdrawEnabled        bool   `json:"isWithdrawEnabled"`
+	Precision                int64  `json:"precision"`
+	Chain                    string `json:"chain"`
+}
+
+type ApplyWithdrawalParam struct {
+	Currency      string `json:"currency" validate:"required"`
+	Address       string `json:"address" validate:"required"`
+	Amount        string `json:"amount" validate:"required"`
+	Memo          string `json:"memo,omitempty"`
+	IsInner       bool   `json:"isInner,omitempty"` // Internal withdrawal or not. Default: false
+	Remark        string `json:"remark,omitempty"`
+	Chain         string `json:"chain,omitempty"`
+	FeeDeductType string `json:"feeDeductType,omitempty" validate:"omitempty,oneof=INTERNAL EXTERNAL"` // Withdrawal fee deduction type: INTERNAL or EXTERNAL
+}
+
+type ApplyWithdrawalResult struct {
+	WithdrawalID string `json:"withdrawalId"`
+}
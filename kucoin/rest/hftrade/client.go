@@ -0,0 +1,778 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hftrade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/rluisr/nexapi/kucoin/rest/hftrade/types"
+	"github.com/rluisr/nexapi/kucoin/rest/utils"
+)
+
+type HFTradeClient struct {
+	cli *utils.KucoinClient
+
+	// validate struct fields
+	validate *validator.Validate
+}
+
+type HFTradeClientCfg struct {
+	Debug bool
+	// Logger
+	Logger *slog.Logger
+
+	BaseURL    string `validate:"required"`
+	HTTPClient *http.Client
+	Key        string `validate:"required"`
+	KeyVersion string `validate:"required"`
+	Secret     string `validate:"required"`
+	Passphrase string `validate:"required"`
+}
+
+func NewHFTradeClient(cfg *HFTradeClientCfg) (*HFTradeClient, error) {
+	validator := validator.New()
+
+	err := validator.Struct(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := utils.NewKucoinRestClient(&utils.KucoinClientCfg{
+		Debug:      cfg.Debug,
+		Logger:     cfg.Logger,
+		BaseURL:    cfg.BaseURL,
+		HTTPClient: cfg.HTTPClient,
+		Key:        cfg.Key,
+		KeyVersion: cfg.KeyVersion,
+		Secret:     cfg.Secret,
+		Passphrase: cfg.Passphrase,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &HFTradeClient{
+		cli:      cli,
+		validate: validator,
+	}, nil
+}
+
+// PlaceOrder places an order in the HF trading system, the result is returned right after the order enters the matching engine.
+func (h *HFTradeClient) PlaceOrder(ctx context.Context, param types.PlaceOrderParam) (*types.PlaceOrderResult, error) {
+	req := utils.HTTPRequest{
+		BaseURL: h.cli.GetBaseURL(),
+		Path:    "/api/v1/hf/orders",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := h.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := h.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := h.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := h.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.PlaceOrderResult
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// SyncPlaceOrder places an order and waits for the matching result.
+func (h *HFTradeClient) SyncPlaceOrder(ctx context.Context, param types.PlaceOrderParam) (*types.SyncPlaceOrderResult, error) {
+	req := utils.HTTPRequest{
+		BaseURL: h.cli.GetBaseURL(),
+		Path:    "/api/v1/hf/orders/sync",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := h.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := h.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := h.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := h.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.SyncPlaceOrderResult
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// PlaceMultiOrders places up to 5 orders of the same symbol at once.
+func (h *HFTradeClient) PlaceMultiOrders(ctx context.Context, param types.PlaceMultiOrdersParam) ([]*types.PlaceMultiOrdersResult, error) {
+	req := utils.HTTPRequest{
+		BaseURL: h.cli.GetBaseURL(),
+		Path:    "/api/v1/hf/orders/multi",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := h.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := h.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := h.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := h.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret []*types.PlaceMultiOrdersResult
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// ModifyOrder modifies the price or size of an order by cancelling it and placing a new one.
+func (h *HFTradeClient) ModifyOrder(ctx context.Context, param types.ModifyOrderParam) (*types.ModifyOrderResult, error) {
+	req := utils.HTTPRequest{
+		BaseURL: h.cli.GetBaseURL(),
+		Path:    "/api/v1/hf/orders/alter",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := h.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := h.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := h.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := h.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.ModifyOrderResult
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// CancelOrder cancels an order by the order id.
+func (h *HFTradeClient) CancelOrder(ctx context.Context, param types.CancelOrderParam) (*types.CancelOrderResult, error) {
+	req := utils.HTTPRequest{
+		BaseURL: h.cli.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/hf/orders/%s", param.OrderID),
+		Method:  http.MethodDelete,
+		Query:   param,
+	}
+
+	{
+		headers, err := h.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := h.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := h.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := h.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.CancelOrderResult
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// SyncCancelOrder cancels an order by the order id and waits for the cancellation result.
+func (h *HFTradeClient) SyncCancelOrder(ctx context.Context, param types.CancelOrderParam) (*types.SyncCancelOrderResult, error) {
+	req := utils.HTTPRequest{
+		BaseURL: h.cli.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/hf/orders/sync/%s", param.OrderID),
+		Method:  http.MethodDelete,
+		Query:   param,
+	}
+
+	{
+		headers, err := h.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := h.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := h.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := h.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.SyncCancelOrderResult
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// CancelOrderByClientOid cancels an order by the client order id.
+func (h *HFTradeClient) CancelOrderByClientOid(ctx context.Context, param types.CancelOrderByClientOidParam) (*types.CancelOrderByClientOidResult, error) {
+	req := utils.HTTPRequest{
+		BaseURL: h.cli.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/hf/orders/client-order/%s", param.ClientOid),
+		Method:  http.MethodDelete,
+		Query:   param,
+	}
+
+	{
+		headers, err := h.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := h.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := h.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := h.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.CancelOrderByClientOidResult
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// SyncCancelOrderByClientOid cancels an order by the client order id and waits for the cancellation result.
+func (h *HFTradeClient) SyncCancelOrderByClientOid(ctx context.Context, param types.CancelOrderByClientOidParam) (*types.SyncCancelOrderResult, error) {
+	req := utils.HTTPRequest{
+		BaseURL: h.cli.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/hf/orders/sync/client-order/%s", param.ClientOid),
+		Method:  http.MethodDelete,
+		Query:   param,
+	}
+
+	{
+		headers, err := h.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := h.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := h.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := h.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.SyncCancelOrderResult
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// CancelAllOrders cancels all the HF orders of a symbol.
+func (h *HFTradeClient) CancelAllOrders(ctx context.Context, param types.CancelAllOrdersParam) (string, error) {
+	req := utils.HTTPRequest{
+		BaseURL: h.cli.GetBaseURL(),
+		Path:    "/api/v1/hf/orders",
+		Method:  http.MethodDelete,
+		Query:   param,
+	}
+
+	{
+		headers, err := h.cli.GetHeaders()
+		if err != nil {
+			return "", err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := h.validate.Struct(param)
+		if err != nil {
+			return "", err
+		}
+
+		h, err := h.cli.GenSignature(req)
+		if err != nil {
+			return "", err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := h.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return "", errors.New(resp.Error())
+	}
+
+	var ret string
+	if err := ar.ReadData(&ret); err != nil {
+		return "", err
+	}
+
+	return ret, nil
+}
+
+// GetActiveOrders gets all the active HF orders of a symbol.
+func (h *HFTradeClient) GetActiveOrders(ctx context.Context, param types.GetActiveOrdersParam) ([]*types.Order, error) {
+	req := utils.HTTPRequest{
+		BaseURL: h.cli.GetBaseURL(),
+		Path:    "/api/v1/hf/orders/active",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := h.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := h.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := h.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := h.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret []*types.Order
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// GetDoneOrders gets the filled or cancelled HF orders of a symbol, paginated by lastId.
+func (h *HFTradeClient) GetDoneOrders(ctx context.Context, param types.GetDoneOrdersParam) (*types.DoneOrders, error) {
+	req := utils.HTTPRequest{
+		BaseURL: h.cli.GetBaseURL(),
+		Path:    "/api/v1/hf/orders/done",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := h.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := h.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := h.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := h.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.DoneOrders
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetOrder gets a single HF order by the order id.
+func (h *HFTradeClient) GetOrder(ctx context.Context, param types.GetOrderParam) (*types.Order, error) {
+	req := utils.HTTPRequest{
+		BaseURL: h.cli.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/hf/orders/%s", param.OrderID),
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := h.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := h.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := h.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := h.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.Order
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetOrderByClientOid gets a single HF order by the client order id.
+func (h *HFTradeClient) GetOrderByClientOid(ctx context.Context, param types.GetOrderByClientOidParam) (*types.Order, error) {
+	req := utils.HTTPRequest{
+		BaseURL: h.cli.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/hf/orders/client-order/%s", param.ClientOid),
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := h.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := h.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := h.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := h.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.Order
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetFills gets the latest HF transaction details, paginated by lastId.
+func (h *HFTradeClient) GetFills(ctx context.Context, param types.GetFillsParam) (*types.Fills, error) {
+	req := utils.HTTPRequest{
+		BaseURL: h.cli.GetBaseURL(),
+		Path:    "/api/v1/hf/fills",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := h.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := h.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := h.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := h.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.Fills
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
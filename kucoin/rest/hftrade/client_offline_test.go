@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hftrade
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/rluisr/nexapi/kucoin/kucointest"
+	"github.com/rluisr/nexapi/kucoin/rest/hftrade/types"
+	"github.com/rluisr/nexapi/utils/fakeserver"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNewFakeHFTradeClient(t *testing.T, secret string) (*HFTradeClient, *fakeserver.Server) {
+	srv := kucointest.NewServer()
+	t.Cleanup(srv.Close)
+
+	cli, err := NewHFTradeClient(&HFTradeClientCfg{
+		BaseURL:    srv.URL,
+		Key:        kucointest.Key,
+		KeyVersion: kucointest.KeyVersion,
+		Secret:     secret,
+		Passphrase: kucointest.Passphrase,
+	})
+	if err != nil {
+		t.Fatalf("Could not create kucoin client, %s", err)
+	}
+
+	return cli, srv
+}
+
+func TestFakeEndpoints(t *testing.T) {
+	cli, srv := testNewFakeHFTradeClient(t, kucointest.Secret)
+
+	order := types.PlaceOrderParam{ClientOid: "c1", Symbol: "BTC-USDT", Type: "limit", Side: "buy", Price: "42000", Size: "0.01"}
+
+	fakeserver.RunEndpoints(t, srv, true, []fakeserver.Endpoint{
+		{Name: "PlaceOrder", Method: http.MethodPost, Path: "/api/v1/hf/orders", Call: func(ctx context.Context) (any, error) {
+			return cli.PlaceOrder(ctx, order)
+		}},
+		{Name: "SyncPlaceOrder", Method: http.MethodPost, Path: "/api/v1/hf/orders/sync", Call: func(ctx context.Context) (any, error) {
+			return cli.SyncPlaceOrder(ctx, order)
+		}},
+		{Name: "PlaceMultiOrders", Method: http.MethodPost, Path: "/api/v1/hf/orders/multi", Call: func(ctx context.Context) (any, error) {
+			return cli.PlaceMultiOrders(ctx, types.PlaceMultiOrdersParam{OrderList: []*types.PlaceOrderParam{&order}})
+		}},
+		{Name: "ModifyOrder", Method: http.MethodPost, Path: "/api/v1/hf/orders/alter", Call: func(ctx context.Context) (any, error) {
+			return cli.ModifyOrder(ctx, types.ModifyOrderParam{Symbol: "BTC-USDT", OrderID: "o1", NewPrice: "42100"})
+		}},
+		{Name: "CancelOrder", Method: http.MethodDelete, Path: "/api/v1/hf/orders/o1", Call: func(ctx context.Context) (any, error) {
+			return cli.CancelOrder(ctx, types.CancelOrderParam{OrderID: "o1", Symbol: "BTC-USDT"})
+		}},
+		{Name: "SyncCancelOrder", Method: http.MethodDelete, Path: "/api/v1/hf/orders/sync/o1", Call: func(ctx context.Context) (any, error) {
+			return cli.SyncCancelOrder(ctx, types.CancelOrderParam{OrderID: "o1", Symbol: "BTC-USDT"})
+		}},
+		{Name: "CancelOrderByClientOid", Method: http.MethodDelete, Path: "/api/v1/hf/orders/client-order/c1", Call: func(ctx context.Context) (any, error) {
+			return cli.CancelOrderByClientOid(ctx, types.CancelOrderByClientOidParam{ClientOid: "c1", Symbol: "BTC-USDT"})
+		}},
+		{Name: "SyncCancelOrderByClientOid", Method: http.MethodDelete, Path: "/api/v1/hf/orders/sync/client-order/c1", Call: func(ctx context.Context) (any, error) {
+			return cli.SyncCancelOrderByClientOid(ctx, types.CancelOrderByClientOidParam{ClientOid: "c1", Symbol: "BTC-USDT"})
+		}},
+		{Name: "CancelAllOrders", Method: http.MethodDelete, Path: "/api/v1/hf/orders", Call: func(ctx context.Context) (any, error) {
+			return cli.CancelAllOrders(ctx, types.CancelAllOrdersParam{Symbol: "BTC-USDT"})
+		}},
+		{Name: "GetActiveOrders", Method: http.MethodGet, Path: "/api/v1/hf/orders/active", Call: func(ctx context.Context) (any, error) {
+			return cli.GetActiveOrders(ctx, types.GetActiveOrdersParam{Symbol: "BTC-USDT"})
+		}},
+		{Name: "GetDoneOrders", Method: http.MethodGet, Path: "/api/v1/hf/orders/done", Call: func(ctx context.Context) (any, error) {
+			return cli.GetDoneOrders(ctx, types.GetDoneOrdersParam{Symbol: "BTC-USDT"})
+		}},
+		{Name: "GetOrder", Method: http.MethodGet, Path: "/api/v1/hf/orders/o1", Call: func(ctx context.Context) (any, error) {
+			return cli.GetOrder(ctx, types.GetOrderParam{OrderID: "o1", Symbol: "BTC-USDT"})
+		}},
+		{Name: "GetOrderByClientOid", Method: http.MethodGet, Path: "/api/v1/hf/orders/client-order/c1", Call: func(ctx context.Context) (any, error) {
+			return cli.GetOrderByClientOid(ctx, types.GetOrderByClientOidParam{ClientOid: "c1", Symbol: "BTC-USDT"})
+		}},
+		{Name: "GetFills", Method: http.MethodGet, Path: "/api/v1/hf/fills", Call: func(ctx context.Context) (any, error) {
+			return cli.GetFills(ctx, types.GetFillsParam{Symbol: "BTC-USDT"})
+		}},
+	})
+}
+
+func TestFakePlaceOrder(t *testing.T) {
+	cli, srv := testNewFakeHFTradeClient(t, kucointest.Secret)
+
+	srv.Handle(http.MethodPost, "/api/v1/hf/orders", `{"code":"200000","data":{"orderId":"6d539dc614db3","clientOid":"c1"}}`)
+
+	ret, err := cli.PlaceOrder(context.Background(), types.PlaceOrderParam{ClientOid: "c1", Symbol: "BTC-USDT", Type: "limit", Side: "buy", Price: "42000", Size: "0.01", PostOnly: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "6d539dc614db3", ret.OrderID)
+
+	var body types.PlaceOrderParam
+	assert.Nil(t, srv.LastRequest().JSON(&body))
+	assert.Equal(t, "42000", body.Price)
+	assert.True(t, body.PostOnly)
+
+	// an invalid order never reaches the server
+	n := len(srv.Requests())
+	_, err = cli.PlaceOrder(context.Background(), types.PlaceOrderParam{Symbol: "BTC-USDT", Type: "stop", Side: "buy"})
+	assert.NotNil(t, err)
+	assert.Len(t, srv.Requests(), n)
+}
+
+func TestFakeGetOrder(t *testing.T) {
+	cli, srv := testNewFakeHFTradeClient(t, kucointest.Secret)
+
+	srv.Handle(http.MethodGet, "/api/v1/hf/orders/o1", `{"code":"200000","data":{"id":"o1","symbol":"BTC-USDT","type":"limit","side":"buy","price":"42000","size":"0.01","dealSize":"0.005","active":true}}`)
+
+	order, err := cli.GetOrder(context.Background(), types.GetOrderParam{OrderID: "o1", Symbol: "BTC-USDT"})
+	assert.Nil(t, err)
+	assert.Equal(t, "0.005", order.DealSize)
+	assert.Equal(t, "BTC-USDT", srv.LastRequest().Query.Get("symbol"))
+
+	// a server error is reported with the status code
+	srv.Fail(http.MethodGet, "/api/v1/hf/orders/o1", fakeserver.Response{Status: http.StatusServiceUnavailable, Body: `{"code":"503000","msg":"Service Unavailable"}`})
+	_, err = cli.GetOrder(context.Background(), types.GetOrderParam{OrderID: "o1", Symbol: "BTC-USDT"})
+	assert.ErrorContains(t, err, "respond code=503")
+}
+
+func TestFakeInvalidSignature(t *testing.T) {
+	cli, _ := testNewFakeHFTradeClient(t, "wrong-secret")
+
+	_, err := cli.GetActiveOrders(context.Background(), types.GetActiveOrdersParam{Symbol: "BTC-USDT"})
+	kucointest.AssertInvalidSignature(t, err)
+}
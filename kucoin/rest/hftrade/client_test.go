@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hftrade
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/rluisr/nexapi/kucoin/rest/hftrade/types"
+	"github.com/rluisr/nexapi/kucoin/rest/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNewHFTradeClient(t *testing.T) *HFTradeClient {
+	cli, err := NewHFTradeClient(&HFTradeClientCfg{
+		BaseURL:    utils.SpotBaseURL,
+		Key:        os.Getenv("KUCOIN_KEY"),
+		KeyVersion: utils.ApiKeyVersionV2,
+		Secret:     os.Getenv("KUCOIN_SECRET"),
+		Passphrase: os.Getenv("KUCOIN_PASS"),
+		Debug:      true,
+	})
+
+	if err != nil {
+		t.Fatalf("Could not create kucoin client, %s", err)
+	}
+
+	return cli
+}
+
+// TestPlaceAndCancelOrder places a limit order far from the market, reads and cancels it,
+// it only runs with KUCOIN_ORDER=1.
+func TestPlaceAndCancelOrder(t *testing.T) {
+	if os.Getenv("KUCOIN_ORDER") != "1" {
+		t.Skip("set KUCOIN_ORDER=1 to place orders on the live API")
+	}
+
+	cli := testNewHFTradeClient(t)
+
+	order, err := cli.PlaceOrder(context.TODO(), types.PlaceOrderParam{
+		ClientOid: "nexapi-test-hf-order",
+		Symbol:    "BTC-USDT",
+		Type:      "limit",
+		Side:      "buy",
+		Price:     "10000",
+		Size:      "0.0001",
+	})
+	assert.Nil(t, err)
+	if err != nil {
+		return
+	}
+
+	_, err = cli.GetOrder(context.TODO(), types.GetOrderParam{
+		OrderID: order.OrderID,
+		Symbol:  "BTC-USDT",
+	})
+	assert.Nil(t, err)
+
+	_, err = cli.CancelOrder(context.TODO(), types.CancelOrderParam{
+		OrderID: order.OrderID,
+		Symbol:  "BTC-USDT",
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetActiveOrders(t *testing.T) {
+	cli := testNewHFTradeClient(t)
+
+	_, err := cli.GetActiveOrders(context.TODO(), types.GetActiveOrdersParam{
+		Symbol: "BTC-USDT",
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetDoneOrders(t *testing.T) {
+	cli := testNewHFTradeClient(t)
+
+	_, err := cli.GetDoneOrders(context.TODO(), types.GetDoneOrdersParam{
+		Symbol: "BTC-USDT",
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetFills(t *testing.T) {
+	cli := testNewHFTradeClient(t)
+
+	_, err := cli.GetFills(context.TODO(), types.GetFillsParam{
+		Symbol: "BTC-USDT",
+	})
+	assert.Nil(t, err)
+}
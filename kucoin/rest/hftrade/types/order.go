@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+type PlaceOrderParam struct {
+	ClientOid   string `json:"clientOid,omitempty"` // Client Order Id, unique identifier created by the user, the use of UUID is recommended
+	Symbol      string `json:"symbol" validate:"required"`
+	Type        string `json:"type" validate:"required,oneof=limit market"`
+	Side        string `json:"side" validate:"required,oneof=buy sell"`
+	Stp         string `json:"stp,omitempty" validate:"omitempty,oneof=CN CO CB DC"` // self trade prevention
+	Tags        string `json:"tags,omitempty"`
+	Remark      string `json:"remark,omitempty"`
+	Price       string `json:"price,omitempty"` // Specify price for currency, required for limit orders
+	Size        string `json:"size,omitempty"`  // Specify quantity for currency, either size or funds is required for market orders
+	Funds       string `json:"funds,omitempty"` // Specify the amount of quote currency, only for market orders
+	TimeInForce string `json:"timeInForce,omitempty" validate:"omitempty,oneof=GTC GTT IOC FOK"`
+	CancelAfter int64  `json:"cancelAfter,omitempty"` // Cancel after n seconds, the order timing strategy is GTT
+	PostOnly    bool   `json:"postOnly,omitempty"`
+	Hidden      bool   `json:"hidden,omitempty"`
+	Iceberg     bool   `json:"iceberg,omitempty"`
+	VisibleSize string `json:"visibleSize,omitempty"`
+}
+
+type PlaceOrderResult struct {
+	OrderID   string `json:"orderId"`
+	ClientOid string `json:"clientOid"`
+}
+
+// A SyncPlaceOrderResult is returned once the order has been matched.
+type SyncPlaceOrderResult struct {
+	OrderID      string `json:"orderId"`
+	ClientOid    string `json:"clientOid"`
+	OrderTime    int64  `json:"orderTime"`
+	OriginSize   string `json:"originSize"`
+	DealSize     string `json:"dealSize"`
+	RemainSize   string `json:"remainSize"`
+	CanceledSize string `json:"canceledSize"`
+	Status       string `json:"status"` // open, done
+	MatchTime    int64  `json:"matchTime"`
+}
+
+type PlaceMultiOrdersParam struct {
+	OrderList []*PlaceOrderParam `json:"orderList" validate:"required,min=1,max=5,dive"`
+}
+
+type PlaceMultiOrdersResult struct {
+	OrderID   string `json:"orderId"`
+	ClientOid string `json:"clientOid"`
+	Success   bool   `json:"success"`
+	FailMsg   string `json:"failMsg"`
+}
+
+type CancelOrderParam struct {
+	OrderID string `url:"-" validate:"required"`
+	Symbol  string `url:"symbol" validate:"required"`
+}
+
+type CancelOrderResult struct {
+	OrderID string `json:"orderId"`
+}
+
+type CancelOrderByClientOidParam struct {
+	ClientOid string `url:"-" validate:"required"`
+	Symbol    string `url:"symbol" validate:"required"`
+}
+
+type CancelOrderByClientOidResult struct {
+	ClientOid string `json:"clientOid"`
+}
+
+type SyncCancelOrderResult struct {
+	OrderID      string `json:"orderId"`
+	ClientOid    string `json:"clientOid"`
+	OriginSize   string `json:"originSize"`
+	DealSize     string `json:"dealSize"`
+	RemainSize   string `json:"remainSize"`
+	CanceledSize string `json:"canceledSize"`
+	Status       string `json:"status"` // open, done
+}
+
+type CancelAllOrdersParam struct {
+	Symbol string `url:"symbol" validate:"required"`
+}
+
+type ModifyOrderParam struct {
+	Symbol    string `json:"symbol" validate:"required"`
+	ClientOid string `json:"clientOid,omitempty" validate:"required_without=OrderID"`
+	OrderID   string `json:"orderId,omitempty" validate:"required_without=ClientOid"`
+	NewPrice  string `json:"newPrice,omitempty"`
+	NewSize   string `json:"newSize,omitempty"`
+}
+
+type ModifyOrderResult struct {
+	NewOrderID string `json:"newOrderId"`
+	ClientOid  string `json:"clientOid"`
+}
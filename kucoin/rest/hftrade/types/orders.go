@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+type GetActiveOrdersParam struct {
+	Symbol string `url:"symbol" validate:"required"`
+}
+
+type GetDoneOrdersParam struct {
+	Symbol  string `url:"symbol" validate:"required"`
+	Side    string `url:"side,omitempty" validate:"omitempty,oneof=buy sell"`
+	Type    string `url:"type,omitempty" validate:"omitempty,oneof=limit market"`
+	StartAt int64  `url:"startAt,omitempty"` // Start time (milisecond)
+	EndAt   int64  `url:"endAt,omitempty"`   // End time (milisecond)
+	LastID  int64  `url:"lastId,omitempty"`  // The id of the last set of data from the previous batch of data
+	Limit   int64  `url:"limit,omitempty" validate:"omitempty,max=100"`
+}
+
+type DoneOrders struct {
+	LastID int64    `json:"lastId"`
+	Items  []*Order `json:"items"`
+}
+
+type GetOrderParam struct {
+	OrderID string `url:"-" validate:"required"`
+	Symbol  string `url:"symbol" validate:"required"`
+}
+
+type GetOrderByClientOidParam struct {
+	ClientOid string `url:"-" validate:"required"`
+	Symbol    string `url:"symbol" validate:"required"`
+}
+
+// An Order represents a HF order.
+type Order struct {
+	ID             string `json:"id"`
+	Symbol         string `json:"symbol"`
+	OpType         string `json:"opType"`
+	Type           string `json:"type"`
+	Side           string `json:"side"`
+	Price          string `json:"price"`
+	Size           string `json:"size"`
+	Funds          string `json:"funds"`
+	DealSize       string `json:"dealSize"`
+	DealFunds      string `json:"dealFunds"`
+	Fee            string `json:"fee"`
+	FeeCurrency    string `json:"feeCurrency"`
+	Stp            string `json:"stp"`
+	TimeInForce    string `json:"timeInForce"`
+	PostOnly       bool   `json:"postOnly"`
+	Hidden         bool   `json:"hidden"`
+	Iceberg        bool   `json:"iceberg"`
+	VisibleSize    string `json:"visibleSize"`
+	CancelAfter    int64  `json:"cancelAfter"`
+	Channel        string `json:"channel"`
+	ClientOid      string `json:"clientOid"`
+	Remark         string `json:"remark"`
+	Tags           string `json:"tags"`
+	Active         bool   `json:"active"`
+	InOrderBook    bool   `json:"inOrderBook"`
+	CancelExist    bool   `json:"cancelExist"`
+	CreatedAt      int64  `json:"createdAt"`
+	LastUpdatedAt  int64  `json:"lastUpdatedAt"`
+	TradeType      string `json:"tradeType"`
+	CancelledSize  string `json:"cancelledSize"`
+	CancelledFunds string `json:"cancelledFunds"`
+	RemainSize     string `json:"remainSize"`
+	RemainFunds    string `json:"remainFunds"`
+}
+
+type GetFillsParam struct {
+	Symbol  string `url:"symbol" validate:"required"`
+	OrderID string `url:"orderId,omitempty"`
+	Side    string `url:"side,omitempty" validate:"omitempty,oneof=buy sell"`
+	Type    string `url:"type,omitempty" validate:"omitempty,oneof=limit market"`
+	StartAt int64  `url:"startAt,omitempty"` // Start time (milisecond)
+	EndAt   int64  `url:"endAt,omitempty"`   // End time (milisecond)
+	LastID  int64  `url:"lastId,omitempty"`  // The id of the last set of data from the previous batch of data
+	Limit   int64  `url:"limit,omitempty" validate:"omitempty,max=100"`
+}
+
+type Fills struct {
+	LastID int64   `json:"lastId"`
+	Items  []*Fill `json:"items"`
+}
+
+type Fill struct {
+	ID             int64  `json:"id"`
+	Symbol         string `json:"symbol"`
+	TradeID        int64  `json:"tradeId"`
+	OrderID        string `json:"orderId"`
+	CounterOrderID string `json:"counterOrderId"`
+	Side           string `json:"side"`
+	Liquidity      string `json:"liquidity"` // taker or maker
+	ForceTaker     bool   `json:"forceTaker"`
+	Price          string `json:"price"`
+	Size           string `json:"size"`
+	Funds          string `json:"funds"`
+	Fee            string `json:"fee"`
+	FeeRate        string `json:"feeRate"`
+	FeeCurrency    string `json:"feeCurrency"`
+	Stop           string `json:"stop"`
+	TradeType      string `json:"tradeType"`
+	Type           string `json:"type"`
+	CreatedAt      int64  `json:"createdAt"`
+}
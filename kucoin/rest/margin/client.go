@@ -0,0 +1,497 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package margin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/rluisr/nexapi/kucoin/rest/margin/types"
+	"github.com/rluisr/nexapi/kucoin/rest/utils"
+	"github.com/rluisr/nexapi/utils/pagination"
+)
+
+type MarginClient struct {
+	cli *utils.KucoinClient
+
+	// validate struct fields
+	validate *validator.Validate
+}
+
+type MarginClientCfg struct {
+	Debug bool
+	// Logger
+	Logger *slog.Logger
+
+	BaseURL    string `validate:"required"`
+	HTTPClient *http.Client
+	Key        string `validate:"required"`
+	KeyVersion string `validate:"required"`
+	Secret     string `validate:"required"`
+	Passphrase string `validate:"required"`
+}
+
+func NewMarginClient(cfg *MarginClientCfg) (*MarginClient, error) {
+	validator := validator.New()
+
+	err := validator.Struct(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := utils.NewKucoinRestClient(&utils.KucoinClientCfg{
+		Debug:      cfg.Debug,
+		Logger:     cfg.Logger,
+		BaseURL:    cfg.BaseURL,
+		HTTPClient: cfg.HTTPClient,
+		Key:        cfg.Key,
+		KeyVersion: cfg.KeyVersion,
+		Secret:     cfg.Secret,
+		Passphrase: cfg.Passphrase,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MarginClient{
+		cli:      cli,
+		validate: validator,
+	}, nil
+}
+
+// GetCrossMarginAccount gets the info of the cross margin account.
+func (m *MarginClient) GetCrossMarginAccount(ctx context.Context, param types.GetCrossMarginAccountParam) (*types.CrossMarginAccount, error) {
+	req := utils.HTTPRequest{
+		BaseURL: m.cli.GetBaseURL(),
+		Path:    "/api/v3/margin/accounts",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := m.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := m.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := m.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := m.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.CrossMarginAccount
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetIsolatedMarginAccount gets the info of the isolated margin accounts.
+func (m *MarginClient) GetIsolatedMarginAccount(ctx context.Context, param types.GetIsolatedMarginAccountParam) (*types.IsolatedMarginAccount, error) {
+	req := utils.HTTPRequest{
+		BaseURL: m.cli.GetBaseURL(),
+		Path:    "/api/v3/isolated/accounts",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := m.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := m.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := m.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := m.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.IsolatedMarginAccount
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// Borrow initiates a loan in the cross or isolated margin account.
+func (m *MarginClient) Borrow(ctx context.Context, param types.BorrowParam) (*types.BorrowResult, error) {
+	req := utils.HTTPRequest{
+		BaseURL: m.cli.GetBaseURL(),
+		Path:    "/api/v3/margin/borrow",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := m.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := m.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := m.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := m.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.BorrowResult
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// Repay repays a loan in the cross or isolated margin account.
+func (m *MarginClient) Repay(ctx context.Context, param types.RepayParam) (*types.RepayResult, error) {
+	req := utils.HTTPRequest{
+		BaseURL: m.cli.GetBaseURL(),
+		Path:    "/api/v3/margin/repay",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := m.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := m.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := m.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := m.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.RepayResult
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetBorrowHistory gets the borrowing orders with pagination.
+func (m *MarginClient) GetBorrowHistory(ctx context.Context, param types.GetBorrowHistoryParam) ([]*types.Borrow, *utils.PaginationModel, error) {
+	req := utils.HTTPRequest{
+		BaseURL: m.cli.GetBaseURL(),
+		Path:    "/api/v3/margin/borrow",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := m.cli.GetHeaders()
+		if err != nil {
+			return nil, nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := m.validate.Struct(param)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		h, err := m.cli.GenSignature(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := m.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, nil, errors.New(resp.Error())
+	}
+
+	var ret []*types.Borrow
+	page, err := ar.ReadPaginationData(&ret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ret, page, nil
+}
+
+// GetRepayHistory gets the repayment orders with pagination.
+func (m *MarginClient) GetRepayHistory(ctx context.Context, param types.GetRepayHistoryParam) ([]*types.Repay, *utils.PaginationModel, error) {
+	req := utils.HTTPRequest{
+		BaseURL: m.cli.GetBaseURL(),
+		Path:    "/api/v3/margin/repay",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := m.cli.GetHeaders()
+		if err != nil {
+			return nil, nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := m.validate.Struct(param)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		h, err := m.cli.GenSignature(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := m.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, nil, errors.New(resp.Error())
+	}
+
+	var ret []*types.Repay
+	page, err := ar.ReadPaginationData(&ret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ret, page, nil
+}
+
+// PlaceHFOrder places a margin order in the HF trading system.
+func (m *MarginClient) PlaceHFOrder(ctx context.Context, param types.PlaceHFOrderParam) (*types.PlaceHFOrderResult, error) {
+	req := utils.HTTPRequest{
+		BaseURL: m.cli.GetBaseURL(),
+		Path:    "/api/v3/hf/margin/order",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := m.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := m.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := m.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := m.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.PlaceHFOrderResult
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// CancelHFOrder cancels a HF margin order by the order id.
+func (m *MarginClient) CancelHFOrder(ctx context.Context, param types.CancelHFOrderParam) (*types.CancelHFOrderResult, error) {
+	req := utils.HTTPRequest{
+		BaseURL: m.cli.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v3/hf/margin/orders/%s", param.OrderID),
+		Method:  http.MethodDelete,
+		Query:   param,
+	}
+
+	{
+		headers, err := m.cli.GetHeaders()
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	{
+		err := m.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := m.cli.GenSignature(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Headers[k] = v
+		}
+	}
+
+	resp, err := m.cli.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &utils.ApiResponse{Resp: resp}
+	if err := resp.ReadJsonBody(ar); err != nil {
+		return nil, errors.New(resp.Error())
+	}
+
+	var ret types.CancelHFOrderResult
+	if err := ar.ReadData(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// IterateBorrowHistory walks all the pages of GetBorrowHistory.
+func (m *MarginClient) IterateBorrowHistory(param types.GetBorrowHistoryParam, pageSize int64) *pagination.Iterator[utils.PaginationParam, *types.Borrow] {
+	return utils.NewPageIterator(func(ctx context.Context, p utils.PaginationParam) ([]*types.Borrow, *utils.PaginationModel, error) {
+		param.PaginationParam = p
+		return m.GetBorrowHistory(ctx, param)
+	}, pageSize)
+}
+
+// IterateRepayHistory walks all the pages of GetRepayHistory.
+func (m *MarginClient) IterateRepayHistory(param types.GetRepayHistoryParam, pageSize int64) *pagination.Iterator[utils.PaginationParam, *types.Repay] {
+	return utils.NewPageIterator(func(ctx context.Context, p utils.PaginationParam) ([]*types.Repay, *utils.PaginationModel, error) {
+		param.PaginationParam = p
+		return m.GetRepayHistory(ctx, param)
+	}, pageSize)
+}
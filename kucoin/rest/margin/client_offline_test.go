@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package margin
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/rluisr/nexapi/kucoin/kucointest"
+	"github.com/rluisr/nexapi/kucoin/rest/margin/types"
+	"github.com/rluisr/nexapi/utils/fakeserver"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNewFakeMarginClient(t *testing.T, secret string) (*MarginClient, *fakeserver.Server) {
+	srv := kucointest.NewServer()
+	t.Cleanup(srv.Close)
+
+	cli, err := NewMarginClient(&MarginClientCfg{
+		BaseURL:    srv.URL,
+		Key:        kucointest.Key,
+		KeyVersion: kucointest.KeyVersion,
+		Secret:     secret,
+		Passphrase: kucointest.Passphrase,
+	})
+	if err != nil {
+		t.Fatalf("Could not create kucoin client, %s", err)
+	}
+
+	return cli, srv
+}
+
+func TestFakeEndpoints(t *testing.T) {
+	cli, srv := testNewFakeMarginClient(t, kucointest.Secret)
+
+	srv.Handle(http.MethodGet, "/api/v3/margin/borrow", kucointest.EmptyPage)
+	srv.Handle(http.MethodGet, "/api/v3/margin/repay", kucointest.EmptyPage)
+
+	fakeserver.RunEndpoints(t, srv, true, []fakeserver.Endpoint{
+		{Name: "GetCrossMarginAccount", Method: http.MethodGet, Path: "/api/v3/margin/accounts", Call: func(ctx context.Context) (any, error) {
+			return cli.GetCrossMarginAccount(ctx, types.GetCrossMarginAccountParam{})
+		}},
+		{Name: "GetIsolatedMarginAccount", Method: http.MethodGet, Path: "/api/v3/isolated/accounts", Call: func(ctx context.Context) (any, error) {
+			return cli.GetIsolatedMarginAccount(ctx, types.GetIsolatedMarginAccountParam{})
+		}},
+		{Name: "Borrow", Method: http.MethodPost, Path: "/api/v3/margin/borrow", Call: func(ctx context.Context) (any, error) {
+			return cli.Borrow(ctx, types.BorrowParam{Currency: "USDT", Size: "10", TimeInForce: "IOC"})
+		}},
+		{Name: "Repay", Method: http.MethodPost, Path: "/api/v3/margin/repay", Call: func(ctx context.Context) (any, error) {
+			return cli.Repay(ctx, types.RepayParam{Currency: "USDT", Size: "10"})
+		}},
+		{Name: "GetBorrowHistory", Method: http.MethodGet, Path: "/api/v3/margin/borrow", Call: func(ctx context.Context) (any, error) {
+			_, _, err := cli.GetBorrowHistory(ctx, types.GetBorrowHistoryParam{Currency: "USDT"})
+			return nil, err
+		}},
+		{Name: "GetRepayHistory", Method: http.MethodGet, Path: "/api/v3/margin/repay", Call: func(ctx context.Context) (any, error) {
+			_, _, err := cli.GetRepayHistory(ctx, types.GetRepayHistoryParam{Currency: "USDT"})
+			return nil, err
+		}},
+		{Name: "PlaceHFOrder", Method: http.MethodPost, Path: "/api/v3/hf/margin/order", Call: func(ctx context.Context) (any, error) {
+			return cli.PlaceHFOrder(ctx, types.PlaceHFOrderParam{ClientOid: "c1", Side: "buy", Symbol: "BTC-USDT", Type: "limit", Price: "42000", Size: "0.01"})
+		}},
+		{Name: "CancelHFOrder", Method: http.MethodDelete, Path: "/api/v3/hf/margin/orders/o1", Call: func(ctx context.Context) (any, error) {
+			return cli.CancelHFOrder(ctx, types.CancelHFOrderParam{OrderID: "o1", Symbol: "BTC-USDT"})
+		}},
+	})
+}
+
+func TestFakeBorrow(t *testing.T) {
+	cli, srv := testNewFakeMarginClient(t, kucointest.Secret)
+
+	srv.Handle(http.MethodPost, "/api/v3/margin/borrow", `{"code":"200000","data":{"orderNo":"b1","actualSize":"10"}}`)
+
+	ret, err := cli.Borrow(context.Background(), types.BorrowParam{Currency: "USDT", Size: "10", TimeInForce: "FOK", IsIsolated: true, Symbol: "BTC-USDT"})
+	assert.Nil(t, err)
+	assert.Equal(t, "b1", ret.OrderNo)
+
+	var body types.BorrowParam
+	assert.Nil(t, srv.LastRequest().JSON(&body))
+	assert.True(t, body.IsIsolated)
+	assert.Equal(t, "BTC-USDT", body.Symbol)
+
+	// the symbol is required for an isolated borrowing
+	n := len(srv.Requests())
+	_, err = cli.Borrow(context.Background(), types.BorrowParam{Currency: "USDT", Size: "10", TimeInForce: "FOK", IsIsolated: true})
+	assert.NotNil(t, err)
+	assert.Len(t, srv.Requests(), n)
+}
+
+func TestFakeIterateHistory(t *testing.T) {
+	cli, srv := testNewFakeMarginClient(t, kucointest.Secret)
+	ctx := context.Background()
+
+	pages := map[string]string{
+		"1": `{"code":"200000","data":{"currentPage":1,"pageSize":2,"totalNum":3,"totalPage":2,"items":[{"orderNo":"1"},{"orderNo":"2"}]}}`,
+		"2": `{"code":"200000","data":{"currentPage":2,"pageSize":2,"totalNum":3,"totalPage":2,"items":[{"orderNo":"3"}]}}`,
+	}
+	paginate := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(pages[r.URL.Query().Get("currentPage")]))
+	}
+	srv.HandleFunc(http.MethodGet, "/api/v3/margin/borrow", paginate)
+	srv.HandleFunc(http.MethodGet, "/api/v3/margin/repay", paginate)
+
+	borrows, err := cli.IterateBorrowHistory(types.GetBorrowHistoryParam{Currency: "USDT"}, 2).All(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, borrows, 3)
+
+	repays, err := cli.IterateRepayHistory(types.GetRepayHistoryParam{Currency: "USDT"}, 2).All(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, repays, 3)
+	assert.Equal(t, "3", repays[2].OrderNo)
+
+	assert.Len(t, srv.Requests(), 4)
+}
+
+func TestFakeInvalidSignature(t *testing.T) {
+	cli, _ := testNewFakeMarginClient(t, "wrong-secret")
+
+	_, err := cli.GetCrossMarginAccount(context.Background(), types.GetCrossMarginAccountParam{})
+	kucointest.AssertInvalidSignature(t, err)
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package margin
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/rluisr/nexapi/kucoin/rest/margin/types"
+	"github.com/rluisr/nexapi/kucoin/rest/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNewMarginClient(t *testing.T) *MarginClient {
+	cli, err := NewMarginClient(&MarginClientCfg{
+		BaseURL:    utils.SpotBaseURL,
+		Key:        os.Getenv("KUCOIN_KEY"),
+		KeyVersion: utils.ApiKeyVersionV2,
+		Secret:     os.Getenv("KUCOIN_SECRET"),
+		Passphrase: os.Getenv("KUCOIN_PASS"),
+		Debug:      true,
+	})
+
+	if err != nil {
+		t.Fatalf("Could not create kucoin client, %s", err)
+	}
+
+	return cli
+}
+
+func TestGetCrossMarginAccount(t *testing.T) {
+	cli := testNewMarginClient(t)
+
+	_, err := cli.GetCrossMarginAccount(context.TODO(), types.GetCrossMarginAccountParam{})
+	assert.Nil(t, err)
+}
+
+func TestGetIsolatedMarginAccount(t *testing.T) {
+	cli := testNewMarginClient(t)
+
+	_, err := cli.GetIsolatedMarginAccount(context.TODO(), types.GetIsolatedMarginAccountParam{})
+	assert.Nil(t, err)
+}
+
+func TestGetBorrowHistory(t *testing.T) {
+	cli := testNewMarginClient(t)
+
+	_, _, err := cli.GetBorrowHistory(context.TODO(), types.GetBorrowHistoryParam{
+		Currency: "USDT",
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetRepayHistory(t *testing.T) {
+	cli := testNewMarginClient(t)
+
+	_, _, err := cli.GetRepayHistory(context.TODO(), types.GetRepayHistoryParam{
+		Currency: "USDT",
+	})
+	assert.Nil(t, err)
+}
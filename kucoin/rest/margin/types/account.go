@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+type GetCrossMarginAccountParam struct {
+	QuoteCurrency string `url:"quoteCurrency,omitempty" validate:"omitempty,oneof=USDT KCS BTC"` // The default is USDT
+	QueryType     string `url:"queryType,omitempty" validate:"omitempty,oneof=MARGIN MARGIN_V2 ALL"`
+}
+
+// A CrossMarginAccount represents the cross margin account.
+type CrossMarginAccount struct {
+	TotalAssetOfQuoteCurrency     string              `json:"totalAssetOfQuoteCurrency"`
+	TotalLiabilityOfQuoteCurrency string              `json:"totalLiabilityOfQuoteCurrency"`
+	DebtRatio                     string              `json:"debtRatio"`
+	Status                        string              `json:"status"` // EFFECTIVE, BANKRUPTCY, LIQUIDATION, REPAY, BORROW
+	Assets                        []*CrossMarginAsset `json:"assets"`
+}
+
+type CrossMarginAsset struct {
+	Currency        string `json:"currency"`
+	BorrowEnabled   bool   `json:"borrowEnabled"`
+	RepayEnabled    bool   `json:"repayEnabled"`
+	TransferEnabled bool   `json:"transferEnabled"`
+	Borrowed        string `json:"borrowed"`
+	TotalAsset      string `json:"totalAsset"`
+	Available       string `json:"available"`
+	Hold            string `json:"hold"`
+	MaxBorrowSize   string `json:"maxBorrowSize"`
+}
+
+type GetIsolatedMarginAccountParam struct {
+	Symbol        string `url:"symbol,omitempty"`
+	QuoteCurrency string `url:"quoteCurrency,omitempty" validate:"omitempty,oneof=USDT KCS BTC"` // The default is USDT
+	QueryType     string `url:"queryType,omitempty" validate:"omitempty,oneof=ISOLATED ISOLATED_V2 ALL"`
+}
+
+// An IsolatedMarginAccount represents the isolated margin accounts.
+type IsolatedMarginAccount struct {
+	TotalAssetOfQuoteCurrency     string                  `json:"totalAssetOfQuoteCurrency"`
+	TotalLiabilityOfQuoteCurrency string                  `json:"totalLiabilityOfQuoteCurrency"`
+	Timestamp                     int64                   `json:"timestamp"`
+	Assets                        []*IsolatedMarginSymbol `json:"assets"`
+}
+
+type IsolatedMarginSymbol struct {
+	Symbol     string               `json:"symbol"`
+	Status     string               `json:"status"`
+	DebtRatio  string               `json:"debtRatio"`
+	BaseAsset  *IsolatedMarginAsset `json:"baseAsset"`
+	QuoteAsset *IsolatedMarginAsset `json:"quoteAsset"`
+}
+
+type IsolatedMarginAsset struct {
+	Currency        string `json:"currency"`
+	BorrowEnabled   bool   `json:"borrowEnabled"`
+	RepayEnabled    bool   `json:"repayEnabled"`
+	TransferEnabled bool   `json:"transferEnabled"`
+	Borrowed        string `json:"borrowed"`
+	TotalAsset      string `json:"totalAsset"`
+	Available       string `json:"available"`
+	Hold            string `json:"hold"`
+	MaxBorrowSize   string `json:"maxBorrowSize"`
+}
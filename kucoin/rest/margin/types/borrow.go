@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import "github.com/rluisr/nexapi/kucoin/rest/utils"
+
+type BorrowParam struct {
+	Currency    string `json:"currency" validate:"required"`
+	Size        string `json:"size" validate:"required"`
+	TimeInForce string `json:"timeInForce" validate:"required,oneof=IOC FOK"`
+	IsIsolated  bool   `json:"isIsolated,omitempty"`                                 // true: isolated, false: cross; default is cross
+	Symbol      string `json:"symbol,omitempty" validate:"required_with=IsIsolated"` // Isolated margin trading pair, required for isolated margin accounts
+	IsHf        bool   `json:"isHf,omitempty"`                                       // true: high frequency borrowing, false: low frequency borrowing; default false
+}
+
+type BorrowResult struct {
+	OrderNo    string `json:"orderNo"`
+	ActualSize string `json:"actualSize"`
+}
+
+type RepayParam struct {
+	Currency   string `json:"currency" validate:"required"`
+	Size       string `json:"size" validate:"required"`
+	IsIsolated bool   `json:"isIsolated,omitempty"`
+	Symbol     string `json:"symbol,omitempty" validate:"required_with=IsIsolated"`
+	IsHf       bool   `json:"isHf,omitempty"`
+}
+
+type RepayResult struct {
+	Timestamp  int64  `json:"timestamp"`
+	OrderNo    string `json:"orderNo"`
+	ActualSize string `json:"actualSize"`
+}
+
+type GetBorrowHistoryParam struct {
+	Currency   string `url:"currency" validate:"required"`
+	IsIsolated bool   `url:"isIsolated,omitempty"`
+	Symbol     string `url:"symbol,omitempty"`
+	OrderNo    string `url:"orderNo,omitempty"`
+	StartTime  int64  `url:"startTime,omitempty"` // Start time (milisecond)
+	EndTime    int64  `url:"endTime,omitempty"`   // End time (milisecond)
+	utils.PaginationParam
+}
+
+type Borrow struct {
+	OrderNo     string `json:"orderNo"`
+	Symbol      string `json:"symbol"`
+	Currency    string `json:"currency"`
+	Size        string `json:"size"`
+	ActualSize  string `json:"actualSize"`
+	Status      string `json:"status"` // PENDING, SUCCESS, FAILED
+	CreatedTime int64  `json:"createdTime"`
+}
+
+type GetRepayHistoryParam struct {
+	Currency   string `url:"currency" validate:"required"`
+	IsIsolated bool   `url:"isIsolated,omitempty"`
+	Symbol     string `url:"symbol,omitempty"`
+	OrderNo    string `url:"orderNo,omitempty"`
+	StartTime  int64  `url:"startTime,omitempty"` // Start time (milisecond)
+	EndTime    int64  `url:"endTime,omitempty"`   // End time (milisecond)
+	utils.PaginationParam
+}
+
+type Repay struct {
+	OrderNo     string `json:"orderNo"`
+	Symbol      string `json:"symbol"`
+	Currency    string `json:"currency"`
+	Size        string `json:"size"`
+	Principal   string `json:"principal"`
+	Interest    string `json:"interest"`
+	Status      string `json:"status"` // PENDING, SUCCESS, FAILED
+	CreatedTime int64  `json:"createdTime"`
+}
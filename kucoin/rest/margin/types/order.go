@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+type PlaceHFOrderParam struct {
+	ClientOid   string `json:"clientOid" validate:"required"`
+	Side        string `json:"side" validate:"required,oneof=buy sell"`
+	Symbol      string `json:"symbol" validate:"required"`
+	Type        string `json:"type,omitempty" validate:"omitempty,oneof=limit market"`
+	Stp         string `json:"stp,omitempty" validate:"omitempty,oneof=CN CO CB DC"` // self trade prevention
+	Price       string `json:"price,omitempty"`                                      // Specify price for currency, required for limit orders
+	Size        string `json:"size,omitempty"`
+	Funds       string `json:"funds,omitempty"` // Specify the amount of quote currency, only for market orders
+	TimeInForce string `json:"timeInForce,omitempty" validate:"omitempty,oneof=GTC GTT IOC FOK"`
+	CancelAfter int64  `json:"cancelAfter,omitempty"`
+	PostOnly    bool   `json:"postOnly,omitempty"`
+	Hidden      bool   `json:"hidden,omitempty"`
+	Iceberg     bool   `json:"iceberg,omitempty"`
+	VisibleSize string `json:"visibleSize,omitempty"`
+	IsIsolated  bool   `json:"isIsolated,omitempty"` // true: isolated, false: cross; default is cross
+	AutoBorrow  bool   `json:"autoBorrow,omitempty"` // When the balance is insufficient, the system borrows automatically
+	AutoRepay   bool   `json:"autoRepay,omitempty"`  // The system repays automatically after the position is closed
+}
+
+type PlaceHFOrderResult struct {
+	OrderID     string `json:"orderId"`
+	ClientOid   string `json:"clientOid"`
+	BorrowSize  string `json:"borrowSize"`
+	LoanApplyID string `json:"loanApplyId"`
+}
+
+type CancelHFOrderParam struct {
+	OrderID string `url:"-" validate:"required"`
+	Symbol  string `url:"symbol" validate:"required"`
+}
+
+type CancelHFOrderResult struct {
+	OrderID string `json:"orderId"`
+}
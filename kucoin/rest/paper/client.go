@@ -0,0 +1,242 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package paper provides a paper trading KuCoin HF client backed by a simulated exchange.
+package paper
+
+import (
+	"context"
+	"errors"
+
+	actypes "github.com/rluisr/nexapi/kucoin/rest/account/types"
+	"github.com/rluisr/nexapi/kucoin/rest/hftrade/types"
+	"github.com/rluisr/nexapi/utils/decimal"
+	"github.com/rluisr/nexapi/utils/sim"
+)
+
+// Client has the order methods of hftrade.HFTradeClient and GetAccountList of
+// account.AccountClient, the balances of the simulated exchange are trade accounts.
+type Client struct {
+	*sim.Exchange
+}
+
+func NewClient(ex *sim.Exchange) *Client {
+	return &Client{Exchange: ex}
+}
+
+func (c *Client) PlaceOrder(ctx context.Context, param types.PlaceOrderParam) (*types.PlaceOrderResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	req := sim.OrderRequest{
+		Symbol:   param.Symbol,
+		ClientID: param.ClientOid,
+		Side:     sim.Side(param.Side),
+	}
+
+	switch {
+	case param.Type == "market":
+		req.Type = sim.Market
+	case param.PostOnly:
+		req.Type = sim.PostOnly
+	case param.TimeInForce == "IOC":
+		req.Type = sim.IOC
+	case param.TimeInForce == "FOK":
+		req.Type = sim.FOK
+	case param.Type == "limit":
+		req.Type = sim.Limit
+	default:
+		return nil, errors.New("unsupported order type: " + param.Type)
+	}
+
+	var err error
+	if req.Price, err = decimal.Parse(param.Price); err != nil {
+		return nil, err
+	}
+	if req.Qty, err = decimal.Parse(param.Size); err != nil {
+		return nil, err
+	}
+	if req.QuoteQty, err = decimal.Parse(param.Funds); err != nil {
+		return nil, err
+	}
+
+	o, err := c.Exchange.PlaceOrder(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.PlaceOrderResult{OrderID: o.ID, ClientOid: o.ClientID}, nil
+}
+
+func (c *Client) CancelOrder(ctx context.Context, param types.CancelOrderParam) (*types.CancelOrderResult, error) {
+	o, err := c.cancel(ctx, param.Symbol, param.OrderID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.CancelOrderResult{OrderID: o.ID}, nil
+}
+
+func (c *Client) CancelOrderByClientOid(ctx context.Context, param types.CancelOrderByClientOidParam) (*types.CancelOrderByClientOidResult, error) {
+	o, err := c.cancel(ctx, param.Symbol, "", param.ClientOid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.CancelOrderByClientOidResult{ClientOid: o.ClientID}, nil
+}
+
+func (c *Client) cancel(ctx context.Context, symbol, id, clientID string) (*sim.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if _, err := c.find(symbol, id, clientID); err != nil {
+		return nil, err
+	}
+
+	return c.Exchange.CancelOrder(id, clientID)
+}
+
+func (c *Client) GetOrder(ctx context.Context, param types.GetOrderParam) (*types.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	o, err := c.find(param.Symbol, param.OrderID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return order(o), nil
+}
+
+func (c *Client) GetOrderByClientOid(ctx context.Context, param types.GetOrderByClientOidParam) (*types.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	o, err := c.find(param.Symbol, "", param.ClientOid)
+	if err != nil {
+		return nil, err
+	}
+
+	return order(o), nil
+}
+
+func (c *Client) GetActiveOrders(ctx context.Context, param types.GetActiveOrdersParam) ([]*types.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var ret []*types.Order
+	for _, o := range c.OpenOrders(param.Symbol) {
+		ret = append(ret, order(o))
+	}
+
+	return ret, nil
+}
+
+// GetAccountList returns the balances as trade accounts.
+func (c *Client) GetAccountList(ctx context.Context, param actypes.GetAccountListParam) ([]*actypes.AccountModel, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if param.Type != "" && param.Type != "trade" {
+		return nil, nil
+	}
+
+	var ret []*actypes.AccountModel
+	for _, b := range c.Balances() {
+		if param.Currency != "" && b.Asset != param.Currency {
+			continue
+		}
+
+		ret = append(ret, &actypes.AccountModel{
+			Id:        b.Asset,
+			Currency:  b.Asset,
+			Type:      "trade",
+			Balance:   b.Total().String(),
+			Available: b.Free.String(),
+			Holds:     b.Locked.String(),
+		})
+	}
+
+	return ret, nil
+}
+
+func (c *Client) find(symbol, id, clientID string) (*sim.Order, error) {
+	o, err := c.Order(id, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if o.Symbol != symbol {
+		return nil, sim.ErrOrderNotFound
+	}
+
+	return o, nil
+}
+
+func order(o *sim.Order) *types.Order {
+	ret := &types.Order{
+		ID:            o.ID,
+		Symbol:        o.Symbol,
+		OpType:        "DEAL",
+		Type:          "limit",
+		Side:          string(o.Side),
+		Price:         o.Price.String(),
+		Size:          o.Qty.String(),
+		Funds:         o.QuoteQty.String(),
+		DealSize:      o.Filled.String(),
+		DealFunds:     o.FilledQuote.String(),
+		Fee:           o.Fee.String(),
+		FeeCurrency:   o.FeeAsset,
+		TimeInForce:   "GTC",
+		PostOnly:      o.Type == sim.PostOnly,
+		ClientOid:     o.ClientID,
+		Active:        o.IsOpen(),
+		InOrderBook:   o.IsOpen(),
+		CreatedAt:     o.CreateTime.UnixMilli(),
+		LastUpdatedAt: o.UpdateTime.UnixMilli(),
+		TradeType:     "TRADE",
+		RemainSize:    "0",
+		CancelledSize: "0",
+	}
+
+	switch o.Type {
+	case sim.Market:
+		ret.Type = "market"
+	case sim.IOC:
+		ret.TimeInForce = "IOC"
+	case sim.FOK:
+		ret.TimeInForce = "FOK"
+	}
+
+	if o.Qty.Sign() > 0 {
+		remaining := o.Remaining()
+		switch {
+		case o.IsOpen():
+			ret.RemainSize = remaining.String()
+		case o.Status == sim.Canceled, o.Status == sim.Rejected:
+			ret.CancelledSize = remaining.String()
+			ret.CancelExist = remaining.Sign() > 0
+		}
+	}
+
+	return ret
+}
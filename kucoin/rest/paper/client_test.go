@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package paper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rluisr/nexapi/kucoin/rest/account"
+	actypes "github.com/rluisr/nexapi/kucoin/rest/account/types"
+	"github.com/rluisr/nexapi/kucoin/rest/hftrade"
+	"github.com/rluisr/nexapi/kucoin/rest/hftrade/types"
+	"github.com/rluisr/nexapi/utils/book"
+	"github.com/rluisr/nexapi/utils/decimal"
+	"github.com/rluisr/nexapi/utils/sim"
+	"github.com/stretchr/testify/assert"
+)
+
+type orderClient interface {
+	PlaceOrder(ctx context.Context, param types.PlaceOrderParam) (*types.PlaceOrderResult, error)
+	CancelOrder(ctx context.Context, param types.CancelOrderParam) (*types.CancelOrderResult, error)
+	CancelOrderByClientOid(ctx context.Context, param types.CancelOrderByClientOidParam) (*types.CancelOrderByClientOidResult, error)
+	GetOrder(ctx context.Context, param types.GetOrderParam) (*types.Order, error)
+	GetOrderByClientOid(ctx context.Context, param types.GetOrderByClientOidParam) (*types.Order, error)
+	GetActiveOrders(ctx context.Context, param types.GetActiveOrdersParam) ([]*types.Order, error)
+}
+
+type accountClient interface {
+	GetAccountList(ctx context.Context, param actypes.GetAccountListParam) ([]*actypes.AccountModel, error)
+}
+
+var (
+	_ orderClient   = (*hftrade.HFTradeClient)(nil)
+	_ orderClient   = (*Client)(nil)
+	_ accountClient = (*account.AccountClient)(nil)
+	_ accountClient = (*Client)(nil)
+)
+
+var testStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func testClient(t *testing.T) *Client {
+	c := NewClient(sim.NewExchange(sim.Config{
+		Instruments: []sim.Instrument{{Symbol: "BTC-USDT", Kind: sim.Spot, Base: "BTC", Quote: "USDT"}},
+		Balances:    map[string]decimal.Decimal{"USDT": decimal.NewFromInt(1000)},
+	}))
+
+	b, err := book.FromStrings([][]string{{"100", "1"}}, [][]string{{"101", "1"}})
+	assert.Nil(t, err)
+	assert.Nil(t, c.UpdateBook("BTC-USDT", b, testStart))
+
+	return c
+}
+
+func TestLimitOrder(t *testing.T) {
+	c := testClient(t)
+	ctx := context.Background()
+
+	resp, err := c.PlaceOrder(ctx, types.PlaceOrderParam{ClientOid: "c1", Symbol: "BTC-USDT", Type: "limit", Side: "buy", Price: "100", Size: "2"})
+	assert.Nil(t, err)
+	assert.Equal(t, "c1", resp.ClientOid)
+
+	order, err := c.GetOrder(ctx, types.GetOrderParam{Symbol: "BTC-USDT", OrderID: resp.OrderID})
+	assert.Nil(t, err)
+	assert.True(t, order.Active)
+	assert.Equal(t, "2", order.RemainSize)
+
+	accounts, err := c.GetAccountList(ctx, actypes.GetAccountListParam{Currency: "USDT"})
+	assert.Nil(t, err)
+	assert.Len(t, accounts, 1)
+	assert.Equal(t, "800", accounts[0].Available)
+	assert.Equal(t, "200", accounts[0].Holds)
+
+	accounts, err = c.GetAccountList(ctx, actypes.GetAccountListParam{Type: "main"})
+	assert.Nil(t, err)
+	assert.Empty(t, accounts)
+
+	err = c.UpdateTrade("BTC-USDT", sim.Trade{Price: decimal.NewFromInt(99), Qty: decimal.MustFromString("1.5"), Side: sim.Sell, Time: testStart.Add(time.Second)})
+	assert.Nil(t, err)
+
+	order, err = c.GetOrderByClientOid(ctx, types.GetOrderByClientOidParam{Symbol: "BTC-USDT", ClientOid: "c1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "1.5", order.DealSize)
+	assert.Equal(t, "0.5", order.RemainSize)
+
+	active, err := c.GetActiveOrders(ctx, types.GetActiveOrdersParam{Symbol: "BTC-USDT"})
+	assert.Nil(t, err)
+	assert.Len(t, active, 1)
+
+	// an order of another symbol is not found, it is not canceled
+	_, err = c.CancelOrderByClientOid(ctx, types.CancelOrderByClientOidParam{Symbol: "ETH-USDT", ClientOid: "c1"})
+	assert.ErrorIs(t, err, sim.ErrOrderNotFound)
+
+	cancel, err := c.CancelOrderByClientOid(ctx, types.CancelOrderByClientOidParam{Symbol: "BTC-USDT", ClientOid: "c1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "c1", cancel.ClientOid)
+
+	order, err = c.GetOrder(ctx, types.GetOrderParam{Symbol: "BTC-USDT", OrderID: resp.OrderID})
+	assert.Nil(t, err)
+	assert.False(t, order.Active)
+	assert.True(t, order.CancelExist)
+	assert.Equal(t, "0.5", order.CancelledSize)
+
+	_, err = c.CancelOrder(ctx, types.CancelOrderParam{Symbol: "BTC-USDT", OrderID: resp.OrderID})
+	assert.ErrorIs(t, err, sim.ErrOrderNotOpen)
+}
+
+func TestOrderTypes(t *testing.T) {
+	c := testClient(t)
+	ctx := context.Background()
+
+	// a market buy of funds
+	resp, err := c.PlaceOrder(ctx, types.PlaceOrderParam{Symbol: "BTC-USDT", Type: "market", Side: "buy", Funds: "50.5"})
+	assert.Nil(t, err)
+	order, err := c.GetOrder(ctx, types.GetOrderParam{Symbol: "BTC-USDT", OrderID: resp.OrderID})
+	assert.Nil(t, err)
+	assert.Equal(t, "market", order.Type)
+	assert.False(t, order.Active)
+	assert.Equal(t, "50.5", order.Funds)
+	assert.True(t, decimal.MustFromString("0.5").Equal(decimal.MustFromString(order.DealSize)))
+
+	resp, err = c.PlaceOrder(ctx, types.PlaceOrderParam{Symbol: "BTC-USDT", Type: "limit", Side: "buy", Price: "101", Size: "2", TimeInForce: "IOC"})
+	assert.Nil(t, err)
+	order, err = c.GetOrder(ctx, types.GetOrderParam{Symbol: "BTC-USDT", OrderID: resp.OrderID})
+	assert.Nil(t, err)
+	assert.Equal(t, "IOC", order.TimeInForce)
+	assert.Equal(t, "1", order.CancelledSize)
+
+	// a post only order crossing the book is not placed
+	resp, err = c.PlaceOrder(ctx, types.PlaceOrderParam{Symbol: "BTC-USDT", Type: "limit", Side: "buy", Price: "101", Size: "1", PostOnly: true})
+	assert.Nil(t, err)
+	order, err = c.GetOrder(ctx, types.GetOrderParam{Symbol: "BTC-USDT", OrderID: resp.OrderID})
+	assert.Nil(t, err)
+	assert.True(t, order.PostOnly)
+	assert.False(t, order.Active)
+	assert.Equal(t, "1", order.CancelledSize)
+
+	_, err = c.PlaceOrder(ctx, types.PlaceOrderParam{Symbol: "BTC-USDT", Type: "stop", Side: "buy", Size: "1"})
+	assert.NotNil(t, err)
+
+	_, err = c.PlaceOrder(ctx, types.PlaceOrderParam{Symbol: "BTC-USDT", Type: "limit", Side: "buy", Price: "100", Size: "100"})
+	assert.ErrorIs(t, err, sim.ErrInsufficientBalance)
+}
@@ -43,6 +43,7 @@ type KucoinClient struct {
 	logger *slog.Logger
 
 	baseURL                             string
+	httpClient                          *http.Client
 	key, secret, passphrase, keyVersion string
 }
 
@@ -52,6 +53,7 @@ type KucoinClientCfg struct {
 	Logger *slog.Logger
 
 	BaseURL    string `validate:"required"`
+	HTTPClient *http.Client
 	Key        string
 	KeyVersion string
 	Secret     string
@@ -68,6 +70,7 @@ func NewKucoinRestClient(cfg *KucoinClientCfg) (*KucoinClient, error) {
 		debug:      cfg.Debug,
 		logger:     cfg.Logger,
 		baseURL:    cfg.BaseURL,
+		httpClient: cfg.HTTPClient,
 		key:        cfg.Key,
 		keyVersion: cfg.KeyVersion,
 		secret:     cfg.Secret,
@@ -78,6 +81,10 @@ func NewKucoinRestClient(cfg *KucoinClientCfg) (*KucoinClient, error) {
 		cli.logger = slog.Default()
 	}
 
+	if cli.httpClient == nil {
+		cli.httpClient = &http.Client{}
+	}
+
 	return &cli, nil
 }
 
@@ -145,8 +152,6 @@ func (k *KucoinClient) GenSignature(req HTTPRequest) (map[string]string, error)
 }
 
 func (s *KucoinClient) SendHTTPRequest(ctx context.Context, req HTTPRequest) (*HTTPResponse, error) {
-	client := http.Client{}
-
 	var body io.Reader
 	if req.Body != nil {
 		jsonBody, err := json.Marshal(req.Body)
@@ -187,7 +192,7 @@ func (s *KucoinClient) SendHTTPRequest(ctx context.Context, req HTTPRequest) (*H
 		s.logger.Info(fmt.Sprintf("\n%s\n", string(dump)))
 	}
 
-	resp, err := client.Do(request)
+	resp, err := s.httpClient.Do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"context"
+
+	"github.com/rluisr/nexapi/utils/pagination"
+)
+
+// DefaultPageSize is the page size used by NewPageIterator when none is given.
+const DefaultPageSize int64 = 50
+
+// PageFetcher fetches the page described by p and returns its items.
+type PageFetcher[T any] func(ctx context.Context, p PaginationParam) ([]T, *PaginationModel, error)
+
+// NewPageIterator creates an iterator walking all the pages of a paginated endpoint
+// from the first page until the TotalPage returned by KuCoin.
+func NewPageIterator[T any](fetch PageFetcher[T], pageSize int64) *pagination.Iterator[PaginationParam, T] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	start := PaginationParam{
+		CurrentPage: 1,
+		PageSize:    pageSize,
+	}
+
+	return pagination.New(start, func(ctx context.Context, p PaginationParam) ([]T, PaginationParam, bool, error) {
+		items, page, err := fetch(ctx, p)
+		if err != nil {
+			return nil, p, false, err
+		}
+
+		next := PaginationParam{
+			CurrentPage: p.CurrentPage + 1,
+			PageSize:    p.PageSize,
+		}
+
+		return items, next, page != nil && page.CurrentPage < page.TotalPage && len(items) > 0, nil
+	})
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testPageFetcher(total int64, pageSize int64) PageFetcher[int64] {
+	return func(ctx context.Context, p PaginationParam) ([]int64, *PaginationModel, error) {
+		totalPage := (total + pageSize - 1) / pageSize
+
+		var items []int64
+		for i := (p.CurrentPage - 1) * p.PageSize; i < p.CurrentPage*p.PageSize && i < total; i++ {
+			items = append(items, i)
+		}
+
+		return items, &PaginationModel{
+			CurrentPage: p.CurrentPage,
+			PageSize:    p.PageSize,
+			TotalNum:    total,
+			TotalPage:   totalPage,
+		}, nil
+	}
+}
+
+func TestPageIterator(t *testing.T) {
+	it := NewPageIterator(testPageFetcher(7, 3), 3)
+
+	var got []int64
+	for it.Next(context.TODO()) {
+		got = append(got, it.Item())
+	}
+
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []int64{0, 1, 2, 3, 4, 5, 6}, got)
+}
+
+func TestPageIteratorEmpty(t *testing.T) {
+	it := NewPageIterator(testPageFetcher(0, 3), 3)
+
+	assert.False(t, it.Next(context.TODO()))
+	assert.Nil(t, it.Err())
+}
+
+func TestPageIteratorError(t *testing.T) {
+	fetchErr := errors.New("fetch failed")
+	it := NewPageIterator(func(ctx context.Context, p PaginationParam) ([]int64, *PaginationModel, error) {
+		return nil, nil, fetchErr
+	}, 3)
+
+	assert.False(t, it.Next(context.TODO()))
+	assert.Equal(t, fetchErr, it.Err())
+}
+
+func TestPageIteratorCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	it := NewPageIterator(testPageFetcher(7, 3), 3)
+
+	assert.False(t, it.Next(ctx))
+	assert.ErrorIs(t, it.Err(), context.Canceled)
+}
@@ -24,8 +24,8 @@ import (
 
 // A PaginationParam represents the pagination parameters `currentPage` `pageSize` in a request .
 type PaginationParam struct {
-	CurrentPage int64
-	PageSize    int64
+	CurrentPage int64 `url:"currentPage,omitempty"`
+	PageSize    int64 `url:"pageSize,omitempty"`
 }
 
 // ReadParam read pagination parameters into params.
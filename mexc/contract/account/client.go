@@ -23,10 +23,13 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
 
 	"github.com/go-playground/validator"
 	"github.com/rluisr/nexapi/mexc/contract/account/types"
 	"github.com/rluisr/nexapi/mexc/contract/utils"
+	"github.com/rluisr/nexapi/utils/decimal"
+	"github.com/rluisr/nexapi/utils/pagination"
 )
 
 type ContractAccountClient struct {
@@ -50,6 +53,7 @@ type ContractAccountClientCfg struct {
 
 func NewContractAccountClient(cfg *utils.ContractClientCfg) (*ContractAccountClient, error) {
 	validator := validator.New()
+	validator.RegisterCustomTypeFunc(decimal.ValidateValue, decimal.Decimal{})
 
 	err := validator.Struct(cfg)
 	if err != nil {
@@ -232,3 +236,1007 @@ func (c *ContractAccountClient) SetPositionLeverage(ctx context.Context, param t
 
 	return &ret, nil
 }
+
+func (c *ContractAccountClient) SubmitOrder(ctx context.Context, param types.NewOrderParam) (*types.SubmitOrderResp, error) {
+	err := c.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/order/submit",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.SubmitOrderResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// SubmitBatchOrders places up to 50 orders in a single request.
+func (c *ContractAccountClient) SubmitBatchOrders(ctx context.Context, params []types.NewOrderParam) (*types.SubmitBatchOrdersResp, error) {
+	if len(params) == 0 || len(params) > 50 {
+		return nil, fmt.Errorf("the number of orders must be between 1 and 50, got %d", len(params))
+	}
+
+	for _, param := range params {
+		err := c.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/order/submit_batch",
+		Method:  http.MethodPost,
+		Body:    params,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.SubmitBatchOrdersResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// CancelOrders cancels up to 50 orders by their order ids.
+func (c *ContractAccountClient) CancelOrders(ctx context.Context, orderIds []int64) (*types.CancelOrdersResp, error) {
+	if len(orderIds) == 0 || len(orderIds) > 50 {
+		return nil, fmt.Errorf("the number of order ids must be between 1 and 50, got %d", len(orderIds))
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/order/cancel",
+		Method:  http.MethodPost,
+		Body:    orderIds,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.CancelOrdersResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (c *ContractAccountClient) CancelOrderByExternalOid(ctx context.Context, param types.CancelOrderByExternalOidParam) (*types.EmptyResp, error) {
+	err := c.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/order/cancel_with_external",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.EmptyResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// CancelAllOrders cancels all open orders, or only those of param.Symbol when it is set.
+func (c *ContractAccountClient) CancelAllOrders(ctx context.Context, param types.CancelAllOrdersParam) (*types.EmptyResp, error) {
+	err := c.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/order/cancel_all",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.EmptyResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (c *ContractAccountClient) GetOrderByID(ctx context.Context, orderId string) (*types.GetOrderResp, error) {
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/private/order/get/%s", orderId),
+		Method:  http.MethodGet,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.GetOrderResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (c *ContractAccountClient) GetOrderByExternalOid(ctx context.Context, param types.GetOrderByExternalOidParam) (*types.GetOrderResp, error) {
+	err := c.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/private/order/external/%s/%s", url.PathEscape(param.Symbol), url.PathEscape(param.ExternalOid)),
+		Method:  http.MethodGet,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.GetOrderResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetOpenOrders lists the current pending orders, optionally filtered by param.Symbol.
+func (c *ContractAccountClient) GetOpenOrders(ctx context.Context, param types.GetOpenOrdersParam) (*types.GetOrdersResp, error) {
+	err := c.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/order/list/open_orders",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	if param.Symbol != "" {
+		req.Path = fmt.Sprintf("/api/v1/private/order/list/open_orders/%s", param.Symbol)
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.GetOrdersResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (c *ContractAccountClient) GetHistoryOrders(ctx context.Context, param types.GetHistoryOrdersParam) (*types.GetOrdersResp, error) {
+	err := c.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/order/list/history_orders",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.GetOrdersResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (c *ContractAccountClient) GetOrderDeals(ctx context.Context, param types.GetOrderDealsParam) (*types.GetOrderDealsResp, error) {
+	err := c.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/order/list/order_deals",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.GetOrderDealsResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (c *ContractAccountClient) GetOrderDealDetails(ctx context.Context, orderId string) (*types.GetOrderDealsResp, error) {
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/private/order/deal_details/%s", orderId),
+		Method:  http.MethodGet,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.GetOrderDealsResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// PlacePlanOrder places a trigger order which is sent to the order book once the trigger price is reached.
+func (c *ContractAccountClient) PlacePlanOrder(ctx context.Context, param types.PlacePlanOrderParam) (*types.PlacePlanOrderResp, error) {
+	err := c.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/planorder/place",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.PlacePlanOrderResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// CancelPlanOrders cancels the given plan orders.
+func (c *ContractAccountClient) CancelPlanOrders(ctx context.Context, params []types.CancelPlanOrderParam) (*types.EmptyResp, error) {
+	if len(params) == 0 {
+		return nil, fmt.Errorf("at least one plan order is required")
+	}
+
+	for _, param := range params {
+		err := c.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/planorder/cancel",
+		Method:  http.MethodPost,
+		Body:    params,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.EmptyResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// CancelAllPlanOrders cancels all plan orders, or only those of param.Symbol when it is set.
+func (c *ContractAccountClient) CancelAllPlanOrders(ctx context.Context, param types.CancelAllPlanOrdersParam) (*types.EmptyResp, error) {
+	err := c.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/planorder/cancel_all",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.EmptyResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (c *ContractAccountClient) GetPlanOrders(ctx context.Context, param types.GetPlanOrdersParam) (*types.GetPlanOrdersResp, error) {
+	err := c.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/planorder/list/orders",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.GetPlanOrdersResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// PlaceStopOrder places a TP/SL order on an open position.
+func (c *ContractAccountClient) PlaceStopOrder(ctx context.Context, param types.PlaceStopOrderParam) (*types.PlaceStopOrderResp, error) {
+	err := c.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/stoporder/place",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.PlaceStopOrderResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// CancelStopOrders cancels the given TP/SL orders.
+func (c *ContractAccountClient) CancelStopOrders(ctx context.Context, params []types.CancelStopOrderParam) (*types.EmptyResp, error) {
+	if len(params) == 0 {
+		return nil, fmt.Errorf("at least one stop order is required")
+	}
+
+	for _, param := range params {
+		err := c.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/stoporder/cancel",
+		Method:  http.MethodPost,
+		Body:    params,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.EmptyResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (c *ContractAccountClient) CancelAllStopOrders(ctx context.Context, param types.CancelAllStopOrdersParam) (*types.EmptyResp, error) {
+	err := c.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/stoporder/cancel_all",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.EmptyResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (c *ContractAccountClient) GetStopOrders(ctx context.Context, param types.GetStopOrdersParam) (*types.GetStopOrdersResp, error) {
+	err := c.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/stoporder/list/orders",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.GetStopOrdersResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (c *ContractAccountClient) GetStopOrderDetails(ctx context.Context, stopOrderId string) (*types.GetStopOrderResp, error) {
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/private/stoporder/order_details/%s", stopOrderId),
+		Method:  http.MethodGet,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.GetStopOrderResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// ChangeLimitOrderStopPrice modifies the TP/SL prices of a pending limit order.
+func (c *ContractAccountClient) ChangeLimitOrderStopPrice(ctx context.Context, param types.ChangeLimitOrderStopPriceParam) (*types.EmptyResp, error) {
+	err := c.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/stoporder/change_price",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.EmptyResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// ChangeStopPlanPrice modifies the TP/SL prices of a stop order attached to a position.
+func (c *ContractAccountClient) ChangeStopPlanPrice(ctx context.Context, param types.ChangeStopPlanPriceParam) (*types.EmptyResp, error) {
+	err := c.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/stoporder/change_plan_price",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.EmptyResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// ChangeMargin adds margin to or subtracts margin from an isolated position.
+func (c *ContractAccountClient) ChangeMargin(ctx context.Context, param types.ChangeMarginParam) (*types.EmptyResp, error) {
+	err := c.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/position/change_margin",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.EmptyResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (c *ContractAccountClient) GetPositionMode(ctx context.Context) (*types.GetPositionModeResp, error) {
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/position/position_mode",
+		Method:  http.MethodGet,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.GetPositionModeResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// ChangePositionMode switches between hedge and one-way mode, it fails while there are open positions or orders.
+func (c *ContractAccountClient) ChangePositionMode(ctx context.Context, param types.ChangePositionModeParam) (*types.EmptyResp, error) {
+	err := c.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/position/change_position_mode",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.EmptyResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// ChangeAutoAddMargin toggles the automatic margin top-up of an isolated position.
+func (c *ContractAccountClient) ChangeAutoAddMargin(ctx context.Context, param types.ChangeAutoAddMarginParam) (*types.EmptyResp, error) {
+	err := c.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/position/change_auto_add_im",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.EmptyResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (c *ContractAccountClient) GetHistoryPositions(ctx context.Context, param types.GetHistoryPositionsParam) (*types.GetHistoryPositionsResp, error) {
+	err := c.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/position/list/history_positions",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.GetHistoryPositionsResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (c *ContractAccountClient) GetFundingRecords(ctx context.Context, param types.GetFundingRecordsParam) (*types.GetFundingRecordsResp, error) {
+	err := c.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: c.GetBaseURL(),
+		Path:    "/api/v1/private/position/funding_records",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	{
+		headers, err := c.GenAuthHeaders(req)
+		if err != nil {
+			return nil, err
+		}
+		req.Headers = headers
+	}
+
+	resp, err := c.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.GetFundingRecordsResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// IterateHistoryOrders walks all the pages of GetHistoryOrders.
+func (c *ContractAccountClient) IterateHistoryOrders(param types.GetHistoryOrdersParam) *pagination.Iterator[int, *types.Order] {
+	if param.PageSize == 0 {
+		param.PageSize = 100
+	}
+
+	return pagination.NewPageNumberIterator(1, param.PageSize, func(ctx context.Context, page int) ([]*types.Order, error) {
+		param.PageNum = page
+
+		resp, err := c.GetHistoryOrders(ctx, param)
+		if err != nil {
+			return nil, err
+		}
+		if err := resp.Err(); err != nil {
+			return nil, err
+		}
+
+		return resp.Data, nil
+	})
+}
+
+// IterateOrderDeals walks all the pages of GetOrderDeals.
+func (c *ContractAccountClient) IterateOrderDeals(param types.GetOrderDealsParam) *pagination.Iterator[int, *types.OrderDeal] {
+	if param.PageSize == 0 {
+		param.PageSize = 100
+	}
+
+	return pagination.NewPageNumberIterator(1, param.PageSize, func(ctx context.Context, page int) ([]*types.OrderDeal, error) {
+		param.PageNum = page
+
+		resp, err := c.GetOrderDeals(ctx, param)
+		if err != nil {
+			return nil, err
+		}
+		if err := resp.Err(); err != nil {
+			return nil, err
+		}
+
+		return resp.Data, nil
+	})
+}
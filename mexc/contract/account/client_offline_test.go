@@ -0,0 +1,269 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package account
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/rluisr/nexapi/mexc/contract/account/types"
+	"github.com/rluisr/nexapi/mexc/contract/contracttest"
+	"github.com/rluisr/nexapi/mexc/contract/utils"
+	"github.com/rluisr/nexapi/utils/decimal"
+	"github.com/rluisr/nexapi/utils/fakeserver"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNewFakeContractAccountClient(t *testing.T, secret string) (*ContractAccountClient, *fakeserver.Server) {
+	srv := contracttest.NewServer()
+	t.Cleanup(srv.Close)
+
+	cli, err := NewContractAccountClient(&utils.ContractClientCfg{
+		BaseURL:    srv.URL,
+		Key:        contracttest.Key,
+		Secret:     secret,
+		HTTPClient: srv.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Could not create mexc client, %s", err)
+	}
+
+	return cli, srv
+}
+
+func TestFakeEndpoints(t *testing.T) {
+	cli, srv := testNewFakeContractAccountClient(t, contracttest.Secret)
+
+	order := types.NewOrderParam{Symbol: "BTC_USDT", Price: decimal.NewFromInt(42000), Vol: decimal.NewFromInt(1), Side: types.OpenLong, Type: types.LimitOrder, OpenType: types.IsolatedMargin, Leverage: 10}
+
+	fakeserver.RunEndpoints(t, srv, true, []fakeserver.Endpoint{
+		{Name: "GetAccountAsset", Method: http.MethodGet, Path: "/api/v1/private/account/asset/USDT", Call: func(ctx context.Context) (any, error) {
+			return cli.GetAccountAsset(ctx, "USDT")
+		}},
+		{Name: "GetAccountAssets", Method: http.MethodGet, Path: "/api/v1/private/account/assets", Call: func(ctx context.Context) (any, error) {
+			return cli.GetAccountAssets(ctx)
+		}},
+		{Name: "GetOpenPositions", Method: http.MethodGet, Path: "/api/v1/private/position/open_positions", Call: func(ctx context.Context) (any, error) {
+			return cli.GetOpenPositions(ctx, types.GetOpenPositionsParams{Symbol: "BTC_USDT"})
+		}},
+		{Name: "GetPositionLeverage", Method: http.MethodGet, Path: "/api/v1/private/position/leverage", Call: func(ctx context.Context) (any, error) {
+			return cli.GetPositionLeverage(ctx, types.GetLeverageParams{Symbol: "BTC_USDT"})
+		}},
+		{Name: "SetPositionLeverage", Method: http.MethodPost, Path: "/api/v1/private/position/change_leverage", Call: func(ctx context.Context) (any, error) {
+			return cli.SetPositionLeverage(ctx, types.SetLeverageParams{PositionId: 1, Leverage: 20})
+		}},
+		{Name: "SubmitOrder", Method: http.MethodPost, Path: "/api/v1/private/order/submit", Call: func(ctx context.Context) (any, error) {
+			return cli.SubmitOrder(ctx, order)
+		}},
+		{Name: "SubmitBatchOrders", Method: http.MethodPost, Path: "/api/v1/private/order/submit_batch", Call: func(ctx context.Context) (any, error) {
+			return cli.SubmitBatchOrders(ctx, []types.NewOrderParam{order, order})
+		}},
+		{Name: "CancelOrders", Method: http.MethodPost, Path: "/api/v1/private/order/cancel", Call: func(ctx context.Context) (any, error) {
+			return cli.CancelOrders(ctx, []int64{1, 2})
+		}},
+		{Name: "CancelOrderByExternalOid", Method: http.MethodPost, Path: "/api/v1/private/order/cancel_with_external", Call: func(ctx context.Context) (any, error) {
+			return cli.CancelOrderByExternalOid(ctx, types.CancelOrderByExternalOidParam{Symbol: "BTC_USDT", ExternalOid: "ext-1"})
+		}},
+		{Name: "CancelAllOrders", Method: http.MethodPost, Path: "/api/v1/private/order/cancel_all", Call: func(ctx context.Context) (any, error) {
+			return cli.CancelAllOrders(ctx, types.CancelAllOrdersParam{Symbol: "BTC_USDT"})
+		}},
+		{Name: "GetOrderByID", Method: http.MethodGet, Path: "/api/v1/private/order/get/123", Call: func(ctx context.Context) (any, error) {
+			return cli.GetOrderByID(ctx, "123")
+		}},
+		{Name: "GetOrderByExternalOid", Method: http.MethodGet, Path: "/api/v1/private/order/external/BTC_USDT/ext-1", Call: func(ctx context.Context) (any, error) {
+			return cli.GetOrderByExternalOid(ctx, types.GetOrderByExternalOidParam{Symbol: "BTC_USDT", ExternalOid: "ext-1"})
+		}},
+		{Name: "GetOpenOrders", Method: http.MethodGet, Path: "/api/v1/private/order/list/open_orders/BTC_USDT", Call: func(ctx context.Context) (any, error) {
+			return cli.GetOpenOrders(ctx, types.GetOpenOrdersParam{Symbol: "BTC_USDT"})
+		}},
+		{Name: "GetHistoryOrders", Method: http.MethodGet, Path: "/api/v1/private/order/list/history_orders", Call: func(ctx context.Context) (any, error) {
+			return cli.GetHistoryOrders(ctx, types.GetHistoryOrdersParam{Symbol: "BTC_USDT", PageSize: 10})
+		}},
+		{Name: "GetOrderDeals", Method: http.MethodGet, Path: "/api/v1/private/order/list/order_deals", Call: func(ctx context.Context) (any, error) {
+			return cli.GetOrderDeals(ctx, types.GetOrderDealsParam{Symbol: "BTC_USDT", PageSize: 10})
+		}},
+		{Name: "GetOrderDealDetails", Method: http.MethodGet, Path: "/api/v1/private/order/deal_details/123", Call: func(ctx context.Context) (any, error) {
+			return cli.GetOrderDealDetails(ctx, "123")
+		}},
+		{Name: "PlacePlanOrder", Method: http.MethodPost, Path: "/api/v1/private/planorder/place", Call: func(ctx context.Context) (any, error) {
+			return cli.PlacePlanOrder(ctx, types.PlacePlanOrderParam{
+				Symbol:       "BTC_USDT",
+				Vol:          1,
+				Side:         types.OpenLong,
+				OpenType:     types.IsolatedMargin,
+				Leverage:     10,
+				TriggerPrice: 43000,
+				TriggerType:  types.GreaterThanOrEqual,
+				ExecuteCycle: types.Hours24,
+				OrderType:    types.PlanMarketOrder,
+				Trend:        types.LatestPrice,
+			})
+		}},
+		{Name: "CancelPlanOrders", Method: http.MethodPost, Path: "/api/v1/private/planorder/cancel", Call: func(ctx context.Context) (any, error) {
+			return cli.CancelPlanOrders(ctx, []types.CancelPlanOrderParam{{Symbol: "BTC_USDT", OrderId: "1"}})
+		}},
+		{Name: "CancelAllPlanOrders", Method: http.MethodPost, Path: "/api/v1/private/planorder/cancel_all", Call: func(ctx context.Context) (any, error) {
+			return cli.CancelAllPlanOrders(ctx, types.CancelAllPlanOrdersParam{Symbol: "BTC_USDT"})
+		}},
+		{Name: "GetPlanOrders", Method: http.MethodGet, Path: "/api/v1/private/planorder/list/orders", Call: func(ctx context.Context) (any, error) {
+			return cli.GetPlanOrders(ctx, types.GetPlanOrdersParam{Symbol: "BTC_USDT"})
+		}},
+		{Name: "PlaceStopOrder", Method: http.MethodPost, Path: "/api/v1/private/stoporder/place", Call: func(ctx context.Context) (any, error) {
+			return cli.PlaceStopOrder(ctx, types.PlaceStopOrderParam{PositionId: 1, Vol: 1, StopLossPrice: 40000})
+		}},
+		{Name: "CancelStopOrders", Method: http.MethodPost, Path: "/api/v1/private/stoporder/cancel", Call: func(ctx context.Context) (any, error) {
+			return cli.CancelStopOrders(ctx, []types.CancelStopOrderParam{{StopPlanOrderId: 1}})
+		}},
+		{Name: "CancelAllStopOrders", Method: http.MethodPost, Path: "/api/v1/private/stoporder/cancel_all", Call: func(ctx context.Context) (any, error) {
+			return cli.CancelAllStopOrders(ctx, types.CancelAllStopOrdersParam{Symbol: "BTC_USDT"})
+		}},
+		{Name: "GetStopOrders", Method: http.MethodGet, Path: "/api/v1/private/stoporder/list/orders", Call: func(ctx context.Context) (any, error) {
+			return cli.GetStopOrders(ctx, types.GetStopOrdersParam{Symbol: "BTC_USDT"})
+		}},
+		{Name: "GetStopOrderDetails", Method: http.MethodGet, Path: "/api/v1/private/stoporder/order_details/1", Call: func(ctx context.Context) (any, error) {
+			return cli.GetStopOrderDetails(ctx, "1")
+		}},
+		{Name: "ChangeLimitOrderStopPrice", Method: http.MethodPost, Path: "/api/v1/private/stoporder/change_price", Call: func(ctx context.Context) (any, error) {
+			return cli.ChangeLimitOrderStopPrice(ctx, types.ChangeLimitOrderStopPriceParam{OrderId: 1, StopLossPrice: 40000})
+		}},
+		{Name: "ChangeStopPlanPrice", Method: http.MethodPost, Path: "/api/v1/private/stoporder/change_plan_price", Call: func(ctx context.Context) (any, error) {
+			return cli.ChangeStopPlanPrice(ctx, types.ChangeStopPlanPriceParam{StopPlanOrderId: 1, TakeProfitPrice: 45000})
+		}},
+		{Name: "ChangeMargin", Method: http.MethodPost, Path: "/api/v1/private/position/change_margin", Call: func(ctx context.Context) (any, error) {
+			return cli.ChangeMargin(ctx, types.ChangeMarginParam{PositionId: 1, Amount: 5, Type: types.AddMargin})
+		}},
+		{Name: "GetPositionMode", Method: http.MethodGet, Path: "/api/v1/private/position/position_mode", Call: func(ctx context.Context) (any, error) {
+			return cli.GetPositionMode(ctx)
+		}},
+		{Name: "ChangePositionMode", Method: http.MethodPost, Path: "/api/v1/private/position/change_position_mode", Call: func(ctx context.Context) (any, error) {
+			return cli.ChangePositionMode(ctx, types.ChangePositionModeParam{PositionMode: types.OneWayMode})
+		}},
+		{Name: "ChangeAutoAddMargin", Method: http.MethodPost, Path: "/api/v1/private/position/change_auto_add_im", Call: func(ctx context.Context) (any, error) {
+			return cli.ChangeAutoAddMargin(ctx, types.ChangeAutoAddMarginParam{PositionId: 1, IsEnabled: true})
+		}},
+		{Name: "GetHistoryPositions", Method: http.MethodGet, Path: "/api/v1/private/position/list/history_positions", Call: func(ctx context.Context) (any, error) {
+			return cli.GetHistoryPositions(ctx, types.GetHistoryPositionsParam{Symbol: "BTC_USDT"})
+		}},
+		{Name: "GetFundingRecords", Method: http.MethodGet, Path: "/api/v1/private/position/funding_records", Call: func(ctx context.Context) (any, error) {
+			return cli.GetFundingRecords(ctx, types.GetFundingRecordsParam{Symbol: "BTC_USDT"})
+		}},
+	})
+}
+
+func TestFakeSubmitOrder(t *testing.T) {
+	cli, srv := testNewFakeContractAccountClient(t, contracttest.Secret)
+
+	srv.Handle(http.MethodPost, "/api/v1/private/order/submit", `{"success":true,"code":0,"data":739113577038255616}`)
+
+	resp, err := cli.SubmitOrder(context.Background(), types.NewOrderParam{
+		Symbol:      "BTC_USDT",
+		Price:       decimal.MustFromString("42000.5"),
+		Vol:         decimal.NewFromInt(2),
+		Side:        types.OpenShort,
+		Type:        types.PostOnlyMaker,
+		OpenType:    types.CrossMargin,
+		ExternalOid: "ext-1",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(739113577038255616), resp.Data)
+
+	var body types.NewOrderParam
+	assert.Nil(t, srv.LastRequest().JSON(&body))
+	assert.Equal(t, types.OpenShort, body.Side)
+	assert.Equal(t, "ext-1", body.ExternalOid)
+	assert.Equal(t, "42000.5", body.Price.String())
+	assert.Contains(t, string(srv.LastRequest().Body), `"price":42000.5,"vol":2`)
+	assert.NotContains(t, string(srv.LastRequest().Body), "stopLossPrice")
+
+	// the volume is required
+	_, err = cli.SubmitOrder(context.Background(), types.NewOrderParam{Symbol: "BTC_USDT", Side: types.OpenLong, Type: types.MarketOrder, OpenType: types.CrossMargin})
+	assert.NotNil(t, err)
+}
+
+func TestFakeGetOrderByExternalOid(t *testing.T) {
+	cli, srv := testNewFakeContractAccountClient(t, contracttest.Secret)
+
+	// the external oid is escaped in the path
+	resp, err := cli.GetOrderByExternalOid(context.Background(), types.GetOrderByExternalOidParam{Symbol: "BTC_USDT", ExternalOid: "a/b?c%d"})
+	assert.Nil(t, err)
+	assert.Nil(t, resp.Err())
+
+	r := srv.LastRequest()
+	assert.Equal(t, "/api/v1/private/order/external/BTC_USDT/a/b?c%d", r.Path)
+	assert.Empty(t, r.Query)
+	assert.True(t, r.Signed)
+}
+
+func TestFakeGetOpenPositions(t *testing.T) {
+	cli, srv := testNewFakeContractAccountClient(t, contracttest.Secret)
+
+	srv.Handle(http.MethodGet, "/api/v1/private/position/open_positions", `{"success":true,"code":0,"data":[{"positionId":1,"symbol":"BTC_USDT","positionType":1,"openType":1,"state":1,"holdVol":3,"holdAvgPrice":42000.5,"openAvgPrice":42000.5,"liquidatePrice":38000,"leverage":10,"realised":-0.1}]}`)
+
+	resp, err := cli.GetOpenPositions(context.Background(), types.GetOpenPositionsParams{Symbol: "BTC_USDT"})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Data, 1)
+	assert.Equal(t, "3", resp.Data[0].HoldVol.String())
+	assert.Equal(t, "42000.5", resp.Data[0].HoldAvgPrice.String())
+	assert.Equal(t, "BTC_USDT", srv.LastRequest().Query.Get("symbol"))
+}
+
+func TestFakeIterateHistoryOrders(t *testing.T) {
+	cli, srv := testNewFakeContractAccountClient(t, contracttest.Secret)
+
+	srv.HandleFunc(http.MethodGet, "/api/v1/private/order/list/history_orders", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page_num") == "1" {
+			w.Write([]byte(`{"success":true,"code":0,"data":[{"orderId":"1"},{"orderId":"2"}]}`))
+			return
+		}
+		w.Write([]byte(`{"success":true,"code":0,"data":[{"orderId":"3"}]}`))
+	})
+
+	orders, err := cli.IterateHistoryOrders(types.GetHistoryOrdersParam{Symbol: "BTC_USDT", PageSize: 2}).All(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, orders, 3)
+	assert.Len(t, srv.Requests(), 2)
+
+	srv.Reset()
+	srv.Handle(http.MethodGet, "/api/v1/private/order/list/order_deals", `{"success":true,"code":0,"data":[{"id":1,"symbol":"BTC_USDT"}]}`)
+	deals, err := cli.IterateOrderDeals(types.GetOrderDealsParam{Symbol: "BTC_USDT"}).All(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, deals, 1)
+
+	// an API error stops the iteration
+	srv.Handle(http.MethodGet, "/api/v1/private/order/list/order_deals", `{"success":false,"code":1002,"message":"contract not allow"}`)
+	_, err = cli.IterateOrderDeals(types.GetOrderDealsParam{Symbol: "BTC_USDT"}).All(context.Background())
+	assert.ErrorContains(t, err, "1002")
+}
+
+func TestFakeInvalidSignature(t *testing.T) {
+	cli, _ := testNewFakeContractAccountClient(t, "wrong-secret")
+
+	resp, err := cli.GetAccountAssets(context.Background())
+	assert.Nil(t, err)
+	contracttest.AssertInvalidSignature(t, resp.Err())
+}
+
+func TestFakeServerError(t *testing.T) {
+	cli, srv := testNewFakeContractAccountClient(t, contracttest.Secret)
+
+	srv.Fail(http.MethodGet, "/api/v1/private/account/assets", fakeserver.Response{Status: http.StatusBadGateway, Body: "bad gateway"})
+
+	_, err := cli.GetAccountAssets(context.Background())
+	assert.ErrorContains(t, err, "502")
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package account
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/rluisr/nexapi/mexc/contract/account/types"
+	"github.com/rluisr/nexapi/mexc/contract/utils"
+	"github.com/rluisr/nexapi/utils/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNewContractAccountClient(t *testing.T) *ContractAccountClient {
+	cli, err := NewContractAccountClient(&utils.ContractClientCfg{
+		BaseURL:    utils.BaseURL,
+		Key:        os.Getenv("MEXC_KEY"),
+		Secret:     os.Getenv("MEXC_SECRET"),
+		Debug:      true,
+		HTTPClient: &http.Client{},
+	})
+
+	if err != nil {
+		t.Fatalf("Could not create mexc client, %s", err)
+	}
+
+	return cli
+}
+
+func TestGetOpenOrders(t *testing.T) {
+	cli := testNewContractAccountClient(t)
+
+	_, err := cli.GetOpenOrders(context.TODO(), types.GetOpenOrdersParam{
+		Symbol: "BTC_USDT",
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetHistoryOrders(t *testing.T) {
+	cli := testNewContractAccountClient(t)
+
+	_, err := cli.GetHistoryOrders(context.TODO(), types.GetHistoryOrdersParam{
+		Symbol:   "BTC_USDT",
+		PageSize: 10,
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetOrderDeals(t *testing.T) {
+	cli := testNewContractAccountClient(t)
+
+	_, err := cli.GetOrderDeals(context.TODO(), types.GetOrderDealsParam{
+		Symbol:   "BTC_USDT",
+		PageSize: 10,
+	})
+	assert.Nil(t, err)
+}
+
+// TestSubmitOrder places a post only order far from the market and cancels it,
+// it only runs with MEXC_ORDER=1.
+func TestSubmitOrder(t *testing.T) {
+	if os.Getenv("MEXC_ORDER") != "1" {
+		t.Skip("set MEXC_ORDER=1 to place orders on the live API")
+	}
+
+	cli := testNewContractAccountClient(t)
+
+	resp, err := cli.SubmitOrder(context.TODO(), types.NewOrderParam{
+		Symbol:   "BTC_USDT",
+		Price:    decimal.NewFromInt(10000),
+		Vol:      decimal.NewFromInt(1),
+		Leverage: 5,
+		Side:     types.OpenLong,
+		Type:     types.PostOnlyMaker,
+		OpenType: types.IsolatedMargin,
+	})
+	assert.Nil(t, err)
+
+	if err == nil && resp.Success {
+		t.Cleanup(func() {
+			_, err := cli.CancelOrders(context.TODO(), []int64{resp.Data})
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestGetPlanOrders(t *testing.T) {
+	cli := testNewContractAccountClient(t)
+
+	_, err := cli.GetPlanOrders(context.TODO(), types.GetPlanOrdersParam{
+		Symbol:   "BTC_USDT",
+		PageSize: 10,
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetStopOrders(t *testing.T) {
+	cli := testNewContractAccountClient(t)
+
+	_, err := cli.GetStopOrders(context.TODO(), types.GetStopOrdersParam{
+		Symbol:   "BTC_USDT",
+		PageSize: 10,
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetPositionMode(t *testing.T) {
+	cli := testNewContractAccountClient(t)
+
+	_, err := cli.GetPositionMode(context.TODO())
+	assert.Nil(t, err)
+}
+
+func TestGetHistoryPositions(t *testing.T) {
+	cli := testNewContractAccountClient(t)
+
+	_, err := cli.GetHistoryPositions(context.TODO(), types.GetHistoryPositionsParam{
+		Symbol:   "BTC_USDT",
+		PageSize: 10,
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetFundingRecords(t *testing.T) {
+	cli := testNewContractAccountClient(t)
+
+	_, err := cli.GetFundingRecords(context.TODO(), types.GetFundingRecordsParam{
+		Symbol:   "BTC_USDT",
+		PageSize: 10,
+	})
+	assert.Nil(t, err)
+}
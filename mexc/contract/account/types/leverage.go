@@ -33,11 +33,11 @@ type GetLeverageResp struct {
 }
 
 type SetLeverageParams struct {
-	PositionId   int64  `url:"positionId,omitempty" validate:"omitempty"`
-	Leverage     int    `url:"positionId,omitempty" validate:"required"`
-	OpenType     int    `url:"openType,omitempty" validate:"omitempty"`
-	Symbol       string `url:"symbol,omitempty" validate:"omitempty"`
-	PositionType int    `url:"positionType,omitempty" validate:"omitempty"`
+	PositionId   int64  `json:"positionId,omitempty" validate:"omitempty"`
+	Leverage     int    `json:"leverage,omitempty" validate:"required"`
+	OpenType     int    `json:"openType,omitempty" validate:"omitempty"`
+	Symbol       string `json:"symbol,omitempty" validate:"omitempty"`
+	PositionType int    `json:"positionType,omitempty" validate:"omitempty"`
 }
 
 type SetLeverageResp struct {
@@ -17,19 +17,53 @@
 
 package types
 
+import (
+	"encoding/json"
+
+	"github.com/rluisr/nexapi/utils/decimal"
+)
+
 type NewOrderParam struct {
-	Symbol   string    `url:"symbol" validate:"required"`
-	Price    float64   `url:"price,omitempty" validate:"omitempty"`
-	Vol      float64   `url:"vol,omitempty" validate:"required"`
-	Leverage int       `url:"leverage,omitempty" validate:"omitempty"`
-	Side     OrderSide `url:"side" validate:"required,oneof=1 2 3 4"`
-	Type     OrderType `url:"type" validate:"required,oneof=1 2 3 4 5 6"`
-	OpenType OpenType  `url:"openType" validate:"required,oneof=1 2"`
+	Symbol   string          `json:"symbol" validate:"required"`
+	Price    decimal.Decimal `json:"price,omitempty" validate:"omitempty"`
+	Vol      decimal.Decimal `json:"vol,omitempty" validate:"required"`
+	Leverage int             `json:"leverage,omitempty" validate:"omitempty"`
+	Side     OrderSide       `json:"side" validate:"required,oneof=1 2 3 4"`
+	Type     OrderType       `json:"type" validate:"required,oneof=1 2 3 4 5 6"`
+	OpenType OpenType        `json:"openType" validate:"required,oneof=1 2"`
+
+	PositionId      int64           `json:"positionId,omitempty" validate:"omitempty"`
+	ExternalOid     string          `json:"externalOid,omitempty" validate:"omitempty,max=32"`
+	StopLossPrice   decimal.Decimal `json:"stopLossPrice,omitempty" validate:"omitempty"`
+	TakeProfitPrice decimal.Decimal `json:"takeProfitPrice,omitempty" validate:"omitempty"`
+	PositionMode    int             `json:"positionMode,omitempty" validate:"omitempty"`
+	ReduceOnly      bool            `json:"reduceOnly,omitempty" validate:"omitempty"`
+}
+
+// MarshalJSON sends the prices and the volume as JSON numbers like the API documents
+// them, the zero ones are omitted.
+func (p NewOrderParam) MarshalJSON() ([]byte, error) {
+	type param NewOrderParam
+	return json.Marshal(struct {
+		param
+		Price           json.Number `json:"price,omitempty"`
+		Vol             json.Number `json:"vol,omitempty"`
+		StopLossPrice   json.Number `json:"stopLossPrice,omitempty"`
+		TakeProfitPrice json.Number `json:"takeProfitPrice,omitempty"`
+	}{
+		param:           param(p),
+		Price:           number(p.Price),
+		Vol:             number(p.Vol),
+		StopLossPrice:   number(p.StopLossPrice),
+		TakeProfitPrice: number(p.TakeProfitPrice),
+	})
+}
 
-	PositionId      int64   `url:"positionId,omitempty" validate:"omitempty"`
-	StopLossPrice   float64 `url:"stopLossPrice,omitempty" validate:"omitempty"`
-	TakeProfitPrice float64 `url:"takeProfitPrice,omitempty" validate:"omitempty"`
-	PositionMode    int     `url:"positionMode,omitempty" validate:"omitempty"`
+func number(d decimal.Decimal) json.Number {
+	if d.IsZero() {
+		return ""
+	}
+	return json.Number(d.String())
 }
 
 type OrderSide = int
@@ -53,3 +87,148 @@ var (
 )
 
 type OpenType = int
+
+var (
+	IsolatedMargin OpenType = 1
+	CrossMargin    OpenType = 2
+)
+
+type OrderState = int
+
+var (
+	OrderUninformed  OrderState = 1
+	OrderUncompleted OrderState = 2
+	OrderCompleted   OrderState = 3
+	OrderCancelled   OrderState = 4
+	OrderInvalid     OrderState = 5
+)
+
+type SubmitOrderResp struct {
+	Response
+	Data int64 `json:"data"`
+}
+
+type SubmitBatchOrdersResp struct {
+	Response
+	Data []*SubmitBatchOrderResult `json:"data"`
+}
+
+type SubmitBatchOrderResult struct {
+	ExternalOid string `json:"externalOid"`
+	OrderId     int64  `json:"orderId"`
+	ErrorMsg    string `json:"errorMsg"`
+	ErrorCode   int    `json:"errorCode"`
+}
+
+type CancelOrdersResp struct {
+	Response
+	Data []*CancelOrderResult `json:"data"`
+}
+
+type CancelOrderResult struct {
+	OrderId   int64  `json:"orderId"`
+	ErrorCode int    `json:"errorCode"`
+	ErrorMsg  string `json:"errorMsg"`
+}
+
+type CancelOrderByExternalOidParam struct {
+	Symbol      string `json:"symbol" validate:"required"`
+	ExternalOid string `json:"externalOid" validate:"required"`
+}
+
+type CancelAllOrdersParam struct {
+	Symbol string `json:"symbol,omitempty" validate:"omitempty"`
+}
+
+// EmptyResp is returned by the endpoints that only report success.
+type EmptyResp struct {
+	Response
+}
+
+type GetOrderByExternalOidParam struct {
+	Symbol      string `validate:"required"`
+	ExternalOid string `validate:"required"`
+}
+
+type GetOpenOrdersParam struct {
+	Symbol   string `url:"-" validate:"omitempty"`
+	PageNum  int    `url:"page_num,omitempty" validate:"omitempty"`
+	PageSize int    `url:"page_size,omitempty" validate:"omitempty,max=100"`
+}
+
+type GetHistoryOrdersParam struct {
+	Symbol    string `url:"symbol,omitempty" validate:"omitempty"`
+	States    string `url:"states,omitempty" validate:"omitempty"`
+	Category  int    `url:"category,omitempty" validate:"omitempty"`
+	StartTime int64  `url:"start_time,omitempty" validate:"omitempty"`
+	EndTime   int64  `url:"end_time,omitempty" validate:"omitempty"`
+	Side      int    `url:"side,omitempty" validate:"omitempty,oneof=1 2 3 4"`
+	PageNum   int    `url:"page_num,omitempty" validate:"omitempty"`
+	PageSize  int    `url:"page_size,omitempty" validate:"omitempty,max=100"`
+}
+
+type GetOrderDealsParam struct {
+	Symbol    string `url:"symbol" validate:"required"`
+	StartTime int64  `url:"start_time,omitempty" validate:"omitempty"`
+	EndTime   int64  `url:"end_time,omitempty" validate:"omitempty"`
+	PageNum   int    `url:"page_num,omitempty" validate:"omitempty"`
+	PageSize  int    `url:"page_size,omitempty" validate:"omitempty,max=100"`
+}
+
+type GetOrderResp struct {
+	Response
+	Data *Order `json:"data"`
+}
+
+type GetOrdersResp struct {
+	Response
+	Data []*Order `json:"data"`
+}
+
+type Order struct {
+	OrderId         string  `json:"orderId"`
+	Symbol          string  `json:"symbol"`
+	PositionId      int64   `json:"positionId"`
+	Price           float64 `json:"price"`
+	Vol             float64 `json:"vol"`
+	Leverage        int     `json:"leverage"`
+	Side            int     `json:"side"`
+	Category        int     `json:"category"`
+	OrderType       int     `json:"orderType"`
+	DealAvgPrice    float64 `json:"dealAvgPrice"`
+	DealVol         float64 `json:"dealVol"`
+	OrderMargin     float64 `json:"orderMargin"`
+	TakerFee        float64 `json:"takerFee"`
+	MakerFee        float64 `json:"makerFee"`
+	Profit          float64 `json:"profit"`
+	FeeCurrency     string  `json:"feeCurrency"`
+	OpenType        int     `json:"openType"`
+	State           int     `json:"state"`
+	ExternalOid     string  `json:"externalOid"`
+	ErrorCode       int     `json:"errorCode"`
+	UsedMargin      float64 `json:"usedMargin"`
+	CreateTime      int64   `json:"createTime"`
+	UpdateTime      int64   `json:"updateTime"`
+	StopLossPrice   float64 `json:"stopLossPrice"`
+	TakeProfitPrice float64 `json:"takeProfitPrice"`
+}
+
+type GetOrderDealsResp struct {
+	Response
+	Data []*OrderDeal `json:"data"`
+}
+
+type OrderDeal struct {
+	Id          int64   `json:"id"`
+	Symbol      string  `json:"symbol"`
+	Side        int     `json:"side"`
+	Vol         float64 `json:"vol"`
+	Price       float64 `json:"price"`
+	FeeCurrency string  `json:"feeCurrency"`
+	Fee         float64 `json:"fee"`
+	Timestamp   int64   `json:"timestamp"`
+	Profit      float64 `json:"profit"`
+	IsTaker     bool    `json:"isTaker"`
+	Category    int     `json:"category"`
+	OrderId     int64   `json:"orderId"`
+}
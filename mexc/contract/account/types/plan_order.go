@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+type PlacePlanOrderParam struct {
+	Symbol       string        `json:"symbol" validate:"required"`
+	Price        float64       `json:"price,omitempty" validate:"omitempty"`
+	Vol          float64       `json:"vol" validate:"required"`
+	Leverage     int           `json:"leverage,omitempty" validate:"omitempty"`
+	Side         OrderSide     `json:"side" validate:"required,oneof=1 2 3 4"`
+	OpenType     OpenType      `json:"openType" validate:"required,oneof=1 2"`
+	TriggerPrice float64       `json:"triggerPrice" validate:"required"`
+	TriggerType  TriggerType   `json:"triggerType" validate:"required,oneof=1 2"`
+	ExecuteCycle ExecuteCycle  `json:"executeCycle" validate:"required,oneof=1 2"`
+	OrderType    PlanOrderType `json:"orderType" validate:"required,oneof=1 2 3 4 5"`
+	Trend        TriggerTrend  `json:"trend" validate:"required,oneof=1 2 3"`
+
+	PositionMode int  `json:"positionMode,omitempty" validate:"omitempty"`
+	ReduceOnly   bool `json:"reduceOnly,omitempty" validate:"omitempty"`
+}
+
+type TriggerType = int
+
+var (
+	GreaterThanOrEqual TriggerType = 1
+	LessThanOrEqual    TriggerType = 2
+)
+
+type ExecuteCycle = int
+
+var (
+	Hours24 ExecuteCycle = 1
+	Days7   ExecuteCycle = 2
+)
+
+type PlanOrderType = int
+
+var (
+	PlanLimitOrder    PlanOrderType = 1
+	PlanPostOnlyMaker PlanOrderType = 2
+	PlanIOC           PlanOrderType = 3
+	PlanFOK           PlanOrderType = 4
+	PlanMarketOrder   PlanOrderType = 5
+)
+
+type TriggerTrend = int
+
+var (
+	LatestPrice TriggerTrend = 1
+	FairPrice   TriggerTrend = 2
+	IndexPrice  TriggerTrend = 3
+)
+
+type PlacePlanOrderResp struct {
+	Response
+	Data string `json:"data"`
+}
+
+type CancelPlanOrderParam struct {
+	Symbol  string `json:"symbol" validate:"required"`
+	OrderId string `json:"orderId" validate:"required"`
+}
+
+type CancelAllPlanOrdersParam struct {
+	Symbol string `json:"symbol,omitempty" validate:"omitempty"`
+}
+
+type GetPlanOrdersParam struct {
+	Symbol    string `url:"symbol,omitempty" validate:"omitempty"`
+	States    string `url:"states,omitempty" validate:"omitempty"`
+	StartTime int64  `url:"start_time,omitempty" validate:"omitempty"`
+	EndTime   int64  `url:"end_time,omitempty" validate:"omitempty"`
+	PageNum   int    `url:"page_num,omitempty" validate:"omitempty"`
+	PageSize  int    `url:"page_size,omitempty" validate:"omitempty,max=100"`
+}
+
+type GetPlanOrdersResp struct {
+	Response
+	Data []*PlanOrder `json:"data"`
+}
+
+type PlanOrder struct {
+	Id           string  `json:"id"`
+	Symbol       string  `json:"symbol"`
+	Leverage     int     `json:"leverage"`
+	Side         int     `json:"side"`
+	TriggerPrice float64 `json:"triggerPrice"`
+	Price        float64 `json:"price"`
+	Vol          float64 `json:"vol"`
+	OpenType     int     `json:"openType"`
+	TriggerType  int     `json:"triggerType"`
+	State        int     `json:"state"`
+	ExecuteCycle int     `json:"executeCycle"`
+	Trend        int     `json:"trend"`
+	OrderType    int     `json:"orderType"`
+	OrderId      string  `json:"orderId"`
+	ErrorCode    int     `json:"errorCode"`
+	CreateTime   int64   `json:"createTime"`
+	UpdateTime   int64   `json:"updateTime"`
+}
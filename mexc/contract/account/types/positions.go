@@ -17,6 +17,8 @@
 
 package types
 
+import "github.com/rluisr/nexapi/utils/decimal"
+
 type GetOpenPositionsParams struct {
 	Symbol string `url:"symbol,omitempty" validate:"omitempty"`
 }
@@ -33,20 +35,96 @@ type OpenPosition struct {
 	OpenType     int    `json:"openType"`
 	State        int    `json:"state"`
 
-	FrozenVol      float64 `json:"frozenVol"`
-	CloseVol       float64 `json:"closeVol"`
-	HoldAvgPrice   float64 `json:"holdAvgPrice"`
-	CloseAvgPrice  float64 `json:"closeAvgPrice"`
-	OpenAvgPrice   float64 `json:"openAvgPrice"`
-	LiquidatePrice float64 `json:"liquidatePrice"`
-	Oim            float64 `json:"oim"`
-	Im             float64 `json:"im"`
-	HoldFee        float64 `json:"holdFee"`
-	Realised       float64 `json:"realised"`
-
-	HoldVol    float64 `json:"holdVol"`
-	Leverage   int     `json:"leverage"`
-	CreateTime int64   `json:"createTime"`
-	UpdateTime int64   `json:"updateTime"`
-	AutoAddIm  bool    `json:"autoAddIm"`
+	FrozenVol      decimal.Decimal `json:"frozenVol"`
+	CloseVol       decimal.Decimal `json:"closeVol"`
+	HoldAvgPrice   decimal.Decimal `json:"holdAvgPrice"`
+	CloseAvgPrice  decimal.Decimal `json:"closeAvgPrice"`
+	OpenAvgPrice   decimal.Decimal `json:"openAvgPrice"`
+	LiquidatePrice decimal.Decimal `json:"liquidatePrice"`
+	Oim            decimal.Decimal `json:"oim"`
+	Im             decimal.Decimal `json:"im"`
+	HoldFee        decimal.Decimal `json:"holdFee"`
+	Realised       decimal.Decimal `json:"realised"`
+
+	HoldVol    decimal.Decimal `json:"holdVol"`
+	Leverage   int             `json:"leverage"`
+	CreateTime int64           `json:"createTime"`
+	UpdateTime int64           `json:"updateTime"`
+	AutoAddIm  bool            `json:"autoAddIm"`
+}
+
+type ChangeMarginParam struct {
+	PositionId int64            `json:"positionId" validate:"required"`
+	Amount     float64          `json:"amount" validate:"required,gt=0"`
+	Type       MarginChangeType `json:"type" validate:"required,oneof=ADD SUB"`
+}
+
+type MarginChangeType = string
+
+var (
+	AddMargin      MarginChangeType = "ADD"
+	SubtractMargin MarginChangeType = "SUB"
+)
+
+type PositionMode = int
+
+var (
+	HedgeMode  PositionMode = 1
+	OneWayMode PositionMode = 2
+)
+
+type GetPositionModeResp struct {
+	Response
+	Data PositionMode `json:"data"`
+}
+
+type ChangePositionModeParam struct {
+	PositionMode PositionMode `json:"positionMode" validate:"required,oneof=1 2"`
+}
+
+type ChangeAutoAddMarginParam struct {
+	PositionId int64 `json:"positionId" validate:"required"`
+	IsEnabled  bool  `json:"isEnabled"`
+}
+
+type GetHistoryPositionsParam struct {
+	Symbol   string `url:"symbol,omitempty" validate:"omitempty"`
+	Type     int    `url:"type,omitempty" validate:"omitempty,oneof=1 2"` // 1: long, 2: short
+	PageNum  int    `url:"page_num,omitempty" validate:"omitempty"`
+	PageSize int    `url:"page_size,omitempty" validate:"omitempty,max=100"`
+}
+
+type GetHistoryPositionsResp struct {
+	Response
+	Data []*OpenPosition `json:"data"`
+}
+
+type GetFundingRecordsParam struct {
+	Symbol     string `url:"symbol,omitempty" validate:"omitempty"`
+	PositionId int64  `url:"position_id,omitempty" validate:"omitempty"`
+	PageNum    int    `url:"page_num,omitempty" validate:"omitempty"`
+	PageSize   int    `url:"page_size,omitempty" validate:"omitempty,max=100"`
+}
+
+type GetFundingRecordsResp struct {
+	Response
+	Data *FundingRecords `json:"data"`
+}
+
+type FundingRecords struct {
+	PageSize    int              `json:"pageSize"`
+	TotalCount  int              `json:"totalCount"`
+	TotalPage   int              `json:"totalPage"`
+	CurrentPage int              `json:"currentPage"`
+	ResultList  []*FundingRecord `json:"resultList"`
+}
+
+type FundingRecord struct {
+	Id            int64   `json:"id"`
+	Symbol        string  `json:"symbol"`
+	PositionType  int     `json:"positionType"`
+	PositionValue float64 `json:"positionValue"`
+	Funding       float64 `json:"funding"`
+	Rate          float64 `json:"rate"`
+	SettleTime    int64   `json:"settleTime"`
 }
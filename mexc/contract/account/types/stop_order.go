@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+type PlaceStopOrderParam struct {
+	PositionId      int64        `json:"positionId" validate:"required"`
+	Vol             float64      `json:"vol" validate:"required"`
+	StopLossPrice   float64      `json:"stopLossPrice,omitempty" validate:"required_without=TakeProfitPrice"`
+	TakeProfitPrice float64      `json:"takeProfitPrice,omitempty" validate:"required_without=StopLossPrice"`
+	LossTrend       TriggerTrend `json:"lossTrend,omitempty" validate:"omitempty,oneof=1 2 3"`
+	ProfitTrend     TriggerTrend `json:"profitTrend,omitempty" validate:"omitempty,oneof=1 2 3"`
+}
+
+type PlaceStopOrderResp struct {
+	Response
+	Data string `json:"data"`
+}
+
+type CancelStopOrderParam struct {
+	StopPlanOrderId int64 `json:"stopPlanOrderId" validate:"required"`
+}
+
+// CancelAllStopOrdersParam cancels the stop orders of a position, or of
+// every position of a symbol when only Symbol is set.
+type CancelAllStopOrdersParam struct {
+	PositionId int64  `json:"positionId,omitempty" validate:"omitempty"`
+	Symbol     string `json:"symbol,omitempty" validate:"omitempty"`
+}
+
+// ChangeLimitOrderStopPriceParam modifies the TP/SL prices attached to a pending limit order.
+type ChangeLimitOrderStopPriceParam struct {
+	OrderId         int64   `json:"orderId" validate:"required"`
+	StopLossPrice   float64 `json:"stopLossPrice,omitempty" validate:"omitempty"`
+	TakeProfitPrice float64 `json:"takeProfitPrice,omitempty" validate:"omitempty"`
+}
+
+// ChangeStopPlanPriceParam modifies the TP/SL prices of a stop order placed on a position.
+type ChangeStopPlanPriceParam struct {
+	StopPlanOrderId int64   `json:"stopPlanOrderId" validate:"required"`
+	StopLossPrice   float64 `json:"stopLossPrice,omitempty" validate:"omitempty"`
+	TakeProfitPrice float64 `json:"takeProfitPrice,omitempty" validate:"omitempty"`
+}
+
+type GetStopOrdersParam struct {
+	Symbol     string `url:"symbol,omitempty" validate:"omitempty"`
+	IsFinished int    `url:"is_finished,omitempty" validate:"omitempty,oneof=0 1"`
+	StartTime  int64  `url:"start_time,omitempty" validate:"omitempty"`
+	EndTime    int64  `url:"end_time,omitempty" validate:"omitempty"`
+	PageNum    int    `url:"page_num,omitempty" validate:"omitempty"`
+	PageSize   int    `url:"page_size,omitempty" validate:"omitempty,max=100"`
+}
+
+type GetStopOrdersResp struct {
+	Response
+	Data []*StopOrder `json:"data"`
+}
+
+type GetStopOrderResp struct {
+	Response
+	Data *StopOrder `json:"data"`
+}
+
+type StopOrder struct {
+	Id              int64   `json:"id"`
+	OrderId         string  `json:"orderId"`
+	Symbol          string  `json:"symbol"`
+	PositionId      int64   `json:"positionId"`
+	StopLossPrice   float64 `json:"stopLossPrice"`
+	TakeProfitPrice float64 `json:"takeProfitPrice"`
+	State           int     `json:"state"`
+	TriggerSide     int     `json:"triggerSide"`
+	PositionType    int     `json:"positionType"`
+	Vol             float64 `json:"vol"`
+	RealityVol      float64 `json:"realityVol"`
+	PlaceOrderId    string  `json:"placeOrderId"`
+	ErrorCode       int     `json:"errorCode"`
+	Version         int     `json:"version"`
+	IsFinished      int     `json:"isFinished"`
+	CreateTime      int64   `json:"createTime"`
+	UpdateTime      int64   `json:"updateTime"`
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package contracttest provides a fake MEXC contract server for offline tests.
+package contracttest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/rluisr/nexapi/utils/fakeserver"
+	"github.com/stretchr/testify/assert"
+)
+
+// The credentials accepted by the server.
+const (
+	Key    = "mexc-contract-test-key"
+	Secret = "mexc-contract-test-secret"
+)
+
+// Success is the response envelope returned for the routes without fixture.
+const Success = `{"success":true,"code":0,"data":null}`
+
+// InvalidSignCode is the error code of the requests rejected by Verify.
+const InvalidSignCode = "602"
+
+// NewServer starts a server that checks the signed requests with Key and Secret.
+// Like the API, a failed signature is reported with status 200 and success false.
+func NewServer() *fakeserver.Server {
+	return fakeserver.New(fakeserver.Config{
+		Verify: Verify,
+		Unauthorized: fakeserver.Response{
+			Status: http.StatusOK,
+			Body:   `{"success":false,"code":` + InvalidSignCode + `,"message":"%s"}`,
+		},
+		Default: fakeserver.Response{Status: http.StatusOK, Body: Success},
+	})
+}
+
+// Verify checks the ApiKey, Request-Time and Signature headers, the signature covers the
+// sorted query of GET and DELETE requests and the raw body of POST requests.
+// A request without ApiKey header is not signed.
+func Verify(r *http.Request, body []byte) (bool, error) {
+	key := r.Header.Get("ApiKey")
+	if key == "" {
+		return false, nil
+	}
+
+	if key != Key {
+		return true, errors.New("api key does not exist")
+	}
+
+	timestamp := r.Header.Get("Request-Time")
+	if timestamp == "" {
+		return true, errors.New("request time is required")
+	}
+
+	var signString string
+	switch r.Method {
+	case http.MethodGet, http.MethodDelete:
+		signString = r.URL.Query().Encode()
+	case http.MethodPost:
+		signString = string(body)
+	}
+
+	h := hmac.New(sha256.New, []byte(Secret))
+	h.Write([]byte(Key + timestamp + signString))
+	expected := hex.EncodeToString(h.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(r.Header.Get("Signature"))) {
+		return true, errors.New("signature verification failed")
+	}
+
+	return true, nil
+}
+
+// AssertInvalidSignature checks that err reports a request rejected by Verify.
+func AssertInvalidSignature(t *testing.T, err error) {
+	t.Helper()
+	assert.ErrorContains(t, err, "code="+InvalidSignCode)
+}
@@ -20,11 +20,14 @@ package marketdata
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/go-playground/validator"
 	"github.com/rluisr/nexapi/mexc/contract/marketdata/types"
 	"github.com/rluisr/nexapi/mexc/contract/utils"
+	"github.com/rluisr/nexapi/utils/backfill"
 )
 
 type ContractMarketDataClient struct {
@@ -165,3 +168,353 @@ func (s *ContractMarketDataClient) GetTickerForAllSymbols(ctx context.Context) (
 
 	return &ret, nil
 }
+
+func (s *ContractMarketDataClient) GetDepth(ctx context.Context, param types.GetDepthParam) (*types.GetDepthResp, error) {
+	err := s.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: s.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/contract/depth/%s", param.Symbol),
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := s.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := s.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.GetDepthResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetDepthCommits returns the last param.Limit incremental depth snapshots.
+func (s *ContractMarketDataClient) GetDepthCommits(ctx context.Context, param types.GetDepthCommitsParam) (*types.GetDepthCommitsResp, error) {
+	err := s.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: s.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/contract/depth_commits/%s/%d", param.Symbol, param.Limit),
+		Method:  http.MethodGet,
+	}
+
+	headers, err := s.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := s.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.GetDepthCommitsResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (s *ContractMarketDataClient) GetIndexPrice(ctx context.Context, symbol string) (*types.GetIndexPriceResp, error) {
+	req := utils.HTTPRequest{
+		BaseURL: s.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/contract/index_price/%s", symbol),
+		Method:  http.MethodGet,
+	}
+
+	headers, err := s.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := s.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.GetIndexPriceResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (s *ContractMarketDataClient) GetFairPrice(ctx context.Context, symbol string) (*types.GetFairPriceResp, error) {
+	req := utils.HTTPRequest{
+		BaseURL: s.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/contract/fair_price/%s", symbol),
+		Method:  http.MethodGet,
+	}
+
+	headers, err := s.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := s.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.GetFairPriceResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (s *ContractMarketDataClient) GetFundingRate(ctx context.Context, symbol string) (*types.GetFundingRateResp, error) {
+	req := utils.HTTPRequest{
+		BaseURL: s.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/contract/funding_rate/%s", symbol),
+		Method:  http.MethodGet,
+	}
+
+	headers, err := s.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := s.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.GetFundingRateResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (s *ContractMarketDataClient) GetFundingRateHistory(ctx context.Context, param types.GetFundingRateHistoryParam) (*types.GetFundingRateHistoryResp, error) {
+	err := s.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: s.GetBaseURL(),
+		Path:    "/api/v1/contract/funding_rate/history",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := s.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := s.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.GetFundingRateHistoryResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (s *ContractMarketDataClient) GetKlines(ctx context.Context, param types.GetKlineParam) (*types.GetKlineResp, error) {
+	err := s.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: s.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/contract/kline/%s", param.Symbol),
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := s.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := s.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.GetKlineResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (s *ContractMarketDataClient) GetIndexPriceKlines(ctx context.Context, param types.GetKlineParam) (*types.GetKlineResp, error) {
+	err := s.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: s.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/contract/kline/index_price/%s", param.Symbol),
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := s.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := s.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.GetKlineResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (s *ContractMarketDataClient) GetFairPriceKlines(ctx context.Context, param types.GetKlineParam) (*types.GetKlineResp, error) {
+	err := s.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: s.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/contract/kline/fair_price/%s", param.Symbol),
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := s.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := s.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.GetKlineResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (s *ContractMarketDataClient) GetDeals(ctx context.Context, param types.GetDealsParam) (*types.GetDealsResp, error) {
+	err := s.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		BaseURL: s.GetBaseURL(),
+		Path:    fmt.Sprintf("/api/v1/contract/deals/%s", param.Symbol),
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := s.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := s.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret types.GetDealsResp
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// KlineSource describes the contract klines of symbol for the backfill package,
+// MEXC returns at most 2000 klines per request.
+func (s *ContractMarketDataClient) KlineSource(symbol string, interval utils.KlineInterval) (backfill.Source[*types.Kline], error) {
+	d, ok := klineDurations[interval]
+	if !ok {
+		return backfill.Source[*types.Kline]{}, fmt.Errorf("unsupported kline interval: %s", interval)
+	}
+
+	return backfill.Source[*types.Kline]{
+		Fetch: func(ctx context.Context, start, end time.Time) ([]*types.Kline, error) {
+			resp, err := s.GetKlines(ctx, types.GetKlineParam{
+				Symbol:   symbol,
+				Interval: interval,
+				Start:    start.Unix(),
+				End:      end.Add(-time.Second).Unix(),
+			})
+			if err != nil {
+				return nil, err
+			}
+			if err := resp.Err(); err != nil {
+				return nil, err
+			}
+			if resp.Data == nil {
+				return nil, nil
+			}
+
+			return resp.Data.Klines()
+		},
+		OpenTime: func(k *types.Kline) time.Time {
+			return time.Unix(k.Time, 0)
+		},
+		Interval: d,
+		Limit:    2000,
+	}, nil
+}
+
+var klineDurations = map[utils.KlineInterval]time.Duration{
+	utils.Minute1:  time.Minute,
+	utils.Minute5:  5 * time.Minute,
+	utils.Minute15: 15 * time.Minute,
+	utils.Minute30: 30 * time.Minute,
+	utils.Minute60: time.Hour,
+	utils.Hour4:    4 * time.Hour,
+	utils.Hour8:    8 * time.Hour,
+	utils.Day1:     24 * time.Hour,
+	utils.Week1:    7 * 24 * time.Hour,
+}
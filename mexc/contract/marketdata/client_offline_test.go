@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package marketdata
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rluisr/nexapi/mexc/contract/contracttest"
+	"github.com/rluisr/nexapi/mexc/contract/marketdata/types"
+	"github.com/rluisr/nexapi/mexc/contract/utils"
+	"github.com/rluisr/nexapi/utils/fakeserver"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNewFakeContractMarketDataClient(t *testing.T) (*ContractMarketDataClient, *fakeserver.Server) {
+	srv := contracttest.NewServer()
+	t.Cleanup(srv.Close)
+
+	cli, err := NewContractMarketDataClient(&utils.ContractClientCfg{
+		BaseURL:    srv.URL,
+		HTTPClient: srv.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Could not create mexc client, %s", err)
+	}
+
+	return cli, srv
+}
+
+func TestFakeEndpoints(t *testing.T) {
+	cli, srv := testNewFakeContractMarketDataClient(t)
+
+	klines := types.GetKlineParam{Symbol: "BTC_USDT", Interval: utils.Minute1}
+
+	fakeserver.RunEndpoints(t, srv, false, []fakeserver.Endpoint{
+		{Name: "GetServerTime", Method: http.MethodGet, Path: "/api/v1/contract/ping", Call: func(ctx context.Context) (any, error) {
+			return cli.GetServerTime(ctx)
+		}},
+		{Name: "GetContractDetails", Method: http.MethodGet, Path: "/api/v1/contract/detail", Call: func(ctx context.Context) (any, error) {
+			return cli.GetContractDetails(ctx, types.GetContractDetailsParams{Symbol: "BTC_USDT"})
+		}},
+		{Name: "GetTickerForSymbol", Method: http.MethodGet, Path: "/api/v1/contract/ticker", Call: func(ctx context.Context) (any, error) {
+			return cli.GetTickerForSymbol(ctx, types.GetTickerForSymbolParam{Symbol: "BTC_USDT"})
+		}},
+		{Name: "GetTickerForAllSymbols", Method: http.MethodGet, Path: "/api/v1/contract/ticker", Call: func(ctx context.Context) (any, error) {
+			return cli.GetTickerForAllSymbols(ctx)
+		}},
+		{Name: "GetDepth", Method: http.MethodGet, Path: "/api/v1/contract/depth/BTC_USDT", Call: func(ctx context.Context) (any, error) {
+			return cli.GetDepth(ctx, types.GetDepthParam{Symbol: "BTC_USDT", Limit: 5})
+		}},
+		{Name: "GetDepthCommits", Method: http.MethodGet, Path: "/api/v1/contract/depth_commits/BTC_USDT/10", Call: func(ctx context.Context) (any, error) {
+			return cli.GetDepthCommits(ctx, types.GetDepthCommitsParam{Symbol: "BTC_USDT", Limit: 10})
+		}},
+		{Name: "GetIndexPrice", Method: http.MethodGet, Path: "/api/v1/contract/index_price/BTC_USDT", Call: func(ctx context.Context) (any, error) {
+			return cli.GetIndexPrice(ctx, "BTC_USDT")
+		}},
+		{Name: "GetFairPrice", Method: http.MethodGet, Path: "/api/v1/contract/fair_price/BTC_USDT", Call: func(ctx context.Context) (any, error) {
+			return cli.GetFairPrice(ctx, "BTC_USDT")
+		}},
+		{Name: "GetFundingRate", Method: http.MethodGet, Path: "/api/v1/contract/funding_rate/BTC_USDT", Call: func(ctx context.Context) (any, error) {
+			return cli.GetFundingRate(ctx, "BTC_USDT")
+		}},
+		{Name: "GetFundingRateHistory", Method: http.MethodGet, Path: "/api/v1/contract/funding_rate/history", Call: func(ctx context.Context) (any, error) {
+			return cli.GetFundingRateHistory(ctx, types.GetFundingRateHistoryParam{Symbol: "BTC_USDT"})
+		}},
+		{Name: "GetKlines", Method: http.MethodGet, Path: "/api/v1/contract/kline/BTC_USDT", Call: func(ctx context.Context) (any, error) {
+			return cli.GetKlines(ctx, klines)
+		}},
+		{Name: "GetIndexPriceKlines", Method: http.MethodGet, Path: "/api/v1/contract/kline/index_price/BTC_USDT", Call: func(ctx context.Context) (any, error) {
+			return cli.GetIndexPriceKlines(ctx, klines)
+		}},
+		{Name: "GetFairPriceKlines", Method: http.MethodGet, Path: "/api/v1/contract/kline/fair_price/BTC_USDT", Call: func(ctx context.Context) (any, error) {
+			return cli.GetFairPriceKlines(ctx, klines)
+		}},
+		{Name: "GetDeals", Method: http.MethodGet, Path: "/api/v1/contract/deals/BTC_USDT", Call: func(ctx context.Context) (any, error) {
+			return cli.GetDeals(ctx, types.GetDealsParam{Symbol: "BTC_USDT", Limit: 10})
+		}},
+	})
+}
+
+func TestFakeGetDepth(t *testing.T) {
+	cli, srv := testNewFakeContractMarketDataClient(t)
+
+	srv.Handle(http.MethodGet, "/api/v1/contract/depth/BTC_USDT", `{"success":true,"code":0,"data":{"asks":[[42001.5,120,2]],"bids":[[42000,300,5],[41999.5,10,1]],"version":7,"timestamp":1704067200000}}`)
+
+	resp, err := cli.GetDepth(context.Background(), types.GetDepthParam{Symbol: "BTC_USDT", Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), resp.Data.Version)
+	assert.Equal(t, "2", srv.LastRequest().Query.Get("limit"))
+
+	b, err := resp.Data.Book()
+	assert.Nil(t, err)
+	spread, ok := b.Spread()
+	assert.True(t, ok)
+	assert.Equal(t, "1.5", spread.String())
+}
+
+func TestFakeKlineSource(t *testing.T) {
+	cli, srv := testNewFakeContractMarketDataClient(t)
+
+	srv.Handle(http.MethodGet, "/api/v1/contract/kline/BTC_USDT", `{"success":true,"code":0,"data":{"time":[1704067200,1704067260],"open":[42000,42010],"close":[42010,42020],"high":[42015,42030],"low":[41990,42005],"vol":[10,12],"amount":[420000,504000]}}`)
+
+	src, err := cli.KlineSource("BTC_USDT", utils.Minute1)
+	assert.Nil(t, err)
+
+	start := time.Unix(1704067200, 0)
+	klines, err := src.Fetch(context.Background(), start, start.Add(2*time.Minute))
+	assert.Nil(t, err)
+	assert.Len(t, klines, 2)
+	assert.Equal(t, 42020.0, klines[1].Close)
+
+	// the last kline is still open at the open time of the next one
+	c := klines[1].Candle(time.Minute, start.Add(2*time.Minute-time.Millisecond))
+	assert.Equal(t, start.Add(time.Minute), c.OpenTime)
+	assert.False(t, c.Closed)
+	assert.True(t, klines[1].Candle(time.Minute, start.Add(2*time.Minute)).Closed)
+
+	q := srv.LastRequest().Query
+	assert.Equal(t, "Min1", q.Get("interval"))
+	assert.Equal(t, "1704067319", q.Get("end"))
+
+	srv.Handle(http.MethodGet, "/api/v1/contract/kline/BTC_USDT", `{"success":false,"code":1001,"message":"contract not exists"}`)
+	_, err = src.Fetch(context.Background(), start, start.Add(2*time.Minute))
+	assert.EqualError(t, err, "[API]Failure: code=1001 message=contract not exists")
+}
+
+func TestFakeLatency(t *testing.T) {
+	cli, srv := testNewFakeContractMarketDataClient(t)
+
+	srv.HandleResponse(http.MethodGet, "/api/v1/contract/ping", fakeserver.Response{Body: `{"success":true,"code":0,"data":1704067200000}`, Delay: 50 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := cli.GetServerTime(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	resp, err := cli.GetServerTime(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1704067200000), resp.Data)
+}
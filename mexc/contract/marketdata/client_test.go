@@ -68,3 +68,97 @@ func TestGetTickerForAllSymbols(t *testing.T) {
 	_, err := cli.GetTickerForAllSymbols(context.TODO())
 	assert.Nil(t, err)
 }
+
+func TestGetDepth(t *testing.T) {
+	cli := testNewContractMarketDataClient(t)
+
+	_, err := cli.GetDepth(context.TODO(), types.GetDepthParam{
+		Symbol: "BTC_USDT",
+		Limit:  5,
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetDepthCommits(t *testing.T) {
+	cli := testNewContractMarketDataClient(t)
+
+	_, err := cli.GetDepthCommits(context.TODO(), types.GetDepthCommitsParam{
+		Symbol: "BTC_USDT",
+		Limit:  5,
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetIndexPrice(t *testing.T) {
+	cli := testNewContractMarketDataClient(t)
+
+	_, err := cli.GetIndexPrice(context.TODO(), "BTC_USDT")
+	assert.Nil(t, err)
+}
+
+func TestGetFairPrice(t *testing.T) {
+	cli := testNewContractMarketDataClient(t)
+
+	_, err := cli.GetFairPrice(context.TODO(), "BTC_USDT")
+	assert.Nil(t, err)
+}
+
+func TestGetFundingRate(t *testing.T) {
+	cli := testNewContractMarketDataClient(t)
+
+	_, err := cli.GetFundingRate(context.TODO(), "BTC_USDT")
+	assert.Nil(t, err)
+}
+
+func TestGetFundingRateHistory(t *testing.T) {
+	cli := testNewContractMarketDataClient(t)
+
+	_, err := cli.GetFundingRateHistory(context.TODO(), types.GetFundingRateHistoryParam{
+		Symbol:   "BTC_USDT",
+		PageSize: 10,
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetKlines(t *testing.T) {
+	cli := testNewContractMarketDataClient(t)
+
+	resp, err := cli.GetKlines(context.TODO(), types.GetKlineParam{
+		Symbol:   "BTC_USDT",
+		Interval: utils.Minute1,
+	})
+	assert.Nil(t, err)
+
+	_, err = resp.Data.Klines()
+	assert.Nil(t, err)
+}
+
+func TestGetIndexPriceKlines(t *testing.T) {
+	cli := testNewContractMarketDataClient(t)
+
+	_, err := cli.GetIndexPriceKlines(context.TODO(), types.GetKlineParam{
+		Symbol:   "BTC_USDT",
+		Interval: utils.Minute1,
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetFairPriceKlines(t *testing.T) {
+	cli := testNewContractMarketDataClient(t)
+
+	_, err := cli.GetFairPriceKlines(context.TODO(), types.GetKlineParam{
+		Symbol:   "BTC_USDT",
+		Interval: utils.Minute1,
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetDeals(t *testing.T) {
+	cli := testNewContractMarketDataClient(t)
+
+	_, err := cli.GetDeals(context.TODO(), types.GetDealsParam{
+		Symbol: "BTC_USDT",
+		Limit:  10,
+	})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	"time"
+
+	"github.com/rluisr/nexapi/utils/candle"
+)
+
+type GetDealsParam struct {
+	Symbol string `url:"-" validate:"required"`
+	Limit  int    `url:"limit,omitempty" validate:"omitempty,max=100"`
+}
+
+type GetDealsResp struct {
+	Response
+	Data []*Deal `json:"data"`
+}
+
+type Deal struct {
+	Price     float64 `json:"p"`
+	Vol       float64 `json:"v"`
+	TradeType int     `json:"T"` // 1: purchase, 2: sell
+	OpenType  int     `json:"O"` // 1: open position, 2: close position, 3: position no change
+	SelfTrade int     `json:"M"` // 1: yes, 2: no
+	Time      int64   `json:"t"`
+}
+
+// CandleTrade converts the deal for the candle builder, the quantity is in contracts.
+func (d *Deal) CandleTrade() *candle.Trade {
+	return &candle.Trade{Time: time.UnixMilli(d.Time), Price: d.Price, Qty: d.Vol}
+}
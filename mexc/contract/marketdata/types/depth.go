@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import "github.com/rluisr/nexapi/utils/book"
+
+type GetDepthParam struct {
+	Symbol string `url:"-" validate:"required"`
+	Limit  int    `url:"limit,omitempty" validate:"omitempty"`
+}
+
+type GetDepthResp struct {
+	Response
+	Data *Depth `json:"data"`
+}
+
+// Depth holds the order book of a contract, every level is [price, volume, order count].
+type Depth struct {
+	Asks      [][]float64 `json:"asks"`
+	Bids      [][]float64 `json:"bids"`
+	Version   int64       `json:"version"`
+	Timestamp int64       `json:"timestamp"`
+}
+
+type GetDepthCommitsParam struct {
+	Symbol string `validate:"required"`
+	Limit  int    `validate:"required"`
+}
+
+type GetDepthCommitsResp struct {
+	Response
+	Data []*Depth `json:"data"`
+}
+
+// Book returns the typed and sorted levels of the order book.
+func (d *Depth) Book() (*book.Book, error) {
+	return book.FromFloats(d.Bids, d.Asks)
+}
+
+func (d *Depth) BidLevels() ([]book.PriceLevel, error) {
+	return book.FloatLevels(d.Bids)
+}
+
+func (d *Depth) AskLevels() ([]book.PriceLevel, error) {
+	return book.FloatLevels(d.Asks)
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+type GetFundingRateResp struct {
+	Response
+	Data *FundingRate `json:"data"`
+}
+
+type FundingRate struct {
+	Symbol         string  `json:"symbol"`
+	FundingRate    float64 `json:"fundingRate"`
+	MaxFundingRate float64 `json:"maxFundingRate"`
+	MinFundingRate float64 `json:"minFundingRate"`
+	CollectCycle   int     `json:"collectCycle"`
+	NextSettleTime int64   `json:"nextSettleTime"`
+	Timestamp      int64   `json:"timestamp"`
+}
+
+type GetFundingRateHistoryParam struct {
+	Symbol   string `url:"symbol" validate:"required"`
+	PageNum  int    `url:"page_num,omitempty" validate:"omitempty"`
+	PageSize int    `url:"page_size,omitempty" validate:"omitempty,max=1000"`
+}
+
+type GetFundingRateHistoryResp struct {
+	Response
+	Data *FundingRateHistory `json:"data"`
+}
+
+type FundingRateHistory struct {
+	PageSize    int                        `json:"pageSize"`
+	TotalCount  int                        `json:"totalCount"`
+	TotalPage   int                        `json:"totalPage"`
+	CurrentPage int                        `json:"currentPage"`
+	ResultList  []*FundingRateHistoryEntry `json:"resultList"`
+}
+
+type FundingRateHistoryEntry struct {
+	Symbol      string  `json:"symbol"`
+	FundingRate float64 `json:"fundingRate"`
+	SettleTime  int64   `json:"settleTime"`
+}
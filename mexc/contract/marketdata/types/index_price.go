@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+type GetIndexPriceResp struct {
+	Response
+	Data *IndexPrice `json:"data"`
+}
+
+type IndexPrice struct {
+	Symbol     string  `json:"symbol"`
+	IndexPrice float64 `json:"indexPrice"`
+	Timestamp  int64   `json:"timestamp"`
+}
+
+type GetFairPriceResp struct {
+	Response
+	Data *FairPrice `json:"data"`
+}
+
+type FairPrice struct {
+	Symbol    string  `json:"symbol"`
+	FairPrice float64 `json:"fairPrice"`
+	Timestamp int64   `json:"timestamp"`
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rluisr/nexapi/mexc/contract/utils"
+	"github.com/rluisr/nexapi/utils/candle"
+)
+
+type GetKlineParam struct {
+	Symbol   string              `url:"-" validate:"required"`
+	Interval utils.KlineInterval `url:"interval,omitempty" validate:"omitempty,oneof=Min1 Min5 Min15 Min30 Min60 Hour4 Hour8 Day1 Week1 Month1"`
+	Start    int64               `url:"start,omitempty" validate:"omitempty"` // start time in seconds
+	End      int64               `url:"end,omitempty" validate:"omitempty"`   // end time in seconds
+}
+
+type GetKlineResp struct {
+	Response
+	Data *KlineData `json:"data"`
+}
+
+// KlineData is the column-oriented kline payload returned by MEXC,
+// the n-th element of each slice belongs to the same kline.
+type KlineData struct {
+	Time   []int64   `json:"time"`
+	Open   []float64 `json:"open"`
+	Close  []float64 `json:"close"`
+	High   []float64 `json:"high"`
+	Low    []float64 `json:"low"`
+	Vol    []float64 `json:"vol"`
+	Amount []float64 `json:"amount"`
+}
+
+type Kline struct {
+	Time   int64   `json:"time"`
+	Open   float64 `json:"open"`
+	Close  float64 `json:"close"`
+	High   float64 `json:"high"`
+	Low    float64 `json:"low"`
+	Vol    float64 `json:"vol"`
+	Amount float64 `json:"amount"`
+}
+
+// Klines converts the column-oriented payload into one Kline per period.
+// Index and fair price klines carry no volume, Vol and Amount are zero for them.
+func (k *KlineData) Klines() ([]*Kline, error) {
+	n := len(k.Time)
+	for _, l := range []int{len(k.Open), len(k.Close), len(k.High), len(k.Low)} {
+		if l != n {
+			return nil, fmt.Errorf("inconsistent kline data: %d timestamps but %d values", n, l)
+		}
+	}
+
+	ret := make([]*Kline, 0, n)
+	for i := 0; i < n; i++ {
+		kline := &Kline{
+			Time:  k.Time[i],
+			Open:  k.Open[i],
+			Close: k.Close[i],
+			High:  k.High[i],
+			Low:   k.Low[i],
+		}
+		if i < len(k.Vol) {
+			kline.Vol = k.Vol[i]
+		}
+		if i < len(k.Amount) {
+			kline.Amount = k.Amount[i]
+		}
+		ret = append(ret, kline)
+	}
+
+	return ret, nil
+}
+
+// Candle converts the kline of the given interval for the candle builder,
+// the kline is closed once its interval has elapsed at now.
+func (k *Kline) Candle(interval time.Duration, now time.Time) *candle.Candle {
+	openTime := time.Unix(k.Time, 0)
+
+	return &candle.Candle{
+		OpenTime:    openTime,
+		Interval:    interval,
+		Open:        k.Open,
+		High:        k.High,
+		Low:         k.Low,
+		Close:       k.Close,
+		Volume:      k.Vol,
+		QuoteVolume: k.Amount,
+		Closed:      !now.Before(openTime.Add(interval)),
+	}
+}
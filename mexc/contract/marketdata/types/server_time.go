@@ -17,12 +17,24 @@
 
 package types
 
+import "fmt"
+
 type Response struct {
-	Success bool `json:"success"`
-	Code    int  `json:"code"`
+	Success bool   `json:"success"`
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
 }
 
 type ServerTime struct {
 	Response
 	Data int64 `json:"data"`
 }
+
+// Err returns the API error described by the response, or nil when the request succeeded.
+func (r *Response) Err() error {
+	if r.Success {
+		return nil
+	}
+
+	return fmt.Errorf("[API]Failure: code=%d message=%s", r.Code, r.Message)
+}
@@ -0,0 +1,336 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package paper provides a paper trading ContractAccountClient backed by a simulated exchange.
+package paper
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/rluisr/nexapi/mexc/contract/account/types"
+	mdtypes "github.com/rluisr/nexapi/mexc/contract/marketdata/types"
+	"github.com/rluisr/nexapi/utils/decimal"
+	"github.com/rluisr/nexapi/utils/sim"
+)
+
+// ContractAccountClient has the order, position and asset methods of account.ContractAccountClient.
+// The positions are in one-way mode and the leverage and margin are not simulated. Like the API,
+// a rejected request is reported by the response instead of an error.
+type ContractAccountClient struct {
+	*sim.Exchange
+}
+
+func NewContractAccountClient(ex *sim.Exchange) *ContractAccountClient {
+	return &ContractAccountClient{Exchange: ex}
+}
+
+var orderTypes = map[types.OrderType]sim.OrderType{
+	types.LimitOrder:             sim.Limit,
+	types.PostOnlyMaker:          sim.PostOnly,
+	types.TransactOrCancel:       sim.IOC,
+	types.TransactAllOrCancelAll: sim.FOK,
+	types.MarketOrder:            sim.Market,
+	types.ConvertToCurrentPrice:  sim.Market,
+}
+
+// UpdateDepth feeds the simulated exchange with the depth of symbol.
+func (c *ContractAccountClient) UpdateDepth(symbol string, depth *mdtypes.Depth) error {
+	b, err := depth.Book()
+	if err != nil {
+		return err
+	}
+
+	return c.UpdateBook(symbol, b, time.UnixMilli(depth.Timestamp))
+}
+
+// UpdateDeals feeds the simulated exchange with the deals of symbol in time order.
+func (c *ContractAccountClient) UpdateDeals(symbol string, deals []*mdtypes.Deal) error {
+	for _, d := range deals {
+		side := sim.Buy
+		if d.TradeType == 2 {
+			side = sim.Sell
+		}
+
+		err := c.UpdateTrade(symbol, sim.Trade{
+			Price: decimal.NewFromFloat(d.Price),
+			Qty:   decimal.NewFromFloat(d.Vol),
+			Side:  side,
+			Time:  time.UnixMilli(d.Time),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ContractAccountClient) SubmitOrder(ctx context.Context, param types.NewOrderParam) (*types.SubmitOrderResp, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	o, err := c.submit(param)
+	if err != nil {
+		return &types.SubmitOrderResp{Response: failure(err)}, nil
+	}
+
+	id, _ := strconv.ParseInt(o.ID, 10, 64)
+	return &types.SubmitOrderResp{Response: success(), Data: id}, nil
+}
+
+func (c *ContractAccountClient) submit(param types.NewOrderParam) (*sim.Order, error) {
+	orderType, ok := orderTypes[param.Type]
+	if !ok {
+		return nil, sim.ErrInvalidOrder
+	}
+
+	req := sim.OrderRequest{
+		Symbol:     param.Symbol,
+		ClientID:   param.ExternalOid,
+		Type:       orderType,
+		Price:      param.Price,
+		Qty:        param.Vol,
+		ReduceOnly: param.ReduceOnly,
+	}
+
+	switch param.Side {
+	case types.OpenLong:
+		req.Side = sim.Buy
+	case types.CloseShort:
+		req.Side, req.ReduceOnly = sim.Buy, true
+	case types.OpenShort:
+		req.Side = sim.Sell
+	case types.CloseLong:
+		req.Side, req.ReduceOnly = sim.Sell, true
+	default:
+		return nil, sim.ErrInvalidOrder
+	}
+
+	return c.PlaceOrder(req)
+}
+
+func (c *ContractAccountClient) CancelOrders(ctx context.Context, orderIds []int64) (*types.CancelOrdersResp, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	resp := &types.CancelOrdersResp{Response: success()}
+	for _, id := range orderIds {
+		result := &types.CancelOrderResult{OrderId: id}
+		if _, err := c.CancelOrder(strconv.FormatInt(id, 10), ""); err != nil {
+			result.ErrorCode, result.ErrorMsg = 1, err.Error()
+		}
+		resp.Data = append(resp.Data, result)
+	}
+
+	return resp, nil
+}
+
+func (c *ContractAccountClient) CancelOrderByExternalOid(ctx context.Context, param types.CancelOrderByExternalOidParam) (*types.EmptyResp, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	o, err := c.Order("", param.ExternalOid)
+	if err == nil && o.Symbol != param.Symbol {
+		err = sim.ErrOrderNotFound
+	}
+	if err == nil {
+		_, err = c.CancelOrder(o.ID, "")
+	}
+	if err != nil {
+		return &types.EmptyResp{Response: failure(err)}, nil
+	}
+
+	return &types.EmptyResp{Response: success()}, nil
+}
+
+func (c *ContractAccountClient) GetOrderByID(ctx context.Context, orderId string) (*types.GetOrderResp, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	o, err := c.Order(orderId, "")
+	if err != nil {
+		return &types.GetOrderResp{Response: failure(err)}, nil
+	}
+
+	return &types.GetOrderResp{Response: success(), Data: c.order(o)}, nil
+}
+
+func (c *ContractAccountClient) GetOrderByExternalOid(ctx context.Context, param types.GetOrderByExternalOidParam) (*types.GetOrderResp, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	o, err := c.Order("", param.ExternalOid)
+	if err == nil && o.Symbol != param.Symbol {
+		err = sim.ErrOrderNotFound
+	}
+	if err != nil {
+		return &types.GetOrderResp{Response: failure(err)}, nil
+	}
+
+	return &types.GetOrderResp{Response: success(), Data: c.order(o)}, nil
+}
+
+// GetOpenOrders returns the open orders, the pagination parameters are ignored.
+func (c *ContractAccountClient) GetOpenOrders(ctx context.Context, param types.GetOpenOrdersParam) (*types.GetOrdersResp, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	resp := &types.GetOrdersResp{Response: success()}
+	for _, o := range c.OpenOrders(param.Symbol) {
+		resp.Data = append(resp.Data, c.order(o))
+	}
+
+	return resp, nil
+}
+
+func (c *ContractAccountClient) GetOpenPositions(ctx context.Context, param types.GetOpenPositionsParams) (*types.GetOpenPositions, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	resp := &types.GetOpenPositions{Response: success()}
+	for i, p := range c.Positions() {
+		if param.Symbol != "" && p.Symbol != param.Symbol {
+			continue
+		}
+
+		position := &types.OpenPosition{
+			PositionID:   int64(i + 1),
+			Symbol:       p.Symbol,
+			PositionType: 1,
+			OpenType:     int(types.CrossMargin),
+			State:        1,
+			HoldAvgPrice: p.EntryPrice,
+			OpenAvgPrice: p.EntryPrice,
+			Realised:     p.RealizedPnL,
+			HoldVol:      p.Qty.Abs(),
+			UpdateTime:   c.Now().UnixMilli(),
+		}
+		if p.Qty.Sign() < 0 {
+			position.PositionType = 2
+		}
+		resp.Data = append(resp.Data, position)
+	}
+
+	return resp, nil
+}
+
+// GetAccountAssets returns the balances, the equity includes the unrealized PnL of the positions.
+func (c *ContractAccountClient) GetAccountAssets(ctx context.Context) (*types.GetAccountAssets, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	unrealized := make(map[string]decimal.Decimal)
+	for _, p := range c.Positions() {
+		inst, err := c.Instrument(p.Symbol)
+		if err != nil {
+			return nil, err
+		}
+		unrealized[inst.Quote] = unrealized[inst.Quote].Add(p.UnrealizedPnL)
+	}
+
+	resp := &types.GetAccountAssets{Response: success()}
+	for _, b := range c.Balances() {
+		resp.Data = append(resp.Data, &types.ContractAsset{
+			Currency:         b.Asset,
+			AvailableBalance: b.Free.Float64(),
+			CashBalance:      b.Total().Float64(),
+			FrozenBalance:    b.Locked.Float64(),
+			Equity:           b.Total().Add(unrealized[b.Asset]).Float64(),
+			Unrealized:       unrealized[b.Asset].Float64(),
+		})
+	}
+
+	return resp, nil
+}
+
+func (c *ContractAccountClient) order(o *sim.Order) *types.Order {
+	ret := &types.Order{
+		OrderId:     o.ID,
+		Symbol:      o.Symbol,
+		Price:       o.Price.Float64(),
+		Vol:         o.Qty.Float64(),
+		Category:    1,
+		OrderType:   int(types.LimitOrder),
+		DealVol:     o.Filled.Float64(),
+		FeeCurrency: o.FeeAsset,
+		OpenType:    int(types.CrossMargin),
+		ExternalOid: o.ClientID,
+		CreateTime:  o.CreateTime.UnixMilli(),
+		UpdateTime:  o.UpdateTime.UnixMilli(),
+	}
+
+	for t, simType := range orderTypes {
+		if simType == o.Type && t != types.ConvertToCurrentPrice {
+			ret.OrderType = int(t)
+		}
+	}
+
+	switch {
+	case o.Side == sim.Buy && o.ReduceOnly:
+		ret.Side = int(types.CloseShort)
+	case o.Side == sim.Buy:
+		ret.Side = int(types.OpenLong)
+	case o.ReduceOnly:
+		ret.Side = int(types.CloseLong)
+	default:
+		ret.Side = int(types.OpenShort)
+	}
+
+	switch o.Status {
+	case sim.Filled:
+		ret.State = int(types.OrderCompleted)
+	case sim.Canceled, sim.Rejected:
+		ret.State = int(types.OrderCancelled)
+	default:
+		ret.State = int(types.OrderUncompleted)
+	}
+
+	if o.Filled.Sign() > 0 {
+		ret.DealAvgPrice = o.AvgPrice(12).Float64()
+	}
+	// the fee of an order is either maker or taker, the fills tell which
+	for _, f := range c.Fills() {
+		if f.OrderID != o.ID {
+			continue
+		}
+		if f.Maker {
+			ret.MakerFee += f.Fee.Float64()
+		} else {
+			ret.TakerFee += f.Fee.Float64()
+		}
+	}
+
+	return ret
+}
+
+func success() types.Response {
+	return types.Response{Success: true}
+}
+
+func failure(err error) types.Response {
+	return types.Response{Success: false, Code: 1, Message: err.Error()}
+}
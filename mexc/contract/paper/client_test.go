@@ -0,0 +1,127 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package paper
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rluisr/nexapi/mexc/contract/account"
+	"github.com/rluisr/nexapi/mexc/contract/account/types"
+	mdtypes "github.com/rluisr/nexapi/mexc/contract/marketdata/types"
+	"github.com/rluisr/nexapi/utils/decimal"
+	"github.com/rluisr/nexapi/utils/sim"
+	"github.com/stretchr/testify/assert"
+)
+
+type orderClient interface {
+	SubmitOrder(ctx context.Context, param types.NewOrderParam) (*types.SubmitOrderResp, error)
+	CancelOrders(ctx context.Context, orderIds []int64) (*types.CancelOrdersResp, error)
+	GetOrderByID(ctx context.Context, orderId string) (*types.GetOrderResp, error)
+	GetOpenPositions(ctx context.Context, param types.GetOpenPositionsParams) (*types.GetOpenPositions, error)
+	GetAccountAssets(ctx context.Context) (*types.GetAccountAssets, error)
+}
+
+var (
+	_ orderClient = (*account.ContractAccountClient)(nil)
+	_ orderClient = (*ContractAccountClient)(nil)
+)
+
+func testClient(t *testing.T) *ContractAccountClient {
+	c := NewContractAccountClient(sim.NewExchange(sim.Config{
+		Instruments: []sim.Instrument{{Symbol: "BTC_USDT", Kind: sim.Linear, Base: "BTC", Quote: "USDT", ContractSize: decimal.MustFromString("0.0001")}},
+		Balances:    map[string]decimal.Decimal{"USDT": decimal.NewFromInt(1000)},
+		Fees:        sim.Fees{Maker: decimal.Zero, Taker: decimal.MustFromString("0.0006")},
+	}))
+
+	err := c.UpdateDepth("BTC_USDT", &mdtypes.Depth{
+		Bids:      [][]float64{{30000, 100, 1}},
+		Asks:      [][]float64{{30001, 100, 1}},
+		Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli(),
+	})
+	assert.Nil(t, err)
+
+	return c
+}
+
+func TestOpenAndCloseLong(t *testing.T) {
+	c := testClient(t)
+	ctx := context.Background()
+
+	resp, err := c.SubmitOrder(ctx, types.NewOrderParam{Symbol: "BTC_USDT", Vol: decimal.NewFromInt(10), Side: types.OpenLong, Type: types.MarketOrder, ExternalOid: "open"})
+	assert.Nil(t, err)
+	assert.True(t, resp.Success)
+
+	order, err := c.GetOrderByExternalOid(ctx, types.GetOrderByExternalOidParam{Symbol: "BTC_USDT", ExternalOid: "open"})
+	assert.Nil(t, err)
+	assert.Equal(t, int(types.OrderCompleted), order.Data.State)
+	assert.Equal(t, 30001.0, order.Data.DealAvgPrice)
+	assert.Greater(t, order.Data.TakerFee, 0.0)
+
+	positions, err := c.GetOpenPositions(ctx, types.GetOpenPositionsParams{Symbol: "BTC_USDT"})
+	assert.Nil(t, err)
+	assert.Len(t, positions.Data, 1)
+	assert.Equal(t, 1, positions.Data[0].PositionType)
+	assert.Equal(t, "10", positions.Data[0].HoldVol.String())
+
+	// a close larger than the position is capped to it
+	resp, err = c.SubmitOrder(ctx, types.NewOrderParam{Symbol: "BTC_USDT", Vol: decimal.NewFromInt(20), Side: types.CloseLong, Type: types.MarketOrder})
+	assert.Nil(t, err)
+	assert.True(t, resp.Success)
+
+	positions, err = c.GetOpenPositions(ctx, types.GetOpenPositionsParams{})
+	assert.Nil(t, err)
+	for _, p := range positions.Data {
+		assert.True(t, p.HoldVol.IsZero())
+	}
+}
+
+func TestRestingOrder(t *testing.T) {
+	c := testClient(t)
+	ctx := context.Background()
+
+	resp, err := c.SubmitOrder(ctx, types.NewOrderParam{Symbol: "BTC_USDT", Price: decimal.NewFromInt(30002), Vol: decimal.NewFromInt(1), Side: types.OpenShort, Type: types.PostOnlyMaker})
+	assert.Nil(t, err)
+	assert.True(t, resp.Success)
+
+	open, err := c.GetOpenOrders(ctx, types.GetOpenOrdersParam{Symbol: "BTC_USDT"})
+	assert.Nil(t, err)
+	assert.Len(t, open.Data, 1)
+	assert.Equal(t, int(types.OpenShort), open.Data[0].Side)
+	assert.Equal(t, int(types.PostOnlyMaker), open.Data[0].OrderType)
+
+	cancel, err := c.CancelOrders(ctx, []int64{resp.Data, resp.Data + 100})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, cancel.Data[0].ErrorCode)
+	assert.NotEqual(t, 0, cancel.Data[1].ErrorCode)
+
+	// a post only order crossing the book is rejected
+	resp, err = c.SubmitOrder(ctx, types.NewOrderParam{Symbol: "BTC_USDT", Price: decimal.NewFromInt(30001), Vol: decimal.NewFromInt(1), Side: types.OpenLong, Type: types.PostOnlyMaker})
+	assert.Nil(t, err)
+
+	order, err := c.GetOrderByID(ctx, strconv.FormatInt(resp.Data, 10))
+	assert.Nil(t, err)
+	assert.Equal(t, int(types.OrderCancelled), order.Data.State)
+	assert.Equal(t, 0.0, order.Data.DealVol)
+
+	assets, err := c.GetAccountAssets(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 1000.0, assets.Data[0].AvailableBalance)
+}
@@ -30,7 +30,6 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
-	"strings"
 	"time"
 
 	"github.com/go-playground/validator"
@@ -146,12 +145,7 @@ func (c *ContractClient) GenAuthHeaders(req HTTPRequest) (map[string]string, err
 
 	timestamp := fmt.Sprintf("%d", time.Now().UnixMilli())
 
-	sign := fmt.Sprintf("%s%s%s", c.key, timestamp, signString)
-	h := hmac.New(sha256.New, []byte(c.secret))
-	h.Write([]byte(sign))
-
-	signature := hex.EncodeToString(h.Sum(nil))
-	headers["Signature"] = signature
+	headers["Signature"] = GenSignature(c.key, c.secret, timestamp, signString)
 
 	headers["ApiKey"] = c.key
 	headers["Request-Time"] = timestamp
@@ -159,14 +153,23 @@ func (c *ContractClient) GenAuthHeaders(req HTTPRequest) (map[string]string, err
 	return headers, nil
 }
 
+// GenSignature signs key + timestamp + signString with HMAC-SHA256, the scheme
+// shared by the REST private endpoints and the WebSocket login.
+func GenSignature(key, secret, timestamp, signString string) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(fmt.Sprintf("%s%s%s", key, timestamp, signString)))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 func (c *ContractClient) SendHTTPRequest(ctx context.Context, req HTTPRequest) ([]byte, error) {
 	var body io.Reader
 	if req.Body != nil {
-		formData, err := query.Values(req.Body)
+		// the body must be sent exactly as it was signed in GenAuthHeaders
+		jsonBody, err := json.Marshal(req.Body)
 		if err != nil {
 			return nil, err
 		}
-		body = strings.NewReader(formData.Encode())
+		body = bytes.NewReader(jsonBody)
 	}
 
 	url, err := url.Parse(req.BaseURL + req.Path)
@@ -0,0 +1,491 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/chuckpreslar/emission"
+	"github.com/go-playground/validator"
+	"github.com/gorilla/websocket"
+	cmap "github.com/orcaman/concurrent-map/v2"
+	"github.com/rluisr/nexapi/mexc/contract/utils"
+	"github.com/rluisr/nexapi/mexc/contract/websocket/types"
+	"github.com/rluisr/nexapi/utils/replay"
+)
+
+var (
+	ContractStreamBaseURL = "wss://contract.mexc.com/edge"
+)
+
+const (
+	// the server drops connections which do not ping within one minute
+	defaultPingInterval = 15 * time.Second
+	writeTimeout        = 10 * time.Second
+	// max delay of the login result
+	loginTimeout = 10 * time.Second
+
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+type ContractStreamClient struct {
+	baseURL     string
+	key, secret string
+	// debug mode
+	debug bool
+	// logger
+	logger        *slog.Logger
+	autoReconnect bool
+	recorder      *replay.Recorder
+	// the server answers every ping, a connection silent for two intervals is dead
+	pingInterval time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	conn        *websocket.Conn
+	mu          sync.RWMutex
+	isConnected bool
+	// gorilla/websocket supports one concurrent writer only
+	sending sync.Mutex
+
+	disconnect    chan struct{}
+	subscriptions cmap.ConcurrentMap[string, struct{}]
+	emitter       *emission.Emitter
+}
+
+type ContractStreamCfg struct {
+	BaseURL string `validate:"required"`
+	// Key and Secret are only required for the personal channels
+	Key           string
+	Secret        string
+	Debug         bool
+	AutoReconnect bool
+	// Logger
+	Logger *slog.Logger
+	// Recorder records the frames of the connections with the credentials redacted
+	Recorder *replay.Recorder
+}
+
+func NewContractStreamClient(cfg *ContractStreamCfg) (*ContractStreamClient, error) {
+	err := validator.New().Struct(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if (cfg.Key == "") != (cfg.Secret == "") {
+		return nil, fmt.Errorf("key and secret must be set together")
+	}
+
+	cli := &ContractStreamClient{
+		baseURL:       cfg.BaseURL,
+		key:           cfg.Key,
+		secret:        cfg.Secret,
+		debug:         cfg.Debug,
+		logger:        cfg.Logger,
+		autoReconnect: cfg.AutoReconnect,
+		recorder:      cfg.Recorder,
+		pingInterval:  defaultPingInterval,
+
+		disconnect:    make(chan struct{}, 1),
+		subscriptions: cmap.New[struct{}](),
+		emitter:       emission.NewEmitter(),
+	}
+
+	if cli.logger == nil {
+		cli.logger = slog.Default()
+	}
+
+	return cli, nil
+}
+
+// Open connects to the server, logs in when a key pair is configured and starts
+// the ping and, if enabled, the reconnect loops. It returns the error of a
+// rejected login. The client can be opened again once closed.
+func (m *ContractStreamClient) Open() error {
+	if m.ctx != nil {
+		return errors.New("stream client is already opened")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	conn, err := m.connect(ctx)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	m.ctx, m.cancel = ctx, cancel
+
+	// drop the disconnection of a previous connection
+	select {
+	case <-m.disconnect:
+	default:
+	}
+
+	m.setConn(conn)
+
+	go m.readMessages(ctx, conn)
+	go m.keepAlive(ctx)
+
+	if m.autoReconnect {
+		go m.reconnect(ctx)
+	}
+
+	return nil
+}
+
+// Close stops every background loop and closes the connection.
+func (m *ContractStreamClient) Close() error {
+	if m.ctx == nil {
+		return errors.New("stream client is not opened")
+	}
+
+	m.cancel()
+	m.ctx, m.cancel = nil, nil
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.isConnected = false
+
+	conn := m.conn
+	if conn == nil {
+		return nil
+	}
+	m.conn = nil
+
+	m.sending.Lock()
+	conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(writeTimeout))
+	m.sending.Unlock()
+
+	return conn.Close()
+}
+
+func (m *ContractStreamClient) IsConnected() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.isConnected
+}
+
+// AddListener registers a listener for a topic, it receives the decoded push,
+// e.g. *types.Ticker for a ticker topic.
+func (m *ContractStreamClient) AddListener(topic string, listener func(any)) {
+	m.emitter.On(topic, listener)
+}
+
+func (m *ContractStreamClient) RemoveListener(topic string, listener func(any)) {
+	m.emitter.Off(topic, listener)
+}
+
+// Subscribe subscribes public topics, they are subscribed again after a reconnection.
+func (m *ContractStreamClient) Subscribe(topics []string) error {
+	for _, topic := range topics {
+		req, err := subscribeRequest(topic, "sub")
+		if err != nil {
+			return err
+		}
+
+		if err := m.send(req); err != nil {
+			return err
+		}
+
+		m.subscriptions.Set(topic, struct{}{})
+	}
+
+	return nil
+}
+
+func (m *ContractStreamClient) Unsubscribe(topics []string) error {
+	for _, topic := range topics {
+		req, err := subscribeRequest(topic, "unsub")
+		if err != nil {
+			return err
+		}
+
+		if err := m.send(req); err != nil {
+			return err
+		}
+
+		m.subscriptions.Remove(topic)
+	}
+
+	return nil
+}
+
+func (m *ContractStreamClient) connect(ctx context.Context) (*websocket.Conn, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, m.baseURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	m.record(replay.Open, nil)
+
+	if m.key != "" {
+		if err := m.login(conn); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+// login sends the signed login request and waits for its result, the server
+// then pushes every personal channel on this connection.
+func (m *ContractStreamClient) login(conn *websocket.Conn) error {
+	reqTime := fmt.Sprintf("%d", time.Now().UnixMilli())
+
+	req := types.Request{
+		Method: "login",
+		Param: types.LoginParam{
+			ApiKey:    m.key,
+			ReqTime:   reqTime,
+			Signature: utils.GenSignature(m.key, m.secret, reqTime, ""),
+		},
+	}
+
+	m.recordJSON(req)
+
+	m.sending.Lock()
+	conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	err := conn.WriteJSON(req)
+	m.sending.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return m.waitLogin(conn)
+}
+
+// waitLogin reads the frames of conn until the result of the login, the frames
+// received before it are handled as usual.
+func (m *ContractStreamClient) waitLogin(conn *websocket.Conn) error {
+	conn.SetReadDeadline(time.Now().Add(loginTimeout))
+	defer conn.SetReadDeadline(time.Time{})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return fmt.Errorf("login: %w", err)
+		}
+
+		if m.debug {
+			m.logger.Info(fmt.Sprintf("receive: %s", data))
+		}
+
+		m.record(replay.Receive, data)
+
+		var msg types.Message
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return fmt.Errorf("login: %w", err)
+		}
+
+		switch msg.Channel {
+		case "rs.login":
+			var result string
+			if err := json.Unmarshal(msg.Data, &result); err != nil || result != "success" {
+				return fmt.Errorf("login failed: %s", msg.Data)
+			}
+			return nil
+		case "rs.error":
+			return fmt.Errorf("login failed: %s", msg.Data)
+		}
+
+		if err := m.handle(data); err != nil {
+			m.logger.Error("handle message", "error", err, "message", string(data))
+		}
+	}
+}
+
+func (m *ContractStreamClient) setConn(conn *websocket.Conn) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.conn = conn
+	m.isConnected = true
+}
+
+func (m *ContractStreamClient) send(req types.Request) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if !m.isConnected {
+		return errors.New("connection is closed")
+	}
+
+	if m.debug {
+		msg, _ := json.Marshal(req)
+		m.logger.Info(fmt.Sprintf("send: %s", msg))
+	}
+
+	m.recordJSON(req)
+
+	m.sending.Lock()
+	defer m.sending.Unlock()
+
+	m.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	return m.conn.WriteJSON(req)
+}
+
+func (m *ContractStreamClient) record(dir replay.Direction, data []byte) {
+	if m.recorder != nil {
+		m.recorder.RecordFrame(m.baseURL, dir, data)
+	}
+}
+
+func (m *ContractStreamClient) recordJSON(req types.Request) {
+	if m.recorder != nil {
+		msg, _ := json.Marshal(req)
+		m.recorder.RecordFrame(m.baseURL, replay.Send, msg)
+	}
+}
+
+// readMessages handles the frames of conn until it fails. Every frame extends the
+// read deadline, so a half-open connection is detected once the pong of a ping is
+// missed and reported like a closed one.
+func (m *ContractStreamClient) readMessages(ctx context.Context, conn *websocket.Conn) {
+	timeout := 2 * m.pingInterval
+	conn.SetReadDeadline(time.Now().Add(timeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(timeout))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			m.logger.Error("read message", "error", err)
+			conn.Close()
+
+			m.mu.Lock()
+			if m.conn == conn {
+				m.isConnected = false
+			}
+			m.mu.Unlock()
+
+			select {
+			case m.disconnect <- struct{}{}:
+			default:
+			}
+
+			return
+		}
+
+		if m.debug {
+			m.logger.Info(fmt.Sprintf("receive: %s", data))
+		}
+
+		conn.SetReadDeadline(time.Now().Add(timeout))
+
+		m.record(replay.Receive, data)
+
+		if err := m.handle(data); err != nil {
+			m.logger.Error("handle message", "error", err, "message", string(data))
+		}
+	}
+}
+
+func (m *ContractStreamClient) keepAlive(ctx context.Context) {
+	ticker := time.NewTicker(m.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !m.IsConnected() {
+				continue
+			}
+
+			if err := m.send(types.Request{Method: "ping"}); err != nil {
+				m.logger.Error("send ping", "error", err)
+			}
+		}
+	}
+}
+
+// reconnect dials again with an exponential backoff whenever the connection
+// drops, then restores the login and the subscriptions. A rejected login is
+// retried like a failed dial.
+func (m *ContractStreamClient) reconnect(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.disconnect:
+		}
+
+		delay := minReconnectDelay
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+
+			conn, err := m.connect(ctx)
+			if err != nil {
+				m.logger.Error("reconnect", "error", err)
+
+				delay *= 2
+				if delay > maxReconnectDelay {
+					delay = maxReconnectDelay
+				}
+				continue
+			}
+
+			m.mu.Lock()
+			// closed while connecting
+			if ctx.Err() != nil {
+				m.mu.Unlock()
+				conn.Close()
+				return
+			}
+			if m.conn != nil {
+				m.conn.Close()
+			}
+			m.conn = conn
+			m.isConnected = true
+			m.mu.Unlock()
+
+			m.emitter.Emit(ReconnectTopic, struct{}{})
+
+			go m.readMessages(ctx, conn)
+
+			if err := m.Subscribe(m.subscriptions.Keys()); err != nil {
+				m.logger.Error("resubscribe", "error", err)
+			}
+
+			break
+		}
+	}
+}
@@ -0,0 +1,417 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package websocket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rluisr/nexapi/mexc/contract/websocket/types"
+	"github.com/rluisr/nexapi/utils/replay"
+	"github.com/rluisr/nexapi/utils/tickdata"
+	"github.com/stretchr/testify/assert"
+)
+
+// testReplayTickers connects a client logged in with key to the stream server of player
+// and returns the prices of the pushed tickers.
+func testReplayTickers(t *testing.T, player *replay.Player, rec *replay.Recorder, n int) []float64 {
+	srv := player.StreamServer(0)
+	defer srv.Close()
+
+	cli, err := NewContractStreamClient(&ContractStreamCfg{
+		BaseURL:  replay.StreamURL(srv),
+		Key:      "stream-key",
+		Secret:   "stream-secret",
+		Recorder: rec,
+	})
+	if err != nil {
+		t.Fatalf("Could not create mexc stream client, %s", err)
+	}
+
+	topic, err := cli.GetTickerTopic("BTC_USDT")
+	assert.Nil(t, err)
+
+	received := make(chan float64, n)
+	cli.AddListener(topic, func(e any) {
+		if ticker, ok := e.(*types.Ticker); ok {
+			received <- ticker.LastPrice
+		}
+	})
+
+	if err := cli.Open(); err != nil {
+		t.Fatalf("Could not open mexc stream client, %s", err)
+	}
+	defer cli.Close()
+
+	var prices []float64
+	for len(prices) < n {
+		select {
+		case p := <-received:
+			prices = append(prices, p)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no ticker received")
+		}
+	}
+
+	return prices
+}
+
+func TestRecordReplayStream(t *testing.T) {
+	// the exchange side of a session
+	session := replay.NewPlayer([]*replay.Entry{
+		{Kind: replay.WebSocket, Direction: replay.Open},
+		{Kind: replay.WebSocket, Direction: replay.Receive, Data: `{"channel":"rs.login","data":"success","ts":1}`},
+		{Kind: replay.WebSocket, Direction: replay.Receive, Data: `{"channel":"push.ticker","data":{"symbol":"BTC_USDT","lastPrice":42000.5},"symbol":"BTC_USDT","ts":2}`},
+		{Kind: replay.WebSocket, Direction: replay.Receive, Data: `{"channel":"push.ticker","data":{"symbol":"BTC_USDT","lastPrice":42001},"symbol":"BTC_USDT","ts":3}`},
+	}, nil)
+
+	var buf bytes.Buffer
+	rec := replay.NewRecorder(&buf, nil)
+
+	prices := testReplayTickers(t, session, rec, 2)
+	assert.Equal(t, []float64{42000.5, 42001}, prices)
+
+	entries, err := replay.ReadEntries(bytes.NewReader(buf.Bytes()))
+	assert.Nil(t, err)
+	assert.Equal(t, replay.Open, entries[0].Direction)
+
+	// the login is recorded without credentials
+	assert.NotContains(t, buf.String(), "stream-key")
+	assert.Equal(t, replay.Send, entries[1].Direction)
+	assert.Contains(t, entries[1].Data, `"method":"login"`)
+	assert.Contains(t, entries[1].Data, `"apiKey":"REDACTED"`)
+
+	// the recording replays the same pushes
+	player := replay.NewPlayer(entries, nil)
+	assert.Equal(t, prices, testReplayTickers(t, player, nil, 2))
+}
+
+// testSessionServer pushes the frames of the n-th session to the n-th connection once
+// subs topics are subscribed. The connection is dropped after the frames, except for the last session.
+func testSessionServer(subs int, sessions ...[]string) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	var mu sync.Mutex
+	next := 0
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		mu.Lock()
+		n := next
+		next++
+		mu.Unlock()
+		if n >= len(sessions) {
+			return
+		}
+
+		for subscribed := 0; subscribed < subs; {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if bytes.Contains(msg, []byte(`"method":"sub.`)) {
+				subscribed++
+			}
+		}
+
+		for _, frame := range sessions[n] {
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(frame)); err != nil {
+				return
+			}
+		}
+
+		if n == len(sessions)-1 {
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}
+	}))
+}
+
+func TestTickSource(t *testing.T) {
+	srv := testSessionServer(3, []string{
+		`{"channel":"push.deal","data":[{"p":42000.5,"v":2,"T":1,"O":1,"M":2,"t":1700000000000}],"symbol":"BTC_USDT","ts":1700000000001}`,
+		`{"channel":"push.depth","data":{"asks":[[42001,5,1]],"bids":[[42000,3,2]],"version":10},"symbol":"BTC_USDT","ts":1700000000002}`,
+		`{"channel":"push.ticker","data":{"symbol":"BTC_USDT","lastPrice":42000.5,"bid1":42000,"ask1":42001,"timestamp":1700000000003},"symbol":"BTC_USDT","ts":1700000000003}`,
+	}, []string{
+		// the last update of the first session is pushed again
+		`{"channel":"push.depth","data":{"asks":[[42001,5,1]],"bids":[[42000,3,2]],"version":10},"symbol":"BTC_USDT","ts":1700000000002}`,
+		`{"channel":"push.depth","data":{"asks":[[42001,0,0]],"bids":[],"version":11},"symbol":"BTC_USDT","ts":1700000001000}`,
+	})
+	defer srv.Close()
+
+	cli, err := NewContractStreamClient(&ContractStreamCfg{
+		BaseURL:       "ws" + strings.TrimPrefix(srv.URL, "http"),
+		AutoReconnect: true,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, cli.Open())
+	defer cli.Close()
+
+	dir := t.TempDir()
+	rec, err := tickdata.NewRecorder(tickdata.RecorderConfig{
+		Writers: []tickdata.WriterConfig{{Dir: dir, Prefix: "mexc", Format: tickdata.Binary, Compress: true}},
+	})
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- rec.Run(ctx, cli.TickSource("BTC_USDT")) }()
+
+	assert.Eventually(t, func() bool { return rec.Stats().Recorded == 5 }, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	assert.Nil(t, <-done)
+	assert.Nil(t, rec.Close())
+	assert.Equal(t, tickdata.Stats{Recorded: 5, Dropped: 1, Reconnects: 1}, rec.Stats())
+
+	r, err := tickdata.OpenDir(dir, "mexc", tickdata.Binary, time.Time{})
+	assert.Nil(t, err)
+	defer r.Close()
+
+	var ticks []*tickdata.Tick
+	for {
+		tick, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		assert.Nil(t, err)
+		ticks = append(ticks, tick)
+	}
+	if !assert.Len(t, ticks, 5) {
+		return
+	}
+
+	// the pushes are recorded in the order they are received
+	trade := ticks[0]
+	assert.Equal(t, tickdata.Trade, trade.Kind)
+	assert.Equal(t, TickVenue, trade.Venue)
+	assert.Equal(t, "BTC_USDT", trade.Symbol)
+	assert.Equal(t, "42000.5", trade.Price.String())
+	assert.Equal(t, tickdata.Buy, trade.Side)
+	assert.Equal(t, int64(1700000000000), trade.ExchangeTime.UnixMilli())
+
+	assert.Equal(t, tickdata.BookUpdate, ticks[1].Kind)
+	assert.Equal(t, int64(10), ticks[1].Version)
+	assert.Equal(t, int64(1700000000002), ticks[1].ExchangeTime.UnixMilli())
+	assert.Equal(t, "3", ticks[1].Bids[0].Size.String())
+
+	assert.Equal(t, tickdata.Ticker, ticks[2].Kind)
+	assert.Equal(t, "42001", ticks[2].Ask.String())
+
+	assert.Equal(t, tickdata.Reconnect, ticks[3].Kind)
+
+	assert.Equal(t, int64(11), ticks[4].Version)
+	assert.True(t, ticks[4].Asks[0].Size.IsZero())
+
+	for i, tick := range ticks {
+		assert.Equal(t, uint64(i+1), tick.Seq)
+	}
+}
+
+// testLoginServer answers the login request of every connection with result.
+func testLoginServer(result string) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if !bytes.Contains(msg, []byte(`"method":"login"`)) {
+				continue
+			}
+			// a push received before the result is handled as usual
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"channel":"pong","data":1,"ts":1}`))
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"channel":"rs.login","data":"`+result+`","ts":2}`))
+		}
+	}))
+}
+
+func TestLogin(t *testing.T) {
+	srv := testLoginServer("success")
+	defer srv.Close()
+
+	cli, err := NewContractStreamClient(&ContractStreamCfg{
+		BaseURL: "ws" + strings.TrimPrefix(srv.URL, "http"),
+		Key:     "key",
+		Secret:  "secret",
+	})
+	assert.Nil(t, err)
+
+	// the client can be opened again once closed
+	for i := 0; i < 2; i++ {
+		assert.Nil(t, cli.Open())
+		assert.True(t, cli.IsConnected())
+		assert.NotNil(t, cli.Open())
+		assert.Nil(t, cli.Close())
+		assert.False(t, cli.IsConnected())
+	}
+	assert.NotNil(t, cli.Close())
+
+	rejected := testLoginServer("signature error")
+	defer rejected.Close()
+
+	cli, err = NewContractStreamClient(&ContractStreamCfg{
+		BaseURL: "ws" + strings.TrimPrefix(rejected.URL, "http"),
+		Key:     "key",
+		Secret:  "secret",
+	})
+	assert.Nil(t, err)
+
+	err = cli.Open()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "signature error")
+	}
+	assert.False(t, cli.IsConnected())
+	assert.NotNil(t, cli.Close())
+}
+
+// testSubServer reports the first sub request received on the n-th connection to
+// subs, then passes the connection to session, which returns once it is done with it.
+func testSubServer(subs chan<- string, session func(n int, conn *websocket.Conn)) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	var mu sync.Mutex
+	next := 0
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		mu.Lock()
+		n := next
+		next++
+		mu.Unlock()
+
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req types.Request
+			if json.Unmarshal(msg, &req) == nil && strings.HasPrefix(req.Method, "sub.") {
+				subs <- fmt.Sprintf("%d %s", n, req.Method)
+				break
+			}
+		}
+
+		session(n, conn)
+	}))
+}
+
+func testReconnect(t *testing.T, srv *httptest.Server, subs <-chan string, pingInterval time.Duration) {
+	cli, err := NewContractStreamClient(&ContractStreamCfg{
+		BaseURL:       "ws" + strings.TrimPrefix(srv.URL, "http"),
+		AutoReconnect: true,
+	})
+	assert.Nil(t, err)
+	cli.pingInterval = pingInterval
+
+	reconnected := make(chan struct{}, 1)
+	cli.AddListener(ReconnectTopic, func(any) { reconnected <- struct{}{} })
+
+	assert.Nil(t, cli.Open())
+	defer cli.Close()
+
+	topic, err := cli.GetTickerTopic("BTC_USDT")
+	assert.Nil(t, err)
+	assert.Nil(t, cli.Subscribe([]string{topic}))
+
+	// the topic is subscribed again on the new connection
+	for _, want := range []string{"0 sub.ticker", "1 sub.ticker"} {
+		select {
+		case got := <-subs:
+			assert.Equal(t, want, got)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s not received", want)
+		}
+	}
+
+	select {
+	case <-reconnected:
+	case <-time.After(time.Second):
+		t.Fatal("reconnect not emitted")
+	}
+	assert.True(t, cli.IsConnected())
+}
+
+func TestReconnect(t *testing.T) {
+	subs := make(chan string, 4)
+	srv := testSubServer(subs, func(n int, conn *websocket.Conn) {
+		// the first connection is dropped
+		for n > 0 {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	})
+	defer srv.Close()
+
+	testReconnect(t, srv, subs, defaultPingInterval)
+}
+
+func TestDeadConnection(t *testing.T) {
+	subs := make(chan string, 4)
+	done := make(chan struct{})
+	srv := testSubServer(subs, func(n int, conn *websocket.Conn) {
+		// the first connection never answers the pings, as a half-open connection
+		if n == 0 {
+			<-done
+			return
+		}
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if bytes.Contains(msg, []byte(`"method":"ping"`)) {
+				conn.WriteMessage(websocket.TextMessage, []byte(`{"channel":"pong","data":1,"ts":1}`))
+			}
+		}
+	})
+	defer srv.Close()
+	defer close(done)
+
+	testReconnect(t, srv, subs, 50*time.Millisecond)
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package websocket
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/rluisr/nexapi/mexc/contract/utils"
+	"github.com/rluisr/nexapi/mexc/contract/websocket/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNewContractStreamClient(t *testing.T, key, secret string) *ContractStreamClient {
+	cli, err := NewContractStreamClient(&ContractStreamCfg{
+		BaseURL:       ContractStreamBaseURL,
+		Key:           key,
+		Secret:        secret,
+		Debug:         true,
+		AutoReconnect: true,
+	})
+
+	if err != nil {
+		t.Fatalf("Could not create mexc stream client, %s", err)
+	}
+
+	return cli
+}
+
+func TestTickerStream(t *testing.T) {
+	cli := testNewContractStreamClient(t, "", "")
+
+	err := cli.Open()
+	if err != nil {
+		t.Fatalf("Could not open mexc stream client, %s", err)
+	}
+	defer cli.Close()
+
+	topic, err := cli.GetTickerTopic("BTC_USDT")
+	assert.Nil(t, err)
+
+	received := make(chan *types.Ticker, 1)
+	cli.AddListener(topic, func(e any) {
+		ticker, ok := e.(*types.Ticker)
+		if !ok {
+			return
+		}
+
+		select {
+		case received <- ticker:
+		default:
+		}
+	})
+
+	err = cli.Subscribe([]string{topic})
+	assert.Nil(t, err)
+
+	select {
+	case ticker := <-received:
+		assert.Equal(t, "BTC_USDT", ticker.Symbol)
+	case <-time.After(10 * time.Second):
+		t.Fatal("no ticker received")
+	}
+}
+
+func TestKlineStream(t *testing.T) {
+	cli := testNewContractStreamClient(t, "", "")
+
+	err := cli.Open()
+	if err != nil {
+		t.Fatalf("Could not open mexc stream client, %s", err)
+	}
+	defer cli.Close()
+
+	topic, err := cli.GetKlineTopic("BTC_USDT", utils.Minute1)
+	assert.Nil(t, err)
+
+	received := make(chan *types.Kline, 1)
+	cli.AddListener(topic, func(e any) {
+		kline, ok := e.(*types.Kline)
+		if !ok {
+			return
+		}
+
+		select {
+		case received <- kline:
+		default:
+		}
+	})
+
+	err = cli.Subscribe([]string{topic})
+	assert.Nil(t, err)
+
+	select {
+	case kline := <-received:
+		assert.Equal(t, string(utils.Minute1), kline.Interval)
+	case <-time.After(10 * time.Second):
+		t.Fatal("no kline received")
+	}
+}
+
+func TestPersonalStream(t *testing.T) {
+	cli := testNewContractStreamClient(t, os.Getenv("MEXC_KEY"), os.Getenv("MEXC_SECRET"))
+
+	err := cli.Open()
+	if err != nil {
+		t.Fatalf("Could not open mexc stream client, %s", err)
+	}
+	defer cli.Close()
+
+	cli.AddListener(PersonalPositionTopic, func(e any) {
+		position, ok := e.(*types.Position)
+		if !ok {
+			return
+		}
+
+		t.Logf("Symbol: %s, HoldVol: %v, LiquidatePrice: %v", position.Symbol, position.HoldVol, position.LiquidatePrice)
+	})
+
+	time.Sleep(5 * time.Second)
+}
@@ -0,0 +1,237 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package websocket
+
+import (
+	"context"
+	"time"
+
+	"github.com/rluisr/nexapi/mexc/contract/websocket/types"
+	"github.com/rluisr/nexapi/utils/book"
+	"github.com/rluisr/nexapi/utils/decimal"
+	"github.com/rluisr/nexapi/utils/tickdata"
+)
+
+// TickVenue is the venue of the ticks recorded by a TickSource.
+const TickVenue = "mexc-contract"
+
+// A TickSource records the tickers, deals and order book updates of contracts.
+// The client must be opened by the caller, with AutoReconnect for a long-running
+// recording.
+type TickSource struct {
+	client  *ContractStreamClient
+	symbols []string
+	// FullDepth records the top 20 levels snapshots instead of the incremental updates
+	FullDepth bool
+	// Kinds to record, the default is the trades, the book updates and the tickers
+	Kinds []tickdata.Kind
+}
+
+func (m *ContractStreamClient) TickSource(symbols ...string) *TickSource {
+	return &TickSource{client: m, symbols: symbols}
+}
+
+type tickListener struct {
+	topic    string
+	listener func(any)
+}
+
+// Run subscribes the topics of the symbols and records their pushes until ctx
+// is done, then unsubscribes them.
+func (s *TickSource) Run(ctx context.Context, rec *tickdata.Recorder) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make(chan error, 1)
+	record := func(t *tickdata.Tick) {
+		t.Venue = TickVenue
+		t.ReceiveTime = time.Now()
+		if err := rec.Record(t); err != nil {
+			select {
+			case errs <- err:
+			default:
+			}
+			cancel()
+		}
+	}
+
+	listeners, err := s.listeners(record)
+	if err != nil {
+		return err
+	}
+
+	var topics []string
+	for _, l := range listeners {
+		s.client.AddListener(l.topic, l.listener)
+		topics = append(topics, l.topic)
+	}
+	defer func() {
+		for _, l := range listeners {
+			s.client.RemoveListener(l.topic, l.listener)
+		}
+	}()
+
+	reconnected := func(any) {
+		if err := rec.Reconnected(TickVenue); err != nil {
+			select {
+			case errs <- err:
+			default:
+			}
+			cancel()
+		}
+	}
+	s.client.AddListener(ReconnectTopic, reconnected)
+	defer s.client.RemoveListener(ReconnectTopic, reconnected)
+
+	if err := s.client.Subscribe(topics); err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+
+	if s.client.IsConnected() {
+		if err := s.client.Unsubscribe(topics); err != nil {
+			s.client.logger.Error("unsubscribe", "error", err)
+		}
+	}
+
+	select {
+	case err := <-errs:
+		return err
+	default:
+		return nil
+	}
+}
+
+func (s *TickSource) listeners(record func(*tickdata.Tick)) ([]tickListener, error) {
+	kinds := s.Kinds
+	if len(kinds) == 0 {
+		kinds = []tickdata.Kind{tickdata.Trade, tickdata.BookUpdate, tickdata.Ticker}
+	}
+
+	var listeners []tickListener
+	for _, symbol := range s.symbols {
+		symbol := symbol
+
+		for _, kind := range kinds {
+			var (
+				topic    string
+				listener func(any)
+				err      error
+			)
+
+			switch kind {
+			case tickdata.Trade:
+				topic, err = s.client.GetDealTopic(symbol)
+				listener = func(e any) {
+					if deal, ok := e.(*types.Deal); ok {
+						record(dealTick(symbol, deal))
+					}
+				}
+			case tickdata.BookUpdate:
+				if s.FullDepth {
+					topic, err = s.client.GetFullDepthTopic(symbol)
+				} else {
+					topic, err = s.client.GetDepthTopic(symbol)
+				}
+				listener = func(e any) {
+					if depth, ok := e.(*types.Depth); ok {
+						record(depthTick(symbol, depth, s.FullDepth))
+					}
+				}
+			case tickdata.Ticker:
+				topic, err = s.client.GetTickerTopic(symbol)
+				listener = func(e any) {
+					if ticker, ok := e.(*types.Ticker); ok {
+						record(tickerTick(symbol, ticker))
+					}
+				}
+			default:
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			listeners = append(listeners, tickListener{topic: topic, listener: listener})
+		}
+	}
+
+	return listeners, nil
+}
+
+func unixMilli(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+func dealTick(symbol string, deal *types.Deal) *tickdata.Tick {
+	t := &tickdata.Tick{
+		Kind:         tickdata.Trade,
+		Symbol:       symbol,
+		ExchangeTime: unixMilli(deal.Time),
+		Price:        decimal.NewFromFloat(deal.Price),
+		Qty:          decimal.NewFromFloat(deal.Vol),
+	}
+
+	switch deal.TradeType {
+	case 1:
+		t.Side = tickdata.Buy
+	case 2:
+		t.Side = tickdata.Sell
+	}
+
+	return t
+}
+
+func depthTick(symbol string, depth *types.Depth, snapshot bool) *tickdata.Tick {
+	return &tickdata.Tick{
+		Kind:         tickdata.BookUpdate,
+		Symbol:       symbol,
+		ExchangeTime: unixMilli(depth.Ts),
+		Bids:         depthLevels(depth.Bids),
+		Asks:         depthLevels(depth.Asks),
+		Snapshot:     snapshot,
+		Version:      depth.Version,
+	}
+}
+
+// depthLevels converts the [price, volume, order count] levels, the volumes are in contracts
+func depthLevels(levels [][]float64) []book.PriceLevel {
+	res := make([]book.PriceLevel, 0, len(levels))
+	for _, l := range levels {
+		if len(l) < 2 {
+			continue
+		}
+		res = append(res, book.PriceLevel{Price: decimal.NewFromFloat(l[0]), Size: decimal.NewFromFloat(l[1])})
+	}
+	return res
+}
+
+func tickerTick(symbol string, ticker *types.Ticker) *tickdata.Tick {
+	return &tickdata.Tick{
+		Kind:         tickdata.Ticker,
+		Symbol:       symbol,
+		ExchangeTime: unixMilli(ticker.Timestamp),
+		Price:        decimal.NewFromFloat(ticker.LastPrice),
+		Bid:          decimal.NewFromFloat(ticker.Bid1),
+		Ask:          decimal.NewFromFloat(ticker.Ask1),
+	}
+}
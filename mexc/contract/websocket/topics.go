@@ -0,0 +1,255 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/rluisr/nexapi/mexc/contract/utils"
+	"github.com/rluisr/nexapi/mexc/contract/websocket/types"
+	"github.com/valyala/fastjson"
+)
+
+// Personal topics are pushed once logged in, they must not be subscribed.
+const (
+	PersonalOrderTopic     = "personal.order"
+	PersonalOrderDealTopic = "personal.order.deal"
+	PersonalPositionTopic  = "personal.position"
+	PersonalAssetTopic     = "personal.asset"
+	PersonalPlanOrderTopic = "personal.plan.order"
+	PersonalStopOrderTopic = "personal.stop.order"
+	PersonalAdlLevelTopic  = "personal.adl.level"
+)
+
+// AllTickersTopic pushes the tickers of every contract.
+const AllTickersTopic = "tickers"
+
+// ReconnectTopic is emitted once a dropped connection is restored, before any push
+// of the new connection. The updates pushed in between are lost.
+const ReconnectTopic = "reconnect"
+
+// topicSeparator separates the channel from its parameters, e.g. kline@BTC_USDT@Min1
+const topicSeparator = "@"
+
+func (m *ContractStreamClient) GetTickerTopic(symbol string) (string, error) {
+	return getTopic("ticker", symbol)
+}
+
+func (m *ContractStreamClient) GetDealTopic(symbol string) (string, error) {
+	return getTopic("deal", symbol)
+}
+
+// GetDepthTopic returns the topic of incremental order book updates.
+func (m *ContractStreamClient) GetDepthTopic(symbol string) (string, error) {
+	return getTopic("depth", symbol)
+}
+
+// GetFullDepthTopic returns the topic of the top 20 levels order book snapshots.
+func (m *ContractStreamClient) GetFullDepthTopic(symbol string) (string, error) {
+	return getTopic("depth.full", symbol)
+}
+
+func (m *ContractStreamClient) GetKlineTopic(symbol string, interval utils.KlineInterval) (string, error) {
+	if interval == "" {
+		return "", fmt.Errorf("interval is required")
+	}
+	return getTopic("kline", symbol, string(interval))
+}
+
+func (m *ContractStreamClient) GetFundingRateTopic(symbol string) (string, error) {
+	return getTopic("funding.rate", symbol)
+}
+
+func (m *ContractStreamClient) GetIndexPriceTopic(symbol string) (string, error) {
+	return getTopic("index.price", symbol)
+}
+
+func (m *ContractStreamClient) GetFairPriceTopic(symbol string) (string, error) {
+	return getTopic("fair.price", symbol)
+}
+
+func getTopic(channel, symbol string, params ...string) (string, error) {
+	if symbol == "" {
+		return "", fmt.Errorf("symbol is required")
+	}
+
+	return strings.Join(append([]string{channel, symbol}, params...), topicSeparator), nil
+}
+
+// subscribeRequest builds the sub or unsub request of a public topic.
+func subscribeRequest(topic, action string) (types.Request, error) {
+	parts := strings.Split(topic, topicSeparator)
+	channel := parts[0]
+
+	if strings.HasPrefix(channel, "personal.") {
+		return types.Request{}, fmt.Errorf("personal topic %s is pushed after login and can not be subscribed", topic)
+	}
+
+	req := types.Request{
+		Method: fmt.Sprintf("%s.%s", action, channel),
+	}
+
+	switch {
+	case channel == AllTickersTopic && len(parts) == 1:
+		req.Param = map[string]any{}
+	case channel == "kline" && len(parts) == 3:
+		req.Param = map[string]any{"symbol": parts[1], "interval": parts[2]}
+	case channel != "kline" && len(parts) == 2:
+		req.Param = map[string]any{"symbol": parts[1]}
+	default:
+		return types.Request{}, fmt.Errorf("unknown topic: %s", topic)
+	}
+
+	return req, nil
+}
+
+func (m *ContractStreamClient) handle(data []byte) error {
+	var msg types.Message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return err
+	}
+
+	switch {
+	case msg.Channel == "pong":
+		return nil
+	case msg.Channel == "rs.error":
+		return fmt.Errorf("server error: %s", msg.Data)
+	case strings.HasPrefix(msg.Channel, "rs."):
+		// results of login, sub and unsub requests
+		var result string
+		if err := json.Unmarshal(msg.Data, &result); err != nil || result != "success" {
+			return fmt.Errorf("%s failed: %s", msg.Channel, msg.Data)
+		}
+		return nil
+	case !strings.HasPrefix(msg.Channel, "push."):
+		return nil
+	}
+
+	channel := strings.TrimPrefix(msg.Channel, "push.")
+
+	switch channel {
+	case AllTickersTopic:
+		var tickers []*types.Ticker
+		if err := json.Unmarshal(msg.Data, &tickers); err != nil {
+			return err
+		}
+		m.emitter.Emit(AllTickersTopic, tickers)
+	case "ticker":
+		return emitData[types.Ticker](m, msg, channel)
+	case "deal":
+		// deals are pushed one by one or in batches
+		var p fastjson.Parser
+		v, err := p.ParseBytes(msg.Data)
+		if err != nil {
+			return err
+		}
+		if v.Type() != fastjson.TypeArray {
+			return emitData[types.Deal](m, msg, channel)
+		}
+
+		var deals []*types.Deal
+		if err := json.Unmarshal(msg.Data, &deals); err != nil {
+			return err
+		}
+		topic, err := getTopic(channel, msg.Symbol)
+		if err != nil {
+			return err
+		}
+		for _, deal := range deals {
+			m.emitter.Emit(topic, deal)
+		}
+	case "depth", "depth.full":
+		var depth types.Depth
+		if err := json.Unmarshal(msg.Data, &depth); err != nil {
+			return err
+		}
+		// the push time is only carried by the envelope
+		depth.Ts = msg.Ts
+		topic, err := getTopic(channel, msg.Symbol)
+		if err != nil {
+			return err
+		}
+		m.emitter.Emit(topic, &depth)
+	case "kline":
+		var kline types.Kline
+		if err := json.Unmarshal(msg.Data, &kline); err != nil {
+			return err
+		}
+		topic, err := getTopic(channel, kline.Symbol, kline.Interval)
+		if err != nil {
+			return err
+		}
+		m.emitter.Emit(topic, &kline)
+	case "funding.rate":
+		return emitData[types.FundingRate](m, msg, channel)
+	case "index.price", "fair.price":
+		return emitData[types.Price](m, msg, channel)
+	case PersonalOrderTopic:
+		return emitPersonalData[types.Order](m, msg, channel)
+	case PersonalOrderDealTopic:
+		return emitPersonalData[types.OrderDeal](m, msg, channel)
+	case PersonalPositionTopic:
+		return emitPersonalData[types.Position](m, msg, channel)
+	case PersonalAssetTopic:
+		return emitPersonalData[types.Asset](m, msg, channel)
+	case PersonalPlanOrderTopic:
+		return emitPersonalData[types.PlanOrder](m, msg, channel)
+	case PersonalStopOrderTopic:
+		return emitPersonalData[types.StopOrder](m, msg, channel)
+	case PersonalAdlLevelTopic:
+		return emitPersonalData[types.AdlLevel](m, msg, channel)
+	}
+
+	return nil
+}
+
+// emitData decodes a public push and emits it on the topic of its symbol.
+func emitData[T any](m *ContractStreamClient, msg types.Message, channel string) error {
+	var v T
+	if err := json.Unmarshal(msg.Data, &v); err != nil {
+		return err
+	}
+
+	symbol := msg.Symbol
+	if symbol == "" {
+		// some channels only carry the symbol inside the payload
+		symbol = fastjson.GetString(msg.Data, "symbol")
+	}
+
+	topic, err := getTopic(channel, symbol)
+	if err != nil {
+		return err
+	}
+
+	m.emitter.Emit(topic, &v)
+
+	return nil
+}
+
+func emitPersonalData[T any](m *ContractStreamClient, msg types.Message, channel string) error {
+	var v T
+	if err := json.Unmarshal(msg.Data, &v); err != nil {
+		return err
+	}
+
+	m.emitter.Emit(channel, &v)
+
+	return nil
+}
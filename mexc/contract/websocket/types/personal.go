@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+type Order struct {
+	OrderId      string  `json:"orderId"`
+	Symbol       string  `json:"symbol"`
+	PositionId   int64   `json:"positionId"`
+	Price        float64 `json:"price"`
+	Vol          float64 `json:"vol"`
+	Leverage     int     `json:"leverage"`
+	Side         int     `json:"side"`
+	Category     int     `json:"category"`
+	OrderType    int     `json:"orderType"`
+	DealAvgPrice float64 `json:"dealAvgPrice"`
+	DealVol      float64 `json:"dealVol"`
+	OrderMargin  float64 `json:"orderMargin"`
+	UsedMargin   float64 `json:"usedMargin"`
+	TakerFee     float64 `json:"takerFee"`
+	MakerFee     float64 `json:"makerFee"`
+	Profit       float64 `json:"profit"`
+	FeeCurrency  string  `json:"feeCurrency"`
+	OpenType     int     `json:"openType"`
+	State        int     `json:"state"`
+	ErrorCode    int     `json:"errorCode"`
+	ExternalOid  string  `json:"externalOid"`
+	CreateTime   int64   `json:"createTime"`
+	UpdateTime   int64   `json:"updateTime"`
+}
+
+type OrderDeal struct {
+	Id           string  `json:"id"`
+	Symbol       string  `json:"symbol"`
+	Side         int     `json:"side"`
+	Vol          float64 `json:"vol"`
+	Price        float64 `json:"price"`
+	FeeCurrency  string  `json:"feeCurrency"`
+	Fee          float64 `json:"fee"`
+	Timestamp    int64   `json:"timestamp"`
+	Profit       float64 `json:"profit"`
+	IsTaker      bool    `json:"isTaker"`
+	Category     int     `json:"category"`
+	OrderId      string  `json:"orderId"`
+	PositionMode int     `json:"positionMode"`
+}
+
+type Position struct {
+	PositionId     int64   `json:"positionId"`
+	Symbol         string  `json:"symbol"`
+	HoldVol        float64 `json:"holdVol"`
+	PositionType   int     `json:"positionType"`
+	OpenType       int     `json:"openType"`
+	State          int     `json:"state"`
+	FrozenVol      float64 `json:"frozenVol"`
+	CloseVol       float64 `json:"closeVol"`
+	HoldAvgPrice   float64 `json:"holdAvgPrice"`
+	CloseAvgPrice  float64 `json:"closeAvgPrice"`
+	OpenAvgPrice   float64 `json:"openAvgPrice"`
+	LiquidatePrice float64 `json:"liquidatePrice"`
+	Oim            float64 `json:"oim"`
+	AdlLevel       int     `json:"adlLevel"`
+	Im             float64 `json:"im"`
+	HoldFee        float64 `json:"holdFee"`
+	Realised       float64 `json:"realised"`
+	Leverage       int     `json:"leverage"`
+	AutoAddIm      bool    `json:"autoAddIm"`
+	CreateTime     int64   `json:"createTime"`
+	UpdateTime     int64   `json:"updateTime"`
+}
+
+type Asset struct {
+	Currency         string  `json:"currency"`
+	PositionMargin   float64 `json:"positionMargin"`
+	AvailableBalance float64 `json:"availableBalance"`
+	CashBalance      float64 `json:"cashBalance"`
+	FrozenBalance    float64 `json:"frozenBalance"`
+	Equity           float64 `json:"equity"`
+	Unrealized       float64 `json:"unrealized"`
+	Bonus            float64 `json:"bonus"`
+}
+
+type PlanOrder struct {
+	Id           string  `json:"id"`
+	Symbol       string  `json:"symbol"`
+	Leverage     int     `json:"leverage"`
+	Side         int     `json:"side"`
+	TriggerPrice float64 `json:"triggerPrice"`
+	Price        float64 `json:"price"`
+	Vol          float64 `json:"vol"`
+	OpenType     int     `json:"openType"`
+	TriggerType  int     `json:"triggerType"`
+	State        int     `json:"state"`
+	ExecuteCycle int     `json:"executeCycle"`
+	Trend        int     `json:"trend"`
+	OrderType    int     `json:"orderType"`
+	ErrorCode    int     `json:"errorCode"`
+	CreateTime   int64   `json:"createTime"`
+	UpdateTime   int64   `json:"updateTime"`
+}
+
+type StopOrder struct {
+	Symbol          string  `json:"symbol"`
+	OrderId         string  `json:"orderId"`
+	LossTrend       int     `json:"lossTrend"`
+	ProfitTrend     int     `json:"profitTrend"`
+	StopLossPrice   float64 `json:"stopLossPrice"`
+	TakeProfitPrice float64 `json:"takeProfitPrice"`
+}
+
+type AdlLevel struct {
+	AdlLevel   int   `json:"adlLevel"`
+	PositionId int64 `json:"positionId"`
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	"time"
+
+	"github.com/rluisr/nexapi/utils/candle"
+)
+
+type Ticker struct {
+	Symbol       string  `json:"symbol"`
+	LastPrice    float64 `json:"lastPrice"`
+	Bid1         float64 `json:"bid1"`
+	Ask1         float64 `json:"ask1"`
+	Volume24     float64 `json:"volume24"`
+	Amount24     float64 `json:"amount24"`
+	HoldVol      float64 `json:"holdVol"`
+	Lower24Price float64 `json:"lower24Price"`
+	High24Price  float64 `json:"high24Price"`
+	RiseFallRate float64 `json:"riseFallRate"`
+	FairPrice    float64 `json:"fairPrice"`
+	IndexPrice   float64 `json:"indexPrice"`
+	FundingRate  float64 `json:"fundingRate"`
+	MaxBidPrice  float64 `json:"maxBidPrice"`
+	MinAskPrice  float64 `json:"minAskPrice"`
+	Timestamp    int64   `json:"timestamp"`
+}
+
+type Deal struct {
+	Price     float64 `json:"p"`
+	Vol       float64 `json:"v"`
+	TradeType int     `json:"T"` // 1: purchase, 2: sell
+	OpenType  int     `json:"O"` // 1: open position, 2: close position, 3: position no change
+	SelfTrade int     `json:"M"` // 1: yes, 2: no
+	Time      int64   `json:"t"`
+}
+
+// CandleTrade converts the deal for the candle builder, the quantity is in contracts.
+func (d *Deal) CandleTrade() *candle.Trade {
+	return &candle.Trade{Time: time.UnixMilli(d.Time), Price: d.Price, Qty: d.Vol}
+}
+
+// Depth is an order book update, every level is [price, volume, order count].
+// A level with a zero volume must be removed from the local book.
+type Depth struct {
+	Asks    [][]float64 `json:"asks"`
+	Bids    [][]float64 `json:"bids"`
+	Version int64       `json:"version"`
+	// Ts is the push time in milliseconds
+	Ts int64 `json:"-"`
+}
+
+type Kline struct {
+	Symbol   string  `json:"symbol"`
+	Interval string  `json:"interval"`
+	Time     int64   `json:"t"` // start time in seconds
+	Open     float64 `json:"o"`
+	Close    float64 `json:"c"`
+	High     float64 `json:"h"`
+	Low      float64 `json:"l"`
+	Amount   float64 `json:"a"`
+	Vol      float64 `json:"q"`
+}
+
+// Candle converts the kline of the given interval for the candle builder. The pushes
+// of a kline are unclosed until its interval has elapsed at now, e.g. the receive
+// time of the push, Builder.AddCandle replaces the previous push of the same kline.
+func (k *Kline) Candle(interval time.Duration, now time.Time) *candle.Candle {
+	openTime := time.Unix(k.Time, 0)
+
+	return &candle.Candle{
+		OpenTime:    openTime,
+		Interval:    interval,
+		Open:        k.Open,
+		High:        k.High,
+		Low:         k.Low,
+		Close:       k.Close,
+		Volume:      k.Vol,
+		QuoteVolume: k.Amount,
+		Closed:      !now.Before(openTime.Add(interval)),
+	}
+}
+
+type FundingRate struct {
+	Symbol         string  `json:"symbol"`
+	FundingRate    float64 `json:"fundingRate"`
+	NextSettleTime int64   `json:"nextSettleTime"`
+}
+
+// Price is pushed by both the index price and the fair price channels.
+type Price struct {
+	Symbol string  `json:"symbol"`
+	Price  float64 `json:"price"`
+}
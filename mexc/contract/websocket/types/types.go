@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import "encoding/json"
+
+type Request struct {
+	Method string `json:"method"`
+	Param  any    `json:"param,omitempty"`
+}
+
+type LoginParam struct {
+	ApiKey    string `json:"apiKey"`
+	ReqTime   string `json:"reqTime"`
+	Signature string `json:"signature"`
+}
+
+// Message is the envelope of every frame sent by the server, Data is decoded
+// according to Channel.
+type Message struct {
+	Channel string          `json:"channel"`
+	Data    json.RawMessage `json:"data"`
+	Symbol  string          `json:"symbol,omitempty"`
+	Ts      int64           `json:"ts"`
+}
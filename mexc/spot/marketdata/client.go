@@ -22,10 +22,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/go-playground/validator"
 	"github.com/rluisr/nexapi/mexc/spot/marketdata/types"
 	spotutils "github.com/rluisr/nexapi/mexc/spot/utils"
+	"github.com/rluisr/nexapi/utils/backfill"
+	"github.com/rluisr/nexapi/utils/pagination"
 	"github.com/valyala/fastjson"
 )
 
@@ -299,13 +302,13 @@ func (s *SpotMarketDataClient) GetKlines(ctx context.Context, param types.GetKli
 
 		ret = append(ret, &types.Kline{
 			OpenTime:         kline[0].GetInt64(),
-			OpenPrice:        kline[1].String(),
-			HighPrice:        kline[2].String(),
-			LowPrice:         kline[3].String(),
-			ClosePrice:       kline[4].String(),
-			Volume:           kline[5].String(),
+			OpenPrice:        klineString(kline[1]),
+			HighPrice:        klineString(kline[2]),
+			LowPrice:         klineString(kline[3]),
+			ClosePrice:       klineString(kline[4]),
+			Volume:           klineString(kline[5]),
 			CloseTime:        kline[6].GetInt64(),
-			QuoteAssetVolume: kline[7].String(),
+			QuoteAssetVolume: klineString(kline[7]),
 		})
 	}
 
@@ -538,3 +541,64 @@ func (s *SpotMarketDataClient) GetBookTickerForSymbols(ctx context.Context) ([]*
 
 	return ret, nil
 }
+
+// IterateAggTrades walks GetAggTrades from start to end, MEXC only serves one hour of
+// aggregate trades per request so the range is fetched window by window.
+func (s *SpotMarketDataClient) IterateAggTrades(param types.GetAggTradesParam, start, end time.Time) *pagination.Iterator[pagination.Window, *types.AggTrade] {
+	if param.Limit == 0 {
+		param.Limit = 1000
+	}
+
+	return pagination.NewTimeWindowIterator(start, end, time.Hour, param.Limit, func(ctx context.Context, w pagination.Window) ([]*types.AggTrade, error) {
+		param.StartTime = w.Start.UnixMilli()
+		param.EndTime = w.End.UnixMilli()
+
+		return s.GetAggTrades(ctx, param)
+	}, func(v *types.AggTrade) time.Time {
+		return time.UnixMilli(v.T)
+	})
+}
+
+// KlineSource describes the spot klines of symbol for the backfill package,
+// MEXC returns at most 1000 klines per request.
+func (s *SpotMarketDataClient) KlineSource(symbol string, interval spotutils.KlineInterval) (backfill.Source[*types.Kline], error) {
+	d, ok := klineDurations[interval]
+	if !ok {
+		return backfill.Source[*types.Kline]{}, fmt.Errorf("unsupported kline interval: %s", interval)
+	}
+
+	return backfill.Source[*types.Kline]{
+		Fetch: func(ctx context.Context, start, end time.Time) ([]*types.Kline, error) {
+			return s.GetKlines(ctx, types.GetKlineParam{
+				Symbol:    symbol,
+				Interval:  interval,
+				StartTime: start.UnixMilli(),
+				EndTime:   end.UnixMilli() - 1,
+				Limit:     1000,
+			})
+		},
+		OpenTime: func(k *types.Kline) time.Time {
+			return time.UnixMilli(k.OpenTime)
+		},
+		Interval: d,
+		Limit:    1000,
+	}, nil
+}
+
+// klineString returns the value of a kline field, MEXC sends prices and volumes as JSON strings.
+func klineString(v *fastjson.Value) string {
+	if v.Type() == fastjson.TypeString {
+		return string(v.GetStringBytes())
+	}
+	return v.String()
+}
+
+var klineDurations = map[spotutils.KlineInterval]time.Duration{
+	spotutils.Minute1:  time.Minute,
+	spotutils.Minute5:  5 * time.Minute,
+	spotutils.Minute15: 15 * time.Minute,
+	spotutils.Minute30: 30 * time.Minute,
+	spotutils.Minute60: time.Hour,
+	spotutils.Hour4:    4 * time.Hour,
+	spotutils.Day1:     24 * time.Hour,
+}
@@ -0,0 +1,222 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package marketdata
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rluisr/nexapi/mexc/spot/marketdata/types"
+	"github.com/rluisr/nexapi/mexc/spot/spottest"
+	spotutils "github.com/rluisr/nexapi/mexc/spot/utils"
+	"github.com/rluisr/nexapi/utils/fakeserver"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNewFakeMarketDataClient(t *testing.T) (*SpotMarketDataClient, *fakeserver.Server) {
+	srv := spottest.NewServer()
+	t.Cleanup(srv.Close)
+
+	cli, err := NewSpotMarketDataClient(&spotutils.SpotClientCfg{
+		BaseURL:    srv.URL,
+		HTTPClient: srv.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Could not create mexc client, %s", err)
+	}
+
+	return cli, srv
+}
+
+const (
+	tickerFixture      = `{"symbol":"BTCUSDT","priceChange":"100","priceChangePercent":"0.002","prevClosePrice":"42000","lastPrice":"42100","bidPrice":"42099.9","bidQty":"1.2","askPrice":"42100.1","askQty":"0.8","openPrice":"42000","highPrice":"42500","lowPrice":"41800","volume":"1234.5","quoteVolume":"51975000","openTime":1704067200000,"closeTime":1704153600000,"count":null}`
+	tickerPriceFixture = `{"symbol":"BTCUSDT","price":"42100"}`
+	bookTickerFixture  = `{"symbol":"BTCUSDT","bidPrice":"42099.9","bidQty":"1.2","askPrice":"42100.1","askQty":"0.8"}`
+)
+
+func TestFakePublicEndpoints(t *testing.T) {
+	cli, srv := testNewFakeMarketDataClient(t)
+	ctx := context.Background()
+
+	srv.Handle(http.MethodGet, "/api/v3/ping", `{}`)
+	assert.Nil(t, cli.Ping(ctx))
+
+	srv.Handle(http.MethodGet, "/api/v3/time", `{"serverTime":1704067200000}`)
+	serverTime, err := cli.GetServerTime(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1704067200000), serverTime.ServerTime)
+
+	srv.Handle(http.MethodGet, "/api/v3/defaultSymbols", `{"code":0,"data":["BTCUSDT","ETHUSDT"],"msg":null}`)
+	symbols, err := cli.GetSymbols(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"BTCUSDT", "ETHUSDT"}, symbols.Data)
+
+	srv.Handle(http.MethodGet, "/api/v3/exchangeInfo", `{"timezone":"CST","serverTime":1704067200000,"rateLimits":[],"symbols":[{"symbol":"BTCUSDT","status":"ENABLED","baseAsset":"BTC","quoteAsset":"USDT","baseSizePrecision":"0.000001","filters":[]}]}`)
+	info, err := cli.GetExchangeInfo(ctx, types.GetExchangeInfoParam{Symbol: "BTCUSDT"})
+	assert.Nil(t, err)
+	assert.Equal(t, "BTC", info.Symbols[0].BaseAsset)
+	assert.Equal(t, "BTCUSDT", srv.LastRequest().Query.Get("symbols"))
+
+	for _, r := range srv.Requests() {
+		assert.False(t, r.Signed)
+	}
+}
+
+func TestFakeGetOrderbook(t *testing.T) {
+	cli, srv := testNewFakeMarketDataClient(t)
+
+	srv.Handle(http.MethodGet, "/api/v3/depth", `{"lastUpdateId":42,"bids":[["42099.9","1.2"],["42099","3"]],"asks":[["42100.1","0.8"]]}`)
+	ob, err := cli.GetOrderbook(context.Background(), types.GetOrderbookParams{Symbol: "BTCUSDT", Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(42), ob.LastUpdateID)
+	assert.Equal(t, "2", srv.LastRequest().Query.Get("limit"))
+
+	b, err := ob.Book()
+	assert.Nil(t, err)
+	best, ok := b.BestBid()
+	assert.True(t, ok)
+	assert.Equal(t, "42099.9", best.Price.String())
+}
+
+func TestFakeTrades(t *testing.T) {
+	cli, srv := testNewFakeMarketDataClient(t)
+	ctx := context.Background()
+
+	srv.Handle(http.MethodGet, "/api/v3/trades", `[{"id":null,"price":"42100","qty":"0.01","quoteQty":"421","time":1704067200000,"isBuyerMaker":true,"isBestMatch":true}]`)
+	trades, err := cli.GetRecentTradeList(ctx, types.GetTradeParams{Symbol: "BTCUSDT"})
+	assert.Nil(t, err)
+	assert.Equal(t, "42100", trades[0].Price)
+
+	srv.Handle(http.MethodGet, "/api/v3/aggTrades", `[{"a":null,"f":null,"l":null,"p":"42100","q":"0.01","T":1704067200000,"m":true,"M":true}]`)
+	aggTrades, err := cli.GetAggTrades(ctx, types.GetAggTradesParam{Symbol: "BTCUSDT", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1704067200000), aggTrades[0].T)
+}
+
+func TestFakeGetKlines(t *testing.T) {
+	cli, srv := testNewFakeMarketDataClient(t)
+
+	srv.Handle(http.MethodGet, "/api/v3/klines", `[[1704067200000,"42000","42500","41800","42100","12.5",1704067259999,"525000"]]`)
+	klines, err := cli.GetKlines(context.Background(), types.GetKlineParam{Symbol: "BTCUSDT", Interval: spotutils.Minute1})
+	assert.Nil(t, err)
+	assert.Len(t, klines, 1)
+	assert.Equal(t, "42100", klines[0].ClosePrice)
+	assert.Equal(t, "1m", srv.LastRequest().Query.Get("interval"))
+
+	// the kline is closed once its interval has elapsed
+	c, err := klines[0].Candle(time.UnixMilli(1704067259999))
+	assert.Nil(t, err)
+	assert.Equal(t, time.Minute, c.Interval)
+	assert.Equal(t, 42100.0, c.Close)
+	assert.False(t, c.Closed)
+	c, err = klines[0].Candle(time.UnixMilli(1704067260000))
+	assert.Nil(t, err)
+	assert.True(t, c.Closed)
+
+	src, err := cli.KlineSource("BTCUSDT", spotutils.Minute1)
+	assert.Nil(t, err)
+	start := time.UnixMilli(1704067200000)
+	klines, err = src.Fetch(context.Background(), start, start.Add(time.Hour))
+	assert.Nil(t, err)
+	assert.Len(t, klines, 1)
+	assert.Equal(t, "1704070799999", srv.LastRequest().Query.Get("endTime"))
+
+	srv.Handle(http.MethodGet, "/api/v3/klines", `[[1704067200000,"42000"]]`)
+	_, err = cli.GetKlines(context.Background(), types.GetKlineParam{Symbol: "BTCUSDT", Interval: spotutils.Minute1})
+	assert.NotNil(t, err)
+}
+
+func TestFakeTickers(t *testing.T) {
+	cli, srv := testNewFakeMarketDataClient(t)
+	ctx := context.Background()
+
+	srv.Handle(http.MethodGet, "/api/v3/avgPrice", `{"mins":5,"price":"42050"}`)
+	avg, err := cli.GetAvgPrice(ctx, types.GetAvgPriceParam{Symbol: "BTCUSDT"})
+	assert.Nil(t, err)
+	assert.Equal(t, "42050", avg.Price)
+
+	srv.Handle(http.MethodGet, "/api/v3/ticker/24hr", tickerFixture)
+	ticker, err := cli.GetTickerForSymbol(ctx, types.GetTickerForSymbolParam{Symbol: "BTCUSDT"})
+	assert.Nil(t, err)
+	assert.Equal(t, "42100", ticker.LastPrice)
+
+	srv.Handle(http.MethodGet, "/api/v3/ticker/24hr", "["+tickerFixture+"]")
+	tickers, err := cli.GetTickerForAllSymbols(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, tickers, 1)
+	assert.Empty(t, srv.LastRequest().Query.Get("symbol"))
+
+	srv.Handle(http.MethodGet, "/api/v3/ticker/price", tickerPriceFixture)
+	price, err := cli.GetTickerPriceForSymbol(ctx, types.GetTickerPriceForSymbolParam{Symbol: "BTCUSDT"})
+	assert.Nil(t, err)
+	assert.Equal(t, "42100", price.Price)
+
+	srv.Handle(http.MethodGet, "/api/v3/ticker/price", "["+tickerPriceFixture+"]")
+	prices, err := cli.GetTickerPriceForAllSymbols(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, prices, 1)
+
+	srv.Handle(http.MethodGet, "/api/v3/ticker/bookTicker", bookTickerFixture)
+	bookTicker, err := cli.GetBookTickerForSymbol(ctx, types.GetBookTickerParam{Symbol: "BTCUSDT"})
+	assert.Nil(t, err)
+	assert.Equal(t, "42100.1", bookTicker.AskPrice)
+
+	srv.Handle(http.MethodGet, "/api/v3/ticker/bookTicker", "["+bookTickerFixture+"]")
+	bookTickers, err := cli.GetBookTickerForSymbols(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, bookTickers, 1)
+}
+
+func TestFakeIterateAggTrades(t *testing.T) {
+	cli, srv := testNewFakeMarketDataClient(t)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	srv.Handle(http.MethodGet, "/api/v3/aggTrades", `[{"p":"42100","q":"0.01","T":1704067200000}]`)
+	trades, err := cli.IterateAggTrades(types.GetAggTradesParam{Symbol: "BTCUSDT"}, start, start.Add(2*time.Hour)).All(context.Background())
+	assert.Nil(t, err)
+	// one request per hour window
+	assert.Len(t, srv.Requests(), 2)
+	assert.Len(t, trades, 2)
+}
+
+func TestFakeErrors(t *testing.T) {
+	cli, srv := testNewFakeMarketDataClient(t)
+	ctx := context.Background()
+
+	srv.Handle(http.MethodGet, "/api/v3/time", `{"serverTime":1704067200000}`)
+	srv.Fail(http.MethodGet, "/api/v3/time", fakeserver.Response{Status: http.StatusTooManyRequests, Body: `{"code":429,"msg":"Too many requests"}`})
+
+	_, err := cli.GetServerTime(ctx)
+	assert.ErrorContains(t, err, "429")
+
+	// the failure is used once
+	_, err = cli.GetServerTime(ctx)
+	assert.Nil(t, err)
+
+	// a route without fixture is not found
+	_, err = cli.GetAvgPrice(ctx, types.GetAvgPriceParam{Symbol: "BTCUSDT"})
+	assert.ErrorContains(t, err, "404")
+
+	srv.SetLatency(time.Second)
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = cli.GetServerTime(timeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -20,9 +20,14 @@ package marketdata
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/rluisr/nexapi/mexc/spot/marketdata/types"
 	spotutils "github.com/rluisr/nexapi/mexc/spot/utils"
+	"github.com/rluisr/nexapi/utils/backfill"
+	"github.com/rluisr/nexapi/utils/book"
+	"github.com/rluisr/nexapi/utils/candle"
+	"github.com/rluisr/nexapi/utils/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -82,6 +87,22 @@ func TestGetOrderbook(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestOrderbookVWAP(t *testing.T) {
+	cli := testNewSpotMarketDataClient(t)
+
+	ob, err := cli.GetOrderbook(context.TODO(), types.GetOrderbookParams{
+		Symbol: "BTCUSDT",
+		Limit:  100,
+	})
+	assert.Nil(t, err)
+
+	b, err := ob.Book()
+	assert.Nil(t, err)
+
+	_, ok := b.VWAP(book.Ask, decimal.MustFromString("0.1"), 2)
+	assert.True(t, ok)
+}
+
 func TestGetRecentTradeList(t *testing.T) {
 	cli := testNewSpotMarketDataClient(t)
 
@@ -169,3 +190,77 @@ func TestGetBookTickerForAllSymbols(t *testing.T) {
 	_, err := cli.GetBookTickerForSymbols(context.TODO())
 	assert.Nil(t, err)
 }
+
+func TestIterateAggTrades(t *testing.T) {
+	cli := testNewSpotMarketDataClient(t)
+
+	end := time.Now()
+	trades, err := cli.IterateAggTrades(types.GetAggTradesParam{
+		Symbol: "BTCUSDT",
+	}, end.Add(-2*time.Hour), end).WithMaxItems(3000).All(context.TODO())
+	assert.Nil(t, err)
+	assert.NotEmpty(t, trades)
+}
+
+func TestBackfillKlines(t *testing.T) {
+	cli := testNewSpotMarketDataClient(t)
+
+	src, err := cli.KlineSource("BTCUSDT", spotutils.Minute1)
+	assert.Nil(t, err)
+
+	end := time.Now().Truncate(time.Minute)
+	var klines []*types.Kline
+	_, err = backfill.Run(context.TODO(), backfill.Config[*types.Kline]{
+		Source: src,
+		Sink: func(ctx context.Context, k []*types.Kline) error {
+			klines = append(klines, k...)
+			return nil
+		},
+		Start:       end.Add(-48 * time.Hour),
+		End:         end,
+		Concurrency: 2,
+	})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, klines)
+}
+
+func TestResampleKlines(t *testing.T) {
+	cli := testNewSpotMarketDataClient(t)
+
+	klines, err := cli.GetKlines(context.TODO(), types.GetKlineParam{
+		Symbol:   "BTCUSDT",
+		Interval: spotutils.Minute60,
+		Limit:    24,
+	})
+	assert.Nil(t, err)
+
+	var candles []*candle.Candle
+	for _, k := range klines {
+		c, err := k.Candle(time.Now())
+		assert.Nil(t, err)
+		candles = append(candles, c)
+	}
+
+	resampled, err := candle.Resample(candle.Config{Interval: 2 * time.Hour}, candles)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, resampled)
+}
+
+func TestCandlesFromAggTrades(t *testing.T) {
+	cli := testNewSpotMarketDataClient(t)
+
+	aggTrades, err := cli.GetAggTrades(context.TODO(), types.GetAggTradesParam{
+		Symbol: "BTCUSDT",
+	})
+	assert.Nil(t, err)
+
+	var trades []*candle.Trade
+	for _, a := range aggTrades {
+		tr, err := a.CandleTrade()
+		assert.Nil(t, err)
+		trades = append(trades, tr)
+	}
+
+	_, err = candle.FromTrades(candle.Config{Interval: 10 * time.Second, FillEmpty: true}, trades, time.Now())
+	assert.Nil(t, err)
+}
@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package marketdata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fastjson"
+)
+
+func TestKlineString(t *testing.T) {
+	// prices and volumes are sent as JSON strings, the times as numbers
+	kline := fastjson.MustParse(`[1704067200000,"42000.5","12",1704067259999]`).GetArray()
+
+	assert.Equal(t, "42000.5", klineString(kline[1]))
+	assert.Equal(t, "12", klineString(kline[2]))
+	assert.Equal(t, "1704067259999", klineString(kline[3]))
+}
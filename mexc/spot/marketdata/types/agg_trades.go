@@ -17,6 +17,13 @@
 
 package types
 
+import (
+	"time"
+
+	"github.com/rluisr/nexapi/utils/candle"
+	"github.com/rluisr/nexapi/utils/decimal"
+)
+
 type GetAggTradesParam struct {
 	Symbol    string `url:"symbol" validate:"required"`
 	StartTime int64  `url:"startTime,omitempty" validate:"omitempty"`
@@ -34,3 +41,21 @@ type AggTrade struct {
 	M  bool   `json:"m"` // Was the buyer the maker?
 	Ma bool   `json:"M"` // Was the trade the best price match?
 }
+
+// CandleTrade converts the aggregated trade for the candle builder.
+func (t *AggTrade) CandleTrade() (*candle.Trade, error) {
+	v, err := parseFloats(t.P, t.Q)
+	if err != nil {
+		return nil, err
+	}
+
+	return &candle.Trade{Time: time.UnixMilli(t.T), Price: v[0], Qty: v[1]}, nil
+}
+
+func (t *AggTrade) PriceDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.P)
+}
+
+func (t *AggTrade) QtyDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.Q)
+}
@@ -18,7 +18,12 @@
 package types
 
 import (
+	"strconv"
+	"time"
+
 	spotutils "github.com/rluisr/nexapi/mexc/spot/utils"
+	"github.com/rluisr/nexapi/utils/candle"
+	"github.com/rluisr/nexapi/utils/decimal"
 )
 
 type GetKlineParam struct {
@@ -39,3 +44,65 @@ type Kline struct {
 	CloseTime        int64  `json:"closeTime"`
 	QuoteAssetVolume string `json:"quoteAssetVolume"`
 }
+
+// Candle converts the kline for the candle builder, the kline is closed once its
+// interval has elapsed at now.
+func (k *Kline) Candle(now time.Time) (*candle.Candle, error) {
+	v, err := parseFloats(k.OpenPrice, k.HighPrice, k.LowPrice, k.ClosePrice, k.Volume, k.QuoteAssetVolume)
+	if err != nil {
+		return nil, err
+	}
+
+	openTime := time.UnixMilli(k.OpenTime)
+	// the close time is either the open time of the next kline or 1ms before it
+	interval := (time.Duration(k.CloseTime-k.OpenTime) * time.Millisecond).Round(time.Second)
+
+	return &candle.Candle{
+		OpenTime:    openTime,
+		Interval:    interval,
+		Open:        v[0],
+		High:        v[1],
+		Low:         v[2],
+		Close:       v[3],
+		Volume:      v[4],
+		QuoteVolume: v[5],
+		Closed:      !now.Before(openTime.Add(interval)),
+	}, nil
+}
+
+func parseFloats(values ...string) ([]float64, error) {
+	ret := make([]float64, len(values))
+	for i, v := range values {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = f
+	}
+
+	return ret, nil
+}
+
+func (k *Kline) OpenPriceDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(k.OpenPrice)
+}
+
+func (k *Kline) HighPriceDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(k.HighPrice)
+}
+
+func (k *Kline) LowPriceDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(k.LowPrice)
+}
+
+func (k *Kline) ClosePriceDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(k.ClosePrice)
+}
+
+func (k *Kline) VolumeDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(k.Volume)
+}
+
+func (k *Kline) QuoteAssetVolumeDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(k.QuoteAssetVolume)
+}
@@ -17,6 +17,8 @@
 
 package types
 
+import "github.com/rluisr/nexapi/utils/book"
+
 type GetOrderbookParams struct {
 	Symbol string `url:"symbol" validate:"required"`
 	Limit  int    `url:"limit,omitempty" validate:"omitempty,max=5000"`
@@ -27,3 +29,16 @@ type Orderbook struct {
 	Bids         [][]string `json:"bids"`
 	Asks         [][]string `json:"asks"`
 }
+
+// Book returns the typed and sorted levels of the order book.
+func (o *Orderbook) Book() (*book.Book, error) {
+	return book.FromStrings(o.Bids, o.Asks)
+}
+
+func (o *Orderbook) BidLevels() ([]book.PriceLevel, error) {
+	return book.ParseLevels(o.Bids)
+}
+
+func (o *Orderbook) AskLevels() ([]book.PriceLevel, error) {
+	return book.ParseLevels(o.Asks)
+}
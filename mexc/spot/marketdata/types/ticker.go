@@ -17,6 +17,8 @@
 
 package types
 
+import "github.com/rluisr/nexapi/utils/decimal"
+
 type GetTickerForSymbolParam struct {
 	Symbol string `url:"symbol" validate:"required"`
 }
@@ -44,3 +46,43 @@ type Ticker struct {
 	CloseTime          int64  `json:"closeTime"`
 	Count              int    `json:"count"`
 }
+
+func (t *Ticker) LastPriceDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.LastPrice)
+}
+
+func (t *Ticker) BidPriceDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.BidPrice)
+}
+
+func (t *Ticker) BidQtyDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.BidQty)
+}
+
+func (t *Ticker) AskPriceDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.AskPrice)
+}
+
+func (t *Ticker) AskQtyDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.AskQty)
+}
+
+func (t *Ticker) OpenPriceDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.OpenPrice)
+}
+
+func (t *Ticker) HighPriceDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.HighPrice)
+}
+
+func (t *Ticker) LowPriceDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.LowPrice)
+}
+
+func (t *Ticker) VolumeDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.Volume)
+}
+
+func (t *Ticker) QuoteVolumeDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.QuoteVolume)
+}
@@ -17,6 +17,13 @@
 
 package types
 
+import (
+	"time"
+
+	"github.com/rluisr/nexapi/utils/candle"
+	"github.com/rluisr/nexapi/utils/decimal"
+)
+
 type GetTradeParams struct {
 	Symbol string `url:"symbol" validate:"required"`
 	Limit  int    `url:"limit,omitempty" validate:"omitempty,max=1000"`
@@ -31,3 +38,25 @@ type Trade struct {
 	IsBuyerMaker bool   `json:"isBuyerMaker"`
 	IsBestMatch  bool   `json:"isBestMatch"`
 }
+
+// CandleTrade converts the trade for the candle builder.
+func (t *Trade) CandleTrade() (*candle.Trade, error) {
+	v, err := parseFloats(t.Price, t.Qty)
+	if err != nil {
+		return nil, err
+	}
+
+	return &candle.Trade{Time: time.UnixMilli(t.Time), Price: v[0], Qty: v[1]}, nil
+}
+
+func (t *Trade) PriceDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.Price)
+}
+
+func (t *Trade) QtyDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.Qty)
+}
+
+func (t *Trade) QuoteQtyDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.QuoteQty)
+}
@@ -0,0 +1,208 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package paper provides a paper trading SpotAccountClient backed by a simulated exchange.
+package paper
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	mdtypes "github.com/rluisr/nexapi/mexc/spot/marketdata/types"
+	"github.com/rluisr/nexapi/mexc/spot/spotaccount/types"
+	"github.com/rluisr/nexapi/utils/sim"
+)
+
+// SpotAccountClient has the order and account methods of spotaccount.SpotAccountClient.
+type SpotAccountClient struct {
+	*sim.Exchange
+}
+
+func NewSpotAccountClient(ex *sim.Exchange) *SpotAccountClient {
+	return &SpotAccountClient{Exchange: ex}
+}
+
+var orderTypes = map[string]sim.OrderType{
+	"LIMIT":               sim.Limit,
+	"MARKET":              sim.Market,
+	"LIMIT_MAKER":         sim.PostOnly,
+	"IMMEDIATE_OR_CANCEL": sim.IOC,
+	"FILL_OR_KILL":        sim.FOK,
+}
+
+var sides = map[string]sim.Side{
+	"BUY":  sim.Buy,
+	"SELL": sim.Sell,
+}
+
+// UpdateOrderbook feeds the simulated exchange with an order book of symbol.
+func (s *SpotAccountClient) UpdateOrderbook(symbol string, ob *mdtypes.Orderbook, t time.Time) error {
+	b, err := ob.Book()
+	if err != nil {
+		return err
+	}
+
+	return s.UpdateBook(symbol, b, t)
+}
+
+// UpdateTrades feeds the simulated exchange with the trades of symbol in time order.
+func (s *SpotAccountClient) UpdateTrades(symbol string, trades []*mdtypes.Trade) error {
+	for _, t := range trades {
+		price, err := t.PriceDecimal()
+		if err != nil {
+			return err
+		}
+
+		qty, err := t.QtyDecimal()
+		if err != nil {
+			return err
+		}
+
+		side := sim.Buy
+		if t.IsBuyerMaker {
+			side = sim.Sell
+		}
+
+		err = s.UpdateTrade(symbol, sim.Trade{Price: price, Qty: qty, Side: side, Time: time.UnixMilli(t.Time)})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *SpotAccountClient) GetAccountInfo(ctx context.Context) (*types.AccountInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ret := &types.AccountInfo{
+		CanTrade:    true,
+		AccountType: "SPOT",
+		UpdateTime:  int(s.Now().UnixMilli()),
+		Permissions: []string{"SPOT"},
+	}
+	for _, b := range s.Balances() {
+		ret.Balances = append(ret.Balances, types.Balance{
+			Asset:  b.Asset,
+			Free:   b.Free.String(),
+			Locked: b.Locked.String(),
+		})
+	}
+
+	return ret, nil
+}
+
+func (s *SpotAccountClient) CreateOrder(ctx context.Context, param types.CreateOrderParam) (*types.CreateOrderResp, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	side, ok := sides[param.Side]
+	if !ok {
+		return nil, errors.New("unknown order side: " + param.Side)
+	}
+
+	orderType, ok := orderTypes[param.Type]
+	if !ok {
+		return nil, errors.New("unknown order type: " + param.Type)
+	}
+
+	req := sim.OrderRequest{
+		Symbol:   param.Symbol,
+		Side:     side,
+		Type:     orderType,
+		Price:    param.Price,
+		Qty:      param.Quantity,
+		QuoteQty: param.QuoteOrderQty,
+	}
+
+	o, err := s.PlaceOrder(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.CreateOrderResp{
+		Symbol:       o.Symbol,
+		OrderID:      o.ID,
+		OrderListId:  -1,
+		Price:        o.Price.String(),
+		OrigQty:      o.Qty.String(),
+		Type:         param.Type,
+		Side:         param.Side,
+		TransactTime: o.CreateTime.UnixMilli(),
+	}, nil
+}
+
+func (s *SpotAccountClient) QueryOrder(ctx context.Context, param types.QueryOrderParam) (*types.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	o, err := s.Order(param.OrderID, "")
+	if err != nil {
+		return nil, err
+	}
+	if o.Symbol != param.Symbol {
+		return nil, sim.ErrOrderNotFound
+	}
+
+	ret := &types.Order{
+		Symbol:              o.Symbol,
+		OrderID:             o.ID,
+		ClientOrderID:       o.ClientID,
+		Price:               o.Price.String(),
+		OrigQty:             o.Qty.String(),
+		ExecutedQty:         o.Filled.String(),
+		CummulativeQuoteQty: o.FilledQuote.String(),
+		Status:              status(o),
+		TimeInForce:         "GTC",
+		Side:                "BUY",
+		Time:                o.CreateTime.UnixMilli(),
+		UpdateTime:          o.UpdateTime.UnixMilli(),
+		IsWorking:           o.IsOpen(),
+		OrigQuoteOrderQty:   o.QuoteQty.String(),
+	}
+	if o.Side == sim.Sell {
+		ret.Side = "SELL"
+	}
+	for name, t := range orderTypes {
+		if t == o.Type {
+			ret.Type = name
+		}
+	}
+
+	return ret, nil
+}
+
+func status(o *sim.Order) string {
+	switch o.Status {
+	case sim.PartiallyFilled:
+		return "PARTIALLY_FILLED"
+	case sim.Filled:
+		return "FILLED"
+	case sim.Canceled, sim.Rejected:
+		if o.Filled.Sign() > 0 {
+			return "PARTIALLY_CANCELED"
+		}
+		return "CANCELED"
+	default:
+		return "NEW"
+	}
+}
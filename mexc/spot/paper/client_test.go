@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package paper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mdtypes "github.com/rluisr/nexapi/mexc/spot/marketdata/types"
+	"github.com/rluisr/nexapi/mexc/spot/spotaccount"
+	"github.com/rluisr/nexapi/mexc/spot/spotaccount/types"
+	"github.com/rluisr/nexapi/utils/decimal"
+	"github.com/rluisr/nexapi/utils/sim"
+	"github.com/stretchr/testify/assert"
+)
+
+type orderClient interface {
+	GetAccountInfo(ctx context.Context) (*types.AccountInfo, error)
+	CreateOrder(ctx context.Context, param types.CreateOrderParam) (*types.CreateOrderResp, error)
+	QueryOrder(ctx context.Context, param types.QueryOrderParam) (*types.Order, error)
+}
+
+var (
+	_ orderClient = (*spotaccount.SpotAccountClient)(nil)
+	_ orderClient = (*SpotAccountClient)(nil)
+)
+
+func TestLimitOrderFill(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewSpotAccountClient(sim.NewExchange(sim.Config{
+		Instruments: []sim.Instrument{{Symbol: "BTCUSDT", Kind: sim.Spot, Base: "BTC", Quote: "USDT"}},
+		Balances:    map[string]decimal.Decimal{"USDT": decimal.NewFromInt(1000)},
+	}))
+	ctx := context.Background()
+
+	err := s.UpdateOrderbook("BTCUSDT", &mdtypes.Orderbook{Bids: [][]string{{"100", "1"}}, Asks: [][]string{{"101", "1"}}}, start)
+	assert.Nil(t, err)
+
+	resp, err := s.CreateOrder(ctx, types.CreateOrderParam{Symbol: "BTCUSDT", Side: "BUY", Type: "LIMIT", Quantity: decimal.NewFromInt(2), Price: decimal.NewFromInt(100)})
+	assert.Nil(t, err)
+
+	order, err := s.QueryOrder(ctx, types.QueryOrderParam{Symbol: "BTCUSDT", OrderID: resp.OrderID})
+	assert.Nil(t, err)
+	assert.Equal(t, "NEW", order.Status)
+	assert.True(t, order.IsWorking)
+
+	info, err := s.GetAccountInfo(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, info.Balances, 1)
+	assert.Equal(t, "800", info.Balances[0].Free)
+
+	err = s.UpdateTrades("BTCUSDT", []*mdtypes.Trade{{Price: "99", Qty: "1.5", Time: start.Add(time.Second).UnixMilli()}})
+	assert.Nil(t, err)
+
+	order, err = s.QueryOrder(ctx, types.QueryOrderParam{Symbol: "BTCUSDT", OrderID: resp.OrderID})
+	assert.Nil(t, err)
+	assert.Equal(t, "PARTIALLY_FILLED", order.Status)
+	assert.Equal(t, "1.5", order.ExecutedQty)
+
+	_, err = s.QueryOrder(ctx, types.QueryOrderParam{Symbol: "ETHUSDT", OrderID: resp.OrderID})
+	assert.NotNil(t, err)
+
+	_, err = s.CreateOrder(ctx, types.CreateOrderParam{Symbol: "BTCUSDT", Side: "BUY", Type: "STOP", Quantity: decimal.NewFromInt(1)})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spotaccount
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/rluisr/nexapi/mexc/spot/spotaccount/types"
+	"github.com/rluisr/nexapi/mexc/spot/spottest"
+	"github.com/rluisr/nexapi/utils/decimal"
+	"github.com/rluisr/nexapi/utils/fakeserver"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNewFakeAccountClient(t *testing.T, secret string) (*SpotAccountClient, *fakeserver.Server) {
+	srv := spottest.NewServer()
+	t.Cleanup(srv.Close)
+
+	cli, err := NewSpotAccountClient(&SpotAccountClientCfg{
+		BaseURL:    srv.URL,
+		Key:        spottest.Key,
+		Secret:     secret,
+		HTTPClient: srv.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Could not create mexc client, %s", err)
+	}
+
+	return cli, srv
+}
+
+func TestFakeGetAccountInfo(t *testing.T) {
+	cli, srv := testNewFakeAccountClient(t, spottest.Secret)
+
+	srv.Handle(http.MethodGet, "/api/v3/account", `{"canTrade":true,"canWithdraw":true,"canDeposit":true,"updateTime":null,"accountType":"SPOT","balances":[{"asset":"USDT","free":"100.5","locked":"10"}],"permissions":["SPOT"]}`)
+
+	info, err := cli.GetAccountInfo(context.Background())
+	assert.Nil(t, err)
+	assert.True(t, info.CanTrade)
+	assert.Equal(t, "USDT", info.Balances[0].Asset)
+
+	free, err := info.Balances[0].FreeDecimal()
+	assert.Nil(t, err)
+	assert.Equal(t, "100.5", free.String())
+
+	r := srv.LastRequest()
+	assert.True(t, r.Signed)
+	assert.Equal(t, "5000", r.Query.Get("recvWindow"))
+}
+
+func TestFakeTransfer(t *testing.T) {
+	cli, srv := testNewFakeAccountClient(t, spottest.Secret)
+
+	srv.Handle(http.MethodPost, "/api/v3/capital/transfer", `{"tranId":"c45d800a47ba4cbc876a5cd29388319"}`)
+
+	err := cli.Transfer(context.Background(), types.TransferParam{
+		FromAccountType: "SPOT",
+		ToAccountType:   "FUTURES",
+		Asset:           "USDT",
+		Amount:          "5",
+	})
+	assert.Nil(t, err)
+
+	r := srv.LastRequest()
+	assert.True(t, r.Signed)
+	assert.Equal(t, "FUTURES", r.Query.Get("toAccountType"))
+}
+
+func TestFakeOrders(t *testing.T) {
+	cli, srv := testNewFakeAccountClient(t, spottest.Secret)
+	ctx := context.Background()
+
+	srv.Handle(http.MethodPost, "/api/v3/order", `{"symbol":"BTCUSDT","orderId":"C02__1","orderListId":-1,"price":"42000","origQty":"0.01","type":"LIMIT","side":"BUY","transactTime":1704067200000}`)
+	created, err := cli.CreateOrder(ctx, types.CreateOrderParam{Symbol: "BTCUSDT", Side: "BUY", Type: "LIMIT", Quantity: decimal.MustFromString("0.01"), Price: decimal.NewFromInt(42000)})
+	assert.Nil(t, err)
+	assert.Equal(t, "C02__1", created.OrderID)
+	assert.True(t, srv.LastRequest().Signed)
+	assert.Equal(t, "0.01", srv.LastRequest().Query.Get("quantity"))
+	assert.False(t, srv.LastRequest().Query.Has("quoteOrderQty"))
+
+	srv.Handle(http.MethodGet, "/api/v3/order", `{"symbol":"BTCUSDT","orderId":"C02__1","price":"42000","origQty":"0.01","executedQty":"0.005","cummulativeQuoteQty":"210","status":"PARTIALLY_FILLED","type":"LIMIT","side":"BUY","time":1704067200000,"updateTime":1704067201000,"isWorking":true}`)
+	order, err := cli.QueryOrder(ctx, types.QueryOrderParam{Symbol: "BTCUSDT", OrderID: created.OrderID})
+	assert.Nil(t, err)
+	assert.Equal(t, "PARTIALLY_FILLED", order.Status)
+	assert.Equal(t, "C02__1", srv.LastRequest().Query.Get("orderId"))
+}
+
+func TestFakeInvalidSignature(t *testing.T) {
+	cli, srv := testNewFakeAccountClient(t, "wrong-secret")
+
+	srv.Handle(http.MethodGet, "/api/v3/account", `{}`)
+
+	_, err := cli.GetAccountInfo(context.Background())
+	spottest.AssertInvalidSignature(t, err)
+	assert.True(t, srv.LastRequest().Signed)
+}
@@ -17,20 +17,32 @@
 
 package types
 
+import "github.com/rluisr/nexapi/utils/decimal"
+
 type AccountInfo struct {
-	MakerCommission  int    `json:"makerCommission"`
-	TakerCommission  int    `json:"takerCommission"`
-	BuyerCommission  int    `json:"buyerCommission"`
-	SellerCommission int    `json:"sellerCommission"`
-	CanTrade         bool   `json:"canTrade"`
-	CanWithdraw      bool   `json:"canWithdraw"`
-	CanDeposit       bool   `json:"canDeposit"`
-	UpdateTime       int    `json:"updateTime"`
-	AccountType      string `json:"accountType"`
-	Balances         []struct {
-		Asset  string `json:"asset"`
-		Free   string `json:"free"`
-		Locked string `json:"locked"`
-	} `json:"balances"`
-	Permissions []string `json:"permissions"`
+	MakerCommission  int       `json:"makerCommission"`
+	TakerCommission  int       `json:"takerCommission"`
+	BuyerCommission  int       `json:"buyerCommission"`
+	SellerCommission int       `json:"sellerCommission"`
+	CanTrade         bool      `json:"canTrade"`
+	CanWithdraw      bool      `json:"canWithdraw"`
+	CanDeposit       bool      `json:"canDeposit"`
+	UpdateTime       int       `json:"updateTime"`
+	AccountType      string    `json:"accountType"`
+	Balances         []Balance `json:"balances"`
+	Permissions      []string  `json:"permissions"`
+}
+
+type Balance struct {
+	Asset  string `json:"asset"`
+	Free   string `json:"free"`
+	Locked string `json:"locked"`
+}
+
+func (b *Balance) FreeDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(b.Free)
+}
+
+func (b *Balance) LockedDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(b.Locked)
 }
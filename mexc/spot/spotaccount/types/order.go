@@ -1,14 +1,17 @@
 package types
 
-import "github.com/rluisr/nexapi/mexc/utils"
+import (
+	"github.com/rluisr/nexapi/mexc/utils"
+	"github.com/rluisr/nexapi/utils/decimal"
+)
 
 type CreateOrderParam struct {
-	Symbol        string   `url:"symbol"`
-	Side          string   `url:"side"`                    // ENUM: Order Side
-	Type          string   `url:"type"`                    // ENUM: Order Type
-	Quantity      *float64 `url:"quantity,omitempty"`      // DECIMAL
-	QuoteOrderQty *float64 `url:"quoteOrderQty,omitempty"` // DECIMAL
-	Price         *float64 `url:"price,omitempty"`         // DECIMAL
+	Symbol        string          `url:"symbol"`
+	Side          string          `url:"side"`                    // ENUM: Order Side
+	Type          string          `url:"type"`                    // ENUM: Order Type
+	Quantity      decimal.Decimal `url:"quantity,omitempty"`      // DECIMAL
+	QuoteOrderQty decimal.Decimal `url:"quoteOrderQty,omitempty"` // DECIMAL
+	Price         decimal.Decimal `url:"price,omitempty"`         // DECIMAL
 }
 
 type CreateOrderParams struct {
@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package spottest provides a fake MEXC spot server for offline tests.
+package spottest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/rluisr/nexapi/utils/fakeserver"
+	"github.com/stretchr/testify/assert"
+)
+
+// The credentials accepted by the server.
+const (
+	Key    = "mexc-test-key"
+	Secret = "mexc-test-secret"
+)
+
+// InvalidSignCode is the error code of the requests rejected by Verify.
+const InvalidSignCode = "700002"
+
+// NewServer starts a server that checks the signed requests with Key and Secret.
+// The routes without fixture return 404 like the API.
+func NewServer() *fakeserver.Server {
+	return fakeserver.New(fakeserver.Config{
+		Verify: Verify,
+		Unauthorized: fakeserver.Response{
+			Status: http.StatusBadRequest,
+			Body:   `{"code":` + InvalidSignCode + `,"msg":"%s"}`,
+		},
+	})
+}
+
+// Verify checks the X-MEXC-APIKEY header and the HMAC-SHA256 signature of the query and
+// form body. A request without signature parameter is not signed.
+func Verify(r *http.Request, body []byte) (bool, error) {
+	q := r.URL.Query()
+	signature := q.Get("signature")
+	if signature == "" {
+		return false, nil
+	}
+
+	if r.Header.Get("X-MEXC-APIKEY") != Key {
+		return true, errors.New("api key does not exist")
+	}
+
+	if q.Get("timestamp") == "" {
+		return true, errors.New("timestamp is required")
+	}
+
+	// the signature is computed on the parameters sorted by key, without the signature itself
+	q.Del("signature")
+	signString := q.Encode()
+
+	if len(body) > 0 {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return true, err
+		}
+		signString += form.Encode()
+	}
+
+	h := hmac.New(sha256.New, []byte(Secret))
+	h.Write([]byte(signString))
+	if !hmac.Equal([]byte(hex.EncodeToString(h.Sum(nil))), []byte(signature)) {
+		return true, errors.New("signature for this request is not valid")
+	}
+
+	return true, nil
+}
+
+// AssertInvalidSignature checks that err reports a request rejected by Verify.
+func AssertInvalidSignature(t *testing.T, err error) {
+	t.Helper()
+	assert.ErrorContains(t, err, InvalidSignCode)
+}
@@ -0,0 +1,382 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package funding
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/rluisr/nexapi/okx/funding/types"
+	okxutils "github.com/rluisr/nexapi/okx/utils"
+	"github.com/rluisr/nexapi/utils"
+)
+
+type FundingClient struct {
+	*okxutils.OKXRestClient
+
+	// validate struct fields
+	validate *validator.Validate
+}
+
+type FundingClientCfg struct {
+	BaseURL    string `validate:"required"`
+	HTTPClient *http.Client
+	Key        string `validate:"required"`
+	Secret     string `validate:"required"`
+	Passphrase string `validate:"required"`
+	Debug      bool
+	IsDemo     bool
+	// Logger
+	Logger *slog.Logger
+}
+
+func NewFundingClient(cfg *FundingClientCfg) (*FundingClient, error) {
+	validator := validator.New()
+
+	err := validator.Struct(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := okxutils.NewOKXRestClient(&okxutils.OKXRestClientCfg{
+		Debug:      cfg.Debug,
+		IsDemo:     cfg.IsDemo,
+		Logger:     cfg.Logger,
+		BaseURL:    cfg.BaseURL,
+		HTTPClient: cfg.HTTPClient,
+		Key:        cfg.Key,
+		Secret:     cfg.Secret,
+		Passphrase: cfg.Passphrase,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &FundingClient{
+		OKXRestClient: cli,
+		validate:      validator,
+	}, nil
+}
+
+// GetCurrencies retrieves a list of all currencies available which are related to the current account's KYC entity.
+func (f *FundingClient) GetCurrencies(ctx context.Context, param types.GetCurrenciesParam) (*types.GetCurrenciesResp, error) {
+	err := f.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   f.GetDebug(),
+		BaseURL: f.GetBaseURL(),
+		Path:    "/api/v5/asset/currencies",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := f.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := f.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetCurrenciesResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetBalances retrieves the funding account balances of all the assets and the amount that is available or on hold.
+func (f *FundingClient) GetBalances(ctx context.Context, param types.GetBalancesParam) (*types.GetBalancesResp, error) {
+	err := f.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   f.GetDebug(),
+		BaseURL: f.GetBaseURL(),
+		Path:    "/api/v5/asset/balances",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := f.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := f.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetBalancesResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// FundsTransfer transfers funds between your funding account and trading account, and from the master account to sub-accounts.
+func (f *FundingClient) FundsTransfer(ctx context.Context, param types.FundsTransferParam) (*types.FundsTransferResp, error) {
+	err := f.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   f.GetDebug(),
+		BaseURL: f.GetBaseURL(),
+		Path:    "/api/v5/asset/transfer",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	headers, err := f.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := f.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.FundsTransferResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetTransferState retrieves the transfer state data of the last 2 weeks.
+func (f *FundingClient) GetTransferState(ctx context.Context, param types.GetTransferStateParam) (*types.GetTransferStateResp, error) {
+	err := f.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   f.GetDebug(),
+		BaseURL: f.GetBaseURL(),
+		Path:    "/api/v5/asset/transfer-state",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := f.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := f.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetTransferStateResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetDepositAddress retrieves the deposit addresses of currencies, including previously-used addresses.
+func (f *FundingClient) GetDepositAddress(ctx context.Context, param types.GetDepositAddressParam) (*types.GetDepositAddressResp, error) {
+	err := f.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   f.GetDebug(),
+		BaseURL: f.GetBaseURL(),
+		Path:    "/api/v5/asset/deposit-address",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := f.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := f.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetDepositAddressResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetDepositHistory retrieves the deposit records according to the currency, deposit status, and time range in reverse chronological order.
+func (f *FundingClient) GetDepositHistory(ctx context.Context, param types.GetDepositHistoryParam) (*types.GetDepositHistoryResp, error) {
+	err := f.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   f.GetDebug(),
+		BaseURL: f.GetBaseURL(),
+		Path:    "/api/v5/asset/deposit-history",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := f.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := f.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetDepositHistoryResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// Withdrawal withdraws assets to an on-chain address or to another OKX account. Withdrawal of sub-account is not supported.
+func (f *FundingClient) Withdrawal(ctx context.Context, param types.WithdrawalParam) (*types.WithdrawalResp, error) {
+	err := f.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   f.GetDebug(),
+		BaseURL: f.GetBaseURL(),
+		Path:    "/api/v5/asset/withdrawal",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	headers, err := f.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := f.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.WithdrawalResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// CancelWithdrawal cancels a normal withdrawal request, but it cannot cancel withdrawal requests on Lightning.
+func (f *FundingClient) CancelWithdrawal(ctx context.Context, param types.CancelWithdrawalParam) (*types.CancelWithdrawalResp, error) {
+	err := f.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   f.GetDebug(),
+		BaseURL: f.GetBaseURL(),
+		Path:    "/api/v5/asset/cancel-withdrawal",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	headers, err := f.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := f.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.CancelWithdrawalResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetWithdrawalHistory retrieves the withdrawal records according to the currency, withdrawal status, and time range in reverse chronological order.
+func (f *FundingClient) GetWithdrawalHistory(ctx context.Context, param types.GetWithdrawalHistoryParam) (*types.GetWithdrawalHistoryResp, error) {
+	err := f.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   f.GetDebug(),
+		BaseURL: f.GetBaseURL(),
+		Path:    "/api/v5/asset/withdrawal-history",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := f.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := f.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetWithdrawalHistoryResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
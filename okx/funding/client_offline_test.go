@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package funding
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/rluisr/nexapi/okx/funding/types"
+	"github.com/rluisr/nexapi/okx/okxtest"
+	"github.com/rluisr/nexapi/utils/fakeserver"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNewFakeFundingClient(t *testing.T, secret string) (*FundingClient, *fakeserver.Server) {
+	srv := okxtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	cli, err := NewFundingClient(&FundingClientCfg{
+		BaseURL:    srv.URL,
+		Key:        okxtest.Key,
+		Secret:     secret,
+		Passphrase: okxtest.Passphrase,
+		HTTPClient: srv.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Could not create okx private client, %s", err)
+	}
+
+	return cli, srv
+}
+
+func TestFakeEndpoints(t *testing.T) {
+	cli, srv := testNewFakeFundingClient(t, okxtest.Secret)
+
+	fakeserver.RunEndpoints(t, srv, true, []fakeserver.Endpoint{
+		{Name: "GetCurrencies", Method: http.MethodGet, Path: "/api/v5/asset/currencies", Call: func(ctx context.Context) (any, error) {
+			return cli.GetCurrencies(ctx, types.GetCurrenciesParam{})
+		}},
+		{Name: "GetBalances", Method: http.MethodGet, Path: "/api/v5/asset/balances", Call: func(ctx context.Context) (any, error) {
+			return cli.GetBalances(ctx, types.GetBalancesParam{})
+		}},
+		{Name: "FundsTransfer", Method: http.MethodPost, Path: "/api/v5/asset/transfer", Call: func(ctx context.Context) (any, error) {
+			return cli.FundsTransfer(ctx, types.FundsTransferParam{Ccy: "USDT", Amt: "10", From: types.FundingAccount, To: types.TradingAccount})
+		}},
+		{Name: "GetTransferState", Method: http.MethodGet, Path: "/api/v5/asset/transfer-state", Call: func(ctx context.Context) (any, error) {
+			return cli.GetTransferState(ctx, types.GetTransferStateParam{TransID: "1"})
+		}},
+		{Name: "GetDepositAddress", Method: http.MethodGet, Path: "/api/v5/asset/deposit-address", Call: func(ctx context.Context) (any, error) {
+			return cli.GetDepositAddress(ctx, types.GetDepositAddressParam{Ccy: "USDT"})
+		}},
+		{Name: "GetDepositHistory", Method: http.MethodGet, Path: "/api/v5/asset/deposit-history", Call: func(ctx context.Context) (any, error) {
+			return cli.GetDepositHistory(ctx, types.GetDepositHistoryParam{})
+		}},
+		{Name: "Withdrawal", Method: http.MethodPost, Path: "/api/v5/asset/withdrawal", Call: func(ctx context.Context) (any, error) {
+			return cli.Withdrawal(ctx, types.WithdrawalParam{Ccy: "USDT", Amt: "10", Dest: "3", ToAddr: "user@example.com", Fee: "0"})
+		}},
+		{Name: "CancelWithdrawal", Method: http.MethodPost, Path: "/api/v5/asset/cancel-withdrawal", Call: func(ctx context.Context) (any, error) {
+			return cli.CancelWithdrawal(ctx, types.CancelWithdrawalParam{WdID: "1"})
+		}},
+		{Name: "GetWithdrawalHistory", Method: http.MethodGet, Path: "/api/v5/asset/withdrawal-history", Call: func(ctx context.Context) (any, error) {
+			return cli.GetWithdrawalHistory(ctx, types.GetWithdrawalHistoryParam{})
+		}},
+	})
+}
+
+func TestFakeFundsTransfer(t *testing.T) {
+	cli, srv := testNewFakeFundingClient(t, okxtest.Secret)
+
+	srv.Handle(http.MethodPost, "/api/v5/asset/transfer", `{"code":"0","msg":"","data":[{"transId":"754147","ccy":"USDT","clientId":"t1","from":"6","amt":"10","to":"18"}]}`)
+
+	resp, err := cli.FundsTransfer(context.Background(), types.FundsTransferParam{Ccy: "USDT", Amt: "10", From: types.FundingAccount, To: types.TradingAccount, ClientID: "t1"})
+	assert.Nil(t, err)
+	assert.Nil(t, resp.Err())
+	assert.Equal(t, "754147", resp.Data[0].TransID)
+
+	var body types.FundsTransferParam
+	assert.Nil(t, srv.LastRequest().JSON(&body))
+	assert.Equal(t, types.FundingAccount, body.From)
+	assert.Equal(t, "t1", body.ClientID)
+
+	// either transId or clientId is required
+	n := len(srv.Requests())
+	_, err = cli.GetTransferState(context.Background(), types.GetTransferStateParam{})
+	assert.NotNil(t, err)
+	assert.Len(t, srv.Requests(), n)
+}
+
+func TestFakeInvalidSignature(t *testing.T) {
+	cli, _ := testNewFakeFundingClient(t, "wrong-secret")
+
+	_, err := cli.GetBalances(context.Background(), types.GetBalancesParam{})
+	okxtest.AssertInvalidSignature(t, err)
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package funding
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/rluisr/nexapi/okx/funding/types"
+	"github.com/rluisr/nexapi/okx/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNewFundingClient(t *testing.T) *FundingClient {
+	cli, err := NewFundingClient(&FundingClientCfg{
+		Debug:      true,
+		BaseURL:    utils.RestURL,
+		Key:        os.Getenv("OKX_KEY"),
+		Secret:     os.Getenv("OKX_SECRET"),
+		Passphrase: os.Getenv("OKX_PASS"),
+	})
+
+	if err != nil {
+		t.Fatalf("Could not create okx funding client, %s", err)
+	}
+
+	return cli
+}
+
+func TestGetCurrencies(t *testing.T) {
+	cli := testNewFundingClient(t)
+
+	_, err := cli.GetCurrencies(context.TODO(), types.GetCurrenciesParam{
+		Ccy: "BTC",
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetBalances(t *testing.T) {
+	cli := testNewFundingClient(t)
+
+	_, err := cli.GetBalances(context.TODO(), types.GetBalancesParam{})
+	assert.Nil(t, err)
+}
+
+// TestFundsTransfer moves 1 USDT of the account, it only runs with OKX_TRANSFER=1.
+func TestFundsTransfer(t *testing.T) {
+	if os.Getenv("OKX_TRANSFER") != "1" {
+		t.Skip("set OKX_TRANSFER=1 to transfer funds on the live API")
+	}
+
+	cli := testNewFundingClient(t)
+
+	resp, err := cli.FundsTransfer(context.TODO(), types.FundsTransferParam{
+		Ccy:  "USDT",
+		Amt:  "1",
+		From: types.FundingAccount,
+		To:   types.TradingAccount,
+	})
+	assert.Nil(t, err)
+
+	if err == nil && len(resp.Data) > 0 {
+		_, err = cli.GetTransferState(context.TODO(), types.GetTransferStateParam{
+			TransID: resp.Data[0].TransID,
+		})
+		assert.Nil(t, err)
+	}
+}
+
+func TestGetDepositAddress(t *testing.T) {
+	cli := testNewFundingClient(t)
+
+	_, err := cli.GetDepositAddress(context.TODO(), types.GetDepositAddressParam{
+		Ccy: "USDT",
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetDepositHistory(t *testing.T) {
+	cli := testNewFundingClient(t)
+
+	_, err := cli.GetDepositHistory(context.TODO(), types.GetDepositHistoryParam{})
+	assert.Nil(t, err)
+}
+
+func TestGetWithdrawalHistory(t *testing.T) {
+	cli := testNewFundingClient(t)
+
+	_, err := cli.GetWithdrawalHistory(context.TODO(), types.GetWithdrawalHistoryParam{})
+	assert.Nil(t, err)
+}
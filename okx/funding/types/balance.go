@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	okxutils "github.com/rluisr/nexapi/okx/utils"
+	"github.com/rluisr/nexapi/utils/decimal"
+)
+
+type GetBalancesParam struct {
+	Ccy string `url:"ccy,omitempty"` // Single currency or multiple currencies (no more than 20) separated with comma, e.g. BTC or BTC,ETH
+}
+
+type GetBalancesResp struct {
+	okxutils.Response
+	Data []*Balance `json:"data"`
+}
+
+// Balance of the funding account
+type Balance struct {
+	Ccy       string `json:"ccy"`
+	Bal       string `json:"bal"`
+	FrozenBal string `json:"frozenBal"`
+	AvailBal  string `json:"availBal"`
+}
+
+func (b *Balance) BalDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(b.Bal)
+}
+
+func (b *Balance) FrozenBalDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(b.FrozenBal)
+}
+
+func (b *Balance) AvailBalDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(b.AvailBal)
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import okxutils "github.com/rluisr/nexapi/okx/utils"
+
+type GetCurrenciesParam struct {
+	Ccy string `url:"ccy,omitempty"` // Single currency or multiple currencies separated with comma, e.g. BTC or BTC,ETH
+}
+
+type GetCurrenciesResp struct {
+	okxutils.Response
+	Data []*Currency `json:"data"`
+}
+
+// Currency
+// doc: https://www.okx.com/docs-v5/en/#funding-account-rest-api-get-currencies
+type Currency struct {
+	Ccy                  string `json:"ccy"`
+	Name                 string `json:"name"`
+	LogoLink             string `json:"logoLink"`
+	Chain                string `json:"chain"` // Chain name, e.g. USDT-ERC20, USDT-TRC20
+	CanDep               bool   `json:"canDep"`
+	CanWd                bool   `json:"canWd"`
+	CanInternal          bool   `json:"canInternal"`
+	MinDep               string `json:"minDep"`
+	MinWd                string `json:"minWd"`
+	MaxWd                string `json:"maxWd"`
+	WdTickSz             string `json:"wdTickSz"`
+	WdQuota              string `json:"wdQuota"`     // The withdrawal limit in the past 24 hours (including on-chain withdrawal and internal transfer), unit in USD
+	UsedWdQuota          string `json:"usedWdQuota"` // The amount of currency withdrawal used in the past 24 hours, unit in USD
+	MinFee               string `json:"minFee"`
+	MaxFee               string `json:"maxFee"`
+	MainNet              bool   `json:"mainNet"`
+	NeedTag              bool   `json:"needTag"`
+	MinDepArrivalConfirm string `json:"minDepArrivalConfirm"`
+	MinWdUnlockConfirm   string `json:"minWdUnlockConfirm"`
+	DepQuotaFixed        string `json:"depQuotaFixed"`
+	UsedDepQuotaFixed    string `json:"usedDepQuotaFixed"`
+	DepQuoteDailyLayer2  string `json:"depQuoteDailyLayer2"`
+}
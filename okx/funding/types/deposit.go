@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import okxutils "github.com/rluisr/nexapi/okx/utils"
+
+type GetDepositAddressParam struct {
+	Ccy string `url:"ccy" validate:"required"`
+}
+
+type GetDepositAddressResp struct {
+	okxutils.Response
+	Data []*DepositAddress `json:"data"`
+}
+
+type DepositAddress struct {
+	Addr     string            `json:"addr"`
+	Tag      string            `json:"tag"`
+	Memo     string            `json:"memo"`
+	PmtID    string            `json:"pmtId"`
+	AddrEx   map[string]string `json:"addrEx"`
+	Ccy      string            `json:"ccy"`
+	Chain    string            `json:"chain"`
+	To       string            `json:"to"` // The beneficiary account, 6: Funding account, 18: Trading account
+	Selected bool              `json:"selected"`
+	CtAddr   string            `json:"ctAddr"`
+}
+
+type GetDepositHistoryParam struct {
+	Ccy      string `url:"ccy,omitempty"`
+	DepID    string `url:"depId,omitempty"`
+	FromWdID string `url:"fromWdId,omitempty"` // Internal transfer initiator's withdrawal ID
+	TxID     string `url:"txId,omitempty"`
+	Type     string `url:"type,omitempty" validate:"omitempty,oneof=3 4"` // Deposit Type, 3: internal transfer, 4: deposit from chain
+	State    string `url:"state,omitempty"`                               // Status of deposit, 0: waiting for confirmation, 1: deposit credited, 2: deposit successful, 8: pending due to temporary deposit suspension on this crypto currency ...
+	After    string `url:"after,omitempty"`                               // Pagination of data to return records earlier than the requested ts, Unix timestamp format in milliseconds
+	Before   string `url:"before,omitempty"`                              // Pagination of data to return records newer than the requested ts, Unix timestamp format in milliseconds
+	Limit    string `url:"limit,omitempty"`                               // Number of results per request. The maximum is 100; The default is 100
+}
+
+type GetDepositHistoryResp struct {
+	okxutils.Response
+	Data []*Deposit `json:"data"`
+}
+
+type Deposit struct {
+	Ccy                 string `json:"ccy"`
+	Chain               string `json:"chain"`
+	Amt                 string `json:"amt"`
+	From                string `json:"from"`
+	AreaCodeFrom        string `json:"areaCodeFrom"`
+	To                  string `json:"to"`
+	TxID                string `json:"txId"`
+	TS                  string `json:"ts"`
+	State               string `json:"state"`
+	DepID               string `json:"depId"`
+	FromWdID            string `json:"fromWdId"`
+	ActualDepBlkConfirm string `json:"actualDepBlkConfirm"`
+}
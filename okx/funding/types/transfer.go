@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import okxutils "github.com/rluisr/nexapi/okx/utils"
+
+type AccountType = string
+
+const (
+	FundingAccount AccountType = "6"
+	TradingAccount AccountType = "18"
+)
+
+type TransferType = string
+
+const (
+	TransferWithinAccount  TransferType = "0"
+	TransferMasterToSub    TransferType = "1"
+	TransferSubToMaster    TransferType = "2" // Only applicable to APIKey from the master account
+	TransferSubToMasterSub TransferType = "3" // Only applicable to APIKey from the sub-account
+	TransferSubToSub       TransferType = "4" // Only applicable to APIKey from the sub-account, and target account needs to be another sub-account which belongs to same master account
+)
+
+type FundsTransferParam struct {
+	Ccy         string       `json:"ccy" validate:"required"`
+	Amt         string       `json:"amt" validate:"required"`
+	From        AccountType  `json:"from" validate:"required,oneof=6 18"`                         // The remitting account, 6: Funding account, 18: Trading account
+	To          AccountType  `json:"to" validate:"required,oneof=6 18"`                           // The beneficiary account, 6: Funding account, 18: Trading account
+	SubAcct     string       `json:"subAcct,omitempty"`                                           // Name of the sub-account. When type is 1/2/4, this parameter is required.
+	Type        TransferType `json:"type,omitempty" validate:"omitempty,oneof=0 1 2 3 4"`         // Transfer type, the default is 0
+	LoanTrans   bool         `json:"loanTrans,omitempty"`                                         // Whether or not borrowed coins can be transferred out under Multi-currency margin and Portfolio margin
+	OmitPosRisk string       `json:"omitPosRisk,omitempty" validate:"omitempty,oneof=true false"` // Ignore position risk
+	ClientID    string       `json:"clientId,omitempty"`                                          // Client-supplied ID, a combination of case-sensitive alphanumerics, all numbers, or all letters of up to 32 characters
+}
+
+type FundsTransferResp struct {
+	okxutils.Response
+	Data []*FundsTransferResult `json:"data"`
+}
+
+type FundsTransferResult struct {
+	TransID  string `json:"transId"`
+	ClientID string `json:"clientId"`
+	Ccy      string `json:"ccy"`
+	From     string `json:"from"`
+	Amt      string `json:"amt"`
+	To       string `json:"to"`
+}
+
+type GetTransferStateParam struct {
+	TransID  string       `url:"transId,omitempty" validate:"required_without=ClientID"` // Either transId or clientId is required. If both are passed, transId will be used.
+	ClientID string       `url:"clientId,omitempty" validate:"required_without=TransID"`
+	Type     TransferType `url:"type,omitempty" validate:"omitempty,oneof=0 1 2 3 4"`
+}
+
+type GetTransferStateResp struct {
+	okxutils.Response
+	Data []*TransferState `json:"data"`
+}
+
+type TransferState struct {
+	TransID  string `json:"transId"`
+	ClientID string `json:"clientId"`
+	Ccy      string `json:"ccy"`
+	Amt      string `json:"amt"`
+	Type     string `json:"type"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	SubAcct  string `json:"subAcct"`
+	State    string `json:"state"` // Transfer state, success, pending, failed
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import okxutils "github.com/rluisr/nexapi/okx/utils"
+
+type WithdrawalParam struct {
+	Ccy      string `json:"ccy" validate:"required"`
+	Amt      string `json:"amt" validate:"required"`            // Withdrawal amount, withdrawal fee is not included
+	Dest     string `json:"dest" validate:"required,oneof=3 4"` // Withdrawal method, 3: internal transfer, 4: on-chain withdrawal
+	ToAddr   string `json:"toAddr" validate:"required"`         // If your dest is 4, toAddr should be a trusted crypto currency address. If your dest is 3, toAddr should be a recipient address which can be email, phone or login account name.
+	Fee      string `json:"fee" validate:"required"`            // Transaction fee, set 0 for internal transfer
+	Chain    string `json:"chain,omitempty"`                    // Chain name, e.g. USDT-ERC20, USDT-TRC20
+	AreaCode string `json:"areaCode,omitempty"`                 // Area code for the phone number, e.g. 86
+	ClientID string `json:"clientId,omitempty"`
+}
+
+type WithdrawalResp struct {
+	okxutils.Response
+	Data []*WithdrawalResult `json:"data"`
+}
+
+type WithdrawalResult struct {
+	Ccy      string `json:"ccy"`
+	Chain    string `json:"chain"`
+	Amt      string `json:"amt"`
+	WdID     string `json:"wdId"`
+	ClientID string `json:"clientId"`
+}
+
+type CancelWithdrawalParam struct {
+	WdID string `json:"wdId" validate:"required"`
+}
+
+type CancelWithdrawalResp struct {
+	okxutils.Response
+	Data []struct {
+		WdID string `json:"wdId"`
+	} `json:"data"`
+}
+
+type GetWithdrawalHistoryParam struct {
+	Ccy      string `url:"ccy,omitempty"`
+	WdID     string `url:"wdId,omitempty"`
+	ClientID string `url:"clientId,omitempty"`
+	TxID     string `url:"txId,omitempty"`
+	Type     string `url:"type,omitempty" validate:"omitempty,oneof=3 4"` // Withdrawal type, 3: internal transfer, 4: on-chain withdrawal
+	State    string `url:"state,omitempty"`                               // Status of withdrawal, -3: canceling, -2: canceled, -1: failed, 0: waiting withdrawal, 1: withdrawing, 2: withdraw success ...
+	After    string `url:"after,omitempty"`                               // Pagination of data to return records earlier than the requested ts, Unix timestamp format in milliseconds
+	Before   string `url:"before,omitempty"`                              // Pagination of data to return records newer than the requested ts, Unix timestamp format in milliseconds
+	Limit    string `url:"limit,omitempty"`                               // Number of results per request. The maximum is 100; The default is 100
+}
+
+type GetWithdrawalHistoryResp struct {
+	okxutils.Response
+	Data []*Withdrawal `json:"data"`
+}
+
+type Withdrawal struct {
+	Ccy              string            `json:"ccy"`
+	Chain            string            `json:"chain"`
+	NonTradableAsset bool              `json:"nonTradableAsset"`
+	Amt              string            `json:"amt"`
+	TS               string            `json:"ts"`
+	From             string            `json:"from"`
+	AreaCodeFrom     string            `json:"areaCodeFrom"`
+	To               string            `json:"to"`
+	AreaCodeTo       string            `json:"areaCodeTo"`
+	Tag              string            `json:"tag"`
+	PmtID            string            `json:"pmtId"`
+	Memo             string            `json:"memo"`
+	AddrEx           map[string]string `json:"addrEx"`
+	TxID             string            `json:"txId"`
+	Fee              string            `json:"fee"`
+	FeeCcy           string            `json:"feeCcy"`
+	State            string            `json:"state"`
+	WdID             string            `json:"wdId"`
+	ClientID         string            `json:"clientId"`
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package okxtest provides a fake OKX server for offline tests.
+package okxtest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rluisr/nexapi/utils/fakeserver"
+	"github.com/stretchr/testify/assert"
+)
+
+// The credentials accepted by the server.
+const (
+	Key        = "okx-test-key"
+	Secret     = "okx-test-secret"
+	Passphrase = "okx-test-passphrase"
+)
+
+// Success is the response envelope returned for the routes without fixture.
+const Success = `{"code":"0","msg":"","data":[]}`
+
+// InvalidSignCode is the error code of the requests rejected by Verify.
+const InvalidSignCode = "50113"
+
+// NewServer starts a server that checks the signed requests with Key, Secret and Passphrase.
+func NewServer() *fakeserver.Server {
+	return fakeserver.New(fakeserver.Config{
+		Verify: Verify,
+		Unauthorized: fakeserver.Response{
+			Status: http.StatusUnauthorized,
+			Body:   `{"code":"` + InvalidSignCode + `","msg":"%s","data":[]}`,
+		},
+		Default: fakeserver.Response{Status: http.StatusOK, Body: Success},
+	})
+}
+
+// Verify checks the OK-ACCESS-* headers. The signature is the base64 HMAC-SHA256 of the
+// timestamp, the method, the request path with its query and the body.
+// A request without OK-ACCESS-KEY header is not signed.
+func Verify(r *http.Request, body []byte) (bool, error) {
+	key := r.Header.Get("OK-ACCESS-KEY")
+	if key == "" {
+		return false, nil
+	}
+
+	if key != Key {
+		return true, errors.New("invalid OK-ACCESS-KEY")
+	}
+
+	if r.Header.Get("OK-ACCESS-PASSPHRASE") != Passphrase {
+		return true, errors.New("invalid OK-ACCESS-PASSPHRASE")
+	}
+
+	timestamp := r.Header.Get("OK-ACCESS-TIMESTAMP")
+	if _, err := time.Parse(time.RFC3339, timestamp); err != nil {
+		return true, errors.New("invalid OK-ACCESS-TIMESTAMP")
+	}
+
+	path := r.URL.Path
+	if r.URL.RawQuery != "" {
+		path += "?" + r.URL.RawQuery
+	}
+
+	h := hmac.New(sha256.New, []byte(Secret))
+	h.Write([]byte(timestamp + r.Method + path + string(body)))
+	expected := base64.StdEncoding.EncodeToString(h.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(r.Header.Get("OK-ACCESS-SIGN"))) {
+		return true, errors.New("invalid sign")
+	}
+
+	return true, nil
+}
+
+// AssertInvalidSignature checks that err reports a request rejected by Verify.
+func AssertInvalidSignature(t *testing.T, err error) {
+	t.Helper()
+	assert.ErrorContains(t, err, InvalidSignCode)
+}
@@ -19,6 +19,7 @@ package orderbookaccount
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
 
@@ -26,6 +27,7 @@ import (
 	"github.com/rluisr/nexapi/okx/orderbookaccount/types"
 	okxutils "github.com/rluisr/nexapi/okx/utils"
 	"github.com/rluisr/nexapi/utils"
+	"github.com/rluisr/nexapi/utils/pagination"
 )
 
 type OrderBookAccountClient struct {
@@ -140,3 +142,737 @@ func (o *OrderBookAccountClient) PlaceOrder(ctx context.Context, param types.Pla
 
 	return &body, nil
 }
+
+func (o *OrderBookAccountClient) CancelOrder(ctx context.Context, param types.CancelOrderParam) (*types.CancelOrderResp, error) {
+	err := o.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   o.GetDebug(),
+		BaseURL: o.GetBaseURL(),
+		Path:    "/api/v5/trade/cancel-order",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	headers, err := o.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := o.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.CancelOrderResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// AmendOrder amends the size or price of an incomplete order.
+func (o *OrderBookAccountClient) AmendOrder(ctx context.Context, param types.AmendOrderParam) (*types.AmendOrderResp, error) {
+	err := o.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   o.GetDebug(),
+		BaseURL: o.GetBaseURL(),
+		Path:    "/api/v5/trade/amend-order",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	headers, err := o.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := o.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.AmendOrderResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// PlaceBatchOrders places up to 20 orders in a single request.
+func (o *OrderBookAccountClient) PlaceBatchOrders(ctx context.Context, params []types.PlaceOrderParam) (*types.PlaceOrderResp, error) {
+	if len(params) == 0 || len(params) > 20 {
+		return nil, fmt.Errorf("the number of orders must be between 1 and 20, got %d", len(params))
+	}
+
+	for _, param := range params {
+		err := o.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   o.GetDebug(),
+		BaseURL: o.GetBaseURL(),
+		Path:    "/api/v5/trade/batch-orders",
+		Method:  http.MethodPost,
+		Body:    params,
+	}
+
+	headers, err := o.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := o.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.PlaceOrderResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// CancelBatchOrders cancels up to 20 incomplete orders in a single request.
+func (o *OrderBookAccountClient) CancelBatchOrders(ctx context.Context, params []types.CancelOrderParam) (*types.CancelOrderResp, error) {
+	if len(params) == 0 || len(params) > 20 {
+		return nil, fmt.Errorf("the number of orders must be between 1 and 20, got %d", len(params))
+	}
+
+	for _, param := range params {
+		err := o.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   o.GetDebug(),
+		BaseURL: o.GetBaseURL(),
+		Path:    "/api/v5/trade/cancel-batch-orders",
+		Method:  http.MethodPost,
+		Body:    params,
+	}
+
+	headers, err := o.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := o.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.CancelOrderResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// AmendBatchOrders amends up to 20 incomplete orders in a single request.
+func (o *OrderBookAccountClient) AmendBatchOrders(ctx context.Context, params []types.AmendOrderParam) (*types.AmendOrderResp, error) {
+	if len(params) == 0 || len(params) > 20 {
+		return nil, fmt.Errorf("the number of orders must be between 1 and 20, got %d", len(params))
+	}
+
+	for _, param := range params {
+		err := o.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   o.GetDebug(),
+		BaseURL: o.GetBaseURL(),
+		Path:    "/api/v5/trade/amend-batch-orders",
+		Method:  http.MethodPost,
+		Body:    params,
+	}
+
+	headers, err := o.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := o.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.AmendOrderResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetOrdersPending retrieves all incomplete orders under the current account.
+func (o *OrderBookAccountClient) GetOrdersPending(ctx context.Context, param types.GetOrdersPendingParam) (*types.GetOrdersResp, error) {
+	err := o.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   o.GetDebug(),
+		BaseURL: o.GetBaseURL(),
+		Path:    "/api/v5/trade/orders-pending",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := o.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := o.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetOrdersResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetOrdersHistory retrieves the completed orders of the last 7 days.
+func (o *OrderBookAccountClient) GetOrdersHistory(ctx context.Context, param types.GetOrdersHistoryParam) (*types.GetOrdersResp, error) {
+	err := o.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   o.GetDebug(),
+		BaseURL: o.GetBaseURL(),
+		Path:    "/api/v5/trade/orders-history",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := o.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := o.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetOrdersResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetOrdersHistoryArchive retrieves the completed orders of the last 3 months.
+func (o *OrderBookAccountClient) GetOrdersHistoryArchive(ctx context.Context, param types.GetOrdersHistoryParam) (*types.GetOrdersResp, error) {
+	err := o.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   o.GetDebug(),
+		BaseURL: o.GetBaseURL(),
+		Path:    "/api/v5/trade/orders-history-archive",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := o.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := o.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetOrdersResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetFills retrieves the transaction details of the last 3 days.
+func (o *OrderBookAccountClient) GetFills(ctx context.Context, param types.GetFillsParam) (*types.GetFillsResp, error) {
+	err := o.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   o.GetDebug(),
+		BaseURL: o.GetBaseURL(),
+		Path:    "/api/v5/trade/fills",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := o.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := o.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetFillsResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetFillsHistory retrieves the transaction details of the last 3 months.
+func (o *OrderBookAccountClient) GetFillsHistory(ctx context.Context, param types.GetFillsParam) (*types.GetFillsResp, error) {
+	if param.InstType == "" {
+		return nil, fmt.Errorf("instType is required")
+	}
+
+	err := o.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   o.GetDebug(),
+		BaseURL: o.GetBaseURL(),
+		Path:    "/api/v5/trade/fills-history",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := o.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := o.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetFillsResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// ClosePosition closes the position of an instrument via a market order.
+func (o *OrderBookAccountClient) ClosePosition(ctx context.Context, param types.ClosePositionParam) (*types.ClosePositionResp, error) {
+	err := o.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   o.GetDebug(),
+		BaseURL: o.GetBaseURL(),
+		Path:    "/api/v5/trade/close-position",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	headers, err := o.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := o.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.ClosePositionResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// PlaceConditionalOrder places a one-way take-profit and/or stop-loss order.
+func (o *OrderBookAccountClient) PlaceConditionalOrder(ctx context.Context, param types.ConditionalOrderParam) (*types.PlaceAlgoOrderResp, error) {
+	param.OrdType = types.AlgoConditional
+	return o.placeAlgoOrder(ctx, param)
+}
+
+// PlaceOCOOrder places a one-cancels-the-other take-profit and stop-loss order.
+func (o *OrderBookAccountClient) PlaceOCOOrder(ctx context.Context, param types.OCOOrderParam) (*types.PlaceAlgoOrderResp, error) {
+	param.OrdType = types.AlgoOCO
+	return o.placeAlgoOrder(ctx, param)
+}
+
+// PlaceTriggerOrder places an order once the trigger price is reached.
+func (o *OrderBookAccountClient) PlaceTriggerOrder(ctx context.Context, param types.TriggerOrderParam) (*types.PlaceAlgoOrderResp, error) {
+	param.OrdType = types.AlgoTrigger
+	return o.placeAlgoOrder(ctx, param)
+}
+
+// PlaceTrailingStopOrder places a trailing stop order.
+func (o *OrderBookAccountClient) PlaceTrailingStopOrder(ctx context.Context, param types.TrailingStopOrderParam) (*types.PlaceAlgoOrderResp, error) {
+	param.OrdType = types.AlgoMoveOrdStop
+	return o.placeAlgoOrder(ctx, param)
+}
+
+// PlaceIcebergOrder places an iceberg order.
+func (o *OrderBookAccountClient) PlaceIcebergOrder(ctx context.Context, param types.IcebergOrderParam) (*types.PlaceAlgoOrderResp, error) {
+	param.OrdType = types.AlgoIceberg
+	return o.placeAlgoOrder(ctx, param)
+}
+
+// PlaceTWAPOrder places a time-weighted average price order.
+func (o *OrderBookAccountClient) PlaceTWAPOrder(ctx context.Context, param types.TWAPOrderParam) (*types.PlaceAlgoOrderResp, error) {
+	param.OrdType = types.AlgoTWAP
+	return o.placeAlgoOrder(ctx, param)
+}
+
+func (o *OrderBookAccountClient) placeAlgoOrder(ctx context.Context, param any) (*types.PlaceAlgoOrderResp, error) {
+	err := o.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   o.GetDebug(),
+		BaseURL: o.GetBaseURL(),
+		Path:    "/api/v5/trade/order-algo",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	headers, err := o.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := o.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.PlaceAlgoOrderResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// CancelAlgoOrders cancels up to 10 incomplete algo orders of any type.
+func (o *OrderBookAccountClient) CancelAlgoOrders(ctx context.Context, params []types.CancelAlgoOrderParam) (*types.CancelAlgoOrdersResp, error) {
+	if len(params) == 0 || len(params) > 10 {
+		return nil, fmt.Errorf("the number of algo orders must be between 1 and 10, got %d", len(params))
+	}
+
+	for _, param := range params {
+		err := o.validate.Struct(param)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   o.GetDebug(),
+		BaseURL: o.GetBaseURL(),
+		Path:    "/api/v5/trade/cancel-algos",
+		Method:  http.MethodPost,
+		Body:    params,
+	}
+
+	headers, err := o.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := o.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.CancelAlgoOrdersResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// AmendAlgoOrder amends an incomplete conditional or OCO order.
+func (o *OrderBookAccountClient) AmendAlgoOrder(ctx context.Context, param types.AmendAlgoOrderParam) (*types.AmendAlgoOrderResp, error) {
+	err := o.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   o.GetDebug(),
+		BaseURL: o.GetBaseURL(),
+		Path:    "/api/v5/trade/amend-algos",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	headers, err := o.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := o.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.AmendAlgoOrderResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+func (o *OrderBookAccountClient) GetAlgoOrder(ctx context.Context, param types.GetAlgoOrderParam) (*types.GetAlgoOrdersResp, error) {
+	err := o.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   o.GetDebug(),
+		BaseURL: o.GetBaseURL(),
+		Path:    "/api/v5/trade/order-algo",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := o.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := o.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetAlgoOrdersResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetAlgoOrdersPending retrieves the incomplete algo orders of a type.
+func (o *OrderBookAccountClient) GetAlgoOrdersPending(ctx context.Context, param types.GetAlgoOrdersPendingParam) (*types.GetAlgoOrdersResp, error) {
+	err := o.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   o.GetDebug(),
+		BaseURL: o.GetBaseURL(),
+		Path:    "/api/v5/trade/orders-algo-pending",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := o.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := o.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetAlgoOrdersResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetAlgoOrdersHistory retrieves the algo orders of a type of the last 3 months.
+func (o *OrderBookAccountClient) GetAlgoOrdersHistory(ctx context.Context, param types.GetAlgoOrdersHistoryParam) (*types.GetAlgoOrdersResp, error) {
+	err := o.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   o.GetDebug(),
+		BaseURL: o.GetBaseURL(),
+		Path:    "/api/v5/trade/orders-algo-history",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := o.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := o.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetAlgoOrdersResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// IterateOrdersHistory walks GetOrdersHistory from param.After back to the oldest order of the last 7 days.
+func (o *OrderBookAccountClient) IterateOrdersHistory(param types.GetOrdersHistoryParam) *pagination.Iterator[string, *types.Order] {
+	return pagination.NewCursorIterator(param.After, func(ctx context.Context, after string) ([]*types.Order, error) {
+		param.After = after
+
+		resp, err := o.GetOrdersHistory(ctx, param)
+		if err != nil {
+			return nil, err
+		}
+		if err := resp.Err(); err != nil {
+			return nil, err
+		}
+
+		return resp.Data, nil
+	}, func(v *types.Order) string {
+		return v.OrdID
+	})
+}
+
+// IterateOrdersHistoryArchive walks GetOrdersHistoryArchive from param.After back to the oldest order of the last 3 months.
+func (o *OrderBookAccountClient) IterateOrdersHistoryArchive(param types.GetOrdersHistoryParam) *pagination.Iterator[string, *types.Order] {
+	return pagination.NewCursorIterator(param.After, func(ctx context.Context, after string) ([]*types.Order, error) {
+		param.After = after
+
+		resp, err := o.GetOrdersHistoryArchive(ctx, param)
+		if err != nil {
+			return nil, err
+		}
+		if err := resp.Err(); err != nil {
+			return nil, err
+		}
+
+		return resp.Data, nil
+	}, func(v *types.Order) string {
+		return v.OrdID
+	})
+}
+
+// IterateFills walks GetFills from param.After back to the oldest fill of the last 3 days.
+func (o *OrderBookAccountClient) IterateFills(param types.GetFillsParam) *pagination.Iterator[string, *types.Fill] {
+	return pagination.NewCursorIterator(param.After, func(ctx context.Context, after string) ([]*types.Fill, error) {
+		param.After = after
+
+		resp, err := o.GetFills(ctx, param)
+		if err != nil {
+			return nil, err
+		}
+		if err := resp.Err(); err != nil {
+			return nil, err
+		}
+
+		return resp.Data, nil
+	}, func(v *types.Fill) string {
+		return v.BillID
+	})
+}
+
+// IterateFillsHistory walks GetFillsHistory from param.After back to the oldest fill of the last 3 months.
+func (o *OrderBookAccountClient) IterateFillsHistory(param types.GetFillsParam) *pagination.Iterator[string, *types.Fill] {
+	return pagination.NewCursorIterator(param.After, func(ctx context.Context, after string) ([]*types.Fill, error) {
+		param.After = after
+
+		resp, err := o.GetFillsHistory(ctx, param)
+		if err != nil {
+			return nil, err
+		}
+		if err := resp.Err(); err != nil {
+			return nil, err
+		}
+
+		return resp.Data, nil
+	}, func(v *types.Fill) string {
+		return v.BillID
+	})
+}
+
+// IterateAlgoOrdersHistory walks GetAlgoOrdersHistory from param.After back to the oldest algo order of the last 3 months.
+func (o *OrderBookAccountClient) IterateAlgoOrdersHistory(param types.GetAlgoOrdersHistoryParam) *pagination.Iterator[string, *types.AlgoOrder] {
+	return pagination.NewCursorIterator(param.After, func(ctx context.Context, after string) ([]*types.AlgoOrder, error) {
+		param.After = after
+
+		resp, err := o.GetAlgoOrdersHistory(ctx, param)
+		if err != nil {
+			return nil, err
+		}
+		if err := resp.Err(); err != nil {
+			return nil, err
+		}
+
+		return resp.Data, nil
+	}, func(v *types.AlgoOrder) string {
+		return v.AlgoId
+	})
+}
@@ -0,0 +1,208 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package orderbookaccount
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/rluisr/nexapi/okx/okxtest"
+	"github.com/rluisr/nexapi/okx/orderbookaccount/types"
+	"github.com/rluisr/nexapi/okx/utils"
+	"github.com/rluisr/nexapi/utils/fakeserver"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNewFakeOrderBookAccountClient(t *testing.T, secret string) (*OrderBookAccountClient, *fakeserver.Server) {
+	srv := okxtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	cli, err := NewOrderBookAccountClient(&OrderBookAccountClientCfg{
+		BaseURL:    srv.URL,
+		Key:        okxtest.Key,
+		Secret:     secret,
+		Passphrase: okxtest.Passphrase,
+		HTTPClient: srv.Client(),
+		IsDemo:     true,
+	})
+	if err != nil {
+		t.Fatalf("Could not create okx private client, %s", err)
+	}
+
+	return cli, srv
+}
+
+func TestFakeEndpoints(t *testing.T) {
+	cli, srv := testNewFakeOrderBookAccountClient(t, okxtest.Secret)
+
+	order := types.PlaceOrderParam{InstId: "BTC-USDT", TdMode: utils.Cash, Side: utils.Buy, OrdType: utils.Limit, Sz: "0.01", Px: "42000"}
+	cancel := types.CancelOrderParam{InstId: "BTC-USDT", OrdId: "1"}
+	amend := types.AmendOrderParam{InstId: "BTC-USDT", OrdId: "1", NewSz: "0.02"}
+	algo := types.AlgoOrderBase{InstId: "BTC-USDT-SWAP", TdMode: utils.Cross, Side: utils.Sell, Sz: "1"}
+
+	fakeserver.RunEndpoints(t, srv, true, []fakeserver.Endpoint{
+		{Name: "GetOrder", Method: http.MethodGet, Path: "/api/v5/trade/order", Call: func(ctx context.Context) (any, error) {
+			return cli.GetOrder(ctx, types.GetOrderParam{InstId: "BTC-USDT", OrdId: "1"})
+		}},
+		{Name: "PlaceOrder", Method: http.MethodPost, Path: "/api/v5/trade/order", Call: func(ctx context.Context) (any, error) {
+			return cli.PlaceOrder(ctx, order)
+		}},
+		{Name: "CancelOrder", Method: http.MethodPost, Path: "/api/v5/trade/cancel-order", Call: func(ctx context.Context) (any, error) {
+			return cli.CancelOrder(ctx, cancel)
+		}},
+		{Name: "AmendOrder", Method: http.MethodPost, Path: "/api/v5/trade/amend-order", Call: func(ctx context.Context) (any, error) {
+			return cli.AmendOrder(ctx, amend)
+		}},
+		{Name: "PlaceBatchOrders", Method: http.MethodPost, Path: "/api/v5/trade/batch-orders", Call: func(ctx context.Context) (any, error) {
+			return cli.PlaceBatchOrders(ctx, []types.PlaceOrderParam{order, order})
+		}},
+		{Name: "CancelBatchOrders", Method: http.MethodPost, Path: "/api/v5/trade/cancel-batch-orders", Call: func(ctx context.Context) (any, error) {
+			return cli.CancelBatchOrders(ctx, []types.CancelOrderParam{cancel})
+		}},
+		{Name: "AmendBatchOrders", Method: http.MethodPost, Path: "/api/v5/trade/amend-batch-orders", Call: func(ctx context.Context) (any, error) {
+			return cli.AmendBatchOrders(ctx, []types.AmendOrderParam{amend})
+		}},
+		{Name: "GetOrdersPending", Method: http.MethodGet, Path: "/api/v5/trade/orders-pending", Call: func(ctx context.Context) (any, error) {
+			return cli.GetOrdersPending(ctx, types.GetOrdersPendingParam{InstType: utils.Spot})
+		}},
+		{Name: "GetOrdersHistory", Method: http.MethodGet, Path: "/api/v5/trade/orders-history", Call: func(ctx context.Context) (any, error) {
+			return cli.GetOrdersHistory(ctx, types.GetOrdersHistoryParam{InstType: utils.Spot})
+		}},
+		{Name: "GetOrdersHistoryArchive", Method: http.MethodGet, Path: "/api/v5/trade/orders-history-archive", Call: func(ctx context.Context) (any, error) {
+			return cli.GetOrdersHistoryArchive(ctx, types.GetOrdersHistoryParam{InstType: utils.Spot})
+		}},
+		{Name: "GetFills", Method: http.MethodGet, Path: "/api/v5/trade/fills", Call: func(ctx context.Context) (any, error) {
+			return cli.GetFills(ctx, types.GetFillsParam{InstType: utils.Spot})
+		}},
+		{Name: "GetFillsHistory", Method: http.MethodGet, Path: "/api/v5/trade/fills-history", Call: func(ctx context.Context) (any, error) {
+			return cli.GetFillsHistory(ctx, types.GetFillsParam{InstType: utils.Spot})
+		}},
+		{Name: "ClosePosition", Method: http.MethodPost, Path: "/api/v5/trade/close-position", Call: func(ctx context.Context) (any, error) {
+			return cli.ClosePosition(ctx, types.ClosePositionParam{InstId: "BTC-USDT-SWAP", MgnMode: utils.Cross})
+		}},
+		{Name: "PlaceConditionalOrder", Method: http.MethodPost, Path: "/api/v5/trade/order-algo", Call: func(ctx context.Context) (any, error) {
+			return cli.PlaceConditionalOrder(ctx, types.ConditionalOrderParam{AlgoOrderBase: algo, SlTriggerPx: "40000", SlOrdPx: "-1"})
+		}},
+		{Name: "PlaceOCOOrder", Method: http.MethodPost, Path: "/api/v5/trade/order-algo", Call: func(ctx context.Context) (any, error) {
+			return cli.PlaceOCOOrder(ctx, types.OCOOrderParam{AlgoOrderBase: algo, TpTriggerPx: "45000", TpOrdPx: "-1", SlTriggerPx: "40000", SlOrdPx: "-1"})
+		}},
+		{Name: "PlaceTriggerOrder", Method: http.MethodPost, Path: "/api/v5/trade/order-algo", Call: func(ctx context.Context) (any, error) {
+			return cli.PlaceTriggerOrder(ctx, types.TriggerOrderParam{AlgoOrderBase: algo, TriggerPx: "43000", OrderPx: "-1"})
+		}},
+		{Name: "PlaceTrailingStopOrder", Method: http.MethodPost, Path: "/api/v5/trade/order-algo", Call: func(ctx context.Context) (any, error) {
+			return cli.PlaceTrailingStopOrder(ctx, types.TrailingStopOrderParam{AlgoOrderBase: algo, CallbackRatio: "0.01"})
+		}},
+		{Name: "PlaceIcebergOrder", Method: http.MethodPost, Path: "/api/v5/trade/order-algo", Call: func(ctx context.Context) (any, error) {
+			return cli.PlaceIcebergOrder(ctx, types.IcebergOrderParam{AlgoOrderBase: algo, PxVar: "0.01", SzLimit: "0.1", PxLimit: "42000"})
+		}},
+		{Name: "PlaceTWAPOrder", Method: http.MethodPost, Path: "/api/v5/trade/order-algo", Call: func(ctx context.Context) (any, error) {
+			return cli.PlaceTWAPOrder(ctx, types.TWAPOrderParam{AlgoOrderBase: algo, PxSpread: "10", SzLimit: "0.1", PxLimit: "42000", TimeInterval: "60"})
+		}},
+		{Name: "CancelAlgoOrders", Method: http.MethodPost, Path: "/api/v5/trade/cancel-algos", Call: func(ctx context.Context) (any, error) {
+			return cli.CancelAlgoOrders(ctx, []types.CancelAlgoOrderParam{{InstId: "BTC-USDT-SWAP", AlgoId: "1"}})
+		}},
+		{Name: "AmendAlgoOrder", Method: http.MethodPost, Path: "/api/v5/trade/amend-algos", Call: func(ctx context.Context) (any, error) {
+			return cli.AmendAlgoOrder(ctx, types.AmendAlgoOrderParam{InstId: "BTC-USDT-SWAP", AlgoId: "1", NewSz: "2"})
+		}},
+		{Name: "GetAlgoOrder", Method: http.MethodGet, Path: "/api/v5/trade/order-algo", Call: func(ctx context.Context) (any, error) {
+			return cli.GetAlgoOrder(ctx, types.GetAlgoOrderParam{AlgoId: "1"})
+		}},
+		{Name: "GetAlgoOrdersPending", Method: http.MethodGet, Path: "/api/v5/trade/orders-algo-pending", Call: func(ctx context.Context) (any, error) {
+			return cli.GetAlgoOrdersPending(ctx, types.GetAlgoOrdersPendingParam{OrdType: types.AlgoConditional})
+		}},
+		{Name: "GetAlgoOrdersHistory", Method: http.MethodGet, Path: "/api/v5/trade/orders-algo-history", Call: func(ctx context.Context) (any, error) {
+			return cli.GetAlgoOrdersHistory(ctx, types.GetAlgoOrdersHistoryParam{OrdType: types.AlgoConditional, State: "effective"})
+		}},
+	})
+
+	// the simulated trading header is sent with every request
+	for _, r := range srv.Requests() {
+		assert.Equal(t, "1", r.Header.Get("x-simulated-trading"))
+	}
+}
+
+func TestFakePlaceOrder(t *testing.T) {
+	cli, srv := testNewFakeOrderBookAccountClient(t, okxtest.Secret)
+
+	srv.Handle(http.MethodPost, "/api/v5/trade/order", `{"code":"0","msg":"","data":[{"clOrdId":"c1","ordId":"312269865356374016","tag":"","sCode":"0","sMsg":""}]}`)
+
+	resp, err := cli.PlaceOrder(context.Background(), types.PlaceOrderParam{InstId: "BTC-USDT", TdMode: utils.Cash, ClOrdId: "c1", Side: utils.Buy, OrdType: utils.Market, Sz: "100"})
+	assert.Nil(t, err)
+	assert.Nil(t, resp.Err())
+	assert.Equal(t, "312269865356374016", resp.Data[0].OrdID)
+
+	var body types.PlaceOrderParam
+	assert.Nil(t, srv.LastRequest().JSON(&body))
+	assert.Equal(t, "c1", body.ClOrdId)
+	assert.Equal(t, utils.Market, body.OrdType)
+
+	// a rejected order is reported by the envelope
+	srv.Handle(http.MethodPost, "/api/v5/trade/order", `{"code":"1","msg":"Operation failed.","data":[{"clOrdId":"c1","ordId":"","sCode":"51008","sMsg":"Order failed. Insufficient balance"}]}`)
+	resp, err = cli.PlaceOrder(context.Background(), types.PlaceOrderParam{InstId: "BTC-USDT", TdMode: utils.Cash, Side: utils.Buy, OrdType: utils.Market, Sz: "100"})
+	assert.Nil(t, err)
+	assert.ErrorContains(t, resp.Err(), "code=1")
+	assert.Equal(t, "51008", resp.Data[0].SCode)
+}
+
+func TestFakeIterators(t *testing.T) {
+	cli, srv := testNewFakeOrderBookAccountClient(t, okxtest.Secret)
+	ctx := context.Background()
+
+	pages := map[string]string{
+		"":  `{"code":"0","msg":"","data":[{"ordId":"3","billId":"3","algoId":"3"},{"ordId":"2","billId":"2","algoId":"2"}]}`,
+		"2": `{"code":"0","msg":"","data":[{"ordId":"1","billId":"1","algoId":"1"}]}`,
+		"1": okxtest.Success,
+	}
+	paginate := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(pages[r.URL.Query().Get("after")]))
+	}
+	for _, path := range []string{"/api/v5/trade/orders-history", "/api/v5/trade/orders-history-archive", "/api/v5/trade/fills", "/api/v5/trade/fills-history", "/api/v5/trade/orders-algo-history"} {
+		srv.HandleFunc(http.MethodGet, path, paginate)
+	}
+
+	orders, err := cli.IterateOrdersHistory(types.GetOrdersHistoryParam{InstType: utils.Spot}).All(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, orders, 3)
+
+	orders, err = cli.IterateOrdersHistoryArchive(types.GetOrdersHistoryParam{InstType: utils.Spot}).All(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, orders, 3)
+
+	fills, err := cli.IterateFills(types.GetFillsParam{}).All(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, fills, 3)
+
+	fills, err = cli.IterateFillsHistory(types.GetFillsParam{InstType: utils.Spot}).All(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, fills, 3)
+
+	algos, err := cli.IterateAlgoOrdersHistory(types.GetAlgoOrdersHistoryParam{OrdType: types.AlgoConditional, State: "effective"}).All(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, algos, 3)
+
+	// 5 iterators of 3 pages
+	assert.Len(t, srv.Requests(), 15)
+}
+
+func TestFakeInvalidSignature(t *testing.T) {
+	cli, _ := testNewFakeOrderBookAccountClient(t, "wrong-secret")
+
+	_, err := cli.GetOrder(context.Background(), types.GetOrderParam{InstId: "BTC-USDT", OrdId: "1"})
+	okxtest.AssertInvalidSignature(t, err)
+}
@@ -90,3 +90,152 @@ func TestPlaceOrder_SpotSell(t *testing.T) {
 		assert.FailNowf(t, "PlaceOrder", "%+v", resp)
 	}
 }
+
+func TestGetOrdersPending(t *testing.T) {
+	cli := testNewOrderBookAccountClient(t)
+
+	resp, err := cli.GetOrdersPending(context.TODO(), types.GetOrdersPendingParam{
+		InstType: utils.Spot,
+	})
+	assert.Nil(t, err)
+
+	if resp.Code != "0" {
+		assert.FailNowf(t, "GetOrdersPending", "%+v", resp)
+	}
+}
+
+func TestGetOrdersHistory(t *testing.T) {
+	cli := testNewOrderBookAccountClient(t)
+
+	resp, err := cli.GetOrdersHistory(context.TODO(), types.GetOrdersHistoryParam{
+		InstType: utils.Spot,
+	})
+	assert.Nil(t, err)
+
+	if resp.Code != "0" {
+		assert.FailNowf(t, "GetOrdersHistory", "%+v", resp)
+	}
+}
+
+func TestGetFills(t *testing.T) {
+	cli := testNewOrderBookAccountClient(t)
+
+	resp, err := cli.GetFills(context.TODO(), types.GetFillsParam{
+		InstType: utils.Spot,
+	})
+	assert.Nil(t, err)
+
+	if resp.Code != "0" {
+		assert.FailNowf(t, "GetFills", "%+v", resp)
+	}
+}
+
+func TestPlaceAndCancelOrder(t *testing.T) {
+	cli := testNewOrderBookAccountClient(t)
+
+	placed, err := cli.PlaceOrder(context.TODO(), types.PlaceOrderParam{
+		InstId:  "BTC-USDT",
+		TdMode:  utils.Cash,
+		Side:    utils.Buy,
+		OrdType: utils.PostOnly,
+		Px:      "1000",
+		Sz:      "0.001",
+	})
+	assert.Nil(t, err)
+
+	if placed.Code != "0" {
+		assert.FailNowf(t, "PlaceOrder", "%+v", placed)
+	}
+
+	amended, err := cli.AmendOrder(context.TODO(), types.AmendOrderParam{
+		InstId: "BTC-USDT",
+		OrdId:  placed.Data[0].OrdID,
+		NewPx:  "1001",
+	})
+	assert.Nil(t, err)
+
+	if amended.Code != "0" {
+		assert.FailNowf(t, "AmendOrder", "%+v", amended)
+	}
+
+	canceled, err := cli.CancelOrder(context.TODO(), types.CancelOrderParam{
+		InstId: "BTC-USDT",
+		OrdId:  placed.Data[0].OrdID,
+	})
+	assert.Nil(t, err)
+
+	if canceled.Code != "0" {
+		assert.FailNowf(t, "CancelOrder", "%+v", canceled)
+	}
+}
+
+func TestPlaceConditionalOrder(t *testing.T) {
+	cli := testNewOrderBookAccountClient(t)
+
+	resp, err := cli.PlaceConditionalOrder(context.TODO(), types.ConditionalOrderParam{
+		AlgoOrderBase: types.AlgoOrderBase{
+			InstId: "BTC-USDT",
+			TdMode: utils.Cash,
+			Side:   utils.Sell,
+			Sz:     "0.001",
+		},
+		SlTriggerPx: "10000",
+		SlOrdPx:     "-1",
+	})
+	assert.Nil(t, err)
+
+	if resp.Code != "0" {
+		assert.FailNowf(t, "PlaceConditionalOrder", "%+v", resp)
+	}
+
+	canceled, err := cli.CancelAlgoOrders(context.TODO(), []types.CancelAlgoOrderParam{
+		{
+			InstId: "BTC-USDT",
+			AlgoId: resp.Data[0].AlgoId,
+		},
+	})
+	assert.Nil(t, err)
+
+	if canceled.Code != "0" {
+		assert.FailNowf(t, "CancelAlgoOrders", "%+v", canceled)
+	}
+}
+
+func TestGetAlgoOrdersPending(t *testing.T) {
+	cli := testNewOrderBookAccountClient(t)
+
+	resp, err := cli.GetAlgoOrdersPending(context.TODO(), types.GetAlgoOrdersPendingParam{
+		OrdType: types.AlgoConditional,
+	})
+	assert.Nil(t, err)
+
+	if resp.Code != "0" {
+		assert.FailNowf(t, "GetAlgoOrdersPending", "%+v", resp)
+	}
+}
+
+func TestGetAlgoOrdersHistory(t *testing.T) {
+	cli := testNewOrderBookAccountClient(t)
+
+	resp, err := cli.GetAlgoOrdersHistory(context.TODO(), types.GetAlgoOrdersHistoryParam{
+		OrdType: types.AlgoConditional,
+		State:   "canceled",
+	})
+	assert.Nil(t, err)
+
+	if resp.Code != "0" {
+		assert.FailNowf(t, "GetAlgoOrdersHistory", "%+v", resp)
+	}
+}
+
+func TestIterateOrdersHistory(t *testing.T) {
+	cli := testNewOrderBookAccountClient(t)
+
+	it := cli.IterateOrdersHistory(types.GetOrdersHistoryParam{
+		InstType: "SPOT",
+	}).WithMaxItems(300)
+	for it.Next(context.TODO()) {
+		assert.NotEmpty(t, it.Item().OrdID)
+	}
+	assert.Nil(t, it.Err())
+}
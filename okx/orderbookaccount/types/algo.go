@@ -0,0 +1,242 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import okxutils "github.com/rluisr/nexapi/okx/utils"
+
+type AlgoOrdType = string
+
+const (
+	AlgoConditional AlgoOrdType = "conditional"
+	AlgoOCO         AlgoOrdType = "oco"
+	AlgoTrigger     AlgoOrdType = "trigger"
+	AlgoMoveOrdStop AlgoOrdType = "move_order_stop"
+	AlgoIceberg     AlgoOrdType = "iceberg"
+	AlgoTWAP        AlgoOrdType = "twap"
+)
+
+// AlgoOrderBase holds the parameters shared by every algo order type,
+// OrdType is filled in by the Place*Order method of each type.
+type AlgoOrderBase struct {
+	InstId      string      `json:"instId" validate:"required"`                                         // Instrument ID, e.g. BTC-USDT
+	TdMode      string      `json:"tdMode" validate:"required,oneof=cross isolated cash spot_isolated"` // Trade mode (Margin mode: cross, isolated; Non-Margin mode: cash)
+	Ccy         string      `json:"ccy,omitempty"`                                                      // Margin currency (Only applicable to cross MARGIN orders in Single-currency margin.)
+	Side        string      `json:"side" validate:"required,oneof=buy sell"`                            // Order side (buy, sell)
+	PosSide     string      `json:"posSide,omitempty"`                                                  // Position side (Required in long/short mode and only be long or short)
+	OrdType     AlgoOrdType `json:"ordType"`                                                            // Order type (conditional, oco, trigger, move_order_stop, iceberg, twap)
+	Sz          string      `json:"sz,omitempty"`                                                       // Quantity to buy or sell (Either sz or closeFraction is required.)
+	Tag         string      `json:"tag,omitempty"`                                                      // Order tag
+	TgtCcy      string      `json:"tgtCcy,omitempty"`                                                   // Order quantity unit setting for sz (base_ccy, quote_ccy; Only applicable to SPOT traded with Market buy conditional order)
+	AlgoClOrdId string      `json:"algoClOrdId,omitempty"`                                              // Client-supplied Algo ID
+	ReduceOnly  bool        `json:"reduceOnly,omitempty"`                                               // Whether the order can only reduce the position size
+}
+
+// ConditionalOrderParam places a one-way stop order, with a take-profit and/or a stop-loss leg.
+type ConditionalOrderParam struct {
+	AlgoOrderBase
+	TpTriggerPx     string `json:"tpTriggerPx,omitempty"`     // Take-profit trigger price
+	TpTriggerPxType string `json:"tpTriggerPxType,omitempty"` // Take-profit trigger price type (last, index, mark; The default is last)
+	TpOrdPx         string `json:"tpOrdPx,omitempty"`         // Take-profit order price (If the price is -1, take-profit will be executed at the market price.)
+	SlTriggerPx     string `json:"slTriggerPx,omitempty"`     // Stop-loss trigger price
+	SlTriggerPxType string `json:"slTriggerPxType,omitempty"` // Stop-loss trigger price type (last, index, mark; The default is last)
+	SlOrdPx         string `json:"slOrdPx,omitempty"`         // Stop-loss order price (If the price is -1, stop-loss will be executed at the market price.)
+	CxlOnClosePos   bool   `json:"cxlOnClosePos,omitempty"`   // Whether the TP/SL order placed by the user is associated with the corresponding position of the instrument
+	CloseFraction   string `json:"closeFraction,omitempty"`   // Fraction of position to be closed when the algo order is triggered, only 1 is supported
+}
+
+// OCOOrderParam places a one-cancels-the-other order, both the take-profit and the stop-loss legs are required.
+type OCOOrderParam struct {
+	AlgoOrderBase
+	TpTriggerPx     string `json:"tpTriggerPx" validate:"required"` // Take-profit trigger price
+	TpTriggerPxType string `json:"tpTriggerPxType,omitempty"`       // Take-profit trigger price type (last, index, mark; The default is last)
+	TpOrdPx         string `json:"tpOrdPx" validate:"required"`     // Take-profit order price (If the price is -1, take-profit will be executed at the market price.)
+	SlTriggerPx     string `json:"slTriggerPx" validate:"required"` // Stop-loss trigger price
+	SlTriggerPxType string `json:"slTriggerPxType,omitempty"`       // Stop-loss trigger price type (last, index, mark; The default is last)
+	SlOrdPx         string `json:"slOrdPx" validate:"required"`     // Stop-loss order price (If the price is -1, stop-loss will be executed at the market price.)
+	CxlOnClosePos   bool   `json:"cxlOnClosePos,omitempty"`         // Whether the TP/SL order placed by the user is associated with the corresponding position of the instrument
+	CloseFraction   string `json:"closeFraction,omitempty"`         // Fraction of position to be closed when the algo order is triggered, only 1 is supported
+}
+
+// TriggerOrderParam places an order once the trigger price is reached.
+type TriggerOrderParam struct {
+	AlgoOrderBase
+	TriggerPx      string          `json:"triggerPx" validate:"required"` // Trigger price
+	OrderPx        string          `json:"orderPx" validate:"required"`   // Order price (If the price is -1, the order will be executed at the market price.)
+	TriggerPxType  string          `json:"triggerPxType,omitempty"`       // Trigger price type (last, index, mark; The default is last)
+	AttachAlgoOrds []AttachAlgoOrd `json:"attachAlgoOrds,omitempty"`      // TP/SL information attached when placing order
+}
+
+// TrailingStopOrderParam places a trailing stop order, one of CallbackRatio and CallbackSpread is required.
+type TrailingStopOrderParam struct {
+	AlgoOrderBase
+	CallbackRatio  string `json:"callbackRatio,omitempty" validate:"required_without=CallbackSpread"` // Callback price ratio, e.g. 0.01 represents 1%
+	CallbackSpread string `json:"callbackSpread,omitempty" validate:"required_without=CallbackRatio"` // Callback price variance
+	ActivePx       string `json:"activePx,omitempty"`                                                 // Active price (The system will only start tracking the market when the active price is reached.)
+	CloseFraction  string `json:"closeFraction,omitempty"`                                            // Fraction of position to be closed when the algo order is triggered, only 1 is supported
+}
+
+// IcebergOrderParam splits a large order into smaller ones, one of PxVar and PxSpread is required.
+type IcebergOrderParam struct {
+	AlgoOrderBase
+	PxVar    string `json:"pxVar,omitempty" validate:"required_without=PxSpread"` // Price ratio (Fill in a number between 0.0001 and 0.01, e.g. 0.01 represents 1%)
+	PxSpread string `json:"pxSpread,omitempty" validate:"required_without=PxVar"` // Price variance
+	SzLimit  string `json:"szLimit" validate:"required"`                          // Average amount
+	PxLimit  string `json:"pxLimit" validate:"required"`                          // Price limit
+}
+
+// TWAPOrderParam splits a large order into smaller ones placed at a regular time interval,
+// one of PxVar and PxSpread is required.
+type TWAPOrderParam struct {
+	AlgoOrderBase
+	PxVar        string `json:"pxVar,omitempty" validate:"required_without=PxSpread"` // Price ratio (Fill in a number between 0.0001 and 0.01, e.g. 0.01 represents 1%)
+	PxSpread     string `json:"pxSpread,omitempty" validate:"required_without=PxVar"` // Price variance
+	SzLimit      string `json:"szLimit" validate:"required"`                          // Average amount
+	PxLimit      string `json:"pxLimit" validate:"required"`                          // Price limit
+	TimeInterval string `json:"timeInterval" validate:"required"`                     // Time interval in seconds
+}
+
+type PlaceAlgoOrderResp struct {
+	okxutils.Response
+	Data []*AlgoOrderResult `json:"data"`
+}
+
+// AlgoOrderResult is the per order result of the algo endpoints,
+// the request for that order succeeded when SCode is "0".
+type AlgoOrderResult struct {
+	AlgoId      string `json:"algoId"`
+	ClOrdId     string `json:"clOrdId,omitempty"`
+	AlgoClOrdId string `json:"algoClOrdId"`
+	ReqId       string `json:"reqId,omitempty"`
+	SCode       string `json:"sCode"`
+	SMsg        string `json:"sMsg"`
+}
+
+type CancelAlgoOrderParam struct {
+	InstId string `json:"instId" validate:"required"`
+	AlgoId string `json:"algoId" validate:"required"`
+}
+
+type CancelAlgoOrdersResp struct {
+	okxutils.Response
+	Data []*AlgoOrderResult `json:"data"`
+}
+
+// AmendAlgoOrderParam amends an incomplete conditional or OCO order.
+type AmendAlgoOrderParam struct {
+	InstId             string `json:"instId" validate:"required"`                               // Instrument ID
+	AlgoId             string `json:"algoId,omitempty" validate:"required_without=AlgoClOrdId"` // Algo ID (Either algoId or algoClOrdId is required.)
+	AlgoClOrdId        string `json:"algoClOrdId,omitempty" validate:"required_without=AlgoId"` // Client-supplied Algo ID
+	CxlOnFail          bool   `json:"cxlOnFail,omitempty"`                                      // Whether the order needs to be automatically canceled when the order amendment fails
+	ReqId              string `json:"reqId,omitempty"`                                          // Client Request ID as assigned by the client for order amendment
+	NewSz              string `json:"newSz,omitempty"`                                          // New quantity after amendment
+	NewTpTriggerPx     string `json:"newTpTriggerPx,omitempty"`                                 // Take-profit trigger price (Either the take-profit trigger price or order price is 0, it means that the take-profit is deleted)
+	NewTpOrdPx         string `json:"newTpOrdPx,omitempty"`                                     // Take-profit order price (If the price is -1, take-profit will be executed at the market price.)
+	NewSlTriggerPx     string `json:"newSlTriggerPx,omitempty"`                                 // Stop-loss trigger price (Either the stop-loss trigger price or order price is 0, it means that the stop-loss is deleted)
+	NewSlOrdPx         string `json:"newSlOrdPx,omitempty"`                                     // Stop-loss order price (If the price is -1, stop-loss will be executed at the market price.)
+	NewTpTriggerPxType string `json:"newTpTriggerPxType,omitempty"`                             // Take-profit trigger price type (last, index, mark)
+	NewSlTriggerPxType string `json:"newSlTriggerPxType,omitempty"`                             // Stop-loss trigger price type (last, index, mark)
+}
+
+type AmendAlgoOrderResp struct {
+	okxutils.Response
+	Data []*AlgoOrderResult `json:"data"`
+}
+
+type GetAlgoOrderParam struct {
+	AlgoId      string `url:"algoId,omitempty" validate:"required_without=AlgoClOrdId"`
+	AlgoClOrdId string `url:"algoClOrdId,omitempty" validate:"required_without=AlgoId"`
+}
+
+type GetAlgoOrdersPendingParam struct {
+	OrdType     AlgoOrdType `url:"ordType" validate:"required"` // Order type, conditional and oco can be queried together, e.g. conditional,oco
+	AlgoId      string      `url:"algoId,omitempty"`
+	AlgoClOrdId string      `url:"algoClOrdId,omitempty"`
+	InstType    string      `url:"instType,omitempty" validate:"omitempty,oneof=SPOT MARGIN SWAP FUTURES"`
+	InstId      string      `url:"instId,omitempty"`
+	After       string      `url:"after,omitempty"`  // Pagination of data to return records earlier than the requested algoId
+	Before      string      `url:"before,omitempty"` // Pagination of data to return records newer than the requested algoId
+	Limit       string      `url:"limit,omitempty"`  // Number of results per request. The maximum is 100; The default is 100
+}
+
+// GetAlgoOrdersHistoryParam requires either State or AlgoId.
+type GetAlgoOrdersHistoryParam struct {
+	OrdType  AlgoOrdType `url:"ordType" validate:"required"`                        // Order type, conditional and oco can be queried together, e.g. conditional,oco
+	State    string      `url:"state,omitempty" validate:"required_without=AlgoId"` // State (effective, canceled, order_failed)
+	AlgoId   string      `url:"algoId,omitempty" validate:"required_without=State"`
+	InstType string      `url:"instType,omitempty" validate:"omitempty,oneof=SPOT MARGIN SWAP FUTURES"`
+	InstId   string      `url:"instId,omitempty"`
+	After    string      `url:"after,omitempty"`  // Pagination of data to return records earlier than the requested algoId
+	Before   string      `url:"before,omitempty"` // Pagination of data to return records newer than the requested algoId
+	Limit    string      `url:"limit,omitempty"`  // Number of results per request. The maximum is 100; The default is 100
+}
+
+type GetAlgoOrdersResp struct {
+	okxutils.Response
+	Data []*AlgoOrder `json:"data"`
+}
+
+// AlgoOrder
+// doc: https://www.okx.com/docs-v5/en/#order-book-trading-algo-trading-get-algo-order-list
+type AlgoOrder struct {
+	InstType        string          `json:"instType"`
+	InstId          string          `json:"instId"`
+	Ccy             string          `json:"ccy"`
+	OrdId           string          `json:"ordId"`
+	OrdIdList       []string        `json:"ordIdList"`
+	AlgoId          string          `json:"algoId"`
+	ClOrdId         string          `json:"clOrdId"`
+	Sz              string          `json:"sz"`
+	CloseFraction   string          `json:"closeFraction"`
+	OrdType         string          `json:"ordType"`
+	Side            string          `json:"side"`
+	PosSide         string          `json:"posSide"`
+	TdMode          string          `json:"tdMode"`
+	TgtCcy          string          `json:"tgtCcy"`
+	State           string          `json:"state"`
+	Lever           string          `json:"lever"`
+	TpTriggerPx     string          `json:"tpTriggerPx"`
+	TpTriggerPxType string          `json:"tpTriggerPxType"`
+	TpOrdPx         string          `json:"tpOrdPx"`
+	SlTriggerPx     string          `json:"slTriggerPx"`
+	SlTriggerPxType string          `json:"slTriggerPxType"`
+	SlOrdPx         string          `json:"slOrdPx"`
+	TriggerPx       string          `json:"triggerPx"`
+	TriggerPxType   string          `json:"triggerPxType"`
+	OrdPx           string          `json:"ordPx"`
+	ActualSz        string          `json:"actualSz"`
+	ActualPx        string          `json:"actualPx"`
+	ActualSide      string          `json:"actualSide"`
+	TriggerTime     string          `json:"triggerTime"`
+	PxVar           string          `json:"pxVar"`
+	PxSpread        string          `json:"pxSpread"`
+	SzLimit         string          `json:"szLimit"`
+	PxLimit         string          `json:"pxLimit"`
+	Tag             string          `json:"tag"`
+	TimeInterval    string          `json:"timeInterval"`
+	CallbackRatio   string          `json:"callbackRatio"`
+	CallbackSpread  string          `json:"callbackSpread"`
+	ActivePx        string          `json:"activePx"`
+	MoveTriggerPx   string          `json:"moveTriggerPx"`
+	ReduceOnly      string          `json:"reduceOnly"`
+	Last            string          `json:"last"`
+	FailCode        string          `json:"failCode"`
+	AlgoClOrdId     string          `json:"algoClOrdId"`
+	AttachAlgoOrds  []AttachAlgoOrd `json:"attachAlgoOrds"`
+	CTime           string          `json:"cTime"`
+	UTime           string          `json:"uTime"`
+}
@@ -39,71 +39,130 @@ type AttachAlgoOrd struct {
 }
 
 type PlaceOrderResp struct {
+	okxutils.Response
+	Data []*OrderResult `json:"data"`
+}
+
+// OrderResult is the per order result of the place, cancel and amend endpoints,
+// the request for that order succeeded when SCode is "0".
+type OrderResult struct {
+	ClOrdID string `json:"clOrdId"`
+	OrdID   string `json:"ordId"`
+	Tag     string `json:"tag,omitempty"`
+	ReqID   string `json:"reqId,omitempty"`
+	SCode   string `json:"sCode"`
+	SMsg    string `json:"sMsg"`
+}
+
+type CancelOrderParam struct {
+	InstId  string `json:"instId" validate:"required"`                          // Instrument ID, e.g. BTC-USDT
+	OrdId   string `json:"ordId,omitempty" validate:"required_without=ClOrdId"` // Order ID (Either ordId or clOrdId is required. If both are passed, ordId will be used.)
+	ClOrdId string `json:"clOrdId,omitempty" validate:"required_without=OrdId"` // Client Order ID as assigned by the client
+}
+
+type CancelOrderResp struct {
+	okxutils.Response
+	Data []*OrderResult `json:"data"`
+}
+
+type AmendOrderParam struct {
+	InstId         string          `json:"instId" validate:"required"`                          // Instrument ID
+	CxlOnFail      bool            `json:"cxlOnFail,omitempty"`                                 // Whether the order needs to be automatically canceled when the order amendment fails (The default is false)
+	OrdId          string          `json:"ordId,omitempty" validate:"required_without=ClOrdId"` // Order ID (Either ordId or clOrdId is required. If both are passed, ordId will be used.)
+	ClOrdId        string          `json:"clOrdId,omitempty" validate:"required_without=OrdId"` // Client Order ID as assigned by the client
+	ReqId          string          `json:"reqId,omitempty"`                                     // Client Request ID as assigned by the client for order amendment
+	NewSz          string          `json:"newSz,omitempty"`                                     // New quantity after amendment (When amending a partially-filled order, the newSz should include the amount that has been filled.)
+	NewPx          string          `json:"newPx,omitempty"`                                     // New price after amendment
+	NewPxUsd       string          `json:"newPxUsd,omitempty"`                                  // Modify options orders using USD prices (Only applicable to options)
+	NewPxVol       string          `json:"newPxVol,omitempty"`                                  // Modify options orders based on implied volatility (Only applicable to options)
+	AttachAlgoOrds []AttachAlgoOrd `json:"attachAlgoOrds,omitempty"`                            // TP/SL information attached when amending order
+}
+
+type AmendOrderResp struct {
+	okxutils.Response
+	Data []*OrderResult `json:"data"`
+}
+
+type ClosePositionParam struct {
+	InstId  string `json:"instId" validate:"required"`                       // Instrument ID
+	PosSide string `json:"posSide,omitempty"`                                // Position side (This parameter can be omitted in net mode, and the default value is net. You can only fill with long or short in long/short mode.)
+	MgnMode string `json:"mgnMode" validate:"required,oneof=cross isolated"` // Margin mode (cross, isolated)
+	Ccy     string `json:"ccy,omitempty"`                                    // Margin currency, required in the case of closing cross MARGIN position for Single-currency margin.
+	AutoCxl bool   `json:"autoCxl,omitempty"`                                // Whether any pending orders for closing out needs to be automatically canceled when close position via a market order.
+	ClOrdId string `json:"clOrdId,omitempty"`                                // Client-supplied ID
+	Tag     string `json:"tag,omitempty"`                                    // Order tag
+}
+
+type ClosePositionResp struct {
 	okxutils.Response
 	Data []struct {
-		ClOrdID string `json:"clOrdId"`
-		OrdID   string `json:"ordId"`
+		InstId  string `json:"instId"`
+		PosSide string `json:"posSide"`
+		ClOrdId string `json:"clOrdId"`
 		Tag     string `json:"tag"`
-		SCode   string `json:"sCode"`
-		SMsg    string `json:"sMsg"`
 	} `json:"data"`
 }
 
 type GetOrderParam struct {
-	InstId string `url:"instId"`
-	OrdId  string `url:"ordId"`
+	InstId  string `url:"instId" validate:"required"`
+	OrdId   string `url:"ordId,omitempty" validate:"required_without=ClOrdId"`
+	ClOrdId string `url:"clOrdId,omitempty" validate:"required_without=OrdId"`
 }
 
 type GetOrderResp struct {
 	okxutils.Response
-	Data []struct {
-		InstType           string `json:"instType"`
-		InstID             string `json:"instId"`
-		Ccy                string `json:"ccy"`
-		OrdID              string `json:"ordId"`
-		ClOrdID            string `json:"clOrdId"`
-		Tag                string `json:"tag"`
-		Px                 string `json:"px"`
-		PxUsd              string `json:"pxUsd"`
-		PxVol              string `json:"pxVol"`
-		PxType             string `json:"pxType"`
-		Sz                 string `json:"sz"`
-		Pnl                string `json:"pnl"`
-		OrdType            string `json:"ordType"`
-		Side               string `json:"side"`
-		PosSide            string `json:"posSide"`
-		TdMode             string `json:"tdMode"`
-		AccFillSz          string `json:"accFillSz"`
-		FillPx             string `json:"fillPx"`
-		TradeID            string `json:"tradeId"`
-		FillSz             string `json:"fillSz"`
-		FillTime           string `json:"fillTime"`
-		State              string `json:"state"`
-		AvgPx              string `json:"avgPx"`
-		Lever              string `json:"lever"`
-		AttachAlgoClOrdID  string `json:"attachAlgoClOrdId"`
-		TpTriggerPx        string `json:"tpTriggerPx"`
-		TpTriggerPxType    string `json:"tpTriggerPxType"`
-		TpOrdPx            string `json:"tpOrdPx"`
-		SlTriggerPx        string `json:"slTriggerPx"`
-		SlTriggerPxType    string `json:"slTriggerPxType"`
-		SlOrdPx            string `json:"slOrdPx"`
-		AttachAlgoOrds     []any  `json:"attachAlgoOrds"`
-		StpID              string `json:"stpId"`
-		StpMode            string `json:"stpMode"`
-		FeeCcy             string `json:"feeCcy"`
-		Fee                string `json:"fee"`
-		RebateCcy          string `json:"rebateCcy"`
-		Rebate             string `json:"rebate"`
-		TgtCcy             string `json:"tgtCcy"`
-		Category           string `json:"category"`
-		ReduceOnly         string `json:"reduceOnly"`
-		CancelSource       string `json:"cancelSource"`
-		CancelSourceReason string `json:"cancelSourceReason"`
-		QuickMgnType       string `json:"quickMgnType"`
-		AlgoClOrdID        string `json:"algoClOrdId"`
-		AlgoID             string `json:"algoId"`
-		UTime              string `json:"uTime"`
-		CTime              string `json:"cTime"`
-	} `json:"data"`
+	Data []*Order `json:"data"`
+}
+
+// Order
+// doc: https://www.okx.com/docs-v5/en/#order-book-trading-trade-get-order-details
+type Order struct {
+	InstType           string `json:"instType"`
+	InstID             string `json:"instId"`
+	Ccy                string `json:"ccy"`
+	OrdID              string `json:"ordId"`
+	ClOrdID            string `json:"clOrdId"`
+	Tag                string `json:"tag"`
+	Px                 string `json:"px"`
+	PxUsd              string `json:"pxUsd"`
+	PxVol              string `json:"pxVol"`
+	PxType             string `json:"pxType"`
+	Sz                 string `json:"sz"`
+	Pnl                string `json:"pnl"`
+	OrdType            string `json:"ordType"`
+	Side               string `json:"side"`
+	PosSide            string `json:"posSide"`
+	TdMode             string `json:"tdMode"`
+	AccFillSz          string `json:"accFillSz"`
+	FillPx             string `json:"fillPx"`
+	TradeID            string `json:"tradeId"`
+	FillSz             string `json:"fillSz"`
+	FillTime           string `json:"fillTime"`
+	State              string `json:"state"`
+	AvgPx              string `json:"avgPx"`
+	Lever              string `json:"lever"`
+	AttachAlgoClOrdID  string `json:"attachAlgoClOrdId"`
+	TpTriggerPx        string `json:"tpTriggerPx"`
+	TpTriggerPxType    string `json:"tpTriggerPxType"`
+	TpOrdPx            string `json:"tpOrdPx"`
+	SlTriggerPx        string `json:"slTriggerPx"`
+	SlTriggerPxType    string `json:"slTriggerPxType"`
+	SlOrdPx            string `json:"slOrdPx"`
+	AttachAlgoOrds     []any  `json:"attachAlgoOrds"`
+	StpID              string `json:"stpId"`
+	StpMode            string `json:"stpMode"`
+	FeeCcy             string `json:"feeCcy"`
+	Fee                string `json:"fee"`
+	RebateCcy          string `json:"rebateCcy"`
+	Rebate             string `json:"rebate"`
+	TgtCcy             string `json:"tgtCcy"`
+	Category           string `json:"category"`
+	ReduceOnly         string `json:"reduceOnly"`
+	CancelSource       string `json:"cancelSource"`
+	CancelSourceReason string `json:"cancelSourceReason"`
+	QuickMgnType       string `json:"quickMgnType"`
+	AlgoClOrdID        string `json:"algoClOrdId"`
+	AlgoID             string `json:"algoId"`
+	UTime              string `json:"uTime"`
+	CTime              string `json:"cTime"`
 }
@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import okxutils "github.com/rluisr/nexapi/okx/utils"
+
+type GetOrdersPendingParam struct {
+	InstType   string `url:"instType,omitempty" validate:"omitempty,oneof=SPOT MARGIN SWAP FUTURES OPTION"`
+	Uly        string `url:"uly,omitempty"`
+	InstFamily string `url:"instFamily,omitempty"`
+	InstId     string `url:"instId,omitempty"`
+	OrdType    string `url:"ordType,omitempty"`                                                // Order type (market, limit, post_only, fok, ioc, optimal_limit_ioc, mmp, mmp_and_post_only)
+	State      string `url:"state,omitempty" validate:"omitempty,oneof=live partially_filled"` // State (live, partially_filled)
+	After      string `url:"after,omitempty"`                                                  // Pagination of data to return records earlier than the requested ordId
+	Before     string `url:"before,omitempty"`                                                 // Pagination of data to return records newer than the requested ordId
+	Limit      string `url:"limit,omitempty"`                                                  // Number of results per request. The maximum is 100; The default is 100
+}
+
+// GetOrdersHistoryParam is used by both the last 7 days and the last 3 months order history.
+type GetOrdersHistoryParam struct {
+	InstType   string `url:"instType" validate:"required,oneof=SPOT MARGIN SWAP FUTURES OPTION"`
+	Uly        string `url:"uly,omitempty"`
+	InstFamily string `url:"instFamily,omitempty"`
+	InstId     string `url:"instId,omitempty"`
+	OrdType    string `url:"ordType,omitempty"`                                          // Order type (market, limit, post_only, fok, ioc, optimal_limit_ioc, mmp, mmp_and_post_only)
+	State      string `url:"state,omitempty" validate:"omitempty,oneof=canceled filled"` // State (canceled, filled)
+	Category   string `url:"category,omitempty"`                                         // Category (twap, adl, full_liquidation, partial_liquidation, delivery, ddh)
+	After      string `url:"after,omitempty"`                                            // Pagination of data to return records earlier than the requested ordId
+	Before     string `url:"before,omitempty"`                                           // Pagination of data to return records newer than the requested ordId
+	Begin      string `url:"begin,omitempty"`                                            // Filter with a begin timestamp cTime. Unix timestamp format in milliseconds
+	End        string `url:"end,omitempty"`                                              // Filter with an end timestamp cTime. Unix timestamp format in milliseconds
+	Limit      string `url:"limit,omitempty"`                                            // Number of results per request. The maximum is 100; The default is 100
+}
+
+type GetOrdersResp struct {
+	okxutils.Response
+	Data []*Order `json:"data"`
+}
@@ -0,0 +1,356 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package paper provides a paper trading OKX client backed by a simulated exchange,
+// for the venues without a demo trading environment like OKX has with IsDemo.
+package paper
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	obtypes "github.com/rluisr/nexapi/okx/orderbookaccount/types"
+	pdtypes "github.com/rluisr/nexapi/okx/publicdata/types"
+	tatypes "github.com/rluisr/nexapi/okx/tradingaccount/types"
+	okxutils "github.com/rluisr/nexapi/okx/utils"
+	"github.com/rluisr/nexapi/utils/decimal"
+	"github.com/rluisr/nexapi/utils/sim"
+)
+
+// Client has the order methods of orderbookaccount.OrderBookAccountClient and the
+// balance and position methods of tradingaccount.TradingAccountClient. Like the API,
+// a rejected request is reported by the response code instead of an error.
+type Client struct {
+	*sim.Exchange
+}
+
+func NewClient(ex *sim.Exchange) *Client {
+	return &Client{Exchange: ex}
+}
+
+var orderTypes = map[string]sim.OrderType{
+	"limit":     sim.Limit,
+	"market":    sim.Market,
+	"post_only": sim.PostOnly,
+	"ioc":       sim.IOC,
+	"fok":       sim.FOK,
+}
+
+var states = map[sim.Status]string{
+	sim.New:             "live",
+	sim.PartiallyFilled: "partially_filled",
+	sim.Filled:          "filled",
+	sim.Canceled:        "canceled",
+	sim.Rejected:        "canceled",
+}
+
+// UpdateOrderBook feeds the simulated exchange with an order book of instID.
+func (c *Client) UpdateOrderBook(instID string, ob *pdtypes.OrderBook) error {
+	b, err := ob.Book()
+	if err != nil {
+		return err
+	}
+
+	ts, err := strconv.ParseInt(ob.TS, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	return c.UpdateBook(instID, b, time.UnixMilli(ts))
+}
+
+// UpdateTrades feeds the simulated exchange with trades in time order.
+func (c *Client) UpdateTrades(trades []*pdtypes.Trade) error {
+	for _, t := range trades {
+		px, err := t.PxDecimal()
+		if err != nil {
+			return err
+		}
+
+		sz, err := t.SzDecimal()
+		if err != nil {
+			return err
+		}
+
+		ts, err := strconv.ParseInt(t.TS, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		err = c.UpdateTrade(t.InstID, sim.Trade{Price: px, Qty: sz, Side: sim.Side(t.Side), Time: time.UnixMilli(ts)})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) PlaceOrder(ctx context.Context, param obtypes.PlaceOrderParam) (*obtypes.PlaceOrderResp, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	o, err := c.placeOrder(param)
+	if err != nil {
+		return &obtypes.PlaceOrderResp{
+			Response: failure(),
+			Data:     []*obtypes.OrderResult{{ClOrdID: param.ClOrdId, Tag: param.Tag, SCode: "1", SMsg: err.Error()}},
+		}, nil
+	}
+
+	return &obtypes.PlaceOrderResp{
+		Response: success(),
+		Data:     []*obtypes.OrderResult{{ClOrdID: o.ClientID, OrdID: o.ID, Tag: param.Tag, SCode: "0"}},
+	}, nil
+}
+
+func (c *Client) placeOrder(param obtypes.PlaceOrderParam) (*sim.Order, error) {
+	inst, err := c.Instrument(param.InstId)
+	if err != nil {
+		return nil, err
+	}
+
+	orderType, ok := orderTypes[param.OrdType]
+	if !ok {
+		return nil, errors.New("unsupported order type: " + param.OrdType)
+	}
+
+	sz, err := decimal.NewFromString(param.Sz)
+	if err != nil {
+		return nil, err
+	}
+
+	px, err := decimal.Parse(param.Px)
+	if err != nil {
+		return nil, err
+	}
+
+	req := sim.OrderRequest{
+		Symbol:     param.InstId,
+		ClientID:   param.ClOrdId,
+		Side:       sim.Side(param.Side),
+		Type:       orderType,
+		Price:      px,
+		Qty:        sz,
+		ReduceOnly: param.ReduceOnly,
+	}
+	// the size of a SPOT market buy is in quote currency by default
+	if inst.Kind == sim.Spot && orderType == sim.Market && req.Side == sim.Buy && param.TgtCcy != "base_ccy" {
+		req.Qty, req.QuoteQty = decimal.Zero, sz
+	}
+
+	return c.Exchange.PlaceOrder(req)
+}
+
+func (c *Client) CancelOrder(ctx context.Context, param obtypes.CancelOrderParam) (*obtypes.CancelOrderResp, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// the order of another instrument is not found, it is not canceled
+	o, err := c.Order(param.OrdId, param.ClOrdId)
+	if err == nil && o.Symbol != param.InstId {
+		err = sim.ErrOrderNotFound
+	}
+	if err == nil {
+		o, err = c.Exchange.CancelOrder(o.ID, "")
+	}
+	if err != nil {
+		return &obtypes.CancelOrderResp{
+			Response: failure(),
+			Data:     []*obtypes.OrderResult{{OrdID: param.OrdId, ClOrdID: param.ClOrdId, SCode: "1", SMsg: err.Error()}},
+		}, nil
+	}
+
+	return &obtypes.CancelOrderResp{
+		Response: success(),
+		Data:     []*obtypes.OrderResult{{OrdID: o.ID, ClOrdID: o.ClientID, SCode: "0"}},
+	}, nil
+}
+
+func (c *Client) GetOrder(ctx context.Context, param obtypes.GetOrderParam) (*obtypes.GetOrderResp, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	o, err := c.Order(param.OrdId, param.ClOrdId)
+	if err == nil && o.Symbol != param.InstId {
+		err = sim.ErrOrderNotFound
+	}
+	if err != nil {
+		return &obtypes.GetOrderResp{Response: okxutils.Response{Code: "51603", Message: "Order does not exist"}}, nil
+	}
+
+	return &obtypes.GetOrderResp{
+		Response: success(),
+		Data:     []*obtypes.Order{c.order(o)},
+	}, nil
+}
+
+// GetOrdersPending returns the open orders, the pagination parameters are ignored.
+func (c *Client) GetOrdersPending(ctx context.Context, param obtypes.GetOrdersPendingParam) (*obtypes.GetOrdersResp, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	resp := &obtypes.GetOrdersResp{Response: success()}
+	orders := c.OpenOrders(param.InstId)
+	// the API returns the newest orders first
+	for i := len(orders) - 1; i >= 0; i-- {
+		o := c.order(orders[i])
+		if param.InstType != "" && o.InstType != param.InstType || param.OrdType != "" && o.OrdType != param.OrdType || param.State != "" && o.State != param.State {
+			continue
+		}
+		resp.Data = append(resp.Data, o)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) order(o *sim.Order) *obtypes.Order {
+	ret := &obtypes.Order{
+		InstType:  "SPOT",
+		InstID:    o.Symbol,
+		OrdID:     o.ID,
+		ClOrdID:   o.ClientID,
+		Sz:        o.Qty.String(),
+		Side:      string(o.Side),
+		PosSide:   "net",
+		TdMode:    "cash",
+		AccFillSz: o.Filled.String(),
+		State:     states[o.Status],
+		FeeCcy:    o.FeeAsset,
+		// OKX reports the fees as negative amounts
+		Fee:        o.Fee.Neg().String(),
+		ReduceOnly: strconv.FormatBool(o.ReduceOnly),
+		Category:   "normal",
+		UTime:      strconv.FormatInt(o.UpdateTime.UnixMilli(), 10),
+		CTime:      strconv.FormatInt(o.CreateTime.UnixMilli(), 10),
+	}
+
+	for name, t := range orderTypes {
+		if t == o.Type {
+			ret.OrdType = name
+		}
+	}
+	if o.Type != sim.Market {
+		ret.Px = o.Price.String()
+	}
+	if o.QuoteQty.Sign() > 0 {
+		ret.Sz, ret.TgtCcy = o.QuoteQty.String(), "quote_ccy"
+	}
+	if o.Filled.Sign() > 0 {
+		ret.AvgPx = o.AvgPrice(12).String()
+	}
+
+	if inst, err := c.Instrument(o.Symbol); err == nil && inst.Kind == sim.Linear {
+		ret.InstType, ret.TdMode = "SWAP", "cross"
+	}
+
+	return ret
+}
+
+// GetBalance returns the balances in a single account, the equity is the cash balance
+// without the unrealized PnL and the USD values are not set.
+func (c *Client) GetBalance(ctx context.Context, param tatypes.GetBalanceParam) (*tatypes.GetBalanceResp, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	resp := &tatypes.GetBalanceResp{Response: success()}
+	resp.Data = appendZero(resp.Data)
+	data := &resp.Data[0]
+	data.UTime = strconv.FormatInt(c.Now().UnixMilli(), 10)
+
+	for _, b := range c.Balances() {
+		if param.Currency != "" && b.Asset != param.Currency {
+			continue
+		}
+
+		total := b.Total().String()
+		data.Details = append(data.Details, tatypes.BalanceDetail{
+			Ccy:       b.Asset,
+			AvailBal:  b.Free.String(),
+			AvailEq:   b.Free.String(),
+			CashBal:   total,
+			Eq:        total,
+			FrozenBal: b.Locked.String(),
+			OrdFrozen: b.Locked.String(),
+			UTime:     data.UTime,
+		})
+	}
+
+	return resp, nil
+}
+
+// GetPositions returns the positions of the Linear instruments as net mode SWAP positions.
+func (c *Client) GetPositions(ctx context.Context, param tatypes.GetPositionsParam) (*tatypes.GetPositionsResp, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	resp := &tatypes.GetPositionsResp{Response: success()}
+	if param.InstType != "" && param.InstType != "SWAP" {
+		return resp, nil
+	}
+
+	for _, p := range c.Positions() {
+		if param.InstId != "" && p.Symbol != param.InstId {
+			continue
+		}
+
+		inst, err := c.Instrument(p.Symbol)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Data = append(resp.Data, &tatypes.Position{
+			InstType:    "SWAP",
+			MgnMode:     "cross",
+			PosSide:     "net",
+			Pos:         p.Qty.String(),
+			AvailPos:    p.Qty.Abs().String(),
+			PosCcy:      inst.Base,
+			AvgPx:       p.EntryPrice.String(),
+			UPL:         p.UnrealizedPnL.String(),
+			InstId:      p.Symbol,
+			MarkPx:      p.MarkPrice.String(),
+			CCY:         inst.Quote,
+			RealizedPnl: p.RealizedPnL.String(),
+			UTime:       strconv.FormatInt(c.Now().UnixMilli(), 10),
+		})
+	}
+
+	return resp, nil
+}
+
+func success() okxutils.Response {
+	return okxutils.Response{Code: "0"}
+}
+
+func failure() okxutils.Response {
+	return okxutils.Response{Code: "1", Message: "Operation failed."}
+}
+
+// appendZero appends a zero element, for the slices of anonymous structs.
+func appendZero[T any](s []T) []T {
+	var zero T
+	return append(s, zero)
+}
@@ -0,0 +1,202 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package paper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rluisr/nexapi/okx/orderbookaccount"
+	obtypes "github.com/rluisr/nexapi/okx/orderbookaccount/types"
+	pdtypes "github.com/rluisr/nexapi/okx/publicdata/types"
+	"github.com/rluisr/nexapi/okx/tradingaccount"
+	tatypes "github.com/rluisr/nexapi/okx/tradingaccount/types"
+	"github.com/rluisr/nexapi/utils/decimal"
+	"github.com/rluisr/nexapi/utils/sim"
+	"github.com/stretchr/testify/assert"
+)
+
+type orderClient interface {
+	PlaceOrder(ctx context.Context, param obtypes.PlaceOrderParam) (*obtypes.PlaceOrderResp, error)
+	CancelOrder(ctx context.Context, param obtypes.CancelOrderParam) (*obtypes.CancelOrderResp, error)
+	GetOrder(ctx context.Context, param obtypes.GetOrderParam) (*obtypes.GetOrderResp, error)
+	GetOrdersPending(ctx context.Context, param obtypes.GetOrdersPendingParam) (*obtypes.GetOrdersResp, error)
+}
+
+type accountClient interface {
+	GetBalance(ctx context.Context, param tatypes.GetBalanceParam) (*tatypes.GetBalanceResp, error)
+	GetPositions(ctx context.Context, param tatypes.GetPositionsParam) (*tatypes.GetPositionsResp, error)
+}
+
+var (
+	_ orderClient   = (*orderbookaccount.OrderBookAccountClient)(nil)
+	_ orderClient   = (*Client)(nil)
+	_ accountClient = (*tradingaccount.TradingAccountClient)(nil)
+	_ accountClient = (*Client)(nil)
+)
+
+// assertDecimal compares the numbers regardless of their trailing zeros.
+func assertDecimal(t *testing.T, expected, actual string) {
+	d, err := decimal.NewFromString(actual)
+	assert.Nil(t, err)
+	assert.True(t, decimal.MustFromString(expected).Equal(d), "expected %s, got %s", expected, actual)
+}
+
+func testClient(t *testing.T) *Client {
+	c := NewClient(sim.NewExchange(sim.Config{
+		Instruments: []sim.Instrument{
+			{Symbol: "BTC-USDT", Kind: sim.Spot, Base: "BTC", Quote: "USDT"},
+			{Symbol: "BTC-USDT-SWAP", Kind: sim.Linear, Base: "BTC", Quote: "USDT", ContractSize: decimal.MustFromString("0.01")},
+		},
+		Balances: map[string]decimal.Decimal{"USDT": decimal.NewFromInt(1000)},
+	}))
+
+	for _, instID := range []string{"BTC-USDT", "BTC-USDT-SWAP"} {
+		err := c.UpdateOrderBook(instID, &pdtypes.OrderBook{
+			Bids: [][]string{{"100", "1", "0", "1"}},
+			Asks: [][]string{{"101", "1", "0", "1"}},
+			TS:   "1704067200000",
+		})
+		assert.Nil(t, err)
+	}
+
+	return c
+}
+
+func TestSpotOrders(t *testing.T) {
+	c := testClient(t)
+	ctx := context.Background()
+
+	resp, err := c.PlaceOrder(ctx, obtypes.PlaceOrderParam{InstId: "BTC-USDT", TdMode: "cash", ClOrdId: "c1", Side: "buy", OrdType: "limit", Px: "100", Sz: "2"})
+	assert.Nil(t, err)
+	assert.Equal(t, "0", resp.Code)
+	assert.Equal(t, "c1", resp.Data[0].ClOrdID)
+
+	order, err := c.GetOrder(ctx, obtypes.GetOrderParam{InstId: "BTC-USDT", ClOrdId: "c1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "live", order.Data[0].State)
+	assert.Equal(t, "SPOT", order.Data[0].InstType)
+
+	balance, err := c.GetBalance(ctx, tatypes.GetBalanceParam{Currency: "USDT"})
+	assert.Nil(t, err)
+	assert.Len(t, balance.Data[0].Details, 1)
+	assert.Equal(t, "800", balance.Data[0].Details[0].AvailBal)
+	assert.Equal(t, "200", balance.Data[0].Details[0].FrozenBal)
+
+	err = c.UpdateTrades([]*pdtypes.Trade{{InstID: "BTC-USDT", Px: "99", Sz: "1.5", Side: "sell", TS: "1704067201000"}})
+	assert.Nil(t, err)
+
+	order, err = c.GetOrder(ctx, obtypes.GetOrderParam{InstId: "BTC-USDT", OrdId: resp.Data[0].OrdID})
+	assert.Nil(t, err)
+	assert.Equal(t, "partially_filled", order.Data[0].State)
+	assert.Equal(t, "1.5", order.Data[0].AccFillSz)
+	assertDecimal(t, "100", order.Data[0].AvgPx)
+
+	// an order of another instrument is not found
+	cancel, err := c.CancelOrder(ctx, obtypes.CancelOrderParam{InstId: "ETH-USDT", OrdId: resp.Data[0].OrdID})
+	assert.Nil(t, err)
+	assert.Equal(t, "1", cancel.Code)
+	assert.Equal(t, "1", cancel.Data[0].SCode)
+
+	cancel, err = c.CancelOrder(ctx, obtypes.CancelOrderParam{InstId: "BTC-USDT", OrdId: resp.Data[0].OrdID})
+	assert.Nil(t, err)
+	assert.Equal(t, "0", cancel.Code)
+
+	order, err = c.GetOrder(ctx, obtypes.GetOrderParam{InstId: "BTC-USDT", OrdId: resp.Data[0].OrdID})
+	assert.Nil(t, err)
+	assert.Equal(t, "canceled", order.Data[0].State)
+
+	// the size of a market buy is in quote currency by default
+	resp, err = c.PlaceOrder(ctx, obtypes.PlaceOrderParam{InstId: "BTC-USDT", TdMode: "cash", Side: "buy", OrdType: "market", Sz: "50.5"})
+	assert.Nil(t, err)
+	assert.Equal(t, "0", resp.Code)
+
+	order, err = c.GetOrder(ctx, obtypes.GetOrderParam{InstId: "BTC-USDT", OrdId: resp.Data[0].OrdID})
+	assert.Nil(t, err)
+	assert.Equal(t, "filled", order.Data[0].State)
+	assert.Equal(t, "quote_ccy", order.Data[0].TgtCcy)
+	assertDecimal(t, "0.5", order.Data[0].AccFillSz)
+
+	// the rejected requests are reported by the response code
+	resp, err = c.PlaceOrder(ctx, obtypes.PlaceOrderParam{InstId: "BTC-USDT", TdMode: "cash", Side: "buy", OrdType: "optimal_limit_ioc", Sz: "1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "1", resp.Code)
+	assert.Equal(t, "1", resp.Data[0].SCode)
+
+	resp, err = c.PlaceOrder(ctx, obtypes.PlaceOrderParam{InstId: "BTC-USDT", TdMode: "cash", Side: "buy", OrdType: "limit", Px: "100", Sz: "100"})
+	assert.Nil(t, err)
+	assert.Equal(t, "1", resp.Code)
+
+	order, err = c.GetOrder(ctx, obtypes.GetOrderParam{InstId: "BTC-USDT", OrdId: "100"})
+	assert.Nil(t, err)
+	assert.Equal(t, "51603", order.Code)
+}
+
+func TestSwapOrders(t *testing.T) {
+	c := testClient(t)
+	ctx := context.Background()
+
+	resp, err := c.PlaceOrder(ctx, obtypes.PlaceOrderParam{InstId: "BTC-USDT-SWAP", TdMode: "cross", Side: "buy", OrdType: "market", Sz: "1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "0", resp.Code)
+
+	positions, err := c.GetPositions(ctx, tatypes.GetPositionsParam{InstId: "BTC-USDT-SWAP"})
+	assert.Nil(t, err)
+	assert.Len(t, positions.Data, 1)
+	assert.Equal(t, "SWAP", positions.Data[0].InstType)
+	assert.Equal(t, "1", positions.Data[0].Pos)
+	assertDecimal(t, "101", positions.Data[0].AvgPx)
+
+	positions, err = c.GetPositions(ctx, tatypes.GetPositionsParam{InstType: "FUTURES"})
+	assert.Nil(t, err)
+	assert.Empty(t, positions.Data)
+
+	// a post only order crossing the book is canceled
+	resp, err = c.PlaceOrder(ctx, obtypes.PlaceOrderParam{InstId: "BTC-USDT-SWAP", TdMode: "cross", Side: "sell", OrdType: "post_only", Px: "100", Sz: "1"})
+	assert.Nil(t, err)
+	order, err := c.GetOrder(ctx, obtypes.GetOrderParam{InstId: "BTC-USDT-SWAP", OrdId: resp.Data[0].OrdID})
+	assert.Nil(t, err)
+	assert.Equal(t, "canceled", order.Data[0].State)
+
+	for _, px := range []string{"102", "103"} {
+		resp, err = c.PlaceOrder(ctx, obtypes.PlaceOrderParam{InstId: "BTC-USDT-SWAP", TdMode: "cross", Side: "sell", OrdType: "post_only", Px: px, Sz: "1", ReduceOnly: true})
+		assert.Nil(t, err)
+		assert.Equal(t, "0", resp.Code)
+	}
+
+	// the newest orders come first
+	pending, err := c.GetOrdersPending(ctx, obtypes.GetOrdersPendingParam{InstId: "BTC-USDT-SWAP"})
+	assert.Nil(t, err)
+	assert.Len(t, pending.Data, 2)
+	assert.Equal(t, "103", pending.Data[0].Px)
+	assert.Equal(t, "SWAP", pending.Data[0].InstType)
+	assert.Equal(t, "post_only", pending.Data[0].OrdType)
+
+	pending, err = c.GetOrdersPending(ctx, obtypes.GetOrdersPendingParam{InstId: "BTC-USDT-SWAP", OrdType: "limit"})
+	assert.Nil(t, err)
+	assert.Empty(t, pending.Data)
+
+	_, err = c.GetOrdersPending(canceled(), obtypes.GetOrdersPendingParam{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func canceled() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
@@ -19,12 +19,17 @@ package publicdata
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator"
 	"github.com/rluisr/nexapi/okx/publicdata/types"
 	okxutils "github.com/rluisr/nexapi/okx/utils"
 	"github.com/rluisr/nexapi/utils"
+	"github.com/rluisr/nexapi/utils/backfill"
 )
 
 type PublicDataClient struct {
@@ -142,3 +147,604 @@ func (p *PublicDataClient) GetIndexTickers(ctx context.Context, param types.GetI
 
 	return &body, nil
 }
+
+// GetOrderBook retrieves order book of the instrument.
+func (p *PublicDataClient) GetOrderBook(ctx context.Context, param types.GetOrderBookParam) (*types.GetOrderBookResp, error) {
+	err := p.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   p.GetDebug(),
+		BaseURL: p.GetBaseURL(),
+		Path:    "/api/v5/market/books",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := p.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := p.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetOrderBookResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetOrderBookFull retrieves order book of the instrument with up to 5000 levels per side.
+func (p *PublicDataClient) GetOrderBookFull(ctx context.Context, param types.GetOrderBookParam) (*types.GetOrderBookResp, error) {
+	err := p.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   p.GetDebug(),
+		BaseURL: p.GetBaseURL(),
+		Path:    "/api/v5/market/books-full",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := p.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := p.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetOrderBookResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetCandles retrieves the candlestick charts, at most 1,440 recent bars.
+func (p *PublicDataClient) GetCandles(ctx context.Context, param types.GetCandlesParam) (*types.GetCandlesResp, error) {
+	err := p.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   p.GetDebug(),
+		BaseURL: p.GetBaseURL(),
+		Path:    "/api/v5/market/candles",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := p.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := p.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetCandlesResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetHistoryCandles retrieves history candlestick charts from recent years.
+func (p *PublicDataClient) GetHistoryCandles(ctx context.Context, param types.GetCandlesParam) (*types.GetCandlesResp, error) {
+	err := p.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   p.GetDebug(),
+		BaseURL: p.GetBaseURL(),
+		Path:    "/api/v5/market/history-candles",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := p.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := p.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetCandlesResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetIndexCandles retrieves the candlestick charts of the index.
+func (p *PublicDataClient) GetIndexCandles(ctx context.Context, param types.GetCandlesParam) (*types.GetCandlesResp, error) {
+	err := p.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   p.GetDebug(),
+		BaseURL: p.GetBaseURL(),
+		Path:    "/api/v5/market/index-candles",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := p.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := p.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetCandlesResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetHistoryIndexCandles retrieves the candlestick charts of the index from recent years.
+func (p *PublicDataClient) GetHistoryIndexCandles(ctx context.Context, param types.GetCandlesParam) (*types.GetCandlesResp, error) {
+	err := p.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   p.GetDebug(),
+		BaseURL: p.GetBaseURL(),
+		Path:    "/api/v5/market/history-index-candles",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := p.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := p.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetCandlesResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetMarkPriceCandles retrieves the candlestick charts of mark price.
+func (p *PublicDataClient) GetMarkPriceCandles(ctx context.Context, param types.GetCandlesParam) (*types.GetCandlesResp, error) {
+	err := p.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   p.GetDebug(),
+		BaseURL: p.GetBaseURL(),
+		Path:    "/api/v5/market/mark-price-candles",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := p.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := p.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetCandlesResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetHistoryMarkPriceCandles retrieves the candlestick charts of mark price from recent years.
+func (p *PublicDataClient) GetHistoryMarkPriceCandles(ctx context.Context, param types.GetCandlesParam) (*types.GetCandlesResp, error) {
+	err := p.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   p.GetDebug(),
+		BaseURL: p.GetBaseURL(),
+		Path:    "/api/v5/market/history-mark-price-candles",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := p.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := p.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetCandlesResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetTrades retrieves the recent transactions of an instrument.
+func (p *PublicDataClient) GetTrades(ctx context.Context, param types.GetTradesParam) (*types.GetTradesResp, error) {
+	err := p.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   p.GetDebug(),
+		BaseURL: p.GetBaseURL(),
+		Path:    "/api/v5/market/trades",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := p.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := p.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetTradesResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetHistoryTrades retrieves the recent transactions of an instrument from the last 3 months with pagination.
+func (p *PublicDataClient) GetHistoryTrades(ctx context.Context, param types.GetHistoryTradesParam) (*types.GetTradesResp, error) {
+	err := p.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   p.GetDebug(),
+		BaseURL: p.GetBaseURL(),
+		Path:    "/api/v5/market/history-trades",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := p.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := p.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetTradesResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetFundingRate retrieves funding rate.
+func (p *PublicDataClient) GetFundingRate(ctx context.Context, param types.GetFundingRateParam) (*types.GetFundingRateResp, error) {
+	err := p.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   p.GetDebug(),
+		BaseURL: p.GetBaseURL(),
+		Path:    "/api/v5/public/funding-rate",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := p.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := p.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetFundingRateResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetFundingRateHistory retrieves funding rate history. This endpoint can retrieve data from the last 3 months.
+func (p *PublicDataClient) GetFundingRateHistory(ctx context.Context, param types.GetFundingRateHistoryParam) (*types.GetFundingRateHistoryResp, error) {
+	err := p.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   p.GetDebug(),
+		BaseURL: p.GetBaseURL(),
+		Path:    "/api/v5/public/funding-rate-history",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := p.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := p.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetFundingRateHistoryResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetOpenInterest retrieves the total open interest for contracts on OKX.
+func (p *PublicDataClient) GetOpenInterest(ctx context.Context, param types.GetOpenInterestParam) (*types.GetOpenInterestResp, error) {
+	err := p.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   p.GetDebug(),
+		BaseURL: p.GetBaseURL(),
+		Path:    "/api/v5/public/open-interest",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := p.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := p.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetOpenInterestResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetMarkPrice retrieves mark price.
+func (p *PublicDataClient) GetMarkPrice(ctx context.Context, param types.GetMarkPriceParam) (*types.GetMarkPriceResp, error) {
+	err := p.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   p.GetDebug(),
+		BaseURL: p.GetBaseURL(),
+		Path:    "/api/v5/public/mark-price",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := p.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := p.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetMarkPriceResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetPriceLimit retrieves the highest buy limit and lowest sell limit of the instrument.
+func (p *PublicDataClient) GetPriceLimit(ctx context.Context, param types.GetPriceLimitParam) (*types.GetPriceLimitResp, error) {
+	err := p.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   p.GetDebug(),
+		BaseURL: p.GetBaseURL(),
+		Path:    "/api/v5/public/price-limit",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := p.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := p.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetPriceLimitResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetEstimatedPrice retrieves the estimated delivery price which will only have a return value one hour before the delivery/exercise.
+func (p *PublicDataClient) GetEstimatedPrice(ctx context.Context, param types.GetEstimatedPriceParam) (*types.GetEstimatedPriceResp, error) {
+	err := p.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   p.GetDebug(),
+		BaseURL: p.GetBaseURL(),
+		Path:    "/api/v5/public/estimated-price",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := p.GenPubHeaders()
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := p.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetEstimatedPriceResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// HistoryCandleSource describes the history candles of instID for the backfill package,
+// OKX returns at most 100 history candles per request.
+func (p *PublicDataClient) HistoryCandleSource(instID, bar string) (backfill.Source[*types.Candle], error) {
+	d, ok := barDurations[strings.TrimSuffix(bar, "utc")]
+	if !ok {
+		return backfill.Source[*types.Candle]{}, fmt.Errorf("unsupported bar: %s", bar)
+	}
+
+	var align time.Duration
+	if d >= 6*time.Hour && !strings.HasSuffix(bar, "utc") {
+		// candles from 6H are opened at Hong Kong time (UTC+8) boundaries
+		align = -8 * time.Hour
+	}
+
+	return backfill.Source[*types.Candle]{
+		Fetch: func(ctx context.Context, start, end time.Time) ([]*types.Candle, error) {
+			resp, err := p.GetHistoryCandles(ctx, types.GetCandlesParam{
+				InstID: instID,
+				Bar:    bar,
+				After:  strconv.FormatInt(end.UnixMilli(), 10),
+				Before: strconv.FormatInt(start.UnixMilli()-1, 10),
+				Limit:  "100",
+			})
+			if err != nil {
+				return nil, err
+			}
+			if err := resp.Err(); err != nil {
+				return nil, err
+			}
+
+			return resp.Data, nil
+		},
+		OpenTime: func(c *types.Candle) time.Time {
+			ts, _ := strconv.ParseInt(c.TS, 10, 64)
+			return time.UnixMilli(ts)
+		},
+		Interval: d,
+		Limit:    100,
+		Align:    align,
+	}, nil
+}
+
+var barDurations = map[string]time.Duration{
+	"1m":  time.Minute,
+	"3m":  3 * time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"30m": 30 * time.Minute,
+	"1H":  time.Hour,
+	"2H":  2 * time.Hour,
+	"4H":  4 * time.Hour,
+	"6H":  6 * time.Hour,
+	"12H": 12 * time.Hour,
+	"1D":  24 * time.Hour,
+	"1W":  7 * 24 * time.Hour,
+}
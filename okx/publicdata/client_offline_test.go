@@ -0,0 +1,173 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package publicdata
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rluisr/nexapi/okx/okxtest"
+	"github.com/rluisr/nexapi/okx/publicdata/types"
+	okxutils "github.com/rluisr/nexapi/okx/utils"
+	"github.com/rluisr/nexapi/utils/fakeserver"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNewFakePublicDataClient(t *testing.T) (*PublicDataClient, *fakeserver.Server) {
+	srv := okxtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	cli, err := NewPublicDataClient(&okxutils.OKXRestClientCfg{
+		BaseURL:    srv.URL,
+		HTTPClient: srv.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Could not create okx client, %s", err)
+	}
+
+	return cli, srv
+}
+
+func TestFakeEndpoints(t *testing.T) {
+	cli, srv := testNewFakePublicDataClient(t)
+
+	candles := types.GetCandlesParam{InstID: "BTC-USDT", Bar: "1H"}
+
+	fakeserver.RunEndpoints(t, srv, false, []fakeserver.Endpoint{
+		{Name: "GetInstruments", Method: http.MethodGet, Path: "/api/v5/public/instruments", Call: func(ctx context.Context) (any, error) {
+			return cli.GetInstruments(ctx, types.GetInstrumentsParam{InstType: okxutils.Spot})
+		}},
+		{Name: "GetMarketTickers", Method: http.MethodGet, Path: "/api/v5/market/tickers", Call: func(ctx context.Context) (any, error) {
+			return cli.GetMarketTickers(ctx, types.GetMarketTickersParam{InstType: types.Swap})
+		}},
+		{Name: "GetIndexTickers", Method: http.MethodGet, Path: "/api/v5/market/index-tickers", Call: func(ctx context.Context) (any, error) {
+			return cli.GetIndexTickers(ctx, types.GetIndexTickersParam{InstID: "BTC-USD"})
+		}},
+		{Name: "GetOrderBook", Method: http.MethodGet, Path: "/api/v5/market/books", Call: func(ctx context.Context) (any, error) {
+			return cli.GetOrderBook(ctx, types.GetOrderBookParam{InstID: "BTC-USDT"})
+		}},
+		{Name: "GetOrderBookFull", Method: http.MethodGet, Path: "/api/v5/market/books-full", Call: func(ctx context.Context) (any, error) {
+			return cli.GetOrderBookFull(ctx, types.GetOrderBookParam{InstID: "BTC-USDT"})
+		}},
+		{Name: "GetCandles", Method: http.MethodGet, Path: "/api/v5/market/candles", Call: func(ctx context.Context) (any, error) {
+			return cli.GetCandles(ctx, candles)
+		}},
+		{Name: "GetHistoryCandles", Method: http.MethodGet, Path: "/api/v5/market/history-candles", Call: func(ctx context.Context) (any, error) {
+			return cli.GetHistoryCandles(ctx, candles)
+		}},
+		{Name: "GetIndexCandles", Method: http.MethodGet, Path: "/api/v5/market/index-candles", Call: func(ctx context.Context) (any, error) {
+			return cli.GetIndexCandles(ctx, candles)
+		}},
+		{Name: "GetHistoryIndexCandles", Method: http.MethodGet, Path: "/api/v5/market/history-index-candles", Call: func(ctx context.Context) (any, error) {
+			return cli.GetHistoryIndexCandles(ctx, candles)
+		}},
+		{Name: "GetMarkPriceCandles", Method: http.MethodGet, Path: "/api/v5/market/mark-price-candles", Call: func(ctx context.Context) (any, error) {
+			return cli.GetMarkPriceCandles(ctx, candles)
+		}},
+		{Name: "GetHistoryMarkPriceCandles", Method: http.MethodGet, Path: "/api/v5/market/history-mark-price-candles", Call: func(ctx context.Context) (any, error) {
+			return cli.GetHistoryMarkPriceCandles(ctx, candles)
+		}},
+		{Name: "GetTrades", Method: http.MethodGet, Path: "/api/v5/market/trades", Call: func(ctx context.Context) (any, error) {
+			return cli.GetTrades(ctx, types.GetTradesParam{InstID: "BTC-USDT"})
+		}},
+		{Name: "GetHistoryTrades", Method: http.MethodGet, Path: "/api/v5/market/history-trades", Call: func(ctx context.Context) (any, error) {
+			return cli.GetHistoryTrades(ctx, types.GetHistoryTradesParam{InstID: "BTC-USDT"})
+		}},
+		{Name: "GetFundingRate", Method: http.MethodGet, Path: "/api/v5/public/funding-rate", Call: func(ctx context.Context) (any, error) {
+			return cli.GetFundingRate(ctx, types.GetFundingRateParam{InstID: "BTC-USDT-SWAP"})
+		}},
+		{Name: "GetFundingRateHistory", Method: http.MethodGet, Path: "/api/v5/public/funding-rate-history", Call: func(ctx context.Context) (any, error) {
+			return cli.GetFundingRateHistory(ctx, types.GetFundingRateHistoryParam{InstID: "BTC-USDT-SWAP"})
+		}},
+		{Name: "GetOpenInterest", Method: http.MethodGet, Path: "/api/v5/public/open-interest", Call: func(ctx context.Context) (any, error) {
+			return cli.GetOpenInterest(ctx, types.GetOpenInterestParam{InstType: types.Swap})
+		}},
+		{Name: "GetMarkPrice", Method: http.MethodGet, Path: "/api/v5/public/mark-price", Call: func(ctx context.Context) (any, error) {
+			return cli.GetMarkPrice(ctx, types.GetMarkPriceParam{InstType: types.Swap})
+		}},
+		{Name: "GetPriceLimit", Method: http.MethodGet, Path: "/api/v5/public/price-limit", Call: func(ctx context.Context) (any, error) {
+			return cli.GetPriceLimit(ctx, types.GetPriceLimitParam{InstID: "BTC-USDT-SWAP"})
+		}},
+		{Name: "GetEstimatedPrice", Method: http.MethodGet, Path: "/api/v5/public/estimated-price", Call: func(ctx context.Context) (any, error) {
+			return cli.GetEstimatedPrice(ctx, types.GetEstimatedPriceParam{InstID: "BTC-USD-240329"})
+		}},
+	})
+}
+
+func TestFakeGetOrderBook(t *testing.T) {
+	cli, srv := testNewFakePublicDataClient(t)
+
+	srv.Handle(http.MethodGet, "/api/v5/market/books", `{"code":"0","msg":"","data":[{"asks":[["42001.5","2","0","1"],["42001","1","0","2"]],"bids":[["42000","3","0","4"],["41999","5","0","1"]],"ts":"1704067200000"}]}`)
+
+	resp, err := cli.GetOrderBook(context.Background(), types.GetOrderBookParam{InstID: "BTC-USDT", Sz: "2"})
+	assert.Nil(t, err)
+	assert.Nil(t, resp.Err())
+	assert.Equal(t, "2", srv.LastRequest().Query.Get("sz"))
+
+	b, err := resp.Data[0].Book()
+	assert.Nil(t, err)
+	ask, ok := b.BestAsk()
+	assert.True(t, ok)
+	assert.Equal(t, "42001", ask.Price.String())
+	spread, ok := b.Spread()
+	assert.True(t, ok)
+	assert.Equal(t, "1", spread.String())
+
+	// a missing required parameter never reaches the server
+	n := len(srv.Requests())
+	_, err = cli.GetOrderBook(context.Background(), types.GetOrderBookParam{})
+	assert.NotNil(t, err)
+	assert.Len(t, srv.Requests(), n)
+}
+
+func TestFakeHistoryCandleSource(t *testing.T) {
+	cli, srv := testNewFakePublicDataClient(t)
+
+	srv.Handle(http.MethodGet, "/api/v5/market/history-candles", `{"code":"0","msg":"","data":[["1704070800000","42100","42200","42000","42150","10","420000","420000","1"],["1704067200000","42000","42100","41900","42100","12","504000","504000","1"]]}`)
+
+	src, err := cli.HistoryCandleSource("BTC-USDT", "1H")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Hour, src.Interval)
+	assert.Equal(t, time.Duration(0), src.Align)
+
+	start := time.UnixMilli(1704067200000)
+	candles, err := src.Fetch(context.Background(), start, start.Add(2*time.Hour))
+	assert.Nil(t, err)
+	assert.Len(t, candles, 2)
+	assert.Equal(t, start.Add(time.Hour), src.OpenTime(candles[0]))
+	assert.Equal(t, "42150", candles[0].Close)
+
+	q := srv.LastRequest().Query
+	assert.Equal(t, "1704074400000", q.Get("after"))
+	assert.Equal(t, "1704067199999", q.Get("before"))
+	assert.Equal(t, "1H", q.Get("bar"))
+
+	src, err = cli.HistoryCandleSource("BTC-USDT", "1D")
+	assert.Nil(t, err)
+	assert.Equal(t, -8*time.Hour, src.Align)
+
+	_, err = cli.HistoryCandleSource("BTC-USDT", "7m")
+	assert.NotNil(t, err)
+
+	// an error envelope is surfaced by the source
+	srv.Handle(http.MethodGet, "/api/v5/market/history-candles", `{"code":"51001","msg":"Instrument ID does not exist","data":[]}`)
+	_, err = src.Fetch(context.Background(), start, start.Add(24*time.Hour))
+	assert.ErrorContains(t, err, "51001")
+}
@@ -47,3 +47,65 @@ func TestGetInstruments(t *testing.T) {
 	})
 	assert.Nil(t, err)
 }
+
+func TestGetOrderBook(t *testing.T) {
+	cli := testNewPublicDataClient(t)
+
+	_, err := cli.GetOrderBook(context.TODO(), types.GetOrderBookParam{
+		InstID: "BTC-USDT",
+		Sz:     "20",
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetCandles(t *testing.T) {
+	cli := testNewPublicDataClient(t)
+
+	resp, err := cli.GetCandles(context.TODO(), types.GetCandlesParam{
+		InstID: "BTC-USDT",
+		Bar:    "1H",
+		Limit:  "10",
+	})
+	assert.Nil(t, err)
+	if err == nil {
+		assert.NotEmpty(t, resp.Data)
+	}
+}
+
+func TestGetMarkPriceCandles(t *testing.T) {
+	cli := testNewPublicDataClient(t)
+
+	_, err := cli.GetMarkPriceCandles(context.TODO(), types.GetCandlesParam{
+		InstID: "BTC-USDT-SWAP",
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetHistoryTrades(t *testing.T) {
+	cli := testNewPublicDataClient(t)
+
+	_, err := cli.GetHistoryTrades(context.TODO(), types.GetHistoryTradesParam{
+		InstID: "BTC-USDT",
+		Limit:  "10",
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetFundingRate(t *testing.T) {
+	cli := testNewPublicDataClient(t)
+
+	_, err := cli.GetFundingRate(context.TODO(), types.GetFundingRateParam{
+		InstID: "BTC-USDT-SWAP",
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetOpenInterest(t *testing.T) {
+	cli := testNewPublicDataClient(t)
+
+	_, err := cli.GetOpenInterest(context.TODO(), types.GetOpenInterestParam{
+		InstType: types.Swap,
+		InstID:   "BTC-USDT-SWAP",
+	})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	okxutils "github.com/rluisr/nexapi/okx/utils"
+	"github.com/rluisr/nexapi/utils/candle"
+	"github.com/rluisr/nexapi/utils/decimal"
+)
+
+type GetCandlesParam struct {
+	InstID string `url:"instId" validate:"required"`
+	Bar    string `url:"bar,omitempty"`    // Bar size, the default is 1m, e.g. 1m/3m/5m/15m/30m/1H/2H/4H, UTC: 6Hutc/12Hutc/1Dutc/2Dutc/3Dutc/1Wutc/1Mutc/3Mutc
+	After  string `url:"after,omitempty"`  // Pagination of data to return records earlier than the requested ts
+	Before string `url:"before,omitempty"` // Pagination of data to return records newer than the requested ts
+	Limit  string `url:"limit,omitempty"`  // Number of results per request. The maximum is 300 for candles and 100 for the others; The default is 100
+}
+
+type GetCandlesResp struct {
+	okxutils.Response
+	Data []*Candle `json:"data"`
+}
+
+// Candle is decoded from [ts, o, h, l, c, vol, volCcy, volCcyQuote, confirm],
+// index and mark price candles only carry [ts, o, h, l, c, confirm].
+type Candle struct {
+	TS          string
+	Open        string
+	High        string
+	Low         string
+	Close       string
+	Vol         string
+	VolCcy      string
+	VolCcyQuote string
+	// 0: the candle is uncompleted, 1: the candle is completed
+	Confirm string
+}
+
+func (c *Candle) UnmarshalJSON(data []byte) error {
+	var arr []string
+	if err := json.Unmarshal(data, &arr); err != nil {
+		return err
+	}
+
+	switch len(arr) {
+	case 9:
+		c.Vol, c.VolCcy, c.VolCcyQuote = arr[5], arr[6], arr[7]
+		c.Confirm = arr[8]
+	case 6:
+		c.Confirm = arr[5]
+	default:
+		return fmt.Errorf("unknown candle value: %s", string(data))
+	}
+
+	c.TS, c.Open, c.High, c.Low, c.Close = arr[0], arr[1], arr[2], arr[3], arr[4]
+
+	return nil
+}
+
+// Candle converts the candle of the given bar duration for the candle builder.
+// Index and mark price candles have no volume.
+func (c *Candle) Candle(interval time.Duration) (*candle.Candle, error) {
+	ts, err := strconv.ParseInt(c.TS, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	values := []string{c.Open, c.High, c.Low, c.Close, c.Vol, c.VolCcyQuote}
+	v := make([]float64, len(values))
+	for i, s := range values {
+		if s == "" {
+			continue
+		}
+		if v[i], err = strconv.ParseFloat(s, 64); err != nil {
+			return nil, err
+		}
+	}
+
+	return &candle.Candle{
+		OpenTime:    time.UnixMilli(ts),
+		Interval:    interval,
+		Open:        v[0],
+		High:        v[1],
+		Low:         v[2],
+		Close:       v[3],
+		Volume:      v[4],
+		QuoteVolume: v[5],
+		Closed:      c.Confirm == "1",
+	}, nil
+}
+
+func (c *Candle) OpenDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(c.Open)
+}
+
+func (c *Candle) HighDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(c.High)
+}
+
+func (c *Candle) LowDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(c.Low)
+}
+
+func (c *Candle) CloseDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(c.Close)
+}
+
+func (c *Candle) VolDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(c.Vol)
+}
+
+func (c *Candle) VolCcyDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(c.VolCcy)
+}
+
+func (c *Candle) VolCcyQuoteDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(c.VolCcyQuote)
+}
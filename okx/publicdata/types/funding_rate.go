@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import okxutils "github.com/rluisr/nexapi/okx/utils"
+
+type GetFundingRateParam struct {
+	InstID string `url:"instId" validate:"required"` // Instrument ID, e.g. BTC-USD-SWAP, only applicable to SWAP
+}
+
+type GetFundingRateResp struct {
+	okxutils.Response
+	Data []*FundingRate `json:"data"`
+}
+
+type FundingRate struct {
+	InstType        string `json:"instType"`
+	InstID          string `json:"instId"`
+	Method          string `json:"method"`
+	FundingRate     string `json:"fundingRate"`
+	NextFundingRate string `json:"nextFundingRate"`
+	FundingTime     string `json:"fundingTime"`
+	NextFundingTime string `json:"nextFundingTime"`
+	MinFundingRate  string `json:"minFundingRate"`
+	MaxFundingRate  string `json:"maxFundingRate"`
+	SettState       string `json:"settState"`
+	SettFundingRate string `json:"settFundingRate"`
+	Premium         string `json:"premium"`
+	TS              string `json:"ts"`
+}
+
+type GetFundingRateHistoryParam struct {
+	InstID string `url:"instId" validate:"required"`
+	Before string `url:"before,omitempty"` // Pagination of data to return records newer than the requested fundingTime
+	After  string `url:"after,omitempty"`  // Pagination of data to return records earlier than the requested fundingTime
+	Limit  string `url:"limit,omitempty"`  // Number of results per request. The maximum is 100; The default is 100
+}
+
+type GetFundingRateHistoryResp struct {
+	okxutils.Response
+	Data []*FundingRateHistory `json:"data"`
+}
+
+type FundingRateHistory struct {
+	InstType     string `json:"instType"`
+	InstID       string `json:"instId"`
+	FundingRate  string `json:"fundingRate"`
+	RealizedRate string `json:"realizedRate"`
+	FundingTime  string `json:"fundingTime"`
+	Method       string `json:"method"`
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	okxutils "github.com/rluisr/nexapi/okx/utils"
+	"github.com/rluisr/nexapi/utils/book"
+)
+
+type GetOrderBookParam struct {
+	InstID string `url:"instId" validate:"required"`
+	Sz     string `url:"sz,omitempty"` // Order book depth per side. Maximum 400 for books and 5000 for books-full, e.g. 400 bids + 400 asks
+}
+
+type GetOrderBookResp struct {
+	okxutils.Response
+	Data []*OrderBook `json:"data"`
+}
+
+// OrderBook levels are [price, size, deprecated, number of orders].
+type OrderBook struct {
+	Asks [][]string `json:"asks"`
+	Bids [][]string `json:"bids"`
+	TS   string     `json:"ts"`
+}
+
+// Book returns the typed and sorted levels of the order book.
+func (o *OrderBook) Book() (*book.Book, error) {
+	return book.FromStrings(o.Bids, o.Asks)
+}
+
+func (o *OrderBook) BidLevels() ([]book.PriceLevel, error) {
+	return book.ParseLevels(o.Bids)
+}
+
+func (o *OrderBook) AskLevels() ([]book.PriceLevel, error) {
+	return book.ParseLevels(o.Asks)
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import okxutils "github.com/rluisr/nexapi/okx/utils"
+
+type GetOpenInterestParam struct {
+	InstType   InstrumentType `url:"instType" validate:"required,oneof=SWAP FUTURES OPTION"`
+	Uly        string         `url:"uly,omitempty"`
+	InstFamily string         `url:"instFamily,omitempty"`
+	InstID     string         `url:"instId,omitempty"`
+}
+
+type GetOpenInterestResp struct {
+	okxutils.Response
+	Data []*OpenInterest `json:"data"`
+}
+
+type OpenInterest struct {
+	InstType string `json:"instType"`
+	InstID   string `json:"instId"`
+	Oi       string `json:"oi"`
+	OiCcy    string `json:"oiCcy"`
+	TS       string `json:"ts"`
+}
+
+type GetMarkPriceParam struct {
+	InstType   InstrumentType `url:"instType" validate:"required,oneof=MARGIN SWAP FUTURES OPTION"`
+	Uly        string         `url:"uly,omitempty"`
+	InstFamily string         `url:"instFamily,omitempty"`
+	InstID     string         `url:"instId,omitempty"`
+}
+
+type GetMarkPriceResp struct {
+	okxutils.Response
+	Data []*MarkPrice `json:"data"`
+}
+
+type MarkPrice struct {
+	InstType string `json:"instType"`
+	InstID   string `json:"instId"`
+	MarkPx   string `json:"markPx"`
+	TS       string `json:"ts"`
+}
+
+type GetPriceLimitParam struct {
+	InstID string `url:"instId" validate:"required"`
+}
+
+type GetPriceLimitResp struct {
+	okxutils.Response
+	Data []*PriceLimit `json:"data"`
+}
+
+type PriceLimit struct {
+	InstType string `json:"instType"`
+	InstID   string `json:"instId"`
+	BuyLmt   string `json:"buyLmt"`
+	SellLmt  string `json:"sellLmt"`
+	Enabled  bool   `json:"enabled"`
+	TS       string `json:"ts"`
+}
+
+type GetEstimatedPriceParam struct {
+	InstID string `url:"instId" validate:"required"` // Instrument ID, e.g. BTC-USD-200214, only applicable to FUTURES/OPTION
+}
+
+type GetEstimatedPriceResp struct {
+	okxutils.Response
+	Data []*EstimatedPrice `json:"data"`
+}
+
+type EstimatedPrice struct {
+	InstType string `json:"instType"`
+	InstID   string `json:"instId"`
+	SettlePx string `json:"settlePx"`
+	TS       string `json:"ts"`
+}
@@ -17,7 +17,10 @@
 
 package types
 
-import okxutils "github.com/rluisr/nexapi/okx/utils"
+import (
+	okxutils "github.com/rluisr/nexapi/okx/utils"
+	"github.com/rluisr/nexapi/utils/decimal"
+)
 
 type GetMarketTickersParam struct {
 	InstType   InstrumentType `url:"instType,omitempty" validate:"omitempty,oneof=SPOT SWAP FUTURES OPTION"`
@@ -78,3 +81,47 @@ type IndexTicker struct {
 	SodUtc8 string `json:"sodUtc8"`
 	Ts      string `json:"ts"`
 }
+
+func (t *MarketTicker) LastDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.Last)
+}
+
+func (t *MarketTicker) LastSzDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.LastSz)
+}
+
+func (t *MarketTicker) AskPxDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.AskPx)
+}
+
+func (t *MarketTicker) AskSzDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.AskSz)
+}
+
+func (t *MarketTicker) BidPxDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.BidPx)
+}
+
+func (t *MarketTicker) BidSzDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.BidSz)
+}
+
+func (t *MarketTicker) Open24hDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.Open24h)
+}
+
+func (t *MarketTicker) High24hDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.High24h)
+}
+
+func (t *MarketTicker) Low24hDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.Low24h)
+}
+
+func (t *MarketTicker) VolCcy24hDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.VolCcy24h)
+}
+
+func (t *MarketTicker) Vol24hDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.Vol24h)
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	"strconv"
+	"time"
+
+	okxutils "github.com/rluisr/nexapi/okx/utils"
+	"github.com/rluisr/nexapi/utils/candle"
+	"github.com/rluisr/nexapi/utils/decimal"
+)
+
+type GetTradesParam struct {
+	InstID string `url:"instId" validate:"required"`
+	Limit  string `url:"limit,omitempty"` // Number of results per request. The maximum is 500; The default is 100
+}
+
+type GetHistoryTradesParam struct {
+	InstID string `url:"instId" validate:"required"`
+	Type   string `url:"type,omitempty" validate:"omitempty,oneof=1 2"` // Pagination type, 1: tradeId, 2: timestamp. The default is 1
+	After  string `url:"after,omitempty"`                               // Pagination of data to return records earlier than the requested tradeId or ts
+	Before string `url:"before,omitempty"`                              // Pagination of data to return records newer than the requested tradeId, do not support timestamp
+	Limit  string `url:"limit,omitempty"`                               // Number of results per request. The maximum is 100; The default is 100
+}
+
+type GetTradesResp struct {
+	okxutils.Response
+	Data []*Trade `json:"data"`
+}
+
+type Trade struct {
+	InstID  string `json:"instId"`
+	TradeID string `json:"tradeId"`
+	Px      string `json:"px"`
+	Sz      string `json:"sz"`
+	Side    string `json:"side"`
+	Count   string `json:"count"`
+	TS      string `json:"ts"`
+}
+
+// CandleTrade converts the trade for the candle builder, the size is in contracts for derivatives.
+func (t *Trade) CandleTrade() (*candle.Trade, error) {
+	ts, err := strconv.ParseInt(t.TS, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	px, err := strconv.ParseFloat(t.Px, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	sz, err := strconv.ParseFloat(t.Sz, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &candle.Trade{Time: time.UnixMilli(ts), Price: px, Qty: sz}, nil
+}
+
+func (t *Trade) PxDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.Px)
+}
+
+func (t *Trade) SzDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(t.Sz)
+}
@@ -0,0 +1,488 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package subaccount
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/rluisr/nexapi/okx/subaccount/types"
+	okxutils "github.com/rluisr/nexapi/okx/utils"
+	"github.com/rluisr/nexapi/utils"
+)
+
+type SubAccountClient struct {
+	*okxutils.OKXRestClient
+
+	// validate struct fields
+	validate *validator.Validate
+}
+
+type SubAccountClientCfg struct {
+	BaseURL    string `validate:"required"`
+	HTTPClient *http.Client
+	Key        string `validate:"required"`
+	Secret     string `validate:"required"`
+	Passphrase string `validate:"required"`
+	Debug      bool
+	IsDemo     bool
+	// Logger
+	Logger *slog.Logger
+}
+
+func NewSubAccountClient(cfg *SubAccountClientCfg) (*SubAccountClient, error) {
+	validator := validator.New()
+
+	err := validator.Struct(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := okxutils.NewOKXRestClient(&okxutils.OKXRestClientCfg{
+		Debug:      cfg.Debug,
+		IsDemo:     cfg.IsDemo,
+		Logger:     cfg.Logger,
+		BaseURL:    cfg.BaseURL,
+		HTTPClient: cfg.HTTPClient,
+		Key:        cfg.Key,
+		Secret:     cfg.Secret,
+		Passphrase: cfg.Passphrase,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SubAccountClient{
+		OKXRestClient: cli,
+		validate:      validator,
+	}, nil
+}
+
+// GetSubAccountList retrieves a list of all sub-accounts under the current account.
+func (s *SubAccountClient) GetSubAccountList(ctx context.Context, param types.GetSubAccountListParam) (*types.GetSubAccountListResp, error) {
+	err := s.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   s.GetDebug(),
+		BaseURL: s.GetBaseURL(),
+		Path:    "/api/v5/users/subaccount/list",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := s.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := s.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetSubAccountListResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// SetTransferOut sets permission of transfer out for sub-account (only applicable to master account API key).
+func (s *SubAccountClient) SetTransferOut(ctx context.Context, param types.SetTransferOutParam) (*types.SetTransferOutResp, error) {
+	err := s.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   s.GetDebug(),
+		BaseURL: s.GetBaseURL(),
+		Path:    "/api/v5/users/subaccount/set-transfer-out",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	headers, err := s.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := s.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.SetTransferOutResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetTradingBalances queries detailed balance info of the trading account of a sub-account via the master account.
+func (s *SubAccountClient) GetTradingBalances(ctx context.Context, param types.GetTradingBalancesParam) (*types.GetTradingBalancesResp, error) {
+	err := s.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   s.GetDebug(),
+		BaseURL: s.GetBaseURL(),
+		Path:    "/api/v5/account/subaccount/balances",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := s.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := s.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetTradingBalancesResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetFundingBalances queries detailed balance info of the funding account of a sub-account via the master account.
+func (s *SubAccountClient) GetFundingBalances(ctx context.Context, param types.GetFundingBalancesParam) (*types.GetFundingBalancesResp, error) {
+	err := s.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   s.GetDebug(),
+		BaseURL: s.GetBaseURL(),
+		Path:    "/api/v5/asset/subaccount/balances",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := s.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := s.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetFundingBalancesResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// TransferToSubAccount transfers funds from the master account to a sub-account.
+func (s *SubAccountClient) TransferToSubAccount(ctx context.Context, param types.MasterSubTransferParam) (*types.MasterSubTransferResp, error) {
+	param.Type = "1"
+
+	err := s.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   s.GetDebug(),
+		BaseURL: s.GetBaseURL(),
+		Path:    "/api/v5/asset/transfer",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	headers, err := s.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := s.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.MasterSubTransferResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// TransferFromSubAccount transfers funds from a sub-account to the master account.
+func (s *SubAccountClient) TransferFromSubAccount(ctx context.Context, param types.MasterSubTransferParam) (*types.MasterSubTransferResp, error) {
+	param.Type = "2"
+
+	err := s.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   s.GetDebug(),
+		BaseURL: s.GetBaseURL(),
+		Path:    "/api/v5/asset/transfer",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	headers, err := s.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := s.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.MasterSubTransferResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// SubAccountTransfer transfers funds between sub-accounts, applies to the master account API key only.
+func (s *SubAccountClient) SubAccountTransfer(ctx context.Context, param types.SubAccountTransferParam) (*types.SubAccountTransferResp, error) {
+	err := s.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   s.GetDebug(),
+		BaseURL: s.GetBaseURL(),
+		Path:    "/api/v5/asset/subaccount/transfer",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	headers, err := s.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := s.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.SubAccountTransferResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetSubAccountBills retrieves the transfer records between the master account and sub-accounts of the last 3 months.
+func (s *SubAccountClient) GetSubAccountBills(ctx context.Context, param types.GetSubAccountBillsParam) (*types.GetSubAccountBillsResp, error) {
+	err := s.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   s.GetDebug(),
+		BaseURL: s.GetBaseURL(),
+		Path:    "/api/v5/asset/subaccount/bills",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := s.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := s.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetSubAccountBillsResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// CreateAPIKey creates an API key for a sub-account, applies to the master account API key only.
+func (s *SubAccountClient) CreateAPIKey(ctx context.Context, param types.CreateAPIKeyParam) (*types.CreateAPIKeyResp, error) {
+	err := s.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   s.GetDebug(),
+		BaseURL: s.GetBaseURL(),
+		Path:    "/api/v5/users/subaccount/apikey",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	headers, err := s.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := s.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.CreateAPIKeyResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetAPIKeys queries the API keys of a sub-account, applies to the master account API key only.
+func (s *SubAccountClient) GetAPIKeys(ctx context.Context, param types.GetAPIKeysParam) (*types.GetAPIKeysResp, error) {
+	err := s.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   s.GetDebug(),
+		BaseURL: s.GetBaseURL(),
+		Path:    "/api/v5/users/subaccount/apikey",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := s.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := s.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetAPIKeysResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// ModifyAPIKey modifies the API key of a sub-account, applies to the master account API key only.
+func (s *SubAccountClient) ModifyAPIKey(ctx context.Context, param types.ModifyAPIKeyParam) (*types.ModifyAPIKeyResp, error) {
+	err := s.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   s.GetDebug(),
+		BaseURL: s.GetBaseURL(),
+		Path:    "/api/v5/users/subaccount/modify-apikey",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	headers, err := s.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := s.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.ModifyAPIKeyResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// DeleteAPIKey deletes the API key of a sub-account, applies to the master account API key only.
+func (s *SubAccountClient) DeleteAPIKey(ctx context.Context, param types.DeleteAPIKeyParam) (*types.DeleteAPIKeyResp, error) {
+	err := s.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   s.GetDebug(),
+		BaseURL: s.GetBaseURL(),
+		Path:    "/api/v5/users/subaccount/delete-apikey",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	headers, err := s.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := s.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.DeleteAPIKeyResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
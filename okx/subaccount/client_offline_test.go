@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package subaccount
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	fundingtypes "github.com/rluisr/nexapi/okx/funding/types"
+	"github.com/rluisr/nexapi/okx/okxtest"
+	"github.com/rluisr/nexapi/okx/subaccount/types"
+	"github.com/rluisr/nexapi/utils/fakeserver"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNewFakeSubAccountClient(t *testing.T, secret string) (*SubAccountClient, *fakeserver.Server) {
+	srv := okxtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	cli, err := NewSubAccountClient(&SubAccountClientCfg{
+		BaseURL:    srv.URL,
+		Key:        okxtest.Key,
+		Secret:     secret,
+		Passphrase: okxtest.Passphrase,
+		HTTPClient: srv.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Could not create okx private client, %s", err)
+	}
+
+	return cli, srv
+}
+
+func TestFakeEndpoints(t *testing.T) {
+	cli, srv := testNewFakeSubAccountClient(t, okxtest.Secret)
+
+	transfer := types.MasterSubTransferParam{Ccy: "USDT", Amt: "10", From: fundingtypes.FundingAccount, To: fundingtypes.FundingAccount, SubAcct: "sub1"}
+
+	fakeserver.RunEndpoints(t, srv, true, []fakeserver.Endpoint{
+		{Name: "GetSubAccountList", Method: http.MethodGet, Path: "/api/v5/users/subaccount/list", Call: func(ctx context.Context) (any, error) {
+			return cli.GetSubAccountList(ctx, types.GetSubAccountListParam{})
+		}},
+		{Name: "SetTransferOut", Method: http.MethodPost, Path: "/api/v5/users/subaccount/set-transfer-out", Call: func(ctx context.Context) (any, error) {
+			return cli.SetTransferOut(ctx, types.SetTransferOutParam{SubAcct: "sub1"})
+		}},
+		{Name: "GetTradingBalances", Method: http.MethodGet, Path: "/api/v5/account/subaccount/balances", Call: func(ctx context.Context) (any, error) {
+			return cli.GetTradingBalances(ctx, types.GetTradingBalancesParam{SubAcct: "sub1"})
+		}},
+		{Name: "GetFundingBalances", Method: http.MethodGet, Path: "/api/v5/asset/subaccount/balances", Call: func(ctx context.Context) (any, error) {
+			return cli.GetFundingBalances(ctx, types.GetFundingBalancesParam{SubAcct: "sub1"})
+		}},
+		{Name: "TransferToSubAccount", Method: http.MethodPost, Path: "/api/v5/asset/transfer", Call: func(ctx context.Context) (any, error) {
+			return cli.TransferToSubAccount(ctx, transfer)
+		}},
+		{Name: "TransferFromSubAccount", Method: http.MethodPost, Path: "/api/v5/asset/transfer", Call: func(ctx context.Context) (any, error) {
+			return cli.TransferFromSubAccount(ctx, transfer)
+		}},
+		{Name: "SubAccountTransfer", Method: http.MethodPost, Path: "/api/v5/asset/subaccount/transfer", Call: func(ctx context.Context) (any, error) {
+			return cli.SubAccountTransfer(ctx, types.SubAccountTransferParam{Ccy: "USDT", Amt: "10", From: fundingtypes.FundingAccount, To: fundingtypes.FundingAccount, FromSubAccount: "sub1", ToSubAccount: "sub2"})
+		}},
+		{Name: "GetSubAccountBills", Method: http.MethodGet, Path: "/api/v5/asset/subaccount/bills", Call: func(ctx context.Context) (any, error) {
+			return cli.GetSubAccountBills(ctx, types.GetSubAccountBillsParam{})
+		}},
+		{Name: "CreateAPIKey", Method: http.MethodPost, Path: "/api/v5/users/subaccount/apikey", Call: func(ctx context.Context) (any, error) {
+			return cli.CreateAPIKey(ctx, types.CreateAPIKeyParam{SubAcct: "sub1", Label: "bot", Passphrase: "Passphrase-1"})
+		}},
+		{Name: "GetAPIKeys", Method: http.MethodGet, Path: "/api/v5/users/subaccount/apikey", Call: func(ctx context.Context) (any, error) {
+			return cli.GetAPIKeys(ctx, types.GetAPIKeysParam{SubAcct: "sub1"})
+		}},
+		{Name: "ModifyAPIKey", Method: http.MethodPost, Path: "/api/v5/users/subaccount/modify-apikey", Call: func(ctx context.Context) (any, error) {
+			return cli.ModifyAPIKey(ctx, types.ModifyAPIKeyParam{SubAcct: "sub1", APIKey: "k1"})
+		}},
+		{Name: "DeleteAPIKey", Method: http.MethodPost, Path: "/api/v5/users/subaccount/delete-apikey", Call: func(ctx context.Context) (any, error) {
+			return cli.DeleteAPIKey(ctx, types.DeleteAPIKeyParam{SubAcct: "sub1", APIKey: "k1"})
+		}},
+	})
+}
+
+func TestFakeMasterSubTransfer(t *testing.T) {
+	cli, srv := testNewFakeSubAccountClient(t, okxtest.Secret)
+
+	transfer := types.MasterSubTransferParam{Ccy: "USDT", Amt: "10", From: fundingtypes.FundingAccount, To: fundingtypes.TradingAccount, SubAcct: "sub1"}
+
+	// the direction is set by the method regardless of the param
+	var body types.MasterSubTransferParam
+	_, err := cli.TransferToSubAccount(context.Background(), transfer)
+	assert.Nil(t, err)
+	assert.Nil(t, srv.LastRequest().JSON(&body))
+	assert.Equal(t, "1", body.Type)
+	assert.Equal(t, "sub1", body.SubAcct)
+
+	transfer.Type = "1"
+	_, err = cli.TransferFromSubAccount(context.Background(), transfer)
+	assert.Nil(t, err)
+	assert.Nil(t, srv.LastRequest().JSON(&body))
+	assert.Equal(t, "2", body.Type)
+}
+
+func TestFakeInvalidSignature(t *testing.T) {
+	cli, _ := testNewFakeSubAccountClient(t, "wrong-secret")
+
+	_, err := cli.GetSubAccountList(context.Background(), types.GetSubAccountListParam{})
+	okxtest.AssertInvalidSignature(t, err)
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package subaccount
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/rluisr/nexapi/okx/subaccount/types"
+	"github.com/rluisr/nexapi/okx/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNewSubAccountClient(t *testing.T) *SubAccountClient {
+	cli, err := NewSubAccountClient(&SubAccountClientCfg{
+		Debug:      true,
+		BaseURL:    utils.RestURL,
+		Key:        os.Getenv("OKX_KEY"),
+		Secret:     os.Getenv("OKX_SECRET"),
+		Passphrase: os.Getenv("OKX_PASS"),
+	})
+
+	if err != nil {
+		t.Fatalf("Could not create okx sub-account client, %s", err)
+	}
+
+	return cli
+}
+
+func TestGetSubAccountList(t *testing.T) {
+	cli := testNewSubAccountClient(t)
+
+	_, err := cli.GetSubAccountList(context.TODO(), types.GetSubAccountListParam{})
+	assert.Nil(t, err)
+}
+
+func TestGetSubAccountBalances(t *testing.T) {
+	cli := testNewSubAccountClient(t)
+
+	list, err := cli.GetSubAccountList(context.TODO(), types.GetSubAccountListParam{
+		Limit: "1",
+	})
+	assert.Nil(t, err)
+	if err != nil || len(list.Data) == 0 {
+		return
+	}
+
+	_, err = cli.GetTradingBalances(context.TODO(), types.GetTradingBalancesParam{
+		SubAcct: list.Data[0].SubAcct,
+	})
+	assert.Nil(t, err)
+
+	_, err = cli.GetFundingBalances(context.TODO(), types.GetFundingBalancesParam{
+		SubAcct: list.Data[0].SubAcct,
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetSubAccountBills(t *testing.T) {
+	cli := testNewSubAccountClient(t)
+
+	_, err := cli.GetSubAccountBills(context.TODO(), types.GetSubAccountBillsParam{})
+	assert.Nil(t, err)
+}
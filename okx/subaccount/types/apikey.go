@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import okxutils "github.com/rluisr/nexapi/okx/utils"
+
+type CreateAPIKeyParam struct {
+	SubAcct    string `json:"subAcct" validate:"required"`
+	Label      string `json:"label" validate:"required"`      // API Key note, 50 characters at most
+	Passphrase string `json:"passphrase" validate:"required"` // API Key password, supports 8-32 alphanumeric characters containing at least 1 number, 1 uppercase letter, 1 lowercase letter and 1 special character
+	Perm       string `json:"perm,omitempty"`                 // API Key permissions, read_only, trade. Separate with commas if more than one.
+	IP         string `json:"ip,omitempty"`                   // Link IP addresses, separate with commas if more than one. Support up to 20 addresses.
+}
+
+type CreateAPIKeyResp struct {
+	okxutils.Response
+	Data []*APIKey `json:"data"`
+}
+
+type APIKey struct {
+	SubAcct    string `json:"subAcct"`
+	Label      string `json:"label"`
+	APIKey     string `json:"apiKey"`
+	SecretKey  string `json:"secretKey"`  // Only returned when the API key is created
+	Passphrase string `json:"passphrase"` // Only returned when the API key is created
+	Perm       string `json:"perm"`
+	IP         string `json:"ip"`
+	TS         string `json:"ts"`
+}
+
+type GetAPIKeysParam struct {
+	SubAcct string `url:"subAcct" validate:"required"`
+	APIKey  string `url:"apiKey,omitempty"`
+}
+
+type GetAPIKeysResp struct {
+	okxutils.Response
+	Data []*APIKey `json:"data"`
+}
+
+type ModifyAPIKeyParam struct {
+	SubAcct string `json:"subAcct" validate:"required"`
+	APIKey  string `json:"apiKey" validate:"required"`
+	Label   string `json:"label,omitempty"`
+	Perm    string `json:"perm,omitempty"` // API Key permissions, read_only, trade. Separate with commas if more than one.
+	IP      string `json:"ip,omitempty"`   // Link IP addresses, separate with commas if more than one.
+}
+
+type ModifyAPIKeyResp struct {
+	okxutils.Response
+	Data []*APIKey `json:"data"`
+}
+
+type DeleteAPIKeyParam struct {
+	SubAcct string `json:"subAcct" validate:"required"`
+	APIKey  string `json:"apiKey" validate:"required"`
+}
+
+type DeleteAPIKeyResp struct {
+	okxutils.Response
+	Data []struct {
+		SubAcct string `json:"subAcct"`
+	} `json:"data"`
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	tatypes "github.com/rluisr/nexapi/okx/tradingaccount/types"
+	okxutils "github.com/rluisr/nexapi/okx/utils"
+)
+
+type GetTradingBalancesParam struct {
+	SubAcct string `url:"subAcct" validate:"required"`
+}
+
+type GetTradingBalancesResp struct {
+	okxutils.Response
+	Data []*TradingBalance `json:"data"`
+}
+
+// TradingBalance of the sub-account's trading account
+type TradingBalance struct {
+	AdjEq       string                  `json:"adjEq"`
+	BorrowFroz  string                  `json:"borrowFroz"`
+	Details     []tatypes.BalanceDetail `json:"details"`
+	Imr         string                  `json:"imr"`
+	IsoEq       string                  `json:"isoEq"`
+	MgnRatio    string                  `json:"mgnRatio"`
+	Mmr         string                  `json:"mmr"`
+	NotionalUsd string                  `json:"notionalUsd"`
+	OrdFroz     string                  `json:"ordFroz"`
+	TotalEq     string                  `json:"totalEq"`
+	UTime       string                  `json:"uTime"`
+}
+
+type GetFundingBalancesParam struct {
+	SubAcct string `url:"subAcct" validate:"required"`
+	Ccy     string `url:"ccy,omitempty"` // Single currency or multiple currencies (no more than 20) separated with comma, e.g. BTC or BTC,ETH
+}
+
+type GetFundingBalancesResp struct {
+	okxutils.Response
+	Data []*FundingBalance `json:"data"`
+}
+
+// FundingBalance of the sub-account's funding account
+type FundingBalance struct {
+	Ccy       string `json:"ccy"`
+	Bal       string `json:"bal"`
+	FrozenBal string `json:"frozenBal"`
+	AvailBal  string `json:"availBal"`
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import okxutils "github.com/rluisr/nexapi/okx/utils"
+
+type GetSubAccountListParam struct {
+	Enable  string `url:"enable,omitempty" validate:"omitempty,oneof=true false"` // Sub-account status, true: Normal, false: Frozen
+	SubAcct string `url:"subAcct,omitempty"`
+	After   string `url:"after,omitempty"`  // Query the data earlier than the requested sub-account creation timestamp, Unix timestamp format in milliseconds
+	Before  string `url:"before,omitempty"` // Query the data newer than the requested sub-account creation timestamp, Unix timestamp format in milliseconds
+	Limit   string `url:"limit,omitempty"`  // Number of results per request. The maximum is 100; The default is 100
+}
+
+type GetSubAccountListResp struct {
+	okxutils.Response
+	Data []*SubAccount `json:"data"`
+}
+
+// SubAccount
+// doc: https://www.okx.com/docs-v5/en/#sub-account-rest-api-get-sub-account-list
+type SubAccount struct {
+	Type        string   `json:"type"` // Sub-account type, 1: Standard sub-account, 2: Managed trading sub-account, 5: Custody trading sub-account - Copper
+	Enable      bool     `json:"enable"`
+	SubAcct     string   `json:"subAcct"`
+	UID         string   `json:"uid"`
+	Label       string   `json:"label"`
+	Mobile      string   `json:"mobile"`
+	GAuth       bool     `json:"gAuth"`
+	FrozenFunc  []string `json:"frozenFunc"`
+	CanTransOut bool     `json:"canTransOut"`
+	TS          string   `json:"ts"`
+}
+
+type SetTransferOutParam struct {
+	SubAcct     string `json:"subAcct" validate:"required"` // Name of the sub-account. Single sub-account or multiple sub-account (no more than 20) separated with comma.
+	CanTransOut bool   `json:"canTransOut"`                 // Whether the sub-account has the right to transfer out
+}
+
+type SetTransferOutResp struct {
+	okxutils.Response
+	Data []struct {
+		SubAcct     string `json:"subAcct"`
+		CanTransOut bool   `json:"canTransOut"`
+	} `json:"data"`
+}
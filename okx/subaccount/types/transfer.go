@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	fundingtypes "github.com/rluisr/nexapi/okx/funding/types"
+	okxutils "github.com/rluisr/nexapi/okx/utils"
+)
+
+// MasterSubTransferParam moves funds between the master account and a sub-account,
+// the direction is decided by the method used.
+type MasterSubTransferParam struct {
+	Ccy       string                   `json:"ccy" validate:"required"`
+	Amt       string                   `json:"amt" validate:"required"`
+	From      fundingtypes.AccountType `json:"from" validate:"required,oneof=6 18"` // The remitting account, 6: Funding account, 18: Trading account
+	To        fundingtypes.AccountType `json:"to" validate:"required,oneof=6 18"`   // The beneficiary account, 6: Funding account, 18: Trading account
+	SubAcct   string                   `json:"subAcct" validate:"required"`
+	LoanTrans bool                     `json:"loanTrans,omitempty"`
+	ClientID  string                   `json:"clientId,omitempty"`
+	// set by TransferToSubAccount and TransferFromSubAccount
+	Type string `json:"type"`
+}
+
+type MasterSubTransferResp struct {
+	okxutils.Response
+	Data []struct {
+		TransID  string `json:"transId"`
+		ClientID string `json:"clientId"`
+		Ccy      string `json:"ccy"`
+		From     string `json:"from"`
+		Amt      string `json:"amt"`
+		To       string `json:"to"`
+	} `json:"data"`
+}
+
+type SubAccountTransferParam struct {
+	Ccy            string                   `json:"ccy" validate:"required"`
+	Amt            string                   `json:"amt" validate:"required"`
+	From           fundingtypes.AccountType `json:"from" validate:"required,oneof=6 18"` // The remitting account, 6: Funding account, 18: Trading account
+	To             fundingtypes.AccountType `json:"to" validate:"required,oneof=6 18"`   // The beneficiary account, 6: Funding account, 18: Trading account
+	FromSubAccount string                   `json:"fromSubAccount" validate:"required"`
+	ToSubAccount   string                   `json:"toSubAccount" validate:"required"`
+	LoanTrans      bool                     `json:"loanTrans,omitempty"`
+	OmitPosRisk    string                   `json:"omitPosRisk,omitempty" validate:"omitempty,oneof=true false"`
+}
+
+type SubAccountTransferResp struct {
+	okxutils.Response
+	Data []struct {
+		TransID string `json:"transId"`
+	} `json:"data"`
+}
+
+type GetSubAccountBillsParam struct {
+	Ccy     string `url:"ccy,omitempty"`
+	Type    string `url:"type,omitempty" validate:"omitempty,oneof=0 1"` // Transfer type, 0: Transfers from master account to sub-account, 1: Transfers from sub-account to master account
+	SubAcct string `url:"subAcct,omitempty"`
+	After   string `url:"after,omitempty"`  // Query the data prior to the requested bill ID creation time, Unix timestamp format in milliseconds
+	Before  string `url:"before,omitempty"` // Query the data after the requested bill ID creation time, Unix timestamp format in milliseconds
+	Limit   string `url:"limit,omitempty"`  // Number of results per request. The maximum is 100; The default is 100
+}
+
+type GetSubAccountBillsResp struct {
+	okxutils.Response
+	Data []*SubAccountBill `json:"data"`
+}
+
+type SubAccountBill struct {
+	BillID  string `json:"billId"`
+	Ccy     string `json:"ccy"`
+	Amt     string `json:"amt"`
+	Type    string `json:"type"`
+	SubAcct string `json:"subAcct"`
+	TS      string `json:"ts"`
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/rluisr/nexapi/okx/tradingaccount/types"
 	okxutils "github.com/rluisr/nexapi/okx/utils"
 	"github.com/rluisr/nexapi/utils"
+	"github.com/rluisr/nexapi/utils/pagination"
 )
 
 type TradingAccountClient struct {
@@ -140,3 +141,438 @@ func (t *TradingAccountClient) GetPositions(ctx context.Context, param types.Get
 
 	return &body, nil
 }
+
+func (t *TradingAccountClient) GetAccountConfig(ctx context.Context) (*types.GetAccountConfigResp, error) {
+	req := utils.HTTPRequest{
+		Debug:   t.GetDebug(),
+		BaseURL: t.GetBaseURL(),
+		Path:    "/api/v5/account/config",
+		Method:  http.MethodGet,
+	}
+
+	headers, err := t.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := t.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetAccountConfigResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// SetPositionMode switches between long/short and net mode for FUTURES and SWAP.
+func (t *TradingAccountClient) SetPositionMode(ctx context.Context, param types.SetPositionModeParam) (*types.SetPositionModeResp, error) {
+	err := t.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   t.GetDebug(),
+		BaseURL: t.GetBaseURL(),
+		Path:    "/api/v5/account/set-position-mode",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	headers, err := t.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := t.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.SetPositionModeResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+func (t *TradingAccountClient) SetLeverage(ctx context.Context, param types.SetLeverageParam) (*types.SetLeverageResp, error) {
+	err := t.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   t.GetDebug(),
+		BaseURL: t.GetBaseURL(),
+		Path:    "/api/v5/account/set-leverage",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	headers, err := t.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := t.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.SetLeverageResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+func (t *TradingAccountClient) GetLeverageInfo(ctx context.Context, param types.GetLeverageInfoParam) (*types.GetLeverageInfoResp, error) {
+	err := t.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   t.GetDebug(),
+		BaseURL: t.GetBaseURL(),
+		Path:    "/api/v5/account/leverage-info",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := t.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := t.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetLeverageInfoResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetMaxSize returns the maximum quantity to buy or sell, it is the order size limit at the current leverage.
+func (t *TradingAccountClient) GetMaxSize(ctx context.Context, param types.GetMaxSizeParam) (*types.GetMaxSizeResp, error) {
+	err := t.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   t.GetDebug(),
+		BaseURL: t.GetBaseURL(),
+		Path:    "/api/v5/account/max-size",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := t.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := t.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetMaxSizeResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetMaxAvailSize returns the maximum available balance or equity for an order.
+func (t *TradingAccountClient) GetMaxAvailSize(ctx context.Context, param types.GetMaxAvailSizeParam) (*types.GetMaxAvailSizeResp, error) {
+	err := t.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   t.GetDebug(),
+		BaseURL: t.GetBaseURL(),
+		Path:    "/api/v5/account/max-avail-size",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := t.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := t.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetMaxAvailSizeResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// AdjustMargin increases or decreases the margin of an isolated position.
+func (t *TradingAccountClient) AdjustMargin(ctx context.Context, param types.AdjustMarginParam) (*types.AdjustMarginResp, error) {
+	err := t.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   t.GetDebug(),
+		BaseURL: t.GetBaseURL(),
+		Path:    "/api/v5/account/position/margin-balance",
+		Method:  http.MethodPost,
+		Body:    param,
+	}
+
+	headers, err := t.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := t.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.AdjustMarginResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetBills retrieves the bills of the last 7 days.
+func (t *TradingAccountClient) GetBills(ctx context.Context, param types.GetBillsParam) (*types.GetBillsResp, error) {
+	err := t.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   t.GetDebug(),
+		BaseURL: t.GetBaseURL(),
+		Path:    "/api/v5/account/bills",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := t.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := t.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetBillsResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetBillsArchive retrieves the bills of the last 3 months.
+func (t *TradingAccountClient) GetBillsArchive(ctx context.Context, param types.GetBillsParam) (*types.GetBillsResp, error) {
+	err := t.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   t.GetDebug(),
+		BaseURL: t.GetBaseURL(),
+		Path:    "/api/v5/account/bills-archive",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := t.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := t.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetBillsResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+func (t *TradingAccountClient) GetFeeRates(ctx context.Context, param types.GetFeeRatesParam) (*types.GetFeeRatesResp, error) {
+	err := t.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   t.GetDebug(),
+		BaseURL: t.GetBaseURL(),
+		Path:    "/api/v5/account/trade-fee",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := t.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := t.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetFeeRatesResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// GetPositionsHistory retrieves the closed positions of the last 3 months.
+func (t *TradingAccountClient) GetPositionsHistory(ctx context.Context, param types.GetPositionsHistoryParam) (*types.GetPositionsHistoryResp, error) {
+	err := t.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   t.GetDebug(),
+		BaseURL: t.GetBaseURL(),
+		Path:    "/api/v5/account/positions-history",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := t.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := t.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetPositionsHistoryResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+func (t *TradingAccountClient) GetAccountPositionRisk(ctx context.Context, param types.GetAccountPositionRiskParam) (*types.GetAccountPositionRiskResp, error) {
+	err := t.validate.Struct(param)
+	if err != nil {
+		return nil, err
+	}
+
+	req := utils.HTTPRequest{
+		Debug:   t.GetDebug(),
+		BaseURL: t.GetBaseURL(),
+		Path:    "/api/v5/account/account-position-risk",
+		Method:  http.MethodGet,
+		Query:   param,
+	}
+
+	headers, err := t.GenAuthHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Headers = headers
+
+	resp, err := t.SendHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body types.GetAccountPositionRiskResp
+	if err := resp.ReadJsonBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// IterateBills walks GetBills from param.After back to the oldest bill of the last 7 days.
+func (t *TradingAccountClient) IterateBills(param types.GetBillsParam) *pagination.Iterator[string, *types.Bill] {
+	return pagination.NewCursorIterator(param.After, func(ctx context.Context, after string) ([]*types.Bill, error) {
+		param.After = after
+
+		resp, err := t.GetBills(ctx, param)
+		if err != nil {
+			return nil, err
+		}
+		if err := resp.Err(); err != nil {
+			return nil, err
+		}
+
+		return resp.Data, nil
+	}, func(v *types.Bill) string {
+		return v.BillId
+	})
+}
+
+// IterateBillsArchive walks GetBillsArchive from param.After back to the oldest bill of the last 3 months.
+func (t *TradingAccountClient) IterateBillsArchive(param types.GetBillsParam) *pagination.Iterator[string, *types.Bill] {
+	return pagination.NewCursorIterator(param.After, func(ctx context.Context, after string) ([]*types.Bill, error) {
+		param.After = after
+
+		resp, err := t.GetBillsArchive(ctx, param)
+		if err != nil {
+			return nil, err
+		}
+		if err := resp.Err(); err != nil {
+			return nil, err
+		}
+
+		return resp.Data, nil
+	}, func(v *types.Bill) string {
+		return v.BillId
+	})
+}
@@ -0,0 +1,169 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tradingaccount
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/rluisr/nexapi/okx/okxtest"
+	"github.com/rluisr/nexapi/okx/tradingaccount/types"
+	"github.com/rluisr/nexapi/utils/fakeserver"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNewFakeTradingAccountClient(t *testing.T, secret string) (*TradingAccountClient, *fakeserver.Server) {
+	srv := okxtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	cli, err := NewTradingAccountClient(&TradingAccountClientCfg{
+		BaseURL:    srv.URL,
+		Key:        okxtest.Key,
+		Secret:     secret,
+		Passphrase: okxtest.Passphrase,
+		HTTPClient: srv.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Could not create okx private client, %s", err)
+	}
+
+	return cli, srv
+}
+
+func TestFakeEndpoints(t *testing.T) {
+	cli, srv := testNewFakeTradingAccountClient(t, okxtest.Secret)
+
+	fakeserver.RunEndpoints(t, srv, true, []fakeserver.Endpoint{
+		{Name: "GetBalance", Method: http.MethodGet, Path: "/api/v5/account/balance", Call: func(ctx context.Context) (any, error) {
+			return cli.GetBalance(ctx, types.GetBalanceParam{})
+		}},
+		{Name: "GetPositions", Method: http.MethodGet, Path: "/api/v5/account/positions", Call: func(ctx context.Context) (any, error) {
+			return cli.GetPositions(ctx, types.GetPositionsParam{})
+		}},
+		{Name: "GetAccountConfig", Method: http.MethodGet, Path: "/api/v5/account/config", Call: func(ctx context.Context) (any, error) {
+			return cli.GetAccountConfig(ctx)
+		}},
+		{Name: "SetPositionMode", Method: http.MethodPost, Path: "/api/v5/account/set-position-mode", Call: func(ctx context.Context) (any, error) {
+			return cli.SetPositionMode(ctx, types.SetPositionModeParam{PosMode: "net_mode"})
+		}},
+		{Name: "SetLeverage", Method: http.MethodPost, Path: "/api/v5/account/set-leverage", Call: func(ctx context.Context) (any, error) {
+			return cli.SetLeverage(ctx, types.SetLeverageParam{InstId: "BTC-USDT-SWAP", Lever: "5", MgnMode: "cross"})
+		}},
+		{Name: "GetLeverageInfo", Method: http.MethodGet, Path: "/api/v5/account/leverage-info", Call: func(ctx context.Context) (any, error) {
+			return cli.GetLeverageInfo(ctx, types.GetLeverageInfoParam{InstId: "BTC-USDT-SWAP", MgnMode: "cross"})
+		}},
+		{Name: "GetMaxSize", Method: http.MethodGet, Path: "/api/v5/account/max-size", Call: func(ctx context.Context) (any, error) {
+			return cli.GetMaxSize(ctx, types.GetMaxSizeParam{InstId: "BTC-USDT", TdMode: "cash"})
+		}},
+		{Name: "GetMaxAvailSize", Method: http.MethodGet, Path: "/api/v5/account/max-avail-size", Call: func(ctx context.Context) (any, error) {
+			return cli.GetMaxAvailSize(ctx, types.GetMaxAvailSizeParam{InstId: "BTC-USDT", TdMode: "cash"})
+		}},
+		{Name: "AdjustMargin", Method: http.MethodPost, Path: "/api/v5/account/position/margin-balance", Call: func(ctx context.Context) (any, error) {
+			return cli.AdjustMargin(ctx, types.AdjustMarginParam{InstId: "BTC-USDT-SWAP", PosSide: "net", Type: "add", Amt: "10"})
+		}},
+		{Name: "GetBills", Method: http.MethodGet, Path: "/api/v5/account/bills", Call: func(ctx context.Context) (any, error) {
+			return cli.GetBills(ctx, types.GetBillsParam{})
+		}},
+		{Name: "GetBillsArchive", Method: http.MethodGet, Path: "/api/v5/account/bills-archive", Call: func(ctx context.Context) (any, error) {
+			return cli.GetBillsArchive(ctx, types.GetBillsParam{})
+		}},
+		{Name: "GetFeeRates", Method: http.MethodGet, Path: "/api/v5/account/trade-fee", Call: func(ctx context.Context) (any, error) {
+			return cli.GetFeeRates(ctx, types.GetFeeRatesParam{InstType: "SPOT"})
+		}},
+		{Name: "GetPositionsHistory", Method: http.MethodGet, Path: "/api/v5/account/positions-history", Call: func(ctx context.Context) (any, error) {
+			return cli.GetPositionsHistory(ctx, types.GetPositionsHistoryParam{})
+		}},
+		{Name: "GetAccountPositionRisk", Method: http.MethodGet, Path: "/api/v5/account/account-position-risk", Call: func(ctx context.Context) (any, error) {
+			return cli.GetAccountPositionRisk(ctx, types.GetAccountPositionRiskParam{})
+		}},
+	})
+}
+
+func TestFakeGetBalance(t *testing.T) {
+	cli, srv := testNewFakeTradingAccountClient(t, okxtest.Secret)
+
+	srv.Handle(http.MethodGet, "/api/v5/account/balance", `{"code":"0","msg":"","data":[{"totalEq":"1000.5","details":[{"ccy":"USDT","cashBal":"900.25","availBal":"800"}]}]}`)
+
+	resp, err := cli.GetBalance(context.Background(), types.GetBalanceParam{Currency: "USDT"})
+	assert.Nil(t, err)
+	assert.Nil(t, resp.Err())
+	assert.Equal(t, "USDT", srv.LastRequest().Query.Get("ccy"))
+	assert.Equal(t, "1000.5", resp.Data[0].TotalEq)
+
+	cash, err := resp.Data[0].Details[0].CashBalDecimal()
+	assert.Nil(t, err)
+	assert.Equal(t, "900.25", cash.String())
+}
+
+func TestFakeSetLeverage(t *testing.T) {
+	cli, srv := testNewFakeTradingAccountClient(t, okxtest.Secret)
+
+	_, err := cli.SetLeverage(context.Background(), types.SetLeverageParam{InstId: "BTC-USDT-SWAP", Lever: "10", MgnMode: "isolated", PosSide: "long"})
+	assert.Nil(t, err)
+
+	var body types.SetLeverageParam
+	assert.Nil(t, srv.LastRequest().JSON(&body))
+	assert.Equal(t, "10", body.Lever)
+	assert.Equal(t, "long", body.PosSide)
+
+	// either instId or ccy is required
+	n := len(srv.Requests())
+	_, err = cli.SetLeverage(context.Background(), types.SetLeverageParam{Lever: "10", MgnMode: "cross"})
+	assert.NotNil(t, err)
+	assert.Len(t, srv.Requests(), n)
+}
+
+func TestFakeIterateBills(t *testing.T) {
+	cli, srv := testNewFakeTradingAccountClient(t, okxtest.Secret)
+	ctx := context.Background()
+
+	pages := map[string]string{
+		"":  `{"code":"0","msg":"","data":[{"billId":"3"},{"billId":"2"}]}`,
+		"2": `{"code":"0","msg":"","data":[{"billId":"1"}]}`,
+		"1": okxtest.Success,
+	}
+	paginate := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(pages[r.URL.Query().Get("after")]))
+	}
+	srv.HandleFunc(http.MethodGet, "/api/v5/account/bills", paginate)
+	srv.HandleFunc(http.MethodGet, "/api/v5/account/bills-archive", paginate)
+
+	bills, err := cli.IterateBills(types.GetBillsParam{}).All(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, bills, 3)
+
+	bills, err = cli.IterateBillsArchive(types.GetBillsParam{}).All(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, bills, 3)
+	assert.Equal(t, "1", bills[2].BillId)
+
+	assert.Len(t, srv.Requests(), 6)
+
+	// an error envelope stops the iterator
+	srv.Handle(http.MethodGet, "/api/v5/account/bills", `{"code":"50001","msg":"Service temporarily unavailable","data":[]}`)
+	_, err = cli.IterateBills(types.GetBillsParam{}).All(ctx)
+	assert.ErrorContains(t, err, "50001")
+}
+
+func TestFakeInvalidSignature(t *testing.T) {
+	cli, _ := testNewFakeTradingAccountClient(t, "wrong-secret")
+
+	_, err := cli.GetAccountConfig(context.Background())
+	okxtest.AssertInvalidSignature(t, err)
+}
@@ -49,3 +49,78 @@ func TestGetBalance(t *testing.T) {
 	_, err := cli.GetBalance(context.TODO(), types.GetBalanceParam{})
 	assert.Nil(t, err)
 }
+
+func TestGetPositions(t *testing.T) {
+	cli := testNewTradingAccountClient(t)
+
+	_, err := cli.GetPositions(context.TODO(), types.GetPositionsParam{})
+	assert.Nil(t, err)
+}
+
+func TestGetAccountConfig(t *testing.T) {
+	cli := testNewTradingAccountClient(t)
+
+	_, err := cli.GetAccountConfig(context.TODO())
+	assert.Nil(t, err)
+}
+
+func TestGetLeverageInfo(t *testing.T) {
+	cli := testNewTradingAccountClient(t)
+
+	_, err := cli.GetLeverageInfo(context.TODO(), types.GetLeverageInfoParam{
+		InstId:  "BTC-USDT-SWAP",
+		MgnMode: utils.Cross,
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetMaxSize(t *testing.T) {
+	cli := testNewTradingAccountClient(t)
+
+	_, err := cli.GetMaxSize(context.TODO(), types.GetMaxSizeParam{
+		InstId: "BTC-USDT",
+		TdMode: utils.Cash,
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetMaxAvailSize(t *testing.T) {
+	cli := testNewTradingAccountClient(t)
+
+	_, err := cli.GetMaxAvailSize(context.TODO(), types.GetMaxAvailSizeParam{
+		InstId: "BTC-USDT",
+		TdMode: utils.Cash,
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetBills(t *testing.T) {
+	cli := testNewTradingAccountClient(t)
+
+	_, err := cli.GetBills(context.TODO(), types.GetBillsParam{})
+	assert.Nil(t, err)
+}
+
+func TestGetFeeRates(t *testing.T) {
+	cli := testNewTradingAccountClient(t)
+
+	_, err := cli.GetFeeRates(context.TODO(), types.GetFeeRatesParam{
+		InstType: utils.Spot,
+		InstId:   "BTC-USDT",
+	})
+	assert.Nil(t, err)
+}
+
+func TestGetPositionsHistory(t *testing.T) {
+	cli := testNewTradingAccountClient(t)
+
+	_, err := cli.GetPositionsHistory(context.TODO(), types.GetPositionsHistoryParam{})
+	assert.Nil(t, err)
+}
+
+func TestGetAccountPositionRisk(t *testing.T) {
+	cli := testNewTradingAccountClient(t)
+
+	_, err := cli.GetAccountPositionRisk(context.TODO(), types.GetAccountPositionRiskParam{})
+	assert.Nil(t, err)
+}
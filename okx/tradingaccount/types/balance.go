@@ -19,6 +19,7 @@ package types
 
 import (
 	okxutils "github.com/rluisr/nexapi/okx/utils"
+	"github.com/rluisr/nexapi/utils/decimal"
 )
 
 type GetBalanceParam struct {
@@ -70,3 +71,39 @@ type BalanceDetail struct {
 	SpotInUseAmt  string `json:"spotInUseAmt"`
 	BorrowFroz    string `json:"borrowFroz"`
 }
+
+func (b *BalanceDetail) AvailBalDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(b.AvailBal)
+}
+
+func (b *BalanceDetail) AvailEqDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(b.AvailEq)
+}
+
+func (b *BalanceDetail) CashBalDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(b.CashBal)
+}
+
+func (b *BalanceDetail) EqDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(b.Eq)
+}
+
+func (b *BalanceDetail) EqUsdDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(b.EqUsd)
+}
+
+func (b *BalanceDetail) FrozenBalDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(b.FrozenBal)
+}
+
+func (b *BalanceDetail) OrdFrozenDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(b.OrdFrozen)
+}
+
+func (b *BalanceDetail) LiabDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(b.Liab)
+}
+
+func (b *BalanceDetail) UplDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(b.Upl)
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import okxutils "github.com/rluisr/nexapi/okx/utils"
+
+// GetBillsParam is used by both the last 7 days and the last 3 months bills.
+type GetBillsParam struct {
+	InstType string `url:"instType,omitempty" validate:"omitempty,oneof=SPOT MARGIN SWAP FUTURES OPTION"`
+	Ccy      string `url:"ccy,omitempty"`
+	MgnMode  string `url:"mgnMode,omitempty" validate:"omitempty,oneof=isolated cross"`
+	CtType   string `url:"ctType,omitempty" validate:"omitempty,oneof=linear inverse"`
+	Type     string `url:"type,omitempty"`    // Bill type
+	SubType  string `url:"subType,omitempty"` // Bill subtype
+	After    string `url:"after,omitempty"`   // Pagination of data to return records earlier than the requested billId
+	Before   string `url:"before,omitempty"`  // Pagination of data to return records newer than the requested billId
+	Begin    string `url:"begin,omitempty"`   // Filter with a begin timestamp ts. Unix timestamp format in milliseconds
+	End      string `url:"end,omitempty"`     // Filter with an end timestamp ts. Unix timestamp format in milliseconds
+	Limit    string `url:"limit,omitempty"`   // Number of results per request. The maximum is 100; The default is 100
+}
+
+type GetBillsResp struct {
+	okxutils.Response
+	Data []*Bill `json:"data"`
+}
+
+// Bill
+// doc: https://www.okx.com/docs-v5/en/#trading-account-rest-api-get-bills-details-last-7-days
+type Bill struct {
+	InstType   string `json:"instType"`
+	BillId     string `json:"billId"`
+	Type       string `json:"type"`
+	SubType    string `json:"subType"`
+	Ts         string `json:"ts"`
+	BalChg     string `json:"balChg"`
+	PosBalChg  string `json:"posBalChg"`
+	Bal        string `json:"bal"`
+	PosBal     string `json:"posBal"`
+	Sz         string `json:"sz"`
+	Px         string `json:"px"`
+	Ccy        string `json:"ccy"`
+	Pnl        string `json:"pnl"`
+	Fee        string `json:"fee"`
+	MgnMode    string `json:"mgnMode"`
+	InstId     string `json:"instId"`
+	OrdId      string `json:"ordId"`
+	ExecType   string `json:"execType"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Notes      string `json:"notes"`
+	Interest   string `json:"interest"`
+	Tag        string `json:"tag"`
+	FillTime   string `json:"fillTime"`
+	TradeId    string `json:"tradeId"`
+	ClOrdId    string `json:"clOrdId"`
+	FillIdxPx  string `json:"fillIdxPx"`
+	FillMarkPx string `json:"fillMarkPx"`
+}
+
+type GetPositionsHistoryParam struct {
+	InstType string `url:"instType,omitempty" validate:"omitempty,oneof=MARGIN SWAP FUTURES OPTION"`
+	InstId   string `url:"instId,omitempty"`
+	MgnMode  string `url:"mgnMode,omitempty" validate:"omitempty,oneof=cross isolated"`
+	Type     string `url:"type,omitempty"` // The type of closing position (1: partially closed, 2: completely closed, 3: liquidation, 4: partial liquidation, 5: ADL)
+	PosId    string `url:"posId,omitempty"`
+	After    string `url:"after,omitempty"`  // Pagination of data to return records earlier than the requested uTime
+	Before   string `url:"before,omitempty"` // Pagination of data to return records newer than the requested uTime
+	Limit    string `url:"limit,omitempty"`  // Number of results per request. The maximum is 100; The default is 100
+}
+
+type GetPositionsHistoryResp struct {
+	okxutils.Response
+	Data []*PositionHistory `json:"data"`
+}
+
+// PositionHistory
+// doc: https://www.okx.com/docs-v5/en/#trading-account-rest-api-get-positions-history
+type PositionHistory struct {
+	InstType      string `json:"instType"`
+	InstId        string `json:"instId"`
+	MgnMode       string `json:"mgnMode"`
+	Type          string `json:"type"`
+	CTime         string `json:"cTime"`
+	UTime         string `json:"uTime"`
+	OpenAvgPx     string `json:"openAvgPx"`
+	CloseAvgPx    string `json:"closeAvgPx"`
+	PosId         string `json:"posId"`
+	OpenMaxPos    string `json:"openMaxPos"`
+	CloseTotalPos string `json:"closeTotalPos"`
+	RealizedPnl   string `json:"realizedPnl"`
+	Fee           string `json:"fee"`
+	FundingFee    string `json:"fundingFee"`
+	LiqPenalty    string `json:"liqPenalty"`
+	Pnl           string `json:"pnl"`
+	PnlRatio      string `json:"pnlRatio"`
+	Lever         string `json:"lever"`
+	Direction     string `json:"direction"`
+	TriggerPx     string `json:"triggerPx"`
+	Uly           string `json:"uly"`
+	Ccy           string `json:"ccy"`
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import okxutils "github.com/rluisr/nexapi/okx/utils"
+
+type GetAccountConfigResp struct {
+	okxutils.Response
+	Data []*AccountConfig `json:"data"`
+}
+
+// AccountConfig
+// doc: https://www.okx.com/docs-v5/en/#trading-account-rest-api-get-account-configuration
+type AccountConfig struct {
+	Uid             string `json:"uid"`
+	MainUid         string `json:"mainUid"`
+	AcctLv          string `json:"acctLv"`  // Account level (1: Simple, 2: Single-currency margin, 3: Multi-currency margin, 4: Portfolio margin)
+	PosMode         string `json:"posMode"` // Position mode (long_short_mode, net_mode)
+	AutoLoan        bool   `json:"autoLoan"`
+	GreeksType      string `json:"greeksType"`
+	Level           string `json:"level"`
+	LevelTmp        string `json:"levelTmp"`
+	CtIsoMode       string `json:"ctIsoMode"`
+	MgnIsoMode      string `json:"mgnIsoMode"`
+	SpotOffsetType  string `json:"spotOffsetType"`
+	RoleType        string `json:"roleType"`
+	TraderInsts     []any  `json:"traderInsts"`
+	SpotRoleType    string `json:"spotRoleType"`
+	SpotTraderInsts []any  `json:"spotTraderInsts"`
+	OpAuth          string `json:"opAuth"`
+	KycLv           string `json:"kycLv"`
+	Label           string `json:"label"`
+	Ip              string `json:"ip"`
+	Perm            string `json:"perm"`
+}
+
+type SetPositionModeParam struct {
+	PosMode string `json:"posMode" validate:"required,oneof=long_short_mode net_mode"` // Position mode (long_short_mode, net_mode)
+}
+
+type SetPositionModeResp struct {
+	okxutils.Response
+	Data []struct {
+		PosMode string `json:"posMode"`
+	} `json:"data"`
+}
+
+type SetLeverageParam struct {
+	InstId  string `json:"instId,omitempty" validate:"required_without=Ccy"`        // Instrument ID (Either instId or ccy is required.)
+	Ccy     string `json:"ccy,omitempty" validate:"required_without=InstId"`        // Currency used for margin, only applicable to cross MARGIN of Multi-currency margin
+	Lever   string `json:"lever" validate:"required"`                               // Leverage
+	MgnMode string `json:"mgnMode" validate:"required,oneof=cross isolated"`        // Margin mode (cross, isolated)
+	PosSide string `json:"posSide,omitempty" validate:"omitempty,oneof=long short"` // Position side (Only required when margin mode is isolated in long/short mode for FUTURES/SWAP.)
+}
+
+type SetLeverageResp struct {
+	okxutils.Response
+	Data []*Leverage `json:"data"`
+}
+
+type GetLeverageInfoParam struct {
+	InstId  string `url:"instId" validate:"required"`                       // Instrument ID, multiple instId are supported, e.g. BTC-USDT-SWAP,ETH-USDT-SWAP
+	MgnMode string `url:"mgnMode" validate:"required,oneof=cross isolated"` // Margin mode (cross, isolated)
+}
+
+type GetLeverageInfoResp struct {
+	okxutils.Response
+	Data []*Leverage `json:"data"`
+}
+
+type Leverage struct {
+	InstId  string `json:"instId"`
+	Ccy     string `json:"ccy,omitempty"`
+	MgnMode string `json:"mgnMode"`
+	PosSide string `json:"posSide"`
+	Lever   string `json:"lever"`
+}
@@ -17,7 +17,10 @@
 
 package types
 
-import okxutils "github.com/rluisr/nexapi/okx/utils"
+import (
+	okxutils "github.com/rluisr/nexapi/okx/utils"
+	"github.com/rluisr/nexapi/utils/decimal"
+)
 
 type GetPositionsParam struct {
 	InstType PosInstType `url:"instType,omitempty" validate:"omitempty,oneof=MARGIN SWAP FUTURES OPTION"`
@@ -110,3 +113,43 @@ type CloseOrderAlgo struct {
 	TpTriggerPxType string `json:"tpTriggerPxType,omitempty"`
 	CloseFraction   string `json:"closeFraction,omitempty"`
 }
+
+func (p *Position) PosDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(p.Pos)
+}
+
+func (p *Position) AvailPosDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(p.AvailPos)
+}
+
+func (p *Position) AvgPxDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(p.AvgPx)
+}
+
+func (p *Position) UPLDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(p.UPL)
+}
+
+func (p *Position) LeverDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(p.Lever)
+}
+
+func (p *Position) LiqPxDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(p.LiqPx)
+}
+
+func (p *Position) MarkPxDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(p.MarkPx)
+}
+
+func (p *Position) MarginDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(p.Margin)
+}
+
+func (p *Position) IMRDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(p.IMR)
+}
+
+func (p *Position) MMRDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(p.MMR)
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import okxutils "github.com/rluisr/nexapi/okx/utils"
+
+type GetMaxSizeParam struct {
+	InstId       string `url:"instId" validate:"required"`                                         // Instrument ID, up to 5 instruments separated with comma
+	TdMode       string `url:"tdMode" validate:"required,oneof=cross isolated cash spot_isolated"` // Trade mode
+	Ccy          string `url:"ccy,omitempty"`                                                      // Currency used for margin
+	Px           string `url:"px,omitempty"`                                                       // Price (When the price is not specified, it will be calculated according to the last traded price.)
+	Leverage     string `url:"leverage,omitempty"`                                                 // Leverage for instrument (The default is current leverage)
+	UnSpotOffset bool   `url:"unSpotOffset,omitempty"`                                             // Spot-Derivatives risk offset
+}
+
+type GetMaxSizeResp struct {
+	okxutils.Response
+	Data []struct {
+		InstId  string `json:"instId"`
+		Ccy     string `json:"ccy"`
+		MaxBuy  string `json:"maxBuy"`
+		MaxSell string `json:"maxSell"`
+	} `json:"data"`
+}
+
+type GetMaxAvailSizeParam struct {
+	InstId       string `url:"instId" validate:"required"`                                         // Instrument ID, up to 5 instruments separated with comma
+	TdMode       string `url:"tdMode" validate:"required,oneof=cross isolated cash spot_isolated"` // Trade mode
+	Ccy          string `url:"ccy,omitempty"`                                                      // Currency used for margin
+	ReduceOnly   bool   `url:"reduceOnly,omitempty"`                                               // Whether to reduce position only
+	UnSpotOffset bool   `url:"unSpotOffset,omitempty"`                                             // Spot-Derivatives risk offset
+	QuickMgnType string `url:"quickMgnType,omitempty"`                                             // Quick Margin type
+}
+
+type GetMaxAvailSizeResp struct {
+	okxutils.Response
+	Data []struct {
+		InstId    string `json:"instId"`
+		Ccy       string `json:"ccy"`
+		AvailBuy  string `json:"availBuy"`
+		AvailSell string `json:"availSell"`
+	} `json:"data"`
+}
+
+type AdjustMarginParam struct {
+	InstId  string `json:"instId" validate:"required"`                       // Instrument ID
+	PosSide string `json:"posSide" validate:"required,oneof=long short net"` // Position side (long, short, net)
+	Type    string `json:"type" validate:"required,oneof=add reduce"`        // add: add margin, reduce: reduce margin
+	Amt     string `json:"amt" validate:"required"`                          // Amount to be increased or decreased
+	Ccy     string `json:"ccy,omitempty"`                                    // Currency, only applicable to MARGIN (Manual transfers and Quick Margin Mode)
+}
+
+type AdjustMarginResp struct {
+	okxutils.Response
+	Data []struct {
+		InstId   string `json:"instId"`
+		PosSide  string `json:"posSide"`
+		Amt      string `json:"amt"`
+		Type     string `json:"type"`
+		Leverage string `json:"leverage"`
+		Ccy      string `json:"ccy"`
+	} `json:"data"`
+}
+
+type GetFeeRatesParam struct {
+	InstType   string `url:"instType" validate:"required,oneof=SPOT MARGIN SWAP FUTURES OPTION"`
+	InstId     string `url:"instId,omitempty"`     // Instrument ID, only applicable to SPOT/MARGIN
+	Uly        string `url:"uly,omitempty"`        // Underlying, only applicable to FUTURES/SWAP/OPTION
+	InstFamily string `url:"instFamily,omitempty"` // Instrument family, only applicable to FUTURES/SWAP/OPTION
+}
+
+type GetFeeRatesResp struct {
+	okxutils.Response
+	Data []*FeeRate `json:"data"`
+}
+
+// FeeRate
+// doc: https://www.okx.com/docs-v5/en/#trading-account-rest-api-get-fee-rates
+type FeeRate struct {
+	Level     string `json:"level"`
+	Taker     string `json:"taker"`
+	Maker     string `json:"maker"`
+	TakerU    string `json:"takerU"`
+	MakerU    string `json:"makerU"`
+	Delivery  string `json:"delivery"`
+	Exercise  string `json:"exercise"`
+	InstType  string `json:"instType"`
+	TakerUSDC string `json:"takerUSDC"`
+	MakerUSDC string `json:"makerUSDC"`
+	Ts        string `json:"ts"`
+}
+
+type GetAccountPositionRiskParam struct {
+	InstType string `url:"instType,omitempty" validate:"omitempty,oneof=MARGIN SWAP FUTURES OPTION"`
+}
+
+type GetAccountPositionRiskResp struct {
+	okxutils.Response
+	Data []struct {
+		AdjEq   string `json:"adjEq"`
+		BalData []struct {
+			Ccy   string `json:"ccy"`
+			DisEq string `json:"disEq"`
+			Eq    string `json:"eq"`
+		} `json:"balData"`
+		PosData []struct {
+			BaseBal     string `json:"baseBal"`
+			Ccy         string `json:"ccy"`
+			InstId      string `json:"instId"`
+			InstType    string `json:"instType"`
+			MgnMode     string `json:"mgnMode"`
+			NotionalCcy string `json:"notionalCcy"`
+			NotionalUsd string `json:"notionalUsd"`
+			Pos         string `json:"pos"`
+			PosCcy      string `json:"posCcy"`
+			PosId       string `json:"posId"`
+			PosSide     string `json:"posSide"`
+			QuoteBal    string `json:"quoteBal"`
+		} `json:"posData"`
+		Ts string `json:"ts"`
+	} `json:"data"`
+}
@@ -17,7 +17,18 @@
 
 package utils
 
+import "fmt"
+
 type Response struct {
 	Code    string `json:"code"`
-	Message string `json:"messsage"`
+	Message string `json:"msg"`
+}
+
+// Err returns the API error described by the response, or nil when the request succeeded.
+func (r *Response) Err() error {
+	if r.Code == "0" {
+		return nil
+	}
+
+	return fmt.Errorf("[API]Failure: code=%s msg=%s", r.Code, r.Message)
 }
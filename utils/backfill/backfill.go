@@ -0,0 +1,234 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package backfill downloads historical klines over a time range.
+//
+// The range is split into windows of at most Source.Limit klines, the windows
+// are fetched concurrently and the klines are written to the sink in time
+// order, without duplicates. Missing intervals are reported as gaps.
+package backfill
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rluisr/nexapi/utils/pagination"
+)
+
+// A Fetcher fetches the klines opened within [start, end).
+type Fetcher[T any] func(ctx context.Context, start, end time.Time) ([]T, error)
+
+// A Source describes how to download the klines of one symbol and interval from a venue.
+type Source[T any] struct {
+	Fetch Fetcher[T]
+	// OpenTime returns the open time of a kline
+	OpenTime func(T) time.Time
+	// Interval is the fixed duration of a kline, calendar intervals such as 1 month are not supported
+	Interval time.Duration
+	// Limit is the max number of klines returned by one request
+	Limit int
+	// Align is the offset of the open times from the UTC interval boundaries,
+	// e.g. -8h for daily klines opening at midnight UTC+8
+	Align time.Duration
+}
+
+// A Sink receives the klines of every window in time order.
+type Sink[T any] func(ctx context.Context, klines []T) error
+
+// A Gap is a range [Start, End) without any kline.
+type Gap struct {
+	Start time.Time
+	End   time.Time
+}
+
+type Config[T any] struct {
+	Source Source[T]
+	Sink   Sink[T]
+
+	// Start and End of the range [Start, End), Start is aligned to the open time of its kline
+	Start time.Time
+	End   time.Time
+
+	// Concurrency is the number of windows fetched at the same time, the default is 1
+	Concurrency int
+	// Limiter throttles the requests to the venue
+	Limiter pagination.Limiter
+	// MaxRetries of a failing window
+	MaxRetries int
+	// RetryBackoff is the first wait between attempts, it doubles every attempt. The default is 1 second
+	RetryBackoff time.Duration
+	// OnGap is called for every gap as soon as it is detected
+	OnGap func(Gap)
+}
+
+// A Report summarizes a backfill.
+type Report struct {
+	Windows int
+	Klines  int
+	Dropped int // duplicated or out of range klines
+	Gaps    []Gap
+}
+
+// Run downloads the klines of cfg.Source between cfg.Start and cfg.End and streams them to cfg.Sink.
+func Run[T any](ctx context.Context, cfg Config[T]) (*Report, error) {
+	src := cfg.Source
+	if src.Fetch == nil || src.OpenTime == nil || cfg.Sink == nil {
+		return nil, errors.New("backfill: fetch, open time and sink are required")
+	}
+	if src.Interval <= 0 || src.Limit <= 0 {
+		return nil, errors.New("backfill: interval and limit must be positive")
+	}
+
+	start := cfg.Start.Add(-src.Align).Truncate(src.Interval).Add(src.Align)
+	if !start.Before(cfg.End) {
+		return &Report{}, nil
+	}
+
+	windows := split(start, cfg.End, src.Interval*time.Duration(src.Limit))
+
+	concurrency := cfg.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	type result struct {
+		klines []T
+		err    error
+	}
+
+	// one buffered channel per window keeps the results in time order
+	results := make([]chan result, len(windows))
+	for i := range results {
+		results[i] = make(chan result, 1)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				klines, err := fetchWindow(ctx, cfg, windows[idx])
+				results[idx] <- result{klines: klines, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for i := range windows {
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- i:
+			}
+		}
+	}()
+
+	// stop the workers before leaving, e.g. when the sink fails
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	report := &Report{Windows: len(windows)}
+	d := detector[T]{
+		interval: src.Interval,
+		openTime: src.OpenTime,
+		end:      cfg.End,
+		next:     start,
+		onGap: func(g Gap) {
+			report.Gaps = append(report.Gaps, g)
+			if cfg.OnGap != nil {
+				cfg.OnGap(g)
+			}
+		},
+	}
+
+	for i := range windows {
+		var res result
+		select {
+		case <-ctx.Done():
+			return report, ctx.Err()
+		case res = <-results[i]:
+		}
+
+		if res.err != nil {
+			return report, res.err
+		}
+
+		klines, dropped := d.accept(res.klines)
+		report.Dropped += dropped
+		report.Klines += len(klines)
+
+		if len(klines) == 0 {
+			continue
+		}
+
+		if err := cfg.Sink(ctx, klines); err != nil {
+			return report, err
+		}
+	}
+
+	d.finish()
+
+	return report, nil
+}
+
+func split(start, end time.Time, size time.Duration) []Gap {
+	var ret []Gap
+	for s := start; s.Before(end); s = s.Add(size) {
+		e := s.Add(size)
+		if e.After(end) {
+			e = end
+		}
+		ret = append(ret, Gap{Start: s, End: e})
+	}
+	return ret
+}
+
+func fetchWindow[T any](ctx context.Context, cfg Config[T], w Gap) ([]T, error) {
+	backoff := cfg.RetryBackoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+
+	for attempt := 0; ; attempt++ {
+		if cfg.Limiter != nil {
+			if err := cfg.Limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		klines, err := cfg.Source.Fetch(ctx, w.Start, w.End)
+		if err == nil || attempt >= cfg.MaxRetries || ctx.Err() != nil {
+			return klines, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
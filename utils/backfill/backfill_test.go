@@ -0,0 +1,165 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backfill
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testKline struct {
+	OpenTime time.Time
+}
+
+// testSource serves one kline per minute except the missing ones. Every request
+// also returns the kline opened at end, like venues treating end as inclusive.
+func testSource(limit int, missing map[int64]bool) Source[*testKline] {
+	return Source[*testKline]{
+		Fetch: func(ctx context.Context, start, end time.Time) ([]*testKline, error) {
+			time.Sleep(time.Duration(rand.Intn(3)) * time.Millisecond)
+
+			var ret []*testKline
+			for t := start; !t.After(end); t = t.Add(time.Minute) {
+				if !missing[t.Unix()] {
+					ret = append(ret, &testKline{OpenTime: t})
+				}
+			}
+
+			// newest first, like OKX
+			for i, j := 0, len(ret)-1; i < j; i, j = i+1, j-1 {
+				ret[i], ret[j] = ret[j], ret[i]
+			}
+			return ret, nil
+		},
+		OpenTime: func(k *testKline) time.Time {
+			return k.OpenTime
+		},
+		Interval: time.Minute,
+		Limit:    limit,
+	}
+}
+
+func TestRun(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 30, 0, time.UTC)
+	end := time.Date(2023, 1, 1, 1, 0, 0, 0, time.UTC)
+
+	missing := map[int64]bool{
+		time.Date(2023, 1, 1, 0, 10, 0, 0, time.UTC).Unix(): true,
+		time.Date(2023, 1, 1, 0, 11, 0, 0, time.UTC).Unix(): true,
+		time.Date(2023, 1, 1, 0, 59, 0, 0, time.UTC).Unix(): true,
+	}
+
+	var got []*testKline
+	var gaps []Gap
+	report, err := Run(context.TODO(), Config[*testKline]{
+		Source: testSource(7, missing),
+		Sink: func(ctx context.Context, klines []*testKline) error {
+			got = append(got, klines...)
+			return nil
+		},
+		Start:       start,
+		End:         end,
+		Concurrency: 4,
+		OnGap: func(g Gap) {
+			gaps = append(gaps, g)
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, got, 57)
+	for i := 1; i < len(got); i++ {
+		assert.True(t, got[i-1].OpenTime.Before(got[i].OpenTime))
+	}
+
+	assert.Equal(t, []Gap{
+		{Start: time.Date(2023, 1, 1, 0, 10, 0, 0, time.UTC), End: time.Date(2023, 1, 1, 0, 12, 0, 0, time.UTC)},
+		{Start: time.Date(2023, 1, 1, 0, 59, 0, 0, time.UTC), End: end},
+	}, gaps)
+	assert.Equal(t, gaps, report.Gaps)
+	assert.Equal(t, 9, report.Windows)
+	assert.Equal(t, 57, report.Klines)
+	assert.Greater(t, report.Dropped, 0)
+}
+
+func TestRunAlign(t *testing.T) {
+	src := testSource(10, nil)
+	src.Interval = 24 * time.Hour
+	src.Align = -8 * time.Hour
+
+	var first time.Time
+	_, err := Run(context.TODO(), Config[*testKline]{
+		Source: src,
+		Sink: func(ctx context.Context, klines []*testKline) error {
+			if first.IsZero() {
+				first = klines[0].OpenTime
+			}
+			return nil
+		},
+		Start: time.Date(2023, 1, 2, 3, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 5, 0, 0, 0, 0, time.UTC),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2023, 1, 1, 16, 0, 0, 0, time.UTC), first)
+}
+
+func TestRunRetry(t *testing.T) {
+	src := testSource(100, nil)
+	fetch := src.Fetch
+
+	var calls int32
+	src.Fetch = func(ctx context.Context, start, end time.Time) ([]*testKline, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return nil, errors.New("too many requests")
+		}
+		return fetch(ctx, start, end)
+	}
+
+	report, err := Run(context.TODO(), Config[*testKline]{
+		Source:       src,
+		Sink:         func(ctx context.Context, klines []*testKline) error { return nil },
+		Start:        time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:          time.Date(2023, 1, 1, 0, 10, 0, 0, time.UTC),
+		MaxRetries:   1,
+		RetryBackoff: time.Millisecond,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 10, report.Klines)
+	assert.Equal(t, int32(2), calls)
+}
+
+func TestRunSinkError(t *testing.T) {
+	sinkErr := errors.New("disk full")
+
+	_, err := Run(context.TODO(), Config[*testKline]{
+		Source:      testSource(5, nil),
+		Sink:        func(ctx context.Context, klines []*testKline) error { return sinkErr },
+		Start:       time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:         time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		Concurrency: 8,
+	})
+
+	assert.Equal(t, sinkErr, err)
+}
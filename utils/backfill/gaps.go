@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backfill
+
+import (
+	"sort"
+	"time"
+)
+
+// detector drops duplicated or out of range klines and reports the missing intervals.
+type detector[T any] struct {
+	interval time.Duration
+	openTime func(T) time.Time
+	end      time.Time
+
+	// next is the open time of the next expected kline
+	next  time.Time
+	onGap func(Gap)
+}
+
+// accept returns in time order the klines which are not emitted yet, and the number of dropped klines.
+func (d *detector[T]) accept(klines []T) ([]T, int) {
+	sorted := make([]T, len(klines))
+	copy(sorted, klines)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return d.openTime(sorted[i]).Before(d.openTime(sorted[j]))
+	})
+
+	ret := sorted[:0]
+	dropped := 0
+	for _, k := range sorted {
+		t := d.openTime(k)
+		if t.Before(d.next) || !t.Before(d.end) {
+			dropped++
+			continue
+		}
+
+		if t.After(d.next) {
+			d.onGap(Gap{Start: d.next, End: t})
+		}
+
+		ret = append(ret, k)
+		d.next = t.Add(d.interval)
+	}
+
+	return ret, dropped
+}
+
+// finish reports the gap between the last kline and the end of the range.
+func (d *detector[T]) finish() {
+	if d.next.Before(d.end) {
+		d.onGap(Gap{Start: d.next, End: d.end})
+		d.next = d.end
+	}
+}
@@ -0,0 +1,298 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package backtest replays recorded market data through a simulated exchange and
+// a strategy, then reports the PnL, the drawdown and the fill statistics.
+//
+// The ticks are applied to a sim.Exchange in the order of the feed: the book updates
+// maintain a local book per symbol which replaces the book of the exchange, and the
+// trades match the resting orders. The strategy is called after every tick and
+// places its orders on the exchange, they reach the matching after the configured
+// latency. The tickers and the ticks of the symbols without an instrument are only
+// passed to the strategy.
+package backtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/rluisr/nexapi/utils/book"
+	"github.com/rluisr/nexapi/utils/decimal"
+	"github.com/rluisr/nexapi/utils/sim"
+	"github.com/rluisr/nexapi/utils/tickdata"
+)
+
+var ErrOutOfOrder = errors.New("backtest: ticks are not in order")
+
+// A Strategy is called once every tick is applied to the exchange.
+type Strategy interface {
+	OnTick(e *Engine, t *tickdata.Tick) error
+}
+
+// A FillHandler is a Strategy notified of the fills of its orders.
+type FillHandler interface {
+	OnFill(e *Engine, f sim.Fill)
+}
+
+type StrategyFunc func(e *Engine, t *tickdata.Tick) error
+
+func (f StrategyFunc) OnTick(e *Engine, t *tickdata.Tick) error {
+	return f(e, t)
+}
+
+type Config struct {
+	// Exchange configures the instruments, the balances, the fees and the latency.
+	// The FillModel defaults to a sim.QueueFill, OnFill is still called
+	Exchange sim.Config
+	// Quote is the asset the equity is valued in, the other assets are valued at
+	// the mark of their Spot instrument against Quote
+	Quote string
+	// Venue filters the ticks of one venue, empty replays every venue
+	Venue string
+	// SampleInterval is the min time between two points of the equity curve, zero
+	// samples after every tick. The drawdown is computed on the samples
+	SampleInterval time.Duration
+}
+
+// An Engine runs one backtest.
+type Engine struct {
+	cfg      Config
+	exchange *sim.Exchange
+	strategy Strategy
+
+	books map[string]*localBook
+	marks map[string]decimal.Decimal
+	seqs  map[string]uint64
+	now   time.Time
+
+	report *Report
+	fills  []sim.Fill
+}
+
+func New(cfg Config) *Engine {
+	e := &Engine{
+		cfg:    cfg,
+		books:  make(map[string]*localBook),
+		marks:  make(map[string]decimal.Decimal),
+		seqs:   make(map[string]uint64),
+		report: newReport(),
+	}
+
+	exCfg := cfg.Exchange
+	if exCfg.FillModel == nil {
+		exCfg.FillModel = sim.NewQueueFill()
+	}
+	onFill := exCfg.OnFill
+	exCfg.OnFill = func(f sim.Fill) {
+		e.fills = append(e.fills, f)
+		if onFill != nil {
+			onFill(f)
+		}
+	}
+	e.exchange = sim.NewExchange(exCfg)
+
+	return e
+}
+
+// Exchange returns the simulated exchange the strategy trades on.
+func (e *Engine) Exchange() *sim.Exchange {
+	return e.exchange
+}
+
+// Book returns the local book of symbol, nil before its first book update.
+func (e *Engine) Book(symbol string) *book.Book {
+	b, ok := e.books[symbol]
+	if !ok {
+		return nil
+	}
+	return b.book()
+}
+
+// Now returns the receive time of the current tick, or of the latest tick when the
+// current one was received before it.
+func (e *Engine) Now() time.Time {
+	return e.now
+}
+
+// Run replays feed through the strategy until the end of the feed, an error of the
+// strategy or the cancellation of ctx. The report covers the replayed ticks in
+// every case.
+func (e *Engine) Run(ctx context.Context, feed Feed, strategy Strategy) (*Report, error) {
+	e.strategy = strategy
+
+	err := e.run(ctx, feed)
+	e.report.finish(e)
+
+	return e.report, err
+}
+
+func (e *Engine) run(ctx context.Context, feed Feed) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		t, err := feed.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if e.cfg.Venue != "" && t.Venue != e.cfg.Venue {
+			continue
+		}
+		if err := e.advance(t); err != nil {
+			return err
+		}
+
+		if err := e.apply(t); err != nil {
+			return err
+		}
+
+		if err := e.strategy.OnTick(e, t); err != nil {
+			return err
+		}
+		e.notify()
+
+		e.report.sample(e)
+	}
+}
+
+// advance moves the clock to t. The recorded ticks are in Seq order, their
+// receive times may go back slightly when the sources stamped them concurrently,
+// the clock then stays at the latest time. The ticks without a Seq must be in
+// time order.
+func (e *Engine) advance(t *tickdata.Tick) error {
+	if t.Seq != 0 {
+		if last, ok := e.seqs[t.Venue]; ok && t.Seq <= last {
+			return fmt.Errorf("%w: tick %d after %d", ErrOutOfOrder, t.Seq, last)
+		}
+		e.seqs[t.Venue] = t.Seq
+	} else if t.ReceiveTime.Before(e.now) {
+		return fmt.Errorf("%w: tick at %s", ErrOutOfOrder, t.ReceiveTime)
+	}
+
+	if t.ReceiveTime.After(e.now) {
+		e.now = t.ReceiveTime
+	}
+	return nil
+}
+
+// apply updates the exchange with t.
+func (e *Engine) apply(t *tickdata.Tick) error {
+	e.report.Ticks++
+	if t.Kind == tickdata.Reconnect {
+		e.report.Reconnects++
+		return nil
+	}
+
+	if _, err := e.exchange.Instrument(t.Symbol); errors.Is(err, sim.ErrUnknownSymbol) {
+		return nil
+	}
+
+	var err error
+	switch t.Kind {
+	case tickdata.BookUpdate:
+		b, ok := e.books[t.Symbol]
+		if !ok {
+			b = newLocalBook()
+			e.books[t.Symbol] = b
+		}
+		b.apply(t)
+
+		current := b.book()
+		if mid, ok := current.Mid(); ok {
+			e.marks[t.Symbol] = mid
+		}
+		err = e.exchange.UpdateBook(t.Symbol, current, e.now)
+	case tickdata.Trade:
+		if _, ok := e.books[t.Symbol]; !ok {
+			e.marks[t.Symbol] = t.Price
+		}
+		err = e.exchange.UpdateTrade(t.Symbol, sim.Trade{Price: t.Price, Qty: t.Qty, Side: side(t.Side), Time: e.now})
+	}
+	if err != nil {
+		return err
+	}
+
+	e.notify()
+	return nil
+}
+
+// notify passes the new fills to the report and the strategy.
+func (e *Engine) notify() {
+	for len(e.fills) > 0 {
+		f := e.fills[0]
+		e.fills = e.fills[1:]
+
+		e.report.fill(f)
+		if h, ok := e.strategy.(FillHandler); ok {
+			h.OnFill(e, f)
+		}
+	}
+}
+
+func side(s tickdata.Side) sim.Side {
+	switch s {
+	case tickdata.Buy:
+		return sim.Buy
+	case tickdata.Sell:
+		return sim.Sell
+	}
+	return ""
+}
+
+// equity values the balances and the positions in the quote asset.
+func (e *Engine) equity() (decimal.Decimal, bool) {
+	equity := decimal.Zero
+	for _, b := range e.exchange.Balances() {
+		if b.Asset == e.cfg.Quote {
+			equity = equity.Add(b.Total())
+			continue
+		}
+		if b.Total().IsZero() {
+			continue
+		}
+
+		mark, ok := e.spotMark(b.Asset)
+		if !ok {
+			return decimal.Zero, false
+		}
+		equity = equity.Add(b.Total().Mul(mark))
+	}
+
+	for _, p := range e.exchange.Positions() {
+		equity = equity.Add(p.UnrealizedPnL)
+	}
+
+	return equity, true
+}
+
+func (e *Engine) spotMark(asset string) (decimal.Decimal, bool) {
+	for _, inst := range e.cfg.Exchange.Instruments {
+		if inst.Kind == sim.Spot && inst.Base == asset && inst.Quote == e.cfg.Quote {
+			mark, ok := e.marks[inst.Symbol]
+			return mark, ok
+		}
+	}
+	return decimal.Zero, false
+}
@@ -0,0 +1,220 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rluisr/nexapi/utils/book"
+	"github.com/rluisr/nexapi/utils/candle"
+	"github.com/rluisr/nexapi/utils/decimal"
+	"github.com/rluisr/nexapi/utils/sim"
+	"github.com/rluisr/nexapi/utils/tickdata"
+	"github.com/stretchr/testify/assert"
+)
+
+var testStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func dec(s string) decimal.Decimal {
+	return decimal.MustFromString(s)
+}
+
+func at(sec int) time.Time {
+	return testStart.Add(time.Duration(sec) * time.Second)
+}
+
+func levels(l ...string) []book.PriceLevel {
+	var res []book.PriceLevel
+	for i := 0; i+1 < len(l); i += 2 {
+		res = append(res, book.PriceLevel{Price: dec(l[i]), Size: dec(l[i+1])})
+	}
+	return res
+}
+
+func bookTick(sec int, snapshot bool, bids, asks []book.PriceLevel) *tickdata.Tick {
+	return &tickdata.Tick{Kind: tickdata.BookUpdate, Venue: "test", Symbol: "BTCUSDT", ReceiveTime: at(sec),
+		Bids: bids, Asks: asks, Snapshot: snapshot}
+}
+
+func tradeTick(sec int, price, qty string, side tickdata.Side) *tickdata.Tick {
+	return &tickdata.Tick{Kind: tickdata.Trade, Venue: "test", Symbol: "BTCUSDT", ReceiveTime: at(sec),
+		Price: dec(price), Qty: dec(qty), Side: side}
+}
+
+type testStrategy struct {
+	t     *testing.T
+	ticks int
+	fills []sim.Fill
+}
+
+func (s *testStrategy) OnTick(e *Engine, t *tickdata.Tick) error {
+	s.ticks++
+
+	var err error
+	switch s.ticks {
+	case 1:
+		_, err = e.Exchange().PlaceOrder(sim.OrderRequest{Symbol: "BTCUSDT", Side: sim.Buy, Type: sim.Limit, Price: dec("100"), Qty: dec("1")})
+	case 5:
+		bid, _ := e.Book("BTCUSDT").BestBid()
+		assert.Equal(s.t, "95", bid.Price.String())
+		_, err = e.Exchange().PlaceOrder(sim.OrderRequest{Symbol: "BTCUSDT", Side: sim.Sell, Type: sim.Market, Qty: dec("1")})
+	}
+	return err
+}
+
+func (s *testStrategy) OnFill(e *Engine, f sim.Fill) {
+	s.fills = append(s.fills, f)
+}
+
+func TestRun(t *testing.T) {
+	e := New(Config{
+		Exchange: sim.Config{
+			Instruments: []sim.Instrument{{Symbol: "BTCUSDT", Kind: sim.Spot, Base: "BTC", Quote: "USDT"}},
+			Balances:    map[string]decimal.Decimal{"USDT": dec("10000")},
+			Fees:        sim.Fees{Taker: dec("0.001")},
+		},
+		Quote: "USDT",
+		Venue: "test",
+	})
+
+	feed := FromTicks([]*tickdata.Tick{
+		bookTick(0, true, levels("100", "1"), levels("101", "1")),
+		// one BTC ahead of the order in the queue
+		tradeTick(1, "100", "1.5", tickdata.Sell),
+		bookTick(2, false, levels("100", "0", "99", "1"), nil),
+		tradeTick(3, "98.5", "2", tickdata.Sell),
+		bookTick(4, true, levels("95", "1"), levels("96", "1")),
+		// another venue
+		{Kind: tickdata.Trade, Venue: "other", Symbol: "BTCUSDT", ReceiveTime: at(5), Price: dec("1"), Qty: dec("1")},
+	})
+
+	strategy := &testStrategy{t: t}
+	report, err := e.Run(context.Background(), feed, strategy)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 5, strategy.ticks)
+	assert.Len(t, strategy.fills, 3)
+	assert.Equal(t, at(0), report.Start)
+	assert.Equal(t, at(4), report.End)
+	assert.Equal(t, 5, report.Ticks)
+
+	var curve []float64
+	for _, p := range report.Equity {
+		curve = append(curve, p.Equity.Float64())
+	}
+	assert.Equal(t, []float64{10000, 10000.25, 10000, 10000, 9994.905}, curve)
+	assert.True(t, report.PnL.Equal(dec("-5.095")))
+	assert.True(t, report.MaxDrawdown.Equal(dec("5.345")))
+	assert.True(t, report.MaxDrawdownRatio.Equal(dec("0.00053449")))
+
+	stats := report.Fills
+	assert.Equal(t, []int{2, 2, 0}, []int{stats.Orders, stats.FilledOrders, stats.CanceledOrders})
+	assert.Equal(t, []int{3, 2, 1}, []int{stats.Fills, stats.MakerFills, stats.TakerFills})
+	assert.True(t, stats.Volume.Equal(dec("2")))
+	assert.True(t, stats.Notional.Equal(dec("195")))
+	assert.True(t, stats.Fees["USDT"].Equal(dec("0.095")))
+	assert.Equal(t, 1.0, stats.FillRatio())
+}
+
+func TestRunKlines(t *testing.T) {
+	e := New(Config{
+		Exchange: sim.Config{
+			Instruments: []sim.Instrument{{Symbol: "BTC-USDT-SWAP", Kind: sim.Linear, Base: "BTC", Quote: "USDT", ContractSize: dec("0.01")}},
+			Balances:    map[string]decimal.Decimal{"USDT": dec("1000")},
+		},
+		Quote: "USDT",
+	})
+
+	feed := FromKlines("okx", "BTC-USDT-SWAP", []*candle.Candle{
+		{OpenTime: at(0), Interval: time.Minute, Open: 100, High: 105, Low: 99, Close: 104, Volume: 10},
+		{OpenTime: at(60), Interval: time.Minute, Open: 104, High: 104, Low: 90, Close: 95, Volume: 10},
+	})
+
+	var prices []string
+	var times []time.Time
+	report, err := e.Run(context.Background(), feed, StrategyFunc(func(e *Engine, t *tickdata.Tick) error {
+		if t.Kind != tickdata.Trade {
+			return nil
+		}
+		prices = append(prices, t.Price.String())
+		times = append(times, t.ReceiveTime)
+
+		if len(prices) == 1 {
+			_, err := e.Exchange().PlaceOrder(sim.OrderRequest{Symbol: "BTC-USDT-SWAP", Side: sim.Buy, Type: sim.Market, Qty: dec("10")})
+			return err
+		}
+		return nil
+	}))
+	assert.Nil(t, err)
+
+	// up candles visit the low first, down candles the high
+	assert.Equal(t, []string{"100", "99", "105", "104", "104", "104", "90", "95"}, prices)
+	assert.Equal(t, at(15), times[1])
+	assert.Equal(t, at(105), times[7])
+
+	p := e.Exchange().Position("BTC-USDT-SWAP")
+	assert.True(t, p.Qty.Equal(dec("10")))
+	assert.True(t, report.PnL.Equal(dec("-0.5")))
+	// from the high of the first candle to the low of the second
+	assert.True(t, report.MaxDrawdown.Equal(dec("1.5")))
+	assert.Equal(t, 1, report.Fills.TakerFills)
+}
+
+func TestMerge(t *testing.T) {
+	a := FromTicks([]*tickdata.Tick{tradeTick(0, "1", "1", 0), tradeTick(2, "3", "1", 0)})
+	b := FromTicks([]*tickdata.Tick{tradeTick(1, "2", "1", 0), tradeTick(2, "4", "1", 0)})
+
+	var prices []string
+	report, err := New(Config{Quote: "USDT"}).Run(context.Background(), Merge(a, b), StrategyFunc(func(e *Engine, t *tickdata.Tick) error {
+		prices = append(prices, t.Price.String())
+		return nil
+	}))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "2", "3", "4"}, prices)
+	assert.Equal(t, 4, report.Ticks)
+
+	feed := FromTicks([]*tickdata.Tick{tradeTick(1, "1", "1", 0), tradeTick(0, "2", "1", 0)})
+	_, err = New(Config{}).Run(context.Background(), feed, StrategyFunc(func(*Engine, *tickdata.Tick) error { return nil }))
+	assert.ErrorIs(t, err, ErrOutOfOrder)
+}
+
+func TestSeqOrder(t *testing.T) {
+	recorded := func(seq uint64, sec int, price string) *tickdata.Tick {
+		tick := tradeTick(sec, price, "1", 0)
+		tick.Seq = seq
+		return tick
+	}
+
+	// the second tick was stamped before the first one by a concurrent source
+	feed := FromTicks([]*tickdata.Tick{recorded(1, 2, "1"), recorded(2, 1, "2"), recorded(3, 3, "3")})
+
+	var times []time.Time
+	report, err := New(Config{Quote: "USDT"}).Run(context.Background(), feed, StrategyFunc(func(e *Engine, t *tickdata.Tick) error {
+		times = append(times, e.Now())
+		return nil
+	}))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, report.Ticks)
+	assert.Equal(t, []time.Time{at(2), at(2), at(3)}, times)
+
+	feed = FromTicks([]*tickdata.Tick{recorded(2, 1, "1"), recorded(1, 2, "2")})
+	_, err = New(Config{}).Run(context.Background(), feed, StrategyFunc(func(*Engine, *tickdata.Tick) error { return nil }))
+	assert.ErrorIs(t, err, ErrOutOfOrder)
+}
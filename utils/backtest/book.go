@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backtest
+
+import (
+	"strings"
+
+	"github.com/rluisr/nexapi/utils/book"
+	"github.com/rluisr/nexapi/utils/tickdata"
+)
+
+// A localBook applies the book updates of a symbol. The updates of a venue which
+// does not send snapshots build the book from an empty one.
+type localBook struct {
+	bids, asks map[string]book.PriceLevel
+	// cached is the sorted book, nil after an update
+	cached *book.Book
+}
+
+func newLocalBook() *localBook {
+	return &localBook{bids: make(map[string]book.PriceLevel), asks: make(map[string]book.PriceLevel)}
+}
+
+func (b *localBook) apply(t *tickdata.Tick) {
+	if t.Snapshot {
+		b.bids = make(map[string]book.PriceLevel)
+		b.asks = make(map[string]book.PriceLevel)
+	}
+	applyLevels(b.bids, t.Bids)
+	applyLevels(b.asks, t.Asks)
+	b.cached = nil
+}
+
+func applyLevels(side map[string]book.PriceLevel, levels []book.PriceLevel) {
+	for _, l := range levels {
+		key := priceKey(l)
+		if l.Size.Sign() <= 0 {
+			delete(side, key)
+			continue
+		}
+		side[key] = l
+	}
+}
+
+// priceKey is the price without its trailing zeros, the venues may send 100 and 100.0
+func priceKey(l book.PriceLevel) string {
+	s := l.Price.String()
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+func (b *localBook) book() *book.Book {
+	if b.cached != nil {
+		return b.cached
+	}
+
+	bids := make([]book.PriceLevel, 0, len(b.bids))
+	for _, l := range b.bids {
+		bids = append(bids, l)
+	}
+	asks := make([]book.PriceLevel, 0, len(b.asks))
+	for _, l := range b.asks {
+		asks = append(asks, l)
+	}
+
+	b.cached = book.New(bids, asks)
+	return b.cached
+}
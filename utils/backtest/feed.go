@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backtest
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/rluisr/nexapi/utils/book"
+	"github.com/rluisr/nexapi/utils/candle"
+	"github.com/rluisr/nexapi/utils/decimal"
+	"github.com/rluisr/nexapi/utils/tickdata"
+)
+
+// A Feed returns the ticks by receive time, or in Seq order for the recorded ones,
+// then io.EOF. The readers of the tickdata package are feeds.
+type Feed interface {
+	Next() (*tickdata.Tick, error)
+}
+
+type sliceFeed struct {
+	ticks []*tickdata.Tick
+}
+
+// FromTicks returns a feed of ticks already sorted by receive time.
+func FromTicks(ticks []*tickdata.Tick) Feed {
+	return &sliceFeed{ticks: ticks}
+}
+
+func (f *sliceFeed) Next() (*tickdata.Tick, error) {
+	if len(f.ticks) == 0 {
+		return nil, io.EOF
+	}
+	t := f.ticks[0]
+	f.ticks = f.ticks[1:]
+	return t, nil
+}
+
+// FromKlines converts the candles of symbol to ticks. Every candle is replayed as
+// four prices spread over its interval: the open, the low then the high when the
+// candle closes up, else the high then the low, and the close. Each price is a
+// book snapshot of one level on both sides, sized by the volume of the candle,
+// followed by a trade of a quarter of the volume.
+func FromKlines(venue, symbol string, candles []*candle.Candle) Feed {
+	var ticks []*tickdata.Tick
+	for _, c := range candles {
+		prices := []float64{c.Open, c.High, c.Low, c.Close}
+		if c.Close >= c.Open {
+			prices[1], prices[2] = c.Low, c.High
+		}
+
+		size := decimal.NewFromFloat(c.Volume)
+		qty := decimal.NewFromFloat(c.Volume / 4)
+		for i, p := range prices {
+			at := c.OpenTime.Add(c.Interval * time.Duration(i) / 4)
+			price := decimal.NewFromFloat(p)
+
+			level := []book.PriceLevel{{Price: price, Size: size}}
+			ticks = append(ticks,
+				&tickdata.Tick{Kind: tickdata.BookUpdate, Venue: venue, Symbol: symbol, ReceiveTime: at,
+					Bids: level, Asks: level, Snapshot: true},
+				&tickdata.Tick{Kind: tickdata.Trade, Venue: venue, Symbol: symbol, ReceiveTime: at,
+					Price: price, Qty: qty})
+		}
+	}
+
+	return FromTicks(ticks)
+}
+
+type mergeFeed struct {
+	feeds []Feed
+	heads []*tickdata.Tick
+}
+
+// Merge merges feeds by receive time, the ticks of the same time are returned in
+// the order of the feeds.
+func Merge(feeds ...Feed) Feed {
+	return &mergeFeed{feeds: feeds, heads: make([]*tickdata.Tick, len(feeds))}
+}
+
+func (m *mergeFeed) Next() (*tickdata.Tick, error) {
+	next := -1
+	for i, f := range m.feeds {
+		if f == nil {
+			continue
+		}
+		if m.heads[i] == nil {
+			t, err := f.Next()
+			if errors.Is(err, io.EOF) {
+				m.feeds[i] = nil
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			m.heads[i] = t
+		}
+		if next < 0 || m.heads[i].ReceiveTime.Before(m.heads[next].ReceiveTime) {
+			next = i
+		}
+	}
+
+	if next < 0 {
+		return nil, io.EOF
+	}
+
+	t := m.heads[next]
+	m.heads[next] = nil
+	return t, nil
+}
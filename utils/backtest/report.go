@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backtest
+
+import (
+	"time"
+
+	"github.com/rluisr/nexapi/utils/decimal"
+	"github.com/rluisr/nexapi/utils/sim"
+)
+
+// ratioPlaces is the precision of the drawdown ratio
+const ratioPlaces = 8
+
+// An EquityPoint is a sample of the equity curve.
+type EquityPoint struct {
+	Time   time.Time
+	Equity decimal.Decimal
+}
+
+// FillStats summarizes the orders and the fills of the strategy.
+type FillStats struct {
+	Orders int
+	// FilledOrders are filled at least partially
+	FilledOrders   int
+	CanceledOrders int
+	Fills          int
+	MakerFills     int
+	TakerFills     int
+	// Volume is the traded quantity, Notional the traded value in the quote assets
+	Volume   decimal.Decimal
+	Notional decimal.Decimal
+	// Fees paid by asset, negative for the rebates
+	Fees map[string]decimal.Decimal
+}
+
+// FillRatio returns the share of the orders filled at least partially.
+func (s *FillStats) FillRatio() float64 {
+	if s.Orders == 0 {
+		return 0
+	}
+	return float64(s.FilledOrders) / float64(s.Orders)
+}
+
+type Report struct {
+	Start      time.Time
+	End        time.Time
+	Ticks      int
+	Reconnects int
+
+	// InitialEquity and FinalEquity are the first and the last samples
+	InitialEquity decimal.Decimal
+	FinalEquity   decimal.Decimal
+	PnL           decimal.Decimal
+	// MaxDrawdown is the largest drop from a peak of the equity, MaxDrawdownRatio
+	// the same drop as a share of the peak
+	MaxDrawdown      decimal.Decimal
+	MaxDrawdownRatio decimal.Decimal
+	Equity           []EquityPoint
+
+	Fills FillStats
+
+	peak decimal.Decimal
+}
+
+func newReport() *Report {
+	return &Report{Fills: FillStats{Fees: make(map[string]decimal.Decimal)}}
+}
+
+func (r *Report) fill(f sim.Fill) {
+	s := &r.Fills
+	s.Fills++
+	if f.Maker {
+		s.MakerFills++
+	} else {
+		s.TakerFills++
+	}
+	s.Volume = s.Volume.Add(f.Qty)
+	s.Notional = s.Notional.Add(f.Qty.Mul(f.Price))
+	if !f.Fee.IsZero() {
+		s.Fees[f.FeeAsset] = s.Fees[f.FeeAsset].Add(f.Fee)
+	}
+}
+
+// sample adds a point to the equity curve when the sample interval has elapsed.
+func (r *Report) sample(e *Engine) {
+	if r.Start.IsZero() {
+		r.Start = e.now
+	}
+	r.End = e.now
+
+	n := len(r.Equity)
+	if n > 0 && e.now.Sub(r.Equity[n-1].Time) < e.cfg.SampleInterval {
+		return
+	}
+
+	equity, ok := e.equity()
+	if !ok {
+		return
+	}
+	r.add(EquityPoint{Time: e.now, Equity: equity})
+}
+
+func (r *Report) add(p EquityPoint) {
+	if len(r.Equity) == 0 {
+		r.InitialEquity = p.Equity
+	}
+	r.Equity = append(r.Equity, p)
+	r.FinalEquity = p.Equity
+	r.PnL = r.FinalEquity.Sub(r.InitialEquity)
+
+	if p.Equity.GreaterThan(r.peak) || len(r.Equity) == 1 {
+		r.peak = p.Equity
+		return
+	}
+
+	drawdown := r.peak.Sub(p.Equity)
+	if drawdown.GreaterThan(r.MaxDrawdown) {
+		r.MaxDrawdown = drawdown
+		if r.peak.Sign() > 0 {
+			r.MaxDrawdownRatio = drawdown.Div(r.peak, ratioPlaces)
+		}
+	}
+}
+
+// finish samples the final equity and counts the orders.
+func (r *Report) finish(e *Engine) {
+	if n := len(r.Equity); n > 0 && r.Equity[n-1].Time.Before(e.now) {
+		if equity, ok := e.equity(); ok {
+			r.add(EquityPoint{Time: e.now, Equity: equity})
+		}
+	}
+
+	for _, o := range e.exchange.Orders("") {
+		r.Fills.Orders++
+		if o.Filled.Sign() > 0 {
+			r.Fills.FilledOrders++
+		}
+		if o.Status == sim.Canceled {
+			r.Fills.CanceledOrders++
+		}
+	}
+}
@@ -0,0 +1,241 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package book provides typed order book levels and the usual book calculations
+// such as spread, mid price, depth and the VWAP of a market order.
+package book
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/rluisr/nexapi/utils/decimal"
+)
+
+// A PriceLevel is the total size resting at one price.
+type PriceLevel struct {
+	Price decimal.Decimal
+	Size  decimal.Decimal
+}
+
+// Side of the book, a market buy is filled by the Ask side.
+type Side int
+
+const (
+	Bid Side = iota
+	Ask
+)
+
+// A Book holds the bids by descending price and the asks by ascending price.
+type Book struct {
+	Bids []PriceLevel
+	Asks []PriceLevel
+}
+
+// New returns the book of sorted copies of the levels, bids and asks are not modified.
+func New(bids, asks []PriceLevel) *Book {
+	bids = append([]PriceLevel(nil), bids...)
+	asks = append([]PriceLevel(nil), asks...)
+
+	sort.SliceStable(bids, func(i, j int) bool {
+		return bids[i].Price.GreaterThan(bids[j].Price)
+	})
+	sort.SliceStable(asks, func(i, j int) bool {
+		return asks[i].Price.LessThan(asks[j].Price)
+	})
+
+	return &Book{Bids: bids, Asks: asks}
+}
+
+// FromStrings parses the levels sent as [price, size, ...] strings, as by MEXC spot and OKX.
+func FromStrings(bids, asks [][]string) (*Book, error) {
+	b, err := ParseLevels(bids)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := ParseLevels(asks)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(b, a), nil
+}
+
+// FromFloats converts the levels sent as [price, size, ...] numbers, as by MEXC contract and KuCoin futures.
+func FromFloats(bids, asks [][]float64) (*Book, error) {
+	b, err := FloatLevels(bids)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := FloatLevels(asks)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(b, a), nil
+}
+
+// ParseLevels parses [price, size, ...] levels, the values after the size are ignored.
+func ParseLevels(levels [][]string) ([]PriceLevel, error) {
+	ret := make([]PriceLevel, 0, len(levels))
+	for _, l := range levels {
+		if len(l) < 2 {
+			return nil, fmt.Errorf("unknown price level: %v", l)
+		}
+
+		price, err := decimal.NewFromString(l[0])
+		if err != nil {
+			return nil, err
+		}
+
+		size, err := decimal.NewFromString(l[1])
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, PriceLevel{Price: price, Size: size})
+	}
+
+	return ret, nil
+}
+
+// FloatLevels converts [price, size, ...] levels using the shortest decimal representation of the numbers.
+func FloatLevels(levels [][]float64) ([]PriceLevel, error) {
+	ret := make([]PriceLevel, 0, len(levels))
+	for _, l := range levels {
+		if len(l) < 2 {
+			return nil, fmt.Errorf("unknown price level: %v", l)
+		}
+
+		ret = append(ret, PriceLevel{Price: decimal.NewFromFloat(l[0]), Size: decimal.NewFromFloat(l[1])})
+	}
+
+	return ret, nil
+}
+
+func (b *Book) Levels(side Side) []PriceLevel {
+	if side == Bid {
+		return b.Bids
+	}
+	return b.Asks
+}
+
+// BestBid returns the highest bid, false when there is no bid.
+func (b *Book) BestBid() (PriceLevel, bool) {
+	if len(b.Bids) == 0 {
+		return PriceLevel{}, false
+	}
+	return b.Bids[0], true
+}
+
+// BestAsk returns the lowest ask, false when there is no ask.
+func (b *Book) BestAsk() (PriceLevel, bool) {
+	if len(b.Asks) == 0 {
+		return PriceLevel{}, false
+	}
+	return b.Asks[0], true
+}
+
+// Spread returns the best ask minus the best bid, false when a side is empty.
+func (b *Book) Spread() (decimal.Decimal, bool) {
+	bid, ok := b.BestBid()
+	if !ok {
+		return decimal.Zero, false
+	}
+
+	ask, ok := b.BestAsk()
+	if !ok {
+		return decimal.Zero, false
+	}
+
+	return ask.Price.Sub(bid.Price), true
+}
+
+// Mid returns the exact middle of the best bid and ask, false when a side is empty.
+func (b *Book) Mid() (decimal.Decimal, bool) {
+	bid, ok := b.BestBid()
+	if !ok {
+		return decimal.Zero, false
+	}
+
+	ask, ok := b.BestAsk()
+	if !ok {
+		return decimal.Zero, false
+	}
+
+	sum := bid.Price.Add(ask.Price)
+	return sum.Div(decimal.NewFromInt(2), sum.Scale()+1), true
+}
+
+// DepthAtPrice returns the size of the side from the best level down to price included,
+// i.e. the size a limit order at price would fill immediately on the other side.
+func (b *Book) DepthAtPrice(side Side, price decimal.Decimal) decimal.Decimal {
+	depth := decimal.Zero
+	for _, l := range b.Levels(side) {
+		if side == Bid && l.Price.LessThan(price) || side == Ask && l.Price.GreaterThan(price) {
+			break
+		}
+		depth = depth.Add(l.Size)
+	}
+
+	return depth
+}
+
+// CumulativeDepth returns the levels of the side with the sizes summed from the best level.
+func (b *Book) CumulativeDepth(side Side) []PriceLevel {
+	levels := b.Levels(side)
+	ret := make([]PriceLevel, len(levels))
+
+	total := decimal.Zero
+	for i, l := range levels {
+		total = total.Add(l.Size)
+		ret[i] = PriceLevel{Price: l.Price, Size: total}
+	}
+
+	return ret
+}
+
+// Fill walks the side from the best level to fill size, e.g. the Ask side for a market buy,
+// and returns the filled size and its cost. The filled size is lower than size when the side is too thin.
+func (b *Book) Fill(side Side, size decimal.Decimal) (filled, cost decimal.Decimal) {
+	filled, cost = decimal.Zero, decimal.Zero
+	for _, l := range b.Levels(side) {
+		remaining := size.Sub(filled)
+		if remaining.Sign() <= 0 {
+			break
+		}
+
+		qty := decimal.Min(remaining, l.Size)
+		filled = filled.Add(qty)
+		cost = cost.Add(qty.Mul(l.Price))
+	}
+
+	return filled, cost
+}
+
+// VWAP returns the average price of filling size on the side, rounded to places digits.
+// It is false when the side cannot fill the whole size, the VWAP is then the one of the filled part.
+func (b *Book) VWAP(side Side, size decimal.Decimal, places int32) (decimal.Decimal, bool) {
+	filled, cost := b.Fill(side, size)
+	if filled.IsZero() {
+		return decimal.Zero, false
+	}
+
+	return cost.Div(filled, places), filled.Cmp(size) >= 0
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package book
+
+import (
+	"testing"
+
+	"github.com/rluisr/nexapi/utils/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func testBook(t *testing.T) *Book {
+	b, err := FromStrings(
+		[][]string{{"99.5", "2"}, {"100", "1", "0", "3"}, {"99", "5"}},
+		[][]string{{"100.5", "1"}, {"101", "2"}, {"102", "4"}},
+	)
+	assert.Nil(t, err)
+	return b
+}
+
+func dec(s string) decimal.Decimal {
+	return decimal.MustFromString(s)
+}
+
+func TestBestSpreadMid(t *testing.T) {
+	b := testBook(t)
+
+	bid, ok := b.BestBid()
+	assert.True(t, ok)
+	assert.Equal(t, "100", bid.Price.String())
+
+	ask, ok := b.BestAsk()
+	assert.True(t, ok)
+	assert.Equal(t, "100.5", ask.Price.String())
+
+	spread, ok := b.Spread()
+	assert.True(t, ok)
+	assert.Equal(t, "0.5", spread.String())
+
+	mid, ok := b.Mid()
+	assert.True(t, ok)
+	assert.True(t, mid.Equal(dec("100.25")))
+
+	_, ok = (&Book{Bids: b.Bids}).Mid()
+	assert.False(t, ok)
+}
+
+func TestDepth(t *testing.T) {
+	b := testBook(t)
+
+	assert.Equal(t, "3", b.DepthAtPrice(Bid, dec("99.5")).String())
+	assert.Equal(t, "0", b.DepthAtPrice(Bid, dec("100.1")).String())
+	assert.Equal(t, "3", b.DepthAtPrice(Ask, dec("101.5")).String())
+
+	cum := b.CumulativeDepth(Ask)
+	assert.Len(t, cum, 3)
+	assert.Equal(t, "7", cum[2].Size.String())
+	assert.Equal(t, "102", cum[2].Price.String())
+}
+
+func TestVWAP(t *testing.T) {
+	b := testBook(t)
+
+	// 1 @ 100.5 + 2 @ 101
+	vwap, ok := b.VWAP(Ask, dec("3"), 4)
+	assert.True(t, ok)
+	assert.Equal(t, "100.8333", vwap.String())
+
+	filled, cost := b.Fill(Bid, dec("2"))
+	assert.Equal(t, "2", filled.String())
+	assert.Equal(t, "199.5", cost.String())
+
+	vwap, ok = b.VWAP(Ask, dec("10"), 2)
+	assert.False(t, ok)
+	assert.Equal(t, "101.50", vwap.String())
+}
+
+func TestFromFloats(t *testing.T) {
+	b, err := FromFloats([][]float64{{0.1, 3, 1}}, [][]float64{{0.3, 0.2, 1}})
+	assert.Nil(t, err)
+
+	spread, ok := b.Spread()
+	assert.True(t, ok)
+	assert.Equal(t, "0.2", spread.String())
+
+	_, err = FromFloats([][]float64{{1}}, nil)
+	assert.NotNil(t, err)
+}
+
+func TestNewCopies(t *testing.T) {
+	bids := []PriceLevel{{Price: dec("99"), Size: dec("1")}, {Price: dec("100"), Size: dec("2")}}
+	asks := []PriceLevel{{Price: dec("102"), Size: dec("1")}, {Price: dec("101"), Size: dec("2")}}
+
+	b := New(bids, asks)
+	assert.Equal(t, "100", b.Bids[0].Price.String())
+	assert.Equal(t, "101", b.Asks[0].Price.String())
+
+	// the levels of the caller keep their order
+	assert.Equal(t, "99", bids[0].Price.String())
+	assert.Equal(t, "102", asks[0].Price.String())
+}
@@ -0,0 +1,220 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package candle
+
+import (
+	"errors"
+	"time"
+)
+
+// A Builder aggregates a live stream of trades or of lower interval candles.
+// One builder must be fed with either trades or candles, not both.
+type Builder struct {
+	cfg Config
+
+	// cur is the candle being built, nil between two candles
+	cur *Candle
+	// first and last are the times of the first and last trades of cur
+	first time.Time
+	last  time.Time
+	// parts are the lower interval candles of cur
+	parts []*Candle
+
+	// next is the open time following the last emitted candle
+	next      time.Time
+	lastClose float64
+}
+
+func NewBuilder(cfg Config) (*Builder, error) {
+	if cfg.Interval <= 0 {
+		return nil, errors.New("candle: interval must be positive")
+	}
+
+	return &Builder{cfg: cfg}, nil
+}
+
+// AddTrade adds a trade to the current candle and returns the candles closed by it.
+// Trades may arrive out of order within the current candle.
+func (b *Builder) AddTrade(t *Trade) ([]*Candle, error) {
+	start := b.openTime(t.Time)
+	if start.Before(b.floor()) {
+		return nil, ErrOutOfOrder
+	}
+
+	var ret []*Candle
+	if b.cur == nil || start.After(b.cur.OpenTime) {
+		ret = b.roll(start)
+		b.cur = &Candle{
+			OpenTime: start,
+			Interval: b.cfg.Interval,
+			Open:     t.Price,
+			High:     t.Price,
+			Low:      t.Price,
+			Close:    t.Price,
+		}
+		b.first, b.last = t.Time, t.Time
+	}
+
+	c := b.cur
+	if t.Price > c.High {
+		c.High = t.Price
+	}
+	if t.Price < c.Low {
+		c.Low = t.Price
+	}
+	if t.Time.Before(b.first) {
+		c.Open, b.first = t.Price, t.Time
+	}
+	if !t.Time.Before(b.last) {
+		c.Close, b.last = t.Price, t.Time
+	}
+	c.Volume += t.Qty
+	c.QuoteVolume += t.Price * t.Qty
+	c.Trades++
+
+	return ret, nil
+}
+
+// AddCandle adds a lower interval candle to the current candle and returns the candles closed by it.
+// A candle with the same open time as the previous input replaces it, so the
+// updates of an unclosed candle pushed by a WebSocket can be added as they come.
+func (b *Builder) AddCandle(in *Candle) ([]*Candle, error) {
+	if in.Interval <= 0 || in.Interval > b.cfg.Interval {
+		return nil, ErrInterval
+	}
+
+	start := b.openTime(in.OpenTime)
+	if !b.openTime(in.CloseTime().Add(-1)).Equal(start) {
+		return nil, ErrInterval
+	}
+	if start.Before(b.floor()) {
+		return nil, ErrOutOfOrder
+	}
+
+	var ret []*Candle
+	if b.cur == nil || start.After(b.cur.OpenTime) {
+		ret = b.roll(start)
+		b.cur = &Candle{OpenTime: start, Interval: b.cfg.Interval}
+		b.parts = b.parts[:0]
+	}
+
+	part := *in
+	n := len(b.parts)
+	switch {
+	case n > 0 && part.OpenTime.Equal(b.parts[n-1].OpenTime):
+		b.parts[n-1] = &part
+	case n > 0 && part.OpenTime.Before(b.parts[n-1].OpenTime):
+		return nil, ErrOutOfOrder
+	default:
+		b.parts = append(b.parts, &part)
+	}
+	b.merge()
+
+	if part.Closed && part.CloseTime().Equal(b.cur.CloseTime()) {
+		ret = append(ret, b.close())
+	}
+
+	return ret, nil
+}
+
+// AdvanceTo closes the current candle once now is past its close time, and returns
+// the closed candles including the empty ones up to now when Config.FillEmpty is set.
+func (b *Builder) AdvanceTo(now time.Time) []*Candle {
+	start := b.openTime(now)
+	if b.cur != nil && !start.After(b.cur.OpenTime) {
+		return nil
+	}
+
+	return b.roll(start)
+}
+
+// Current returns a copy of the unclosed candle being built, or nil.
+func (b *Builder) Current() *Candle {
+	if b.cur == nil {
+		return nil
+	}
+
+	c := *b.cur
+	return &c
+}
+
+func (b *Builder) openTime(t time.Time) time.Time {
+	return t.Add(-b.cfg.Align).Truncate(b.cfg.Interval).Add(b.cfg.Align)
+}
+
+// floor is the earliest open time accepted for an input.
+func (b *Builder) floor() time.Time {
+	if b.cur != nil {
+		return b.cur.OpenTime
+	}
+	return b.next
+}
+
+// roll closes the current candle and the empty candles opened before start.
+func (b *Builder) roll(start time.Time) []*Candle {
+	var ret []*Candle
+	if b.cur != nil {
+		ret = append(ret, b.close())
+	}
+
+	if b.cfg.FillEmpty && !b.next.IsZero() {
+		for ; b.next.Before(start); b.next = b.next.Add(b.cfg.Interval) {
+			ret = append(ret, &Candle{
+				OpenTime: b.next,
+				Interval: b.cfg.Interval,
+				Open:     b.lastClose,
+				High:     b.lastClose,
+				Low:      b.lastClose,
+				Close:    b.lastClose,
+				Closed:   true,
+			})
+		}
+	}
+
+	return ret
+}
+
+func (b *Builder) close() *Candle {
+	c := b.cur
+	c.Closed = true
+	b.cur = nil
+	b.next = c.CloseTime()
+	b.lastClose = c.Close
+
+	return c
+}
+
+// merge recomputes the current candle from its parts.
+func (b *Builder) merge() {
+	c := b.cur
+	first, last := b.parts[0], b.parts[len(b.parts)-1]
+	c.Open, c.High, c.Low, c.Close = first.Open, first.High, first.Low, last.Close
+	c.Volume, c.QuoteVolume, c.Trades = 0, 0, 0
+
+	for _, p := range b.parts {
+		if p.High > c.High {
+			c.High = p.High
+		}
+		if p.Low < c.Low {
+			c.Low = p.Low
+		}
+		c.Volume += p.Volume
+		c.QuoteVolume += p.QuoteVolume
+		c.Trades += p.Trades
+	}
+}
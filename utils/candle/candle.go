@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package candle builds candles of any fixed interval, either from trades or
+// from candles of a lower interval.
+//
+// Candles open at the multiples of the interval since the zero time, shifted by
+// Config.Align. Weekly candles therefore open on Monday 00:00 UTC, calendar
+// intervals such as 1 month are not supported.
+package candle
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+var (
+	// ErrOutOfOrder is returned for an input belonging to a candle which is already closed.
+	ErrOutOfOrder = errors.New("candle: input belongs to a closed candle")
+	// ErrInterval is returned for a lower interval candle which does not fit in one candle.
+	ErrInterval = errors.New("candle: input does not fit in one candle")
+)
+
+// A Candle is the OHLCV bar of the range [OpenTime, OpenTime+Interval).
+type Candle struct {
+	OpenTime time.Time
+	Interval time.Duration
+	Open     float64
+	High     float64
+	Low      float64
+	Close    float64
+	// Volume is in base asset or contracts, QuoteVolume is the traded value
+	Volume      float64
+	QuoteVolume float64
+	// Trades is the number of trades, zero when the venue does not report it
+	Trades int
+	// Closed is false while the interval has not elapsed, the values may still change
+	Closed bool
+}
+
+func (c *Candle) CloseTime() time.Time {
+	return c.OpenTime.Add(c.Interval)
+}
+
+// A Trade is a single execution.
+type Trade struct {
+	Time  time.Time
+	Price float64
+	Qty   float64
+}
+
+type Config struct {
+	Interval time.Duration
+	// Align is the offset of the open times from the interval boundaries,
+	// e.g. -8h for daily candles opening at midnight UTC+8
+	Align time.Duration
+	// FillEmpty emits a candle for the intervals without any input, at the previous close and without volume
+	FillEmpty bool
+}
+
+// FromTrades builds the candles of trades given in any order. now is the time
+// the trades are known up to: the candles ending after now are returned unclosed.
+func FromTrades(cfg Config, trades []*Trade, now time.Time) ([]*Candle, error) {
+	b, err := NewBuilder(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]*Trade, len(trades))
+	copy(sorted, trades)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+
+	var ret []*Candle
+	for _, t := range sorted {
+		closed, err := b.AddTrade(t)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, closed...)
+	}
+	ret = append(ret, b.AdvanceTo(now)...)
+
+	if c := b.Current(); c != nil {
+		ret = append(ret, c)
+	}
+
+	return ret, nil
+}
+
+// Resample merges lower interval candles given in any order into candles of cfg.Interval.
+// The last candle stays unclosed unless its last input is closed and ends with it.
+func Resample(cfg Config, candles []*Candle) ([]*Candle, error) {
+	b, err := NewBuilder(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]*Candle, len(candles))
+	copy(sorted, candles)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].OpenTime.Before(sorted[j].OpenTime)
+	})
+
+	var ret []*Candle
+	for _, c := range sorted {
+		closed, err := b.AddCandle(c)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, closed...)
+	}
+
+	if c := b.Current(); c != nil {
+		ret = append(ret, c)
+	}
+
+	return ret, nil
+}
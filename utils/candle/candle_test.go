@@ -0,0 +1,178 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package candle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testStart = time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+
+func testTrade(sec int, price, qty float64) *Trade {
+	return &Trade{Time: testStart.Add(time.Duration(sec) * time.Second), Price: price, Qty: qty}
+}
+
+func testCandle(min int, open, high, low, close, vol float64) *Candle {
+	return &Candle{
+		OpenTime: testStart.Add(time.Duration(min) * time.Minute),
+		Interval: time.Minute,
+		Open:     open,
+		High:     high,
+		Low:      low,
+		Close:    close,
+		Volume:   vol,
+		Closed:   true,
+	}
+}
+
+func TestFromTrades(t *testing.T) {
+	trades := []*Trade{
+		testTrade(10, 101, 1),
+		testTrade(5, 100, 2),
+		testTrade(50, 99, 1),
+		testTrade(30, 103, 1),
+		// nothing in the second minute
+		testTrade(130, 104, 3),
+	}
+
+	candles, err := FromTrades(Config{Interval: time.Minute}, trades, testStart.Add(150*time.Second))
+	assert.Nil(t, err)
+	assert.Len(t, candles, 2)
+
+	c := candles[0]
+	assert.Equal(t, testStart, c.OpenTime)
+	assert.Equal(t, []float64{100, 103, 99, 99}, []float64{c.Open, c.High, c.Low, c.Close})
+	assert.Equal(t, 5.0, c.Volume)
+	assert.Equal(t, 200+101+103+99.0, c.QuoteVolume)
+	assert.Equal(t, 4, c.Trades)
+	assert.True(t, c.Closed)
+
+	c = candles[1]
+	assert.Equal(t, testStart.Add(2*time.Minute), c.OpenTime)
+	assert.Equal(t, 104.0, c.Close)
+	assert.False(t, c.Closed)
+}
+
+func TestFromTradesFillEmpty(t *testing.T) {
+	trades := []*Trade{testTrade(10, 100, 1), testTrade(130, 104, 3)}
+
+	candles, err := FromTrades(Config{Interval: time.Minute, FillEmpty: true}, trades, testStart.Add(5*time.Minute))
+	assert.Nil(t, err)
+	assert.Len(t, candles, 5)
+
+	empty := candles[1]
+	assert.Equal(t, testStart.Add(time.Minute), empty.OpenTime)
+	assert.Equal(t, []float64{100, 100, 100, 100}, []float64{empty.Open, empty.High, empty.Low, empty.Close})
+	assert.Zero(t, empty.Volume)
+	assert.True(t, empty.Closed)
+
+	// the trade candle is closed by now, followed by empty candles up to now
+	assert.True(t, candles[2].Closed)
+	assert.Equal(t, 104.0, candles[4].Close)
+	assert.Zero(t, candles[4].Volume)
+	assert.Equal(t, testStart.Add(4*time.Minute), candles[4].OpenTime)
+}
+
+func TestBuilderOutOfOrder(t *testing.T) {
+	b, err := NewBuilder(Config{Interval: time.Minute})
+	assert.Nil(t, err)
+
+	_, err = b.AddTrade(testTrade(70, 100, 1))
+	assert.Nil(t, err)
+
+	_, err = b.AddTrade(testTrade(65, 99, 1))
+	assert.Nil(t, err)
+	assert.Equal(t, 99.0, b.Current().Open)
+	assert.Equal(t, 100.0, b.Current().Close)
+
+	closed, err := b.AddTrade(testTrade(121, 98, 1))
+	assert.Nil(t, err)
+	assert.Len(t, closed, 1)
+
+	_, err = b.AddTrade(testTrade(100, 101, 1))
+	assert.ErrorIs(t, err, ErrOutOfOrder)
+}
+
+func TestResample(t *testing.T) {
+	candles := []*Candle{
+		testCandle(0, 10, 12, 9, 11, 1),
+		testCandle(1, 11, 13, 10, 12, 2),
+		testCandle(2, 12, 12, 8, 9, 3),
+		testCandle(3, 9, 10, 9, 10, 1),
+		testCandle(4, 10, 10, 10, 10, 1),
+	}
+	last := testCandle(5, 10, 11, 10, 11, 1)
+	last.Closed = false
+	candles = append(candles, last)
+
+	out, err := Resample(Config{Interval: 3 * time.Minute}, candles)
+	assert.Nil(t, err)
+	assert.Len(t, out, 2)
+
+	c := out[0]
+	assert.Equal(t, []float64{10, 13, 8, 9}, []float64{c.Open, c.High, c.Low, c.Close})
+	assert.Equal(t, 6.0, c.Volume)
+	assert.True(t, c.Closed)
+
+	c = out[1]
+	assert.Equal(t, testStart.Add(3*time.Minute), c.OpenTime)
+	assert.Equal(t, []float64{9, 11, 9, 11}, []float64{c.Open, c.High, c.Low, c.Close})
+	assert.False(t, c.Closed)
+}
+
+func TestBuilderCandleUpdates(t *testing.T) {
+	b, err := NewBuilder(Config{Interval: 2 * time.Minute})
+	assert.Nil(t, err)
+
+	update := testCandle(0, 10, 11, 10, 11, 1)
+	update.Closed = false
+	closed, err := b.AddCandle(update)
+	assert.Nil(t, err)
+	assert.Empty(t, closed)
+
+	// the same kline pushed again replaces the previous update
+	update = testCandle(0, 10, 12, 10, 12, 3)
+	update.Closed = false
+	_, err = b.AddCandle(update)
+	assert.Nil(t, err)
+	assert.Equal(t, 3.0, b.Current().Volume)
+
+	closed, err = b.AddCandle(testCandle(1, 12, 12, 11, 11, 1))
+	assert.Nil(t, err)
+	assert.Len(t, closed, 1)
+	assert.Equal(t, 4.0, closed[0].Volume)
+	assert.Nil(t, b.Current())
+
+	_, err = b.AddCandle(&Candle{OpenTime: testStart.Add(3 * time.Minute), Interval: 2 * time.Minute})
+	assert.ErrorIs(t, err, ErrInterval)
+}
+
+func TestWeeklyAlign(t *testing.T) {
+	// 2023-12-01 is a Friday, weekly candles open on Monday
+	candles, err := FromTrades(Config{Interval: 7 * 24 * time.Hour}, []*Trade{testTrade(0, 1, 1)}, time.Time{})
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2023, 11, 27, 0, 0, 0, 0, time.UTC), candles[0].OpenTime)
+
+	// daily candles at midnight UTC+8
+	candles, err = FromTrades(Config{Interval: 24 * time.Hour, Align: -8 * time.Hour}, []*Trade{testTrade(0, 1, 1)}, time.Time{})
+	assert.Nil(t, err)
+	assert.Equal(t, testStart.Add(-8*time.Hour), candles[0].OpenTime)
+}
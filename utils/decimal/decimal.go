@@ -0,0 +1,332 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package decimal implements exact decimal numbers for prices, quantities and balances.
+//
+// A Decimal is an arbitrary precision integer scaled by a power of ten, so the
+// values sent by the venues as strings are kept exactly. Addition, subtraction
+// and multiplication are exact, division rounds to a given number of places.
+// The zero value is 0 and Decimal values are immutable.
+package decimal
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+type Decimal struct {
+	// the value is coef * 10^-scale, a nil coef is zero
+	coef  *big.Int
+	scale int32
+}
+
+var Zero = Decimal{}
+
+// maxExponent bounds the exponent and the scale of the parsed numbers, larger ones are
+// not prices nor quantities and would make the arithmetic arbitrarily slow.
+const maxExponent = 1000
+
+// New returns value * 10^-scale, e.g. New(123, 2) is 1.23.
+func New(value int64, scale int32) Decimal {
+	return newDecimal(big.NewInt(value), scale)
+}
+
+func NewFromInt(value int64) Decimal {
+	return New(value, 0)
+}
+
+// NewFromFloat returns the shortest decimal representation of f.
+func NewFromFloat(f float64) Decimal {
+	d, err := NewFromString(strconv.FormatFloat(f, 'f', -1, 64))
+	if err != nil {
+		panic(fmt.Sprintf("decimal: cannot convert %v", f))
+	}
+	return d
+}
+
+// NewFromString parses a decimal number such as "-1.25", "0.00012" or "1.5e-8", the
+// exponent and the number of digits after the point are limited to 1000.
+func NewFromString(s string) (Decimal, error) {
+	str := s
+
+	var exp int64
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(str[i+1:], 10, 32)
+		if err != nil {
+			return Zero, fmt.Errorf("decimal: invalid number %q", s)
+		}
+		exp, str = e, str[:i]
+	}
+
+	var scale int64
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		scale = int64(len(str) - i - 1)
+		str = str[:i] + str[i+1:]
+	}
+
+	digits := strings.TrimLeft(str, "+-")
+	if digits == "" || len(str)-len(digits) > 1 || strings.IndexFunc(digits, notDigit) >= 0 {
+		return Zero, fmt.Errorf("decimal: invalid number %q", s)
+	}
+
+	coef, ok := new(big.Int).SetString(str, 10)
+	if !ok {
+		return Zero, fmt.Errorf("decimal: invalid number %q", s)
+	}
+
+	scale -= exp
+	if exp < -maxExponent || exp > maxExponent || scale < -maxExponent || scale > maxExponent {
+		return Zero, fmt.Errorf("decimal: exponent out of range %q", s)
+	}
+	if scale < 0 {
+		coef.Mul(coef, pow10(int32(-scale)))
+		scale = 0
+	}
+
+	return newDecimal(coef, int32(scale)), nil
+}
+
+// Parse is NewFromString returning zero for an empty string, as venues send
+// empty strings for the values which do not apply.
+func Parse(s string) (Decimal, error) {
+	if s == "" {
+		return Zero, nil
+	}
+	return NewFromString(s)
+}
+
+// MustFromString is NewFromString panicking on an invalid number, for constants.
+func MustFromString(s string) Decimal {
+	d, err := NewFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func newDecimal(coef *big.Int, scale int32) Decimal {
+	if scale < 0 {
+		coef = new(big.Int).Mul(coef, pow10(-scale))
+		scale = 0
+	}
+	return Decimal{coef: coef, scale: scale}
+}
+
+func notDigit(r rune) bool {
+	return r < '0' || r > '9'
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) int() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// rescale returns the coefficient of d at a scale not lower than d.scale.
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale == d.scale {
+		return d.int()
+	}
+	return new(big.Int).Mul(d.int(), pow10(scale-d.scale))
+}
+
+func maxScale(d, d2 Decimal) int32 {
+	if d.scale > d2.scale {
+		return d.scale
+	}
+	return d2.scale
+}
+
+// Scale is the number of digits after the decimal point.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+func (d Decimal) Add(d2 Decimal) Decimal {
+	scale := maxScale(d, d2)
+	return newDecimal(new(big.Int).Add(d.rescale(scale), d2.rescale(scale)), scale)
+}
+
+func (d Decimal) Sub(d2 Decimal) Decimal {
+	scale := maxScale(d, d2)
+	return newDecimal(new(big.Int).Sub(d.rescale(scale), d2.rescale(scale)), scale)
+}
+
+func (d Decimal) Mul(d2 Decimal) Decimal {
+	return newDecimal(new(big.Int).Mul(d.int(), d2.int()), d.scale+d2.scale)
+}
+
+// Div returns d / d2 rounded half away from zero to places digits after the decimal point.
+// It panics if d2 is zero.
+func (d Decimal) Div(d2 Decimal, places int32) Decimal {
+	if d2.IsZero() {
+		panic("decimal: division by zero")
+	}
+	if places < 0 {
+		places = 0
+	}
+
+	// d / d2 = coef * 10^scale2 / (coef2 * 10^scale)
+	num := new(big.Int).Mul(d.int(), pow10(d2.scale+places))
+	den := new(big.Int).Mul(d2.int(), pow10(d.scale))
+
+	return newDecimal(quoRound(num, den), places)
+}
+
+// quoRound returns num / den rounded half away from zero.
+func quoRound(num, den *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+
+	if new(big.Int).Abs(new(big.Int).Lsh(r, 1)).Cmp(new(big.Int).Abs(den)) >= 0 {
+		if num.Sign() == den.Sign() {
+			q.Add(q, big.NewInt(1))
+		} else {
+			q.Sub(q, big.NewInt(1))
+		}
+	}
+
+	return q
+}
+
+// Round rounds d half away from zero to places digits after the decimal point.
+func (d Decimal) Round(places int32) Decimal {
+	if places < 0 {
+		places = 0
+	}
+	if places >= d.scale {
+		return d
+	}
+	return newDecimal(quoRound(d.int(), pow10(d.scale-places)), places)
+}
+
+// Truncate drops the digits after places digits after the decimal point.
+func (d Decimal) Truncate(places int32) Decimal {
+	if places < 0 {
+		places = 0
+	}
+	if places >= d.scale {
+		return d
+	}
+	return newDecimal(new(big.Int).Quo(d.int(), pow10(d.scale-places)), places)
+}
+
+// FloorTo returns the greatest multiple of step lower than or equal to d,
+// e.g. to round a quantity down to the lot size. The result has the scale of step,
+// d is returned as is when step is not positive.
+func (d Decimal) FloorTo(step Decimal) Decimal {
+	if step.Sign() <= 0 {
+		return d
+	}
+
+	scale := maxScale(d, step)
+	// Div is the euclidean division, which is the floor division for a positive divisor
+	q := new(big.Int).Div(d.rescale(scale), step.rescale(scale))
+
+	return newDecimal(q.Mul(q, step.int()), step.scale)
+}
+
+func (d Decimal) Neg() Decimal {
+	return newDecimal(new(big.Int).Neg(d.int()), d.scale)
+}
+
+func (d Decimal) Abs() Decimal {
+	return newDecimal(new(big.Int).Abs(d.int()), d.scale)
+}
+
+// Sign returns -1, 0 or +1 according to the sign of d.
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp returns -1 if d < d2, 0 if d == d2 and +1 if d > d2. The scale is not compared.
+func (d Decimal) Cmp(d2 Decimal) int {
+	scale := maxScale(d, d2)
+	return d.rescale(scale).Cmp(d2.rescale(scale))
+}
+
+func (d Decimal) Equal(d2 Decimal) bool {
+	return d.Cmp(d2) == 0
+}
+
+func (d Decimal) LessThan(d2 Decimal) bool {
+	return d.Cmp(d2) < 0
+}
+
+func (d Decimal) GreaterThan(d2 Decimal) bool {
+	return d.Cmp(d2) > 0
+}
+
+func Min(d Decimal, rest ...Decimal) Decimal {
+	for _, v := range rest {
+		if v.LessThan(d) {
+			d = v
+		}
+	}
+	return d
+}
+
+func Max(d Decimal, rest ...Decimal) Decimal {
+	for _, v := range rest {
+		if v.GreaterThan(d) {
+			d = v
+		}
+	}
+	return d
+}
+
+// Float64 returns the nearest float64 to d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String returns d with its scale, e.g. "1.50", without exponent.
+func (d Decimal) String() string {
+	s := new(big.Int).Abs(d.int()).String()
+	if d.scale > 0 {
+		if n := int(d.scale) + 1 - len(s); n > 0 {
+			s = strings.Repeat("0", n) + s
+		}
+		i := len(s) - int(d.scale)
+		s = s[:i] + "." + s[i:]
+	}
+
+	if d.Sign() < 0 {
+		return "-" + s
+	}
+	return s
+}
+
+// StringFixed returns d rounded to places digits after the decimal point, padded with zeros.
+func (d Decimal) StringFixed(places int32) string {
+	r := d.Round(places)
+	return newDecimal(r.rescale(places), places).String()
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package decimal
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-querystring/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFromString(t *testing.T) {
+	tests := map[string]string{
+		"0":        "0",
+		"1.50":     "1.50",
+		"-0.00012": "-0.00012",
+		"+3":       "3",
+		".5":       "0.5",
+		"1.5e-8":   "0.000000015",
+		"2.5E3":    "2500",
+		"123456789012345678901234567890.123456789": "123456789012345678901234567890.123456789",
+	}
+	for in, want := range tests {
+		d, err := NewFromString(in)
+		assert.Nil(t, err, in)
+		assert.Equal(t, want, d.String(), in)
+	}
+
+	for _, in := range []string{"", "-", "1.2.3", "1e", "abc", "+-1", "1_000"} {
+		_, err := NewFromString(in)
+		assert.NotNil(t, err, in)
+	}
+
+	// the exponent and the scale are limited
+	for _, in := range []string{"1e2147483647", "1e-50000000", "1e1001", "0.5e-1000", "1." + strings.Repeat("0", 1001)} {
+		_, err := NewFromString(in)
+		assert.NotNil(t, err, in)
+	}
+	for _, in := range []string{"1e1000", "1e-1000", "0.5e1000"} {
+		_, err := NewFromString(in)
+		assert.Nil(t, err, in)
+	}
+
+	d, err := Parse("")
+	assert.Nil(t, err)
+	assert.True(t, d.IsZero())
+}
+
+func TestArithmetic(t *testing.T) {
+	a := MustFromString("0.1")
+	b := MustFromString("0.2")
+
+	assert.Equal(t, "0.3", a.Add(b).String())
+	assert.True(t, a.Add(b).Equal(MustFromString("0.30")))
+	assert.Equal(t, "-0.1", a.Sub(b).String())
+	assert.Equal(t, "0.02", a.Mul(b).String())
+	assert.Equal(t, "0.3333", MustFromString("1").Div(MustFromString("3"), 4).String())
+	assert.Equal(t, "-0.6667", MustFromString("-2").Div(MustFromString("3"), 4).String())
+	assert.Equal(t, "2.5", MustFromString("0.05").Div(MustFromString("0.02"), 1).String())
+	assert.Panics(t, func() { a.Div(Zero, 2) })
+
+	assert.Equal(t, -1, a.Cmp(b))
+	assert.True(t, Zero.Add(a).Equal(a))
+	assert.Equal(t, "0.1", Min(b, a).String())
+	assert.Equal(t, "0.2", Max(a, b).String())
+	assert.Equal(t, 0.3, a.Add(b).Float64())
+	assert.Equal(t, "0.1", NewFromFloat(0.1).String())
+	assert.Equal(t, "1.23", New(123, 2).String())
+}
+
+func TestRounding(t *testing.T) {
+	d := MustFromString("-1.2350")
+
+	assert.Equal(t, "-1.24", d.Round(2).String())
+	assert.Equal(t, "-1.23", d.Truncate(2).String())
+	assert.Equal(t, "-1", d.Round(0).String())
+	assert.Equal(t, "-1.2350", d.Round(6).String())
+	assert.Equal(t, "1.50", MustFromString("1.5").StringFixed(2))
+
+	step := MustFromString("0.05")
+	assert.Equal(t, "1.20", MustFromString("1.2345").FloorTo(step).String())
+	assert.Equal(t, "-1.25", d.FloorTo(step).String())
+	assert.Equal(t, "100", MustFromString("123.4").FloorTo(MustFromString("100")).String())
+}
+
+func TestEncoding(t *testing.T) {
+	type order struct {
+		Price Decimal `json:"price" url:"price"`
+		Size  Decimal `json:"size,omitempty" url:"size,omitempty"`
+	}
+
+	var o order
+	assert.Nil(t, json.Unmarshal([]byte(`{"price":"27000.10","size":0.001}`), &o))
+	assert.Equal(t, "27000.10", o.Price.String())
+	assert.Equal(t, "0.001", o.Size.String())
+
+	b, err := json.Marshal(o)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"price":"27000.10","size":"0.001"}`, string(b))
+
+	assert.Nil(t, json.Unmarshal([]byte(`{"price":"","size":null}`), &o))
+	assert.True(t, o.Price.IsZero())
+	assert.NotNil(t, json.Unmarshal([]byte(`{"price":"x"}`), &o))
+
+	v, err := query.Values(order{Price: MustFromString("1.5")})
+	assert.Nil(t, err)
+	assert.Equal(t, "price=1.5", v.Encode())
+}
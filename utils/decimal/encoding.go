@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package decimal
+
+import (
+	"bytes"
+	"net/url"
+	"reflect"
+)
+
+// MarshalJSON encodes d as a JSON string, like the venues send prices and quantities.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte("\"" + d.String() + "\""), nil
+}
+
+// UnmarshalJSON accepts a JSON string or number, an empty string or null is zero.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*d = Zero
+		return nil
+	}
+	return d.UnmarshalText(bytes.Trim(data, "\""))
+}
+
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalText(text []byte) error {
+	v, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	*d = v
+	return nil
+}
+
+// EncodeValues implements query.Encoder so a Decimal can be used in request parameters,
+// the omitempty option omits a zero Decimal.
+func (d Decimal) EncodeValues(key string, v *url.Values) error {
+	v.Add(key, d.String())
+	return nil
+}
+
+// ValidateValue is a CustomTypeFunc of the go-playground validator, registered for
+// Decimal it validates the float64 value and a zero Decimal fails required.
+func ValidateValue(field reflect.Value) interface{} {
+	if d, ok := field.Interface().(Decimal); ok && !d.IsZero() {
+		return d.Float64()
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fakeserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// An Envelope is a decoded response which reports the API error of its envelope.
+type Envelope interface {
+	Err() error
+}
+
+// An Endpoint is a client call and the request it must send. Call returns the
+// response of the client method, its envelope is checked when it is an Envelope.
+type Endpoint struct {
+	Name   string
+	Method string
+	Path   string
+	Call   func(ctx context.Context) (any, error)
+}
+
+// RunEndpoints calls every endpoint against the default response of srv and checks
+// the route of its request and whether it is signed.
+func RunEndpoints(t *testing.T, srv *Server, signed bool, endpoints []Endpoint) {
+	for _, e := range endpoints {
+		t.Run(e.Name, func(t *testing.T) {
+			resp, err := e.Call(context.Background())
+			assert.Nil(t, err)
+			if env, ok := resp.(Envelope); ok && err == nil {
+				assert.Nil(t, env.Err())
+			}
+
+			r := srv.LastRequest()
+			assert.Equal(t, e.Method, r.Method)
+			assert.Equal(t, e.Path, r.Path)
+			assert.Equal(t, signed, r.Signed)
+		})
+	}
+}
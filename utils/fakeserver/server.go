@@ -0,0 +1,286 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fakeserver provides an httptest based exchange server for offline tests.
+//
+// The venue packages (spottest, contracttest, okxtest and kucointest) configure it with
+// the signature check of the venue and its response envelope. Tests register fixtures
+// for the routes they call, inject failures and latency, and inspect the recorded requests.
+package fakeserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Verifier checks the credentials of a request. It reports whether the request was signed,
+// an unsigned request is passed to the route as a public one.
+type Verifier func(r *http.Request, body []byte) (signed bool, err error)
+
+type Config struct {
+	// Verify checks the signature of the requests, nil accepts every request unsigned
+	Verify Verifier
+	// Unauthorized is returned when Verify fails, its body may contain %s for the error
+	Unauthorized Response
+	// Default is returned for the routes without fixture, the zero value returns 404
+	Default Response
+}
+
+// Response is a canned response. Body is sent as is when it is a string or []byte
+// and JSON encoded otherwise.
+type Response struct {
+	Status int
+	Body   any
+	Delay  time.Duration
+	Header http.Header
+}
+
+// Request is a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+	Signed bool
+	Time   time.Time
+}
+
+// JSON decodes the body of the request into v.
+func (r *Request) JSON(v any) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+type Server struct {
+	*httptest.Server
+
+	cfg Config
+
+	mu       sync.Mutex
+	routes   map[string]http.HandlerFunc
+	failures map[string][]Response
+	latency  time.Duration
+	requests []*Request
+}
+
+// New starts a server, it must be closed by the caller.
+func New(cfg Config) *Server {
+	s := &Server{
+		cfg:      cfg,
+		routes:   make(map[string]http.HandlerFunc),
+		failures: make(map[string][]Response),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+func routeKey(method, path string) string {
+	return method + " " + path
+}
+
+// Handle serves body with status 200 on method and path.
+func (s *Server) Handle(method, path string, body any) {
+	s.HandleResponse(method, path, Response{Status: http.StatusOK, Body: body})
+}
+
+// HandleResponse serves resp on method and path.
+func (s *Server) HandleResponse(method, path string, resp Response) {
+	s.HandleFunc(method, path, func(w http.ResponseWriter, r *http.Request) {
+		resp.write(w, r)
+	})
+}
+
+// HandleFunc serves method and path with h, the request body can be read again by h.
+func (s *Server) HandleFunc(method, path string, h http.HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.routes[routeKey(method, path)] = h
+}
+
+// Fail makes the next request on method and path return resp instead of the fixture.
+// Several calls queue several failures.
+func (s *Server) Fail(method, path string, resp Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := routeKey(method, path)
+	s.failures[key] = append(s.failures[key], resp)
+}
+
+// SetLatency delays every response by d.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency = d
+}
+
+// Requests returns the received requests in order.
+func (s *Server) Requests() []*Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*Request(nil), s.requests...)
+}
+
+// LastRequest returns the last received request, or nil.
+func (s *Server) LastRequest() *Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.requests) == 0 {
+		return nil
+	}
+
+	return s.requests[len(s.requests)-1]
+}
+
+// Reset removes the fixtures, failures and recorded requests.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.routes = make(map[string]http.HandlerFunc)
+	s.failures = make(map[string][]Response)
+	s.latency = 0
+	s.requests = nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	req := &Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+		Time:   time.Now(),
+	}
+
+	var verifyErr error
+	if s.cfg.Verify != nil {
+		req.Signed, verifyErr = s.cfg.Verify(r, body)
+	}
+
+	key := routeKey(r.Method, r.URL.Path)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	latency := s.latency
+	h := s.routes[key]
+	var failure *Response
+	if queue := s.failures[key]; len(queue) > 0 {
+		failure, s.failures[key] = &queue[0], queue[1:]
+	}
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	switch {
+	case verifyErr != nil:
+		unauthorized := s.cfg.Unauthorized
+		if unauthorized.Status == 0 {
+			unauthorized.Status = http.StatusUnauthorized
+		}
+		if b, ok := unauthorized.Body.(string); ok {
+			unauthorized.Body = replaceError(b, verifyErr)
+		}
+		unauthorized.write(w, r)
+	case failure != nil:
+		failure.write(w, r)
+	case h != nil:
+		h(w, r)
+	case s.cfg.Default.Status != 0 || s.cfg.Default.Body != nil:
+		s.cfg.Default.write(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (resp Response) write(w http.ResponseWriter, r *http.Request) {
+	if resp.Delay > 0 {
+		select {
+		case <-time.After(resp.Delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	body, err := Fixture(resp.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	for k, v := range resp.Header {
+		w.Header()[k] = v
+	}
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+
+	status := resp.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// Fixture returns the bytes sent for body: strings and byte slices as is, nil as nothing
+// and other values JSON encoded.
+func Fixture(body any) ([]byte, error) {
+	switch b := body.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []byte(b), nil
+	case []byte:
+		return b, nil
+	case json.RawMessage:
+		return b, nil
+	}
+
+	return json.Marshal(body)
+}
+
+// replaceError puts the message of err, escaped for a JSON string, in place of %s.
+func replaceError(body string, err error) string {
+	msg, _ := json.Marshal(err.Error())
+	return strings.Replace(body, "%s", string(msg[1:len(msg)-1]), 1)
+}
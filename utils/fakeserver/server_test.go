@@ -0,0 +1,177 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fakeserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testGet(t *testing.T, s *Server, path string, header http.Header) (int, string) {
+	req, err := http.NewRequest(http.MethodGet, s.URL+path, nil)
+	if err != nil {
+		t.Fatalf("Could not create request, %s", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	resp, err := s.Client().Do(req)
+	if err != nil {
+		t.Fatalf("Could not send request, %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestServerRoutes(t *testing.T) {
+	s := New(Config{})
+	defer s.Close()
+
+	s.Handle(http.MethodGet, "/ping", map[string]int{"n": 1})
+	s.HandleResponse(http.MethodGet, "/teapot", Response{Status: http.StatusTeapot, Body: "short and stout"})
+
+	status, body := testGet(t, s, "/ping?a=1", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"n":1}`, body)
+
+	status, body = testGet(t, s, "/teapot", nil)
+	assert.Equal(t, http.StatusTeapot, status)
+	assert.Equal(t, "short and stout", body)
+
+	status, _ = testGet(t, s, "/missing", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	reqs := s.Requests()
+	assert.Len(t, reqs, 3)
+	assert.Equal(t, "/ping", reqs[0].Path)
+	assert.Equal(t, "1", reqs[0].Query.Get("a"))
+
+	s.Reset()
+	assert.Nil(t, s.LastRequest())
+	status, _ = testGet(t, s, "/ping", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestServerDefault(t *testing.T) {
+	s := New(Config{Default: Response{Body: `{"ok":true}`}})
+	defer s.Close()
+
+	status, body := testGet(t, s, "/anything", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"ok":true}`, body)
+}
+
+func TestServerFail(t *testing.T) {
+	s := New(Config{})
+	defer s.Close()
+
+	s.Handle(http.MethodGet, "/ping", "pong")
+	s.Fail(http.MethodGet, "/ping", Response{Status: http.StatusTooManyRequests})
+	s.Fail(http.MethodGet, "/ping", Response{Status: http.StatusBadGateway})
+
+	status, _ := testGet(t, s, "/ping", nil)
+	assert.Equal(t, http.StatusTooManyRequests, status)
+	status, _ = testGet(t, s, "/ping", nil)
+	assert.Equal(t, http.StatusBadGateway, status)
+	status, body := testGet(t, s, "/ping", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "pong", body)
+}
+
+func TestServerVerify(t *testing.T) {
+	s := New(Config{
+		Verify: func(r *http.Request, body []byte) (bool, error) {
+			key := r.Header.Get("X-Key")
+			if key == "" {
+				return false, nil
+			}
+			if key != "good" {
+				return true, errors.New(`bad "key"`)
+			}
+			return true, nil
+		},
+		Unauthorized: Response{Body: `{"msg":"%s"}`},
+	})
+	defer s.Close()
+
+	s.Handle(http.MethodGet, "/private", "ok")
+
+	status, _ := testGet(t, s, "/private", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.False(t, s.LastRequest().Signed)
+
+	status, _ = testGet(t, s, "/private", http.Header{"X-Key": {"good"}})
+	assert.Equal(t, http.StatusOK, status)
+	assert.True(t, s.LastRequest().Signed)
+
+	status, body := testGet(t, s, "/private", http.Header{"X-Key": {"bad"}})
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, `{"msg":"bad \"key\""}`, body)
+}
+
+func TestServerLatency(t *testing.T) {
+	s := New(Config{})
+	defer s.Close()
+
+	s.Handle(http.MethodGet, "/ping", "pong")
+	s.SetLatency(time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, s.URL+"/ping", nil)
+	_, err := s.Client().Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	s.SetLatency(0)
+	s.HandleResponse(http.MethodGet, "/slow", Response{Body: "done", Delay: 10 * time.Millisecond})
+
+	start := time.Now()
+	_, body := testGet(t, s, "/slow", nil)
+	assert.Equal(t, "done", body)
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+}
+
+func TestServerRequestBody(t *testing.T) {
+	s := New(Config{})
+	defer s.Close()
+
+	s.HandleFunc(http.MethodPost, "/echo", func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(w, r.Body)
+	})
+
+	resp, err := s.Client().Post(s.URL+"/echo", "application/json", strings.NewReader(`{"a":"b"}`))
+	assert.Nil(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, `{"a":"b"}`, string(body))
+
+	var v map[string]string
+	assert.Nil(t, s.LastRequest().JSON(&v))
+	assert.Equal(t, "b", v["a"])
+}
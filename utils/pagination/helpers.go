@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pagination
+
+import (
+	"context"
+	"time"
+)
+
+// NewPageNumberIterator walks endpoints paginated by page number, starting at page first.
+// The iteration stops at the first page holding less than pageSize items.
+func NewPageNumberIterator[T any](first, pageSize int, fetch func(ctx context.Context, page int) ([]T, error)) *Iterator[int, T] {
+	return New(first, func(ctx context.Context, page int) ([]T, int, bool, error) {
+		items, err := fetch(ctx, page)
+		if err != nil {
+			return nil, page, false, err
+		}
+
+		return items, page + 1, len(items) >= pageSize, nil
+	})
+}
+
+// NewCursorIterator walks endpoints paginated by an id cursor, such as OKX's after/before.
+// The page is fetched with the cursor returned by cursorOf for the last item of the
+// previous page, an empty start fetches the first page.
+// The iteration stops at the first empty page.
+func NewCursorIterator[T any](start string, fetch func(ctx context.Context, cursor string) ([]T, error), cursorOf func(T) string) *Iterator[string, T] {
+	return New(start, func(ctx context.Context, cursor string) ([]T, string, bool, error) {
+		items, err := fetch(ctx, cursor)
+		if err != nil {
+			return nil, cursor, false, err
+		}
+
+		if len(items) == 0 {
+			return nil, cursor, false, nil
+		}
+
+		next := cursorOf(items[len(items)-1])
+
+		return items, next, next != "" && next != cursor, nil
+	})
+}
+
+// A Window is the time range [Start, End) requested for a page.
+type Window struct {
+	Start time.Time
+	End   time.Time
+	// Skip is the number of items at Start already returned by the previous page
+	Skip int
+}
+
+// NewTimeWindowIterator walks endpoints paginated by a time range such as MEXC's
+// startTime/endTime/limit, from start to end in windows of at most step.
+//
+// Items are expected in ascending time order. When a window returns limit items or
+// more, the window is assumed truncated and the next one starts at the time of the
+// last returned item, the items of that millisecond already returned are skipped.
+// A millisecond holding more than limit items cannot be walked by time, the items
+// beyond the limit are not returned.
+func NewTimeWindowIterator[T any](start, end time.Time, step time.Duration, limit int, fetch func(ctx context.Context, w Window) ([]T, error), timeOf func(T) time.Time) *Iterator[Window, T] {
+	first := Window{Start: start, End: minTime(start.Add(step), end)}
+
+	return New(first, func(ctx context.Context, w Window) ([]T, Window, bool, error) {
+		page, err := fetch(ctx, w)
+		if err != nil {
+			return nil, w, false, err
+		}
+
+		items := page
+		for skip := w.Skip; skip > 0 && len(items) > 0 && timeOf(items[0]).Equal(w.Start); skip-- {
+			items = items[1:]
+		}
+
+		next := Window{Start: w.End}
+		if limit > 0 && len(page) >= limit {
+			last := timeOf(page[len(page)-1])
+			if last.After(w.Start) {
+				next.Start = last
+				for i := len(page) - 1; i >= 0 && timeOf(page[i]).Equal(last); i-- {
+					next.Skip++
+				}
+			} else {
+				// the whole page is in the millisecond at Start
+				next.Start = w.Start.Add(time.Millisecond)
+			}
+		}
+
+		if !next.Start.Before(end) {
+			// the range is exhausted
+			return items, w, false, nil
+		}
+
+		next.End = minTime(next.Start.Add(step), end)
+
+		return items, next, true, nil
+	})
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
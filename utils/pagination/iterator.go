@@ -0,0 +1,191 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package pagination walks paginated exchange endpoints.
+//
+// An Iterator is driven by a Fetcher which receives the cursor of the page to
+// fetch and returns the items of that page together with the cursor of the
+// next page. The cursor is whatever the venue paginates with: a page number
+// for KuCoin, an after/before id for OKX or a time window for MEXC.
+package pagination
+
+import (
+	"context"
+	"time"
+)
+
+// A Fetcher fetches the page at cursor. It returns the items of the page, the
+// cursor of the next page and whether there is a next page at all.
+type Fetcher[C, T any] func(ctx context.Context, cursor C) (items []T, next C, more bool, err error)
+
+// A Limiter throttles page requests, Wait blocks until the next request is allowed.
+// *rate.Limiter from golang.org/x/time/rate satisfies it.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// An Iterator walks all the pages of a paginated endpoint.
+//
+//	it := pagination.New(start, fetch).WithMaxItems(1000)
+//	for it.Next(ctx) {
+//		item := it.Item()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[C, T any] struct {
+	fetch  Fetcher[C, T]
+	cursor C
+
+	maxItems int
+	limiter  Limiter
+
+	isRetryable func(error) bool
+	maxRetries  int
+	backoff     time.Duration
+
+	items []T
+	idx   int
+	count int
+	done  bool
+	err   error
+}
+
+// New creates an Iterator starting at cursor start.
+func New[C, T any](start C, fetch Fetcher[C, T]) *Iterator[C, T] {
+	return &Iterator[C, T]{
+		fetch:  fetch,
+		cursor: start,
+		idx:    -1,
+	}
+}
+
+// WithMaxItems stops the iteration after n items, n <= 0 means no limit.
+func (it *Iterator[C, T]) WithMaxItems(n int) *Iterator[C, T] {
+	it.maxItems = n
+	return it
+}
+
+// WithLimiter waits on l before every page request.
+func (it *Iterator[C, T]) WithLimiter(l Limiter) *Iterator[C, T] {
+	it.limiter = l
+	return it
+}
+
+// WithRetry retries a page up to maxRetries times when isRetryable reports the
+// error as temporary, e.g. the venue's rate limit error. The wait between
+// attempts starts at backoff and doubles every attempt.
+func (it *Iterator[C, T]) WithRetry(isRetryable func(error) bool, maxRetries int, backoff time.Duration) *Iterator[C, T] {
+	it.isRetryable = isRetryable
+	it.maxRetries = maxRetries
+	it.backoff = backoff
+	return it
+}
+
+// Next advances to the next item, fetching the next page when needed.
+// It returns false when all pages are consumed, the max items are reached or an error occurs.
+func (it *Iterator[C, T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	if it.maxItems > 0 && it.count >= it.maxItems {
+		return false
+	}
+
+	it.idx++
+	for it.idx >= len(it.items) {
+		if it.done {
+			return false
+		}
+
+		items, next, more, err := it.fetchPage(ctx)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.items, it.idx = items, 0
+		it.cursor = next
+
+		// a page may be empty while more pages follow, e.g. a quiet time window
+		if !more {
+			it.done = true
+		}
+	}
+
+	it.count++
+
+	return true
+}
+
+func (it *Iterator[C, T]) fetchPage(ctx context.Context) ([]T, C, bool, error) {
+	backoff := it.backoff
+
+	for attempt := 0; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			var zero C
+			return nil, zero, false, err
+		}
+
+		if it.limiter != nil {
+			if err := it.limiter.Wait(ctx); err != nil {
+				var zero C
+				return nil, zero, false, err
+			}
+		}
+
+		items, next, more, err := it.fetch(ctx, it.cursor)
+		if err == nil || it.isRetryable == nil || attempt >= it.maxRetries || !it.isRetryable(err) {
+			return items, next, more, err
+		}
+
+		select {
+		case <-ctx.Done():
+			var zero C
+			return nil, zero, false, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// Item returns the current item.
+func (it *Iterator[C, T]) Item() T {
+	return it.items[it.idx]
+}
+
+// Cursor returns the cursor of the next page to fetch,
+// it can be used to resume an interrupted iteration.
+func (it *Iterator[C, T]) Cursor() C {
+	return it.cursor
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *Iterator[C, T]) Err() error {
+	return it.err
+}
+
+// All drains the iterator into a slice.
+func (it *Iterator[C, T]) All(ctx context.Context) ([]T, error) {
+	var ret []T
+	for it.Next(ctx) {
+		ret = append(ret, it.Item())
+	}
+
+	return ret, it.Err()
+}
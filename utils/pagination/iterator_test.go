@@ -0,0 +1,194 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pagination
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testPageNumberFetcher(total, pageSize int) func(ctx context.Context, page int) ([]int, error) {
+	return func(ctx context.Context, page int) ([]int, error) {
+		var items []int
+		for i := (page - 1) * pageSize; i < page*pageSize && i < total; i++ {
+			items = append(items, i)
+		}
+		return items, nil
+	}
+}
+
+func TestPageNumberIterator(t *testing.T) {
+	got, err := NewPageNumberIterator(1, 3, testPageNumberFetcher(7, 3)).All(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6}, got)
+}
+
+func TestIteratorMaxItems(t *testing.T) {
+	got, err := NewPageNumberIterator(1, 3, testPageNumberFetcher(7, 3)).WithMaxItems(4).All(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3}, got)
+}
+
+func TestIteratorRetry(t *testing.T) {
+	errRateLimited := errors.New("too many requests")
+
+	calls := 0
+	fetch := testPageNumberFetcher(5, 3)
+	it := NewPageNumberIterator(1, 3, func(ctx context.Context, page int) ([]int, error) {
+		calls++
+		if calls == 2 {
+			return nil, errRateLimited
+		}
+		return fetch(ctx, page)
+	}).WithRetry(func(err error) bool {
+		return errors.Is(err, errRateLimited)
+	}, 2, time.Millisecond)
+
+	got, err := it.All(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, got)
+	assert.Equal(t, 3, calls)
+}
+
+func TestIteratorError(t *testing.T) {
+	fetchErr := errors.New("fetch failed")
+
+	it := NewPageNumberIterator(1, 3, func(ctx context.Context, page int) ([]int, error) {
+		return nil, fetchErr
+	}).WithRetry(func(err error) bool { return false }, 3, time.Millisecond)
+
+	assert.False(t, it.Next(context.TODO()))
+	assert.Equal(t, fetchErr, it.Err())
+}
+
+func TestIteratorCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	it := NewPageNumberIterator(1, 3, testPageNumberFetcher(7, 3))
+
+	assert.False(t, it.Next(ctx))
+	assert.ErrorIs(t, it.Err(), context.Canceled)
+}
+
+func TestCursorIterator(t *testing.T) {
+	// records are returned newest first, like OKX history endpoints
+	ids := []int{9, 8, 7, 6, 5, 4, 3}
+
+	fetch := func(ctx context.Context, after string) ([]string, error) {
+		var ret []string
+		for _, id := range ids {
+			if after != "" {
+				a, _ := strconv.Atoi(after)
+				if id >= a {
+					continue
+				}
+			}
+			ret = append(ret, strconv.Itoa(id))
+			if len(ret) == 3 {
+				break
+			}
+		}
+		return ret, nil
+	}
+
+	got, err := NewCursorIterator("", fetch, func(id string) string { return id }).All(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"9", "8", "7", "6", "5", "4", "3"}, got)
+}
+
+func TestTimeWindowIterator(t *testing.T) {
+	start := time.UnixMilli(0)
+	end := start.Add(10 * time.Second)
+
+	// one item per second, with a quiet period between 3s and 6s
+	var data []time.Time
+	for _, s := range []int{0, 1, 2, 6, 7, 8, 9} {
+		data = append(data, start.Add(time.Duration(s)*time.Second))
+	}
+
+	var windows []Window
+	fetch := func(ctx context.Context, w Window) ([]time.Time, error) {
+		windows = append(windows, w)
+
+		var ret []time.Time
+		for _, v := range data {
+			if !v.Before(w.Start) && v.Before(w.End) && len(ret) < 2 {
+				ret = append(ret, v)
+			}
+		}
+		return ret, nil
+	}
+
+	got, err := NewTimeWindowIterator(start, end, 3*time.Second, 2, fetch, func(v time.Time) time.Time { return v }).All(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, data, got)
+	assert.True(t, windows[len(windows)-1].End.Equal(end))
+}
+
+func TestTimeWindowIteratorSameMillisecond(t *testing.T) {
+	start := time.UnixMilli(0)
+	end := start.Add(time.Second)
+
+	// the pages of 3 items cut the items of 1ms, the items of 4ms fill a whole page
+	type item struct {
+		id int
+		ms int64
+	}
+	var data []item
+	for i, ms := range []int64{0, 1, 1, 1, 4, 4, 4, 5} {
+		data = append(data, item{id: i, ms: ms})
+	}
+
+	fetch := func(ctx context.Context, w Window) ([]item, error) {
+		var ret []item
+		for _, v := range data {
+			ts := time.UnixMilli(v.ms)
+			if !ts.Before(w.Start) && ts.Before(w.End) && len(ret) < 3 {
+				ret = append(ret, v)
+			}
+		}
+		return ret, nil
+	}
+
+	got, err := NewTimeWindowIterator(start, end, time.Second, 3, fetch, func(v item) time.Time { return time.UnixMilli(v.ms) }).All(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, data, got)
+}
+
+func TestIntervalLimiter(t *testing.T) {
+	l := NewIntervalLimiter(10, 100*time.Millisecond)
+
+	begin := time.Now()
+	for i := 0; i < 4; i++ {
+		assert.Nil(t, l.Wait(context.TODO()))
+	}
+
+	assert.GreaterOrEqual(t, time.Since(begin), 30*time.Millisecond)
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package replay records the HTTP exchanges and WebSocket frames of the clients and
+// plays them back deterministically.
+//
+// A Recorder is an http.RoundTripper set as the Transport of the HTTPClient of a client,
+// it writes every request and response as a line of JSON with the credentials redacted.
+// A Player loads those lines and serves the recorded responses in order to the same
+// clients, and its StreamServer pushes the recorded WebSocket frames again.
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+type Kind = string
+
+const (
+	HTTP      Kind = "http"
+	WebSocket Kind = "ws"
+)
+
+// Direction is the direction of a WebSocket frame.
+type Direction = string
+
+const (
+	// Open marks a new connection, the frames following it belong to that connection
+	Open    Direction = "open"
+	Send    Direction = "send"
+	Receive Direction = "receive"
+)
+
+// Entry is a recorded HTTP exchange or WebSocket frame.
+type Entry struct {
+	Time time.Time `json:"time"`
+	Kind Kind      `json:"kind"`
+	URL  string    `json:"url"`
+
+	// HTTP exchange
+	Method         string        `json:"method,omitempty"`
+	RequestHeader  http.Header   `json:"requestHeader,omitempty"`
+	RequestBody    string        `json:"requestBody,omitempty"`
+	Status         int           `json:"status,omitempty"`
+	ResponseHeader http.Header   `json:"responseHeader,omitempty"`
+	ResponseBody   string        `json:"responseBody,omitempty"`
+	Duration       time.Duration `json:"duration,omitempty"`
+	// Error is the transport error of the exchange, no response was received
+	Error string `json:"error,omitempty"`
+
+	// WebSocket frame
+	Direction Direction `json:"direction,omitempty"`
+	Data      string    `json:"data,omitempty"`
+}
+
+// ReadEntries reads the entries written by a Recorder.
+func ReadEntries(r io.Reader) ([]*Entry, error) {
+	var entries []*Entry
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for sc.Scan() {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+
+		var e Entry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &e)
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,263 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// DefaultVolatileParams are the query parameters changing at every call, they are
+// ignored when a request is matched with the recording.
+var DefaultVolatileParams = []string{"timestamp", "recvWindow", "signature"}
+
+// Player serves the recorded responses. The requests are matched by method, path and
+// query, the host is ignored, and the recorded responses of a same request are served
+// in the recorded order. It is safe for concurrent use.
+type Player struct {
+	// ignored are the volatile parameters and the redacted ones
+	ignored map[string]bool
+
+	mu      sync.Mutex
+	queues  map[string][]*Entry
+	streams [][]*Entry
+	next    int
+}
+
+// NewPlayer creates a player of the entries, the volatile parameters are ignored when
+// matching the requests, nil means DefaultVolatileParams.
+func NewPlayer(entries []*Entry, volatile []string) *Player {
+	if volatile == nil {
+		volatile = DefaultVolatileParams
+	}
+
+	p := &Player{
+		ignored: make(map[string]bool),
+		queues:  make(map[string][]*Entry),
+	}
+
+	for _, name := range volatile {
+		p.ignored[name] = true
+	}
+
+	urls := make(map[*Entry]*url.URL)
+	for _, e := range entries {
+		if e.Kind != HTTP {
+			continue
+		}
+
+		u, err := url.Parse(e.URL)
+		if err != nil {
+			continue
+		}
+		urls[e] = u
+
+		for name, values := range u.Query() {
+			for _, v := range values {
+				if v == Redacted {
+					p.ignored[name] = true
+				}
+			}
+		}
+	}
+
+	for _, e := range entries {
+		switch e.Kind {
+		case HTTP:
+			u, ok := urls[e]
+			if !ok {
+				continue
+			}
+			key := p.key(e.Method, u)
+			p.queues[key] = append(p.queues[key], e)
+		case WebSocket:
+			if e.Direction == Open || len(p.streams) == 0 {
+				p.streams = append(p.streams, nil)
+			}
+			if e.Direction == Receive {
+				last := len(p.streams) - 1
+				p.streams[last] = append(p.streams[last], e)
+			}
+		}
+	}
+
+	return p
+}
+
+// LoadPlayer creates a player of the entries read from r.
+func LoadPlayer(r io.Reader, volatile []string) (*Player, error) {
+	entries, err := ReadEntries(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPlayer(entries, volatile), nil
+}
+
+// key identifies a request by its method, path and sorted query without the volatile
+// and redacted parameters.
+func (p *Player) key(method string, u *url.URL) string {
+	q := u.Query()
+	for name := range q {
+		if p.ignored[name] {
+			q.Del(name)
+		}
+	}
+
+	return method + " " + u.Path + "?" + q.Encode()
+}
+
+// Client returns an HTTP client replaying through p, e.g. for the HTTPClient of a client config.
+func (p *Player) Client() *http.Client {
+	return &http.Client{Transport: p}
+}
+
+// Remaining returns the number of recorded HTTP exchanges not served yet.
+func (p *Player) Remaining() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var n int
+	for _, q := range p.queues {
+		n += len(q)
+	}
+
+	return n
+}
+
+// RoundTrip serves the next recorded response of the request.
+func (p *Player) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	key := p.key(req.Method, req.URL)
+
+	p.mu.Lock()
+	queue := p.queues[key]
+	if len(queue) == 0 {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("replay: no recorded response for %s %s", req.Method, req.URL.RequestURI())
+	}
+	e := queue[0]
+	p.queues[key] = queue[1:]
+	p.mu.Unlock()
+
+	if e.Error != "" {
+		return nil, errors.New(e.Error)
+	}
+
+	body := e.ResponseBody
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status)),
+		StatusCode:    e.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.ResponseHeader.Clone(),
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// StreamServer starts a WebSocket server pushing the received frames of the recorded
+// connections, the n-th connection to the server gets the frames of the n-th recorded
+// connection. The frames are pushed in order with their recorded gaps divided by speed,
+// a zero speed pushes them at once. The messages of the client are read and dropped.
+// The server must be closed by the caller, its URL uses the http scheme.
+func (p *Player) StreamServer(speed float64) *httptest.Server {
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		p.mu.Lock()
+		var frames []*Entry
+		if p.next < len(p.streams) {
+			frames = p.streams[p.next]
+		}
+		p.next++
+		p.mu.Unlock()
+
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+
+		for i, f := range frames {
+			if i > 0 && speed > 0 {
+				gap := time.Duration(float64(f.Time.Sub(frames[i-1].Time)) / speed)
+				select {
+				case <-time.After(gap):
+				case <-closed:
+					return
+				}
+			}
+
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(f.Data)); err != nil {
+				return
+			}
+		}
+
+		<-closed
+	}))
+}
+
+// StreamURL returns the ws URL of a server started by StreamServer.
+func StreamURL(srv *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+// Keys returns the sorted request keys of the recorded HTTP exchanges not served yet,
+// it helps to find out why a request was not matched.
+func (p *Player) Keys() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var keys []string
+	for k, q := range p.queues {
+		if len(q) > 0 {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Recorder records the traffic of the clients as lines of JSON. It is safe for
+// concurrent use.
+type Recorder struct {
+	base     http.RoundTripper
+	redactor *Redactor
+
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+}
+
+type RecorderCfg struct {
+	// Base sends the requests, the default is http.DefaultTransport
+	Base http.RoundTripper
+	// Redactor removes the credentials, the default is DefaultRedactor
+	Redactor *Redactor
+}
+
+// NewRecorder creates a recorder writing the entries to w.
+func NewRecorder(w io.Writer, cfg *RecorderCfg) *Recorder {
+	r := &Recorder{
+		base:     http.DefaultTransport,
+		redactor: DefaultRedactor(),
+		enc:      json.NewEncoder(w),
+	}
+
+	if cfg != nil {
+		if cfg.Base != nil {
+			r.base = cfg.Base
+		}
+		if cfg.Redactor != nil {
+			r.redactor = cfg.Redactor
+		}
+	}
+
+	return r
+}
+
+// Client returns an HTTP client recording through r, e.g. for the HTTPClient of a client config.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Err returns the first error writing an entry.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.err
+}
+
+// RoundTrip sends the request with the base transport and records the exchange.
+// The response body is read entirely and given back to the caller unchanged.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = b
+
+		// a RoundTripper must not modify the request, send a copy with the body read again
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	e := &Entry{
+		Time:          time.Now(),
+		Kind:          HTTP,
+		URL:           r.redactor.URL(req.URL),
+		Method:        req.Method,
+		RequestHeader: r.redactor.Header(req.Header),
+		RequestBody:   r.redactor.Body(req.Header.Get("Content-Type"), reqBody),
+	}
+
+	resp, err := r.base.RoundTrip(req)
+	e.Duration = time.Since(e.Time)
+	if err != nil {
+		e.Error = err.Error()
+		r.write(e)
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		e.Error = err.Error()
+		r.write(e)
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	e.Status = resp.StatusCode
+	e.ResponseHeader = r.redactor.Header(resp.Header)
+	e.ResponseBody = r.redactor.Body(resp.Header.Get("Content-Type"), respBody)
+	r.write(e)
+
+	return resp, nil
+}
+
+// RecordFrame records a WebSocket frame of the connection to url, an Open frame
+// starts a new connection.
+func (r *Recorder) RecordFrame(url string, dir Direction, data []byte) {
+	r.write(&Entry{
+		Time:      time.Now(),
+		Kind:      WebSocket,
+		URL:       url,
+		Direction: dir,
+		Data:      r.redactor.Body("", data),
+	})
+}
+
+func (r *Recorder) write(e *Entry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.enc.Encode(e); err != nil && r.err == nil {
+		r.err = err
+	}
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replay
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Redacted replaces the value of a credential.
+const Redacted = "REDACTED"
+
+// Redactor removes the credentials from the recorded traffic. The names are matched
+// case-insensitively.
+type Redactor struct {
+	// Headers are the request and response headers to redact
+	Headers []string
+	// Params are the query and form parameters to redact
+	Params []string
+	// Fields are the JSON object fields to redact in the bodies and frames
+	Fields []string
+}
+
+// DefaultRedactor redacts the API keys, signatures and passphrases of the supported venues.
+func DefaultRedactor() *Redactor {
+	return &Redactor{
+		Headers: []string{
+			"Authorization", "Cookie", "Set-Cookie",
+			// MEXC
+			"X-MEXC-APIKEY", "ApiKey", "Signature",
+			// OKX
+			"OK-ACCESS-KEY", "OK-ACCESS-SIGN", "OK-ACCESS-PASSPHRASE",
+			// KuCoin
+			"KC-API-KEY", "KC-API-SIGN", "KC-API-PASSPHRASE",
+		},
+		Params: []string{"signature", "apiKey"},
+		Fields: []string{"apiKey", "signature", "sign", "passphrase", "secret", "secretKey"},
+	}
+}
+
+func matchName(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Header returns a copy of h with the credentials redacted.
+func (r *Redactor) Header(h http.Header) http.Header {
+	if h == nil {
+		return nil
+	}
+
+	h = h.Clone()
+	for k, v := range h {
+		if matchName(r.Headers, k) {
+			for i := range v {
+				v[i] = Redacted
+			}
+		}
+	}
+
+	return h
+}
+
+// URL returns u with the credentials of its query redacted.
+func (r *Redactor) URL(u *url.URL) string {
+	redacted := *u
+	redacted.RawQuery = r.Query(u.RawQuery)
+
+	return redacted.String()
+}
+
+// Query returns the raw query with the credentials redacted, the order of the
+// parameters is kept.
+func (r *Redactor) Query(raw string) string {
+	if raw == "" {
+		return raw
+	}
+
+	pairs := strings.Split(raw, "&")
+	for i, pair := range pairs {
+		key, _, _ := strings.Cut(pair, "=")
+		if name, err := url.QueryUnescape(key); err == nil && matchName(r.Params, name) {
+			pairs[i] = key + "=" + Redacted
+		}
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+// Body returns the body with the credentials redacted. JSON bodies have their fields
+// redacted and form bodies their parameters, other bodies are returned as is.
+func (r *Redactor) Body(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		return r.Query(string(body))
+	}
+
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+
+	if !r.redactValue(v) {
+		return string(body)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return string(body)
+	}
+
+	return string(b)
+}
+
+// redactValue redacts the fields of the objects in v and reports whether one was found.
+func (r *Redactor) redactValue(v any) bool {
+	var found bool
+
+	switch v := v.(type) {
+	case map[string]any:
+		for k, field := range v {
+			if matchName(r.Fields, k) {
+				v[k] = Redacted
+				found = true
+				continue
+			}
+			if r.redactValue(field) {
+				found = true
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if r.redactValue(item) {
+				found = true
+			}
+		}
+	}
+
+	return found
+}
@@ -0,0 +1,193 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replay
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rluisr/nexapi/utils/fakeserver"
+	"github.com/stretchr/testify/assert"
+)
+
+func testDo(t *testing.T, cli *http.Client, method, url, body string, header http.Header) (int, string, error) {
+	var r io.Reader
+	if body != "" {
+		r = strings.NewReader(body)
+	}
+
+	req, err := http.NewRequest(method, url, r)
+	if err != nil {
+		t.Fatalf("Could not create request, %s", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	resp, err := cli.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	b, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(b), nil
+}
+
+func TestRecordReplay(t *testing.T) {
+	srv := fakeserver.New(fakeserver.Config{})
+	defer srv.Close()
+
+	srv.Handle(http.MethodGet, "/api/v3/account", `{"balances":[{"asset":"USDT","free":"10"}]}`)
+	srv.Handle(http.MethodPost, "/api/v3/order", `{"orderId":"1"}`)
+	srv.Fail(http.MethodGet, "/api/v3/account", fakeserver.Response{Status: http.StatusTooManyRequests, Body: `{"code":429}`})
+
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf, nil)
+	cli := rec.Client()
+
+	header := http.Header{"X-Mexc-Apikey": {"my-key"}, "Content-Type": {"application/json"}}
+
+	status, _, err := testDo(t, cli, http.MethodGet, srv.URL+"/api/v3/account?timestamp=1&signature=abc", "", header)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, status)
+
+	status, body, err := testDo(t, cli, http.MethodGet, srv.URL+"/api/v3/account?timestamp=2&signature=def", "", header)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"balances":[{"asset":"USDT","free":"10"}]}`, body)
+
+	_, _, err = testDo(t, cli, http.MethodPost, srv.URL+"/api/v3/order", `{"symbol":"BTCUSDT","apiKey":"my-key"}`, header)
+	assert.Nil(t, err)
+
+	// the server received the credentials, the recording did not
+	assert.Equal(t, "abc", srv.Requests()[0].Query.Get("signature"))
+	assert.Equal(t, `{"symbol":"BTCUSDT","apiKey":"my-key"}`, string(srv.LastRequest().Body))
+	assert.Nil(t, rec.Err())
+	assert.NotContains(t, buf.String(), "my-key")
+	assert.NotContains(t, buf.String(), "abc")
+
+	entries, err := ReadEntries(bytes.NewReader(buf.Bytes()))
+	assert.Nil(t, err)
+	assert.Len(t, entries, 3)
+	assert.Equal(t, HTTP, entries[0].Kind)
+	assert.Equal(t, Redacted, entries[0].RequestHeader.Get("X-Mexc-Apikey"))
+	assert.Contains(t, entries[0].URL, "signature="+Redacted)
+	assert.Equal(t, `{"apiKey":"REDACTED","symbol":"BTCUSDT"}`, entries[2].RequestBody)
+
+	// replay on another host with other volatile parameters
+	player := NewPlayer(entries, nil)
+	cli = player.Client()
+
+	status, _, err = testDo(t, cli, http.MethodGet, "https://api.example.com/api/v3/account?timestamp=3&signature=ghi", "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, status)
+
+	status, body, err = testDo(t, cli, http.MethodGet, "https://api.example.com/api/v3/account?timestamp=4&signature=jkl", "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"balances":[{"asset":"USDT","free":"10"}]}`, body)
+
+	assert.Equal(t, 1, player.Remaining())
+	assert.Equal(t, []string{"POST /api/v3/order?"}, player.Keys())
+
+	_, _, err = testDo(t, cli, http.MethodGet, "https://api.example.com/api/v3/account", "", nil)
+	assert.ErrorContains(t, err, "no recorded response for GET /api/v3/account")
+}
+
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestRecordTransportError(t *testing.T) {
+	var buf bytes.Buffer
+	cli := NewRecorder(&buf, &RecorderCfg{Base: failingTransport{}}).Client()
+
+	_, _, err := testDo(t, cli, http.MethodGet, "http://localhost/ping", "", nil)
+	assert.ErrorContains(t, err, "connection refused")
+
+	player, err := LoadPlayer(&buf, nil)
+	assert.Nil(t, err)
+
+	_, _, err = testDo(t, player.Client(), http.MethodGet, "http://localhost/ping", "", nil)
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestRedactor(t *testing.T) {
+	r := DefaultRedactor()
+
+	assert.Equal(t, "a=1&Signature=REDACTED&b=2", r.Query("a=1&Signature=xyz&b=2"))
+	assert.Equal(t, "apiKey=REDACTED&x=1", r.Body("application/x-www-form-urlencoded", []byte("apiKey=k&x=1")))
+	assert.Equal(t, `{"method":"login","param":{"apiKey":"REDACTED","reqTime":"1","signature":"REDACTED"}}`,
+		r.Body("", []byte(`{"method":"login","param":{"apiKey":"k","reqTime":"1","signature":"s"}}`)))
+
+	// bodies without credential are kept byte for byte
+	assert.Equal(t, `{"b":1, "a":2}`, r.Body("application/json", []byte(`{"b":1, "a":2}`)))
+	assert.Equal(t, "plain text", r.Body("text/plain", []byte("plain text")))
+
+	h := r.Header(http.Header{"Ok-Access-Sign": {"s"}, "Accept": {"application/json"}})
+	assert.Equal(t, Redacted, h.Get("OK-ACCESS-SIGN"))
+	assert.Equal(t, "application/json", h.Get("Accept"))
+}
+
+func TestStreamReplay(t *testing.T) {
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf, nil)
+
+	rec.RecordFrame("wss://example.com/ws", Open, nil)
+	rec.RecordFrame("wss://example.com/ws", Send, []byte(`{"method":"login","param":{"apiKey":"k","signature":"s"}}`))
+	rec.RecordFrame("wss://example.com/ws", Receive, []byte(`{"channel":"rs.login","data":"success"}`))
+	rec.RecordFrame("wss://example.com/ws", Receive, []byte(`{"channel":"push.ticker","data":{"lastPrice":1}}`))
+	// a reconnection
+	rec.RecordFrame("wss://example.com/ws", Open, nil)
+	rec.RecordFrame("wss://example.com/ws", Receive, []byte(`{"channel":"push.ticker","data":{"lastPrice":2}}`))
+	assert.NotContains(t, buf.String(), `"s"`)
+
+	player, err := LoadPlayer(&buf, nil)
+	assert.Nil(t, err)
+
+	srv := player.StreamServer(0)
+	defer srv.Close()
+
+	read := func(conn *websocket.Conn) string {
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		_, data, err := conn.ReadMessage()
+		assert.Nil(t, err)
+		return string(data)
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial(StreamURL(srv), nil)
+	assert.Nil(t, err)
+	assert.Nil(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"method":"ping"}`)))
+	assert.Equal(t, `{"channel":"rs.login","data":"success"}`, read(conn))
+	assert.Equal(t, `{"channel":"push.ticker","data":{"lastPrice":1}}`, read(conn))
+	conn.Close()
+
+	conn, _, err = websocket.DefaultDialer.Dial(StreamURL(srv), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"channel":"push.ticker","data":{"lastPrice":2}}`, read(conn))
+	conn.Close()
+}
@@ -0,0 +1,771 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package sim implements an in-process simulated exchange for paper trading.
+//
+// The exchange is fed with live or replayed market data through UpdateBook and
+// UpdateTrade, and its clock follows the time of that data. Orders reach the
+// matching after the configured latency and are filled by a FillModel, the fees
+// and the balances or positions are updated on every fill. The venue packages
+// wrap an Exchange with the method sets of their clients.
+package sim
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rluisr/nexapi/utils/book"
+	"github.com/rluisr/nexapi/utils/decimal"
+)
+
+var (
+	ErrUnknownSymbol       = errors.New("sim: unknown symbol")
+	ErrOrderNotFound       = errors.New("sim: order not found")
+	ErrOrderNotOpen        = errors.New("sim: order is not open")
+	ErrInsufficientBalance = errors.New("sim: insufficient balance")
+	ErrInvalidOrder        = errors.New("sim: invalid order")
+)
+
+const (
+	// qtyPlaces is the precision of the quantities bought with a quote amount
+	qtyPlaces = 8
+	// pricePlaces is the precision of the average prices
+	pricePlaces = 12
+)
+
+type Config struct {
+	Instruments []Instrument
+	// Balances are the initial free balances by asset
+	Balances   map[string]decimal.Decimal
+	Fees       Fees
+	SymbolFees map[string]Fees
+	// Latency delays the orders and cancels before they reach the matching
+	Latency time.Duration
+	// FillModel defaults to BookFill
+	FillModel FillModel
+	// OnFill is called for every fill, outside of the exchange lock
+	OnFill func(Fill)
+}
+
+type Exchange struct {
+	mu sync.Mutex
+
+	cfg         Config
+	instruments map[string]*Instrument
+	markets     map[string]*MarketState
+	balances    map[string]*Balance
+	positions   map[string]*Position
+
+	orders    map[string]*order
+	clientIDs map[string]string
+	// resting are the open orders which reached the matching, in arrival order
+	resting []*order
+	// pending are the orders and cancels delayed by the latency
+	pending []*action
+	fills   []Fill
+	// unsent are the fills not yet passed to OnFill
+	unsent []Fill
+
+	seq int64
+	now time.Time
+}
+
+type order struct {
+	Order
+	inst *Instrument
+	// lock is the amount of lockAsset still reserved by the order
+	lock      decimal.Decimal
+	lockAsset string
+}
+
+type action struct {
+	at     time.Time
+	order  *order
+	cancel bool
+}
+
+func NewExchange(cfg Config) *Exchange {
+	if cfg.FillModel == nil {
+		cfg.FillModel = BookFill{}
+	}
+
+	e := &Exchange{
+		cfg:         cfg,
+		instruments: make(map[string]*Instrument),
+		markets:     make(map[string]*MarketState),
+		balances:    make(map[string]*Balance),
+		positions:   make(map[string]*Position),
+		orders:      make(map[string]*order),
+		clientIDs:   make(map[string]string),
+	}
+
+	for i := range cfg.Instruments {
+		inst := cfg.Instruments[i]
+		if inst.ContractSize.IsZero() {
+			inst.ContractSize = decimal.NewFromInt(1)
+		}
+		e.instruments[inst.Symbol] = &inst
+	}
+
+	for asset, amount := range cfg.Balances {
+		e.balance(asset).Free = amount
+	}
+
+	return e
+}
+
+// Now returns the time of the latest market data.
+func (e *Exchange) Now() time.Time {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.now
+}
+
+// Instrument returns the instrument of symbol.
+func (e *Exchange) Instrument(symbol string) (Instrument, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	inst, ok := e.instruments[symbol]
+	if !ok {
+		return Instrument{}, ErrUnknownSymbol
+	}
+	return *inst, nil
+}
+
+// UpdateBook replaces the book of symbol and matches the orders against it.
+func (e *Exchange) UpdateBook(symbol string, b *book.Book, t time.Time) error {
+	return e.update(symbol, t, func(m *MarketState) {
+		m.setBook(b)
+	})
+}
+
+// UpdateTrade matches the orders against a trade printed by the venue.
+func (e *Exchange) UpdateTrade(symbol string, trade Trade) error {
+	return e.update(symbol, trade.Time, func(m *MarketState) {
+		m.Trade, m.traded = &trade, decimal.Zero
+	})
+}
+
+func (e *Exchange) update(symbol string, t time.Time, set func(*MarketState)) error {
+	e.mu.Lock()
+
+	if _, ok := e.instruments[symbol]; !ok {
+		e.mu.Unlock()
+		return ErrUnknownSymbol
+	}
+
+	m, ok := e.markets[symbol]
+	if !ok {
+		m = &MarketState{Symbol: symbol}
+		e.markets[symbol] = m
+	}
+	set(m)
+	m.Time = t
+	if t.After(e.now) {
+		e.now = t
+	}
+
+	// the orders arriving with this update take liquidity, the resting ones are matched after them
+	arrived := make(map[*order]bool)
+	pending := e.pending[:0]
+	for _, a := range e.pending {
+		if a.at.After(e.now) {
+			pending = append(pending, a)
+			continue
+		}
+		if a.cancel {
+			e.cancel(a.order)
+			continue
+		}
+		// an order waits for the first market data of its own instrument
+		if _, ok := e.markets[a.order.Symbol]; !ok {
+			pending = append(pending, a)
+			continue
+		}
+		arrived[a.order] = true
+		e.activate(a.order)
+	}
+	e.pending = pending
+
+	for _, o := range e.resting {
+		if o.Symbol != symbol || arrived[o] || !o.IsOpen() {
+			continue
+		}
+		for _, match := range e.cfg.FillModel.Match(&o.Order, m, false) {
+			e.fill(o, match, true)
+		}
+	}
+	e.prune()
+	e.mark(symbol)
+
+	e.flush()
+	return nil
+}
+
+// PlaceOrder submits an order, it reaches the matching after the latency.
+func (e *Exchange) PlaceOrder(req OrderRequest) (*Order, error) {
+	e.mu.Lock()
+	defer e.flush()
+
+	inst, ok := e.instruments[req.Symbol]
+	if !ok {
+		return nil, ErrUnknownSymbol
+	}
+
+	quoteBuy := inst.Kind == Spot && req.Type == Market && req.Side == Buy && req.Qty.IsZero()
+	switch {
+	case req.Side != Buy && req.Side != Sell:
+		return nil, ErrInvalidOrder
+	case quoteBuy && req.QuoteQty.Sign() <= 0, !quoteBuy && req.Qty.Sign() <= 0:
+		return nil, ErrInvalidOrder
+	case req.Type != Market && req.Price.Sign() <= 0:
+		return nil, ErrInvalidOrder
+	}
+
+	e.seq++
+	o := &order{
+		Order: Order{
+			ID:         strconv.FormatInt(e.seq, 10),
+			ClientID:   req.ClientID,
+			Symbol:     req.Symbol,
+			Side:       req.Side,
+			Type:       req.Type,
+			Price:      req.Price,
+			Qty:        req.Qty,
+			QuoteQty:   req.QuoteQty,
+			ReduceOnly: req.ReduceOnly,
+			Status:     New,
+			CreateTime: e.now,
+			UpdateTime: e.now,
+		},
+		inst: inst,
+	}
+	if !quoteBuy {
+		o.QuoteQty = decimal.Zero
+	}
+
+	if inst.Kind == Spot {
+		if err := e.reserve(o); err != nil {
+			return nil, err
+		}
+	}
+
+	e.orders[o.ID] = o
+	if o.ClientID != "" {
+		e.clientIDs[o.ClientID] = o.ID
+	}
+
+	if _, ok := e.markets[o.Symbol]; ok && e.cfg.Latency <= 0 {
+		e.activate(o)
+		e.prune()
+		e.mark(o.Symbol)
+	} else {
+		e.pending = append(e.pending, &action{at: e.now.Add(e.cfg.Latency), order: o})
+	}
+
+	ret := o.Order
+	return &ret, nil
+}
+
+// CancelOrder cancels an open order by its ID or client ID, the cancel reaches the matching after the latency.
+func (e *Exchange) CancelOrder(id, clientID string) (*Order, error) {
+	e.mu.Lock()
+	defer e.flush()
+
+	o, err := e.find(id, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if !o.IsOpen() {
+		return nil, ErrOrderNotOpen
+	}
+
+	if e.cfg.Latency <= 0 {
+		e.cancel(o)
+		e.prune()
+	} else {
+		e.pending = append(e.pending, &action{at: e.now.Add(e.cfg.Latency), order: o, cancel: true})
+	}
+
+	ret := o.Order
+	return &ret, nil
+}
+
+// Order returns an order by its ID or client ID.
+func (e *Exchange) Order(id, clientID string) (*Order, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	o, err := e.find(id, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := o.Order
+	return &ret, nil
+}
+
+// OpenOrders returns the open orders of symbol, or of every symbol when symbol is empty, by creation.
+func (e *Exchange) OpenOrders(symbol string) []*Order {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var ret []*Order
+	for _, o := range e.orders {
+		if o.IsOpen() && (symbol == "" || o.Symbol == symbol) {
+			c := o.Order
+			ret = append(ret, &c)
+		}
+	}
+	sortOrders(ret)
+
+	return ret
+}
+
+// Orders returns all the orders of symbol, or of every symbol when symbol is empty, by creation.
+func (e *Exchange) Orders(symbol string) []*Order {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var ret []*Order
+	for _, o := range e.orders {
+		if symbol == "" || o.Symbol == symbol {
+			c := o.Order
+			ret = append(ret, &c)
+		}
+	}
+	sortOrders(ret)
+
+	return ret
+}
+
+// Fills returns the fills in time order.
+func (e *Exchange) Fills() []Fill {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return append([]Fill(nil), e.fills...)
+}
+
+// Balances returns the balances sorted by asset.
+func (e *Exchange) Balances() []Balance {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	ret := make([]Balance, 0, len(e.balances))
+	for _, b := range e.balances {
+		ret = append(ret, *b)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Asset < ret[j].Asset
+	})
+
+	return ret
+}
+
+// Balance returns the balance of asset, zero when unknown.
+func (e *Exchange) Balance(asset string) Balance {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if b, ok := e.balances[asset]; ok {
+		return *b
+	}
+	return Balance{Asset: asset}
+}
+
+// Positions returns the open positions sorted by symbol.
+func (e *Exchange) Positions() []Position {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var ret []Position
+	for _, p := range e.positions {
+		if !p.Qty.IsZero() {
+			ret = append(ret, *p)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Symbol < ret[j].Symbol
+	})
+
+	return ret
+}
+
+// Position returns the position of symbol, with a zero quantity when flat.
+func (e *Exchange) Position(symbol string) Position {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if p, ok := e.positions[symbol]; ok {
+		return *p
+	}
+	return Position{Symbol: symbol}
+}
+
+func sortOrders(orders []*Order) {
+	sort.Slice(orders, func(i, j int) bool {
+		a, _ := strconv.ParseInt(orders[i].ID, 10, 64)
+		b, _ := strconv.ParseInt(orders[j].ID, 10, 64)
+		return a < b
+	})
+}
+
+// flush unlocks the exchange and passes the new fills to OnFill.
+func (e *Exchange) flush() {
+	fills := e.unsent
+	e.unsent = nil
+	e.mu.Unlock()
+
+	if e.cfg.OnFill != nil {
+		for _, f := range fills {
+			e.cfg.OnFill(f)
+		}
+	}
+}
+
+func (e *Exchange) find(id, clientID string) (*order, error) {
+	if id == "" {
+		id = e.clientIDs[clientID]
+	}
+
+	o, ok := e.orders[id]
+	if !ok {
+		return nil, ErrOrderNotFound
+	}
+	return o, nil
+}
+
+func (e *Exchange) balance(asset string) *Balance {
+	b, ok := e.balances[asset]
+	if !ok {
+		b = &Balance{Asset: asset}
+		e.balances[asset] = b
+	}
+	return b
+}
+
+func (e *Exchange) fees(symbol string) Fees {
+	if f, ok := e.cfg.SymbolFees[symbol]; ok {
+		return f
+	}
+	return e.cfg.Fees
+}
+
+// reserve locks the balance a Spot order may spend. The market buys of a base quantity
+// have an unknown cost, their fills are capped by the free balance instead.
+func (e *Exchange) reserve(o *order) error {
+	switch {
+	case o.Side == Sell:
+		o.lockAsset, o.lock = o.inst.Base, o.Qty
+	case o.Type == Market && o.QuoteQty.Sign() > 0:
+		o.lockAsset, o.lock = o.inst.Quote, o.QuoteQty
+	case o.Type == Market:
+		return nil
+	default:
+		o.lockAsset, o.lock = o.inst.Quote, o.Price.Mul(o.Qty)
+	}
+
+	b := e.balance(o.lockAsset)
+	if b.Free.LessThan(o.lock) {
+		return ErrInsufficientBalance
+	}
+	b.Free = b.Free.Sub(o.lock)
+	b.Locked = b.Locked.Add(o.lock)
+
+	return nil
+}
+
+// release returns the balance still locked by a closed order.
+func (e *Exchange) release(o *order) {
+	if o.lock.IsZero() {
+		return
+	}
+
+	b := e.balance(o.lockAsset)
+	b.Free = b.Free.Add(o.lock)
+	b.Locked = b.Locked.Sub(o.lock)
+	o.lock = decimal.Zero
+}
+
+// activate matches an order reaching the exchange against the market.
+func (e *Exchange) activate(o *order) {
+	m := e.markets[o.Symbol]
+
+	if o.Type == PostOnly && m.Book != nil {
+		if bid, ok := m.Book.BestBid(); ok && o.Side == Sell && !bid.Price.LessThan(o.Price) {
+			e.reject(o, ReasonPostOnly)
+			return
+		}
+		if ask, ok := m.Book.BestAsk(); ok && o.Side == Buy && !ask.Price.GreaterThan(o.Price) {
+			e.reject(o, ReasonPostOnly)
+			return
+		}
+	}
+
+	var matches []Match
+	if o.Type != PostOnly {
+		matches = e.cfg.FillModel.Match(&o.Order, m, true)
+	}
+
+	if o.Type == FOK {
+		total := decimal.Zero
+		for _, match := range matches {
+			total = total.Add(match.Qty)
+		}
+		if total.LessThan(o.Qty) {
+			e.cancel(o)
+			return
+		}
+	}
+
+	for _, match := range matches {
+		e.fill(o, match, false)
+	}
+
+	if !o.IsOpen() {
+		return
+	}
+	if o.Type == Market || o.Type == IOC || o.Type == FOK {
+		e.cancel(o)
+		return
+	}
+	e.resting = append(e.resting, o)
+}
+
+func (e *Exchange) cancel(o *order) {
+	if !o.IsOpen() {
+		return
+	}
+
+	o.Status = Canceled
+	o.UpdateTime = e.now
+	e.release(o)
+}
+
+// reject closes an order refused by the matching, with the reason in RejectReason.
+func (e *Exchange) reject(o *order, reason string) {
+	if !o.IsOpen() {
+		return
+	}
+
+	o.Status = Rejected
+	o.RejectReason = reason
+	o.UpdateTime = e.now
+	e.release(o)
+}
+
+// prune drops the closed orders from the resting orders.
+func (e *Exchange) prune() {
+	closer, _ := e.cfg.FillModel.(orderCloser)
+
+	resting := e.resting[:0]
+	for _, o := range e.resting {
+		if o.IsOpen() {
+			resting = append(resting, o)
+		} else if closer != nil {
+			closer.closed(o.ID)
+		}
+	}
+	e.resting = resting
+}
+
+// fill applies a match to an order, capped by its remaining quantity and the balances.
+func (e *Exchange) fill(o *order, match Match, maker bool) {
+	if !o.IsOpen() || match.Qty.Sign() <= 0 {
+		return
+	}
+
+	inst := o.inst
+	price, qty := match.Price, match.Qty
+	if o.Qty.Sign() > 0 {
+		qty = decimal.Min(qty, o.Remaining())
+	} else {
+		// market buy of a quote amount, capped by the amount left
+		qty = decimal.Min(qty, o.lock.Div(price, qtyPlaces).Truncate(qtyPlaces))
+	}
+
+	if inst.Kind == Spot && o.Side == Buy && o.Type == Market && o.QuoteQty.IsZero() {
+		free := e.balance(inst.Quote).Free
+		qty = decimal.Min(qty, free.Div(price, qtyPlaces).Truncate(qtyPlaces))
+	}
+	if inst.Kind == Linear && o.ReduceOnly {
+		qty = decimal.Min(qty, e.reducible(o))
+	}
+	if qty.Sign() <= 0 {
+		if o.Type == Market || o.ReduceOnly {
+			e.cancel(o)
+		}
+		return
+	}
+
+	fees := e.fees(o.Symbol)
+	rate := fees.Taker
+	if maker {
+		rate = fees.Maker
+	}
+
+	value := price.Mul(qty)
+	var fee decimal.Decimal
+	var feeAsset string
+	switch {
+	case inst.Kind == Linear:
+		value = value.Mul(inst.ContractSize)
+		fee, feeAsset = value.Mul(rate), inst.Quote
+		e.trade(o, price, qty, fee)
+	case o.Side == Buy:
+		fee, feeAsset = qty.Mul(rate), inst.Base
+		e.spotBuy(o, price, qty, fee)
+	default:
+		fee, feeAsset = value.Mul(rate), inst.Quote
+		e.spotSell(o, price, qty, fee)
+	}
+
+	o.Filled = o.Filled.Add(qty)
+	o.FilledQuote = o.FilledQuote.Add(price.Mul(qty))
+	o.Fee = o.Fee.Add(fee)
+	o.FeeAsset = feeAsset
+	o.UpdateTime = e.now
+
+	o.Status = PartiallyFilled
+	done := o.Qty.Sign() > 0 && o.Remaining().Sign() <= 0
+	if o.Qty.IsZero() && o.lock.Div(price, qtyPlaces).Truncate(qtyPlaces).IsZero() {
+		done = true
+	}
+	if done {
+		o.Status = Filled
+		e.release(o)
+	}
+
+	f := Fill{
+		OrderID:  o.ID,
+		ClientID: o.ClientID,
+		Symbol:   o.Symbol,
+		Side:     o.Side,
+		Price:    price,
+		Qty:      qty,
+		Fee:      fee,
+		FeeAsset: feeAsset,
+		Maker:    maker,
+		Time:     e.now,
+	}
+	e.fills = append(e.fills, f)
+	e.unsent = append(e.unsent, f)
+}
+
+func (e *Exchange) spotBuy(o *order, price, qty, fee decimal.Decimal) {
+	quote, base := e.balance(o.inst.Quote), e.balance(o.inst.Base)
+	cost := price.Mul(qty)
+
+	switch {
+	case o.lock.IsZero():
+		quote.Free = quote.Free.Sub(cost)
+	case o.Type == Market:
+		o.lock = o.lock.Sub(cost)
+		quote.Locked = quote.Locked.Sub(cost)
+	default:
+		// the lock is at the limit price, the price improvement is returned
+		reserved := o.Price.Mul(qty)
+		o.lock = o.lock.Sub(reserved)
+		quote.Locked = quote.Locked.Sub(reserved)
+		quote.Free = quote.Free.Add(reserved.Sub(cost))
+	}
+
+	base.Free = base.Free.Add(qty.Sub(fee))
+}
+
+func (e *Exchange) spotSell(o *order, price, qty, fee decimal.Decimal) {
+	quote, base := e.balance(o.inst.Quote), e.balance(o.inst.Base)
+
+	o.lock = o.lock.Sub(qty)
+	base.Locked = base.Locked.Sub(qty)
+	quote.Free = quote.Free.Add(price.Mul(qty).Sub(fee))
+}
+
+// reducible returns the quantity an order can trade without increasing the position.
+func (e *Exchange) reducible(o *order) decimal.Decimal {
+	p, ok := e.positions[o.Symbol]
+	if !ok {
+		return decimal.Zero
+	}
+	if o.Side == Buy && p.Qty.Sign() < 0 || o.Side == Sell && p.Qty.Sign() > 0 {
+		return p.Qty.Abs()
+	}
+	return decimal.Zero
+}
+
+// trade updates the position of a Linear instrument and settles the realized PnL and the fee.
+func (e *Exchange) trade(o *order, price, qty, fee decimal.Decimal) {
+	p, ok := e.positions[o.Symbol]
+	if !ok {
+		p = &Position{Symbol: o.Symbol}
+		e.positions[o.Symbol] = p
+	}
+	quote := e.balance(o.inst.Quote)
+	quote.Free = quote.Free.Sub(fee)
+
+	delta := qty
+	if o.Side == Sell {
+		delta = qty.Neg()
+	}
+
+	if p.Qty.IsZero() || p.Qty.Sign() == delta.Sign() {
+		// increase, the entry price is the average of the position and the fill
+		size := p.Qty.Abs()
+		p.EntryPrice = p.EntryPrice.Mul(size).Add(price.Mul(qty)).Div(size.Add(qty), pricePlaces)
+		p.Qty = p.Qty.Add(delta)
+		return
+	}
+
+	closed := decimal.Min(qty, p.Qty.Abs())
+	pnl := price.Sub(p.EntryPrice).Mul(closed).Mul(o.inst.ContractSize)
+	if p.Qty.Sign() < 0 {
+		pnl = pnl.Neg()
+	}
+	p.RealizedPnL = p.RealizedPnL.Add(pnl)
+	quote.Free = quote.Free.Add(pnl)
+
+	p.Qty = p.Qty.Add(delta)
+	switch {
+	case p.Qty.IsZero():
+		p.EntryPrice = decimal.Zero
+	case qty.GreaterThan(closed):
+		// the position flipped, the rest opened at the fill price
+		p.EntryPrice = price
+	}
+}
+
+// mark updates the unrealized PnL of the position of symbol.
+func (e *Exchange) mark(symbol string) {
+	p, ok := e.positions[symbol]
+	if !ok {
+		return
+	}
+
+	mark, ok := e.markets[symbol].Mark()
+	if !ok {
+		return
+	}
+
+	p.MarkPrice = mark
+	p.UnrealizedPnL = mark.Sub(p.EntryPrice).Mul(p.Qty).Mul(e.instruments[symbol].ContractSize)
+}
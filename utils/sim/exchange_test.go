@@ -0,0 +1,350 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sim
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rluisr/nexapi/utils/book"
+	"github.com/rluisr/nexapi/utils/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var testStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func dec(s string) decimal.Decimal {
+	return decimal.MustFromString(s)
+}
+
+func testBook(t *testing.T, bids, asks [][]string) *book.Book {
+	b, err := book.FromStrings(bids, asks)
+	assert.Nil(t, err)
+	return b
+}
+
+func testExchange(t *testing.T, latency time.Duration) *Exchange {
+	e := NewExchange(Config{
+		Instruments: []Instrument{
+			{Symbol: "BTCUSDT", Kind: Spot, Base: "BTC", Quote: "USDT"},
+			{Symbol: "BTC-USDT-SWAP", Kind: Linear, Base: "BTC", Quote: "USDT", ContractSize: dec("0.01")},
+		},
+		Balances: map[string]decimal.Decimal{"USDT": dec("10000"), "BTC": dec("1")},
+		Fees:     Fees{Maker: dec("0.001"), Taker: dec("0.002")},
+		Latency:  latency,
+	})
+
+	b := testBook(t, [][]string{{"100", "1"}, {"99", "2"}}, [][]string{{"101", "1"}, {"102", "2"}})
+	assert.Nil(t, e.UpdateBook("BTCUSDT", b, testStart))
+	assert.Nil(t, e.UpdateBook("BTC-USDT-SWAP", b, testStart))
+
+	return e
+}
+
+func TestSpotLimitOrder(t *testing.T) {
+	e := testExchange(t, 0)
+
+	o, err := e.PlaceOrder(OrderRequest{Symbol: "BTCUSDT", ClientID: "c1", Side: Buy, Type: Limit, Price: dec("100.5"), Qty: dec("2")})
+	assert.Nil(t, err)
+	assert.Equal(t, New, o.Status)
+	assert.Equal(t, "201.0", e.Balance("USDT").Locked.String())
+
+	// the asks move down through the order price
+	b := testBook(t, [][]string{{"99", "1"}}, [][]string{{"100", "1.5"}})
+	assert.Nil(t, e.UpdateBook("BTCUSDT", b, testStart.Add(time.Second)))
+
+	o, err = e.Order("", "c1")
+	assert.Nil(t, err)
+	assert.Equal(t, PartiallyFilled, o.Status)
+	assert.Equal(t, "1.5", o.Filled.String())
+
+	// the maker fee is taken from the bought BTC
+	assert.True(t, e.Balance("BTC").Free.Equal(dec("2.4985")))
+	assert.True(t, e.Balance("USDT").Locked.Equal(dec("50.25")))
+
+	o, err = e.CancelOrder(o.ID, "")
+	assert.Nil(t, err)
+	assert.Equal(t, Canceled, o.Status)
+	assert.True(t, e.Balance("USDT").Locked.IsZero())
+	assert.True(t, e.Balance("USDT").Free.Equal(dec("9849.25")))
+
+	_, err = e.CancelOrder(o.ID, "")
+	assert.ErrorIs(t, err, ErrOrderNotOpen)
+}
+
+func TestSpotMarketOrders(t *testing.T) {
+	e := testExchange(t, 0)
+
+	var fills []Fill
+	e.cfg.OnFill = func(f Fill) { fills = append(fills, f) }
+
+	o, err := e.PlaceOrder(OrderRequest{Symbol: "BTCUSDT", Side: Buy, Type: Market, Qty: dec("2")})
+	assert.Nil(t, err)
+	assert.Equal(t, Filled, o.Status)
+	assert.True(t, o.AvgPrice(2).Equal(dec("101.5")))
+	assert.Len(t, fills, 2)
+	assert.False(t, fills[0].Maker)
+	assert.True(t, e.Balance("USDT").Free.Equal(dec("9797")))
+
+	o, err = e.PlaceOrder(OrderRequest{Symbol: "BTCUSDT", Side: Buy, Type: Market, QuoteQty: dec("50.5")})
+	assert.Nil(t, err)
+	assert.Equal(t, Filled, o.Status)
+	assert.True(t, o.Filled.Equal(dec("0.5")))
+
+	// only 3 BTC on the bids, the rest is canceled
+	o, err = e.PlaceOrder(OrderRequest{Symbol: "BTCUSDT", Side: Sell, Type: Market, Qty: dec("3.2")})
+	assert.Nil(t, err)
+	assert.Equal(t, Canceled, o.Status)
+	assert.Equal(t, "3", o.Filled.String())
+
+	_, err = e.PlaceOrder(OrderRequest{Symbol: "BTCUSDT", Side: Sell, Type: Limit, Price: dec("200"), Qty: dec("10")})
+	assert.ErrorIs(t, err, ErrInsufficientBalance)
+
+	_, err = e.PlaceOrder(OrderRequest{Symbol: "ETHUSDT", Side: Sell, Type: Market, Qty: dec("1")})
+	assert.ErrorIs(t, err, ErrUnknownSymbol)
+}
+
+func TestTimeInForce(t *testing.T) {
+	e := testExchange(t, 0)
+
+	o, err := e.PlaceOrder(OrderRequest{Symbol: "BTCUSDT", Side: Buy, Type: PostOnly, Price: dec("101"), Qty: dec("1")})
+	assert.Nil(t, err)
+	assert.Equal(t, Rejected, o.Status)
+	assert.Equal(t, ReasonPostOnly, o.RejectReason)
+	assert.True(t, e.Balance("USDT").Locked.IsZero())
+
+	o, err = e.PlaceOrder(OrderRequest{Symbol: "BTCUSDT", Side: Buy, Type: FOK, Price: dec("101"), Qty: dec("2")})
+	assert.Nil(t, err)
+	assert.Equal(t, Canceled, o.Status)
+	assert.True(t, o.Filled.IsZero())
+
+	o, err = e.PlaceOrder(OrderRequest{Symbol: "BTCUSDT", Side: Buy, Type: IOC, Price: dec("101"), Qty: dec("2")})
+	assert.Nil(t, err)
+	assert.Equal(t, Canceled, o.Status)
+	assert.Equal(t, "1", o.Filled.String())
+
+	assert.Empty(t, e.OpenOrders(""))
+	assert.Len(t, e.Orders("BTCUSDT"), 3)
+}
+
+func TestLatency(t *testing.T) {
+	e := testExchange(t, 100*time.Millisecond)
+
+	o, err := e.PlaceOrder(OrderRequest{Symbol: "BTCUSDT", Side: Buy, Type: Market, Qty: dec("1")})
+	assert.Nil(t, err)
+	assert.Equal(t, New, o.Status)
+
+	// the book moves before the order arrives
+	b := testBook(t, [][]string{{"104", "1"}}, [][]string{{"105", "1"}})
+	assert.Nil(t, e.UpdateBook("BTCUSDT", b, testStart.Add(50*time.Millisecond)))
+	o, _ = e.Order(o.ID, "")
+	assert.Equal(t, New, o.Status)
+
+	assert.Nil(t, e.UpdateBook("BTCUSDT", b, testStart.Add(100*time.Millisecond)))
+	o, _ = e.Order(o.ID, "")
+	assert.Equal(t, Filled, o.Status)
+	assert.Equal(t, "105", o.AvgPrice(0).String())
+}
+
+func TestMultipleSymbols(t *testing.T) {
+	e := NewExchange(Config{
+		Instruments: []Instrument{
+			{Symbol: "BTCUSDT", Kind: Spot, Base: "BTC", Quote: "USDT"},
+			{Symbol: "ETHUSDT", Kind: Spot, Base: "ETH", Quote: "USDT"},
+		},
+		Balances: map[string]decimal.Decimal{"USDT": dec("10000")},
+	})
+
+	// no market data of ETHUSDT yet, the order waits for it
+	o, err := e.PlaceOrder(OrderRequest{Symbol: "ETHUSDT", Side: Buy, Type: Limit, Price: dec("10"), Qty: dec("1")})
+	assert.Nil(t, err)
+
+	b := testBook(t, [][]string{{"100", "1"}}, [][]string{{"101", "1"}})
+	assert.Nil(t, e.UpdateBook("BTCUSDT", b, testStart))
+	o, _ = e.Order(o.ID, "")
+	assert.Equal(t, New, o.Status)
+	assert.True(t, o.Filled.IsZero())
+
+	b = testBook(t, [][]string{{"9", "1"}}, [][]string{{"9.5", "2"}})
+	assert.Nil(t, e.UpdateBook("ETHUSDT", b, testStart.Add(time.Second)))
+	o, _ = e.Order(o.ID, "")
+	assert.Equal(t, Filled, o.Status)
+	assert.Equal(t, "9.5", o.AvgPrice(1).String())
+}
+
+func TestTradeThrough(t *testing.T) {
+	e := testExchange(t, 0)
+
+	o, err := e.PlaceOrder(OrderRequest{Symbol: "BTCUSDT", Side: Sell, Type: Limit, Price: dec("101"), Qty: dec("1")})
+	assert.Nil(t, err)
+
+	assert.Nil(t, e.UpdateTrade("BTCUSDT", Trade{Price: dec("101"), Qty: dec("5"), Side: Buy, Time: testStart.Add(time.Second)}))
+	o, _ = e.Order(o.ID, "")
+	assert.Equal(t, New, o.Status)
+
+	assert.Nil(t, e.UpdateTrade("BTCUSDT", Trade{Price: dec("101.5"), Qty: dec("0.4"), Side: Buy, Time: testStart.Add(2 * time.Second)}))
+	o, _ = e.Order(o.ID, "")
+	assert.Equal(t, "0.4", o.Filled.String())
+	assert.Equal(t, "0.4", o.Fee.Div(dec("0.101"), 1).String())
+}
+
+func TestSharedLiquidity(t *testing.T) {
+	e := testExchange(t, 0)
+
+	var ids []string
+	for i := 0; i < 2; i++ {
+		o, err := e.PlaceOrder(OrderRequest{Symbol: "BTCUSDT", Side: Buy, Type: Limit, Price: dec("100.5"), Qty: dec("1")})
+		assert.Nil(t, err)
+		ids = append(ids, o.ID)
+	}
+	filled := func() []string {
+		var ret []string
+		for _, id := range ids {
+			o, _ := e.Order(id, "")
+			ret = append(ret, o.Filled.String())
+		}
+		return ret
+	}
+
+	// the orders share the crossing level in arrival order
+	b := testBook(t, [][]string{{"99", "1"}}, [][]string{{"100", "1.5"}})
+	assert.Nil(t, e.UpdateBook("BTCUSDT", b, testStart.Add(time.Second)))
+	assert.Equal(t, []string{"1", "0.5"}, filled())
+
+	// the same level does not fill them again
+	b = testBook(t, [][]string{{"99", "2"}}, [][]string{{"100.0", "1.5"}})
+	assert.Nil(t, e.UpdateBook("BTCUSDT", b, testStart.Add(2*time.Second)))
+	assert.Equal(t, []string{"1", "0.5"}, filled())
+
+	// a new size of the level is new liquidity
+	b = testBook(t, [][]string{{"99", "2"}}, [][]string{{"100", "2"}})
+	assert.Nil(t, e.UpdateBook("BTCUSDT", b, testStart.Add(3*time.Second)))
+	assert.Equal(t, []string{"1", "1.0"}, filled())
+
+	// a trade is shared by the resting orders too
+	ids = ids[:0]
+	for i := 0; i < 2; i++ {
+		o, err := e.PlaceOrder(OrderRequest{Symbol: "BTCUSDT", Side: Sell, Type: Limit, Price: dec("101"), Qty: dec("1")})
+		assert.Nil(t, err)
+		ids = append(ids, o.ID)
+	}
+	assert.Nil(t, e.UpdateTrade("BTCUSDT", Trade{Price: dec("101.5"), Qty: dec("0.4"), Side: Buy, Time: testStart.Add(4 * time.Second)}))
+	assert.Equal(t, []string{"0.4", "0"}, filled())
+}
+
+func TestLinearPosition(t *testing.T) {
+	e := testExchange(t, 0)
+
+	_, err := e.PlaceOrder(OrderRequest{Symbol: "BTC-USDT-SWAP", Side: Sell, Type: Market, Qty: dec("1"), ReduceOnly: true})
+	assert.Nil(t, err)
+	assert.True(t, e.Position("BTC-USDT-SWAP").Qty.IsZero())
+
+	// long 2 contracts at 101.5 average
+	_, err = e.PlaceOrder(OrderRequest{Symbol: "BTC-USDT-SWAP", Side: Buy, Type: Market, Qty: dec("2")})
+	assert.Nil(t, err)
+	p := e.Position("BTC-USDT-SWAP")
+	assert.True(t, p.Qty.Equal(dec("2")))
+	assert.True(t, p.EntryPrice.Equal(dec("101.5")))
+
+	b := testBook(t, [][]string{{"110", "5"}}, [][]string{{"111", "5"}})
+	assert.Nil(t, e.UpdateBook("BTC-USDT-SWAP", b, testStart.Add(time.Second)))
+	p = e.Position("BTC-USDT-SWAP")
+	assert.True(t, p.UnrealizedPnL.Equal(dec("0.18")))
+
+	// sell 3: close 2 with a profit of 8.5 * 2 * 0.01 and open a short of 1
+	_, err = e.PlaceOrder(OrderRequest{Symbol: "BTC-USDT-SWAP", Side: Sell, Type: Market, Qty: dec("3")})
+	assert.Nil(t, err)
+	p = e.Position("BTC-USDT-SWAP")
+	assert.True(t, p.Qty.Equal(dec("-1")))
+	assert.True(t, p.EntryPrice.Equal(dec("110")))
+	assert.True(t, p.RealizedPnL.Equal(dec("0.17")))
+	assert.Len(t, e.Positions(), 1)
+
+	// fees: 2.03 * 0.002 + 3.3 * 0.002
+	assert.True(t, e.Balance("USDT").Free.Equal(dec("10000.15934")))
+}
+
+func TestQueueFill(t *testing.T) {
+	queue := NewQueueFill()
+	e := NewExchange(Config{
+		Instruments: []Instrument{{Symbol: "BTCUSDT", Kind: Spot, Base: "BTC", Quote: "USDT"}},
+		Balances:    map[string]decimal.Decimal{"USDT": dec("10000")},
+		FillModel:   queue,
+	})
+	b := testBook(t, [][]string{{"100", "1"}, {"99", "2"}}, [][]string{{"101", "1"}, {"102", "2"}})
+	assert.Nil(t, e.UpdateBook("BTCUSDT", b, testStart))
+
+	o, err := e.PlaceOrder(OrderRequest{Symbol: "BTCUSDT", Side: Buy, Type: Limit, Price: dec("100"), Qty: dec("1")})
+	assert.Nil(t, err)
+	ahead, ok := queue.Ahead(o.ID)
+	assert.True(t, ok)
+	assert.Equal(t, "1", ahead.String())
+
+	trade := func(sec int, price, qty string, side Side) *Order {
+		assert.Nil(t, e.UpdateTrade("BTCUSDT", Trade{Price: dec(price), Qty: dec(qty), Side: side, Time: testStart.Add(time.Duration(sec) * time.Second)}))
+		o, err := e.Order(o.ID, "")
+		assert.Nil(t, err)
+		return o
+	}
+
+	// the orders ahead are filled first
+	o = trade(1, "100", "0.4", Sell)
+	assert.True(t, o.Filled.IsZero())
+	ahead, _ = queue.Ahead(o.ID)
+	assert.Equal(t, "0.6", ahead.String())
+
+	// cancels shrink the queue ahead down to the level size
+	b = testBook(t, [][]string{{"100", "0.5"}, {"99", "2"}}, [][]string{{"101", "1"}})
+	assert.Nil(t, e.UpdateBook("BTCUSDT", b, testStart.Add(2*time.Second)))
+	ahead, _ = queue.Ahead(o.ID)
+	assert.Equal(t, "0.5", ahead.String())
+
+	o = trade(3, "100", "0.8", Sell)
+	assert.Equal(t, "0.3", o.Filled.String())
+
+	// a buyer does not trade with the bids
+	o = trade(4, "100", "0.2", Buy)
+	assert.Equal(t, "0.3", o.Filled.String())
+
+	o = trade(5, "99.5", "5", Sell)
+	assert.Equal(t, Filled, o.Status)
+	assert.True(t, o.Filled.Equal(dec("1")))
+
+	_, ok = queue.Ahead(o.ID)
+	assert.False(t, ok)
+}
+
+func TestQueueFillZeroValue(t *testing.T) {
+	queue := &QueueFill{}
+	e := NewExchange(Config{
+		Instruments: []Instrument{{Symbol: "BTCUSDT", Kind: Spot, Base: "BTC", Quote: "USDT"}},
+		Balances:    map[string]decimal.Decimal{"USDT": dec("10000")},
+		FillModel:   queue,
+	})
+	b := testBook(t, [][]string{{"100", "1"}}, [][]string{{"101", "1"}})
+	assert.Nil(t, e.UpdateBook("BTCUSDT", b, testStart))
+
+	_, ok := queue.Ahead("1")
+	assert.False(t, ok)
+
+	o, err := e.PlaceOrder(OrderRequest{Symbol: "BTCUSDT", Side: Buy, Type: Limit, Price: dec("100"), Qty: dec("1")})
+	assert.Nil(t, err)
+	ahead, ok := queue.Ahead(o.ID)
+	assert.True(t, ok)
+	assert.Equal(t, "1", ahead.String())
+}
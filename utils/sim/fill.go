@@ -0,0 +1,214 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sim
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/rluisr/nexapi/utils/book"
+	"github.com/rluisr/nexapi/utils/decimal"
+)
+
+// A Match is a part of an order filled at one price.
+type Match struct {
+	Price decimal.Decimal
+	Qty   decimal.Decimal
+}
+
+// A FillModel decides how an open order is filled by the market. Match is called
+// with taker true once when the order reaches the exchange, then with taker false on
+// every market update while the order rests. The exchange caps the matches to the
+// remaining quantity and the balances.
+type FillModel interface {
+	Match(o *Order, m *MarketState, taker bool) []Match
+}
+
+// An orderCloser is a FillModel keeping a state per resting order, it is told when
+// the orders leave the resting orders.
+type orderCloser interface {
+	closed(orderID string)
+}
+
+// BookFill fills the arriving orders against the levels of the book, and the resting
+// orders at their price when the book or a trade goes through it. The liquidity taken
+// by arriving orders is not removed from the book until the next book update.
+//
+// The resting orders share the crossing liquidity in arrival order: a level of the
+// book fills them again only once its size changes, and a trade fills them up to its
+// quantity.
+type BookFill struct {
+	// AtTouch also fills resting orders on trades at their price, which is optimistic
+	// as the orders ahead in the queue are filled first
+	AtTouch bool
+}
+
+func (f BookFill) Match(o *Order, m *MarketState, taker bool) []Match {
+	if taker {
+		return f.take(o, m)
+	}
+
+	if m.Trade != nil {
+		t := m.Trade
+		through := o.Side == Buy && t.Price.LessThan(o.Price) || o.Side == Sell && t.Price.GreaterThan(o.Price)
+		if through || f.AtTouch && t.Price.Equal(o.Price) {
+			return matchAt(o.Price, m.takeTrade(o.Remaining()))
+		}
+		return nil
+	}
+
+	if m.Book == nil {
+		return nil
+	}
+
+	// the book crossed the order, the crossing liquidity left fills it at its price
+	side := book.Ask
+	if o.Side == Sell {
+		side = book.Bid
+	}
+
+	qty := decimal.Zero
+	remaining := o.Remaining()
+	for _, l := range m.Book.Levels(side) {
+		if remaining.Sign() <= 0 || side == book.Ask && l.Price.GreaterThan(o.Price) || side == book.Bid && l.Price.LessThan(o.Price) {
+			break
+		}
+		taken := m.takeLevel(side, l, remaining)
+		qty, remaining = qty.Add(taken), remaining.Sub(taken)
+	}
+
+	return matchAt(o.Price, qty)
+}
+
+func matchAt(price, qty decimal.Decimal) []Match {
+	if qty.Sign() <= 0 {
+		return nil
+	}
+	return []Match{{Price: price, Qty: qty}}
+}
+
+type levelUse struct {
+	// size is the size of the level when it was used
+	size decimal.Decimal
+	used decimal.Decimal
+}
+
+// levelKey identifies a level of the book, the venues may send 100 and 100.0 for a price.
+func levelKey(side book.Side, price decimal.Decimal) string {
+	s := price.String()
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return strconv.Itoa(int(side)) + ":" + s
+}
+
+// setBook replaces the book, the liquidity used at the levels which did not change is kept.
+func (m *MarketState) setBook(b *book.Book) {
+	m.Book, m.Trade = b, nil
+	if len(m.used) == 0 {
+		return
+	}
+
+	used := make(map[string]levelUse)
+	for _, side := range []book.Side{book.Bid, book.Ask} {
+		for _, l := range b.Levels(side) {
+			key := levelKey(side, l.Price)
+			if u, ok := m.used[key]; ok && u.size.Equal(l.Size) {
+				used[key] = u
+			}
+		}
+	}
+	m.used = used
+}
+
+// takeLevel returns up to qty of the level not yet filling resting orders and marks it used.
+func (m *MarketState) takeLevel(side book.Side, l book.PriceLevel, qty decimal.Decimal) decimal.Decimal {
+	key := levelKey(side, l.Price)
+	u, ok := m.used[key]
+	if !ok || !u.size.Equal(l.Size) {
+		u = levelUse{size: l.Size}
+	}
+
+	qty = decimal.Min(qty, l.Size.Sub(u.used))
+	if qty.Sign() <= 0 {
+		return decimal.Zero
+	}
+
+	u.used = u.used.Add(qty)
+	if m.used == nil {
+		m.used = make(map[string]levelUse)
+	}
+	m.used[key] = u
+	return qty
+}
+
+// takeTrade returns up to qty of the trade not yet filling resting orders and marks it used.
+func (m *MarketState) takeTrade(qty decimal.Decimal) decimal.Decimal {
+	qty = decimal.Min(qty, m.Trade.Qty.Sub(m.traded))
+	if qty.Sign() <= 0 {
+		return decimal.Zero
+	}
+
+	m.traded = m.traded.Add(qty)
+	return qty
+}
+
+// take walks the opposite side of the book up to the limit price of the order.
+func (f BookFill) take(o *Order, m *MarketState) []Match {
+	if m.Book == nil {
+		return nil
+	}
+
+	side := book.Ask
+	if o.Side == Sell {
+		side = book.Bid
+	}
+
+	var ret []Match
+	remaining := o.Remaining()
+	spend := o.QuoteQty
+	for _, l := range m.Book.Levels(side) {
+		if o.Type != Market && (o.Side == Buy && l.Price.GreaterThan(o.Price) || o.Side == Sell && l.Price.LessThan(o.Price)) {
+			break
+		}
+
+		qty := l.Size
+		if o.Qty.Sign() > 0 {
+			if remaining.Sign() <= 0 {
+				break
+			}
+			qty = decimal.Min(qty, remaining)
+			remaining = remaining.Sub(qty)
+		} else {
+			// market buy of a quote amount
+			if spend.Sign() <= 0 {
+				break
+			}
+			if cost := qty.Mul(l.Price); cost.GreaterThan(spend) {
+				qty = spend.Div(l.Price, qtyPlaces).Truncate(qtyPlaces)
+			}
+			spend = spend.Sub(qty.Mul(l.Price))
+		}
+
+		if qty.Sign() > 0 {
+			ret = append(ret, Match{Price: l.Price, Qty: qty})
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sim
+
+import (
+	"github.com/rluisr/nexapi/utils/book"
+	"github.com/rluisr/nexapi/utils/decimal"
+)
+
+// QueueFill fills the arriving orders like BookFill, then keeps the queue position
+// of the resting orders. An order joins the queue behind the size of the book at
+// its price, the trades at its price fill the orders ahead first. A smaller size of
+// the level shrinks the queue ahead down to it, the cancels are assumed to come from
+// behind the order otherwise. The book or a trade going through the price fills the order.
+//
+// The post only orders, which are not matched on arrival, join the queue on the first
+// update after their arrival. A QueueFill keeps the state of its orders and must not be
+// shared by exchanges, the zero value is ready to use.
+type QueueFill struct {
+	// ahead is the quantity ahead of the resting orders by order ID
+	ahead map[string]decimal.Decimal
+}
+
+func NewQueueFill() *QueueFill {
+	return &QueueFill{ahead: make(map[string]decimal.Decimal)}
+}
+
+// Ahead returns the quantity ahead of a resting order in the queue of its price.
+func (f *QueueFill) Ahead(orderID string) (decimal.Decimal, bool) {
+	ahead, ok := f.ahead[orderID]
+	return ahead, ok
+}
+
+func (f *QueueFill) Match(o *Order, m *MarketState, taker bool) []Match {
+	if f.ahead == nil {
+		f.ahead = make(map[string]decimal.Decimal)
+	}
+
+	if taker {
+		matches := BookFill{}.take(o, m)
+
+		taken := decimal.Zero
+		for _, match := range matches {
+			taken = taken.Add(match.Qty)
+		}
+		if o.Type == Limit && taken.LessThan(o.Remaining()) {
+			f.ahead[o.ID] = f.levelSize(o, m)
+		}
+		return matches
+	}
+
+	ahead, ok := f.ahead[o.ID]
+	if !ok {
+		ahead = f.levelSize(o, m)
+		f.ahead[o.ID] = ahead
+		return nil
+	}
+
+	if m.Trade != nil {
+		t := m.Trade
+		through := o.Side == Buy && t.Price.LessThan(o.Price) || o.Side == Sell && t.Price.GreaterThan(o.Price)
+		if through {
+			f.ahead[o.ID] = decimal.Zero
+			return matchAt(o.Price, m.takeTrade(o.Remaining()))
+		}
+		// a taker on the side of the order does not trade with its queue
+		if !t.Price.Equal(o.Price) || t.Side == o.Side {
+			return nil
+		}
+
+		if !t.Qty.GreaterThan(ahead) {
+			f.ahead[o.ID] = ahead.Sub(t.Qty)
+			return nil
+		}
+		f.ahead[o.ID] = decimal.Zero
+		return matchAt(o.Price, m.takeTrade(decimal.Min(t.Qty.Sub(ahead), o.Remaining())))
+	}
+
+	if m.Book == nil {
+		return nil
+	}
+
+	if matches := (BookFill{}).Match(o, m, false); len(matches) > 0 {
+		f.ahead[o.ID] = decimal.Zero
+		return matches
+	}
+
+	f.ahead[o.ID] = decimal.Min(ahead, f.levelSize(o, m))
+	return nil
+}
+
+func (f *QueueFill) closed(orderID string) {
+	delete(f.ahead, orderID)
+}
+
+// levelSize returns the size of the book at the price of the order, on its side.
+func (f *QueueFill) levelSize(o *Order, m *MarketState) decimal.Decimal {
+	if m.Book == nil {
+		return decimal.Zero
+	}
+
+	side := book.Bid
+	if o.Side == Sell {
+		side = book.Ask
+	}
+
+	for _, l := range m.Book.Levels(side) {
+		if l.Price.Equal(o.Price) {
+			return l.Size
+		}
+	}
+	return decimal.Zero
+}
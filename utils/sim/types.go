@@ -0,0 +1,204 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sim
+
+import (
+	"time"
+
+	"github.com/rluisr/nexapi/utils/book"
+	"github.com/rluisr/nexapi/utils/decimal"
+)
+
+type Side string
+
+const (
+	Buy  Side = "buy"
+	Sell Side = "sell"
+)
+
+type OrderType string
+
+const (
+	Limit    OrderType = "limit"
+	Market   OrderType = "market"
+	PostOnly OrderType = "post_only"
+	// IOC orders cancel the part which is not filled on arrival
+	IOC OrderType = "ioc"
+	// FOK orders are canceled unless fully filled on arrival
+	FOK OrderType = "fok"
+)
+
+type Status string
+
+const (
+	New             Status = "new"
+	PartiallyFilled Status = "partially_filled"
+	Filled          Status = "filled"
+	Canceled        Status = "canceled"
+	Rejected        Status = "rejected"
+)
+
+// ReasonPostOnly is the RejectReason of a post only order which would take liquidity.
+const ReasonPostOnly = "post only order would take liquidity"
+
+type Kind int
+
+const (
+	// Spot instruments move the base and quote balances
+	Spot Kind = iota
+	// Linear instruments are perpetuals or futures settled in the quote asset,
+	// the margin requirements and the funding are not simulated
+	Linear
+)
+
+type Instrument struct {
+	Symbol string
+	Kind   Kind
+	Base   string
+	Quote  string
+	// ContractSize is the base quantity of one contract of a Linear instrument, the default is 1
+	ContractSize decimal.Decimal
+}
+
+type OrderRequest struct {
+	Symbol   string
+	ClientID string
+	Side     Side
+	Type     OrderType
+	Price    decimal.Decimal
+	// Qty is in base asset for Spot and in contracts for Linear instruments
+	Qty decimal.Decimal
+	// QuoteQty is the amount to spend by a Spot market buy, instead of Qty
+	QuoteQty   decimal.Decimal
+	ReduceOnly bool
+}
+
+type Order struct {
+	ID         string
+	ClientID   string
+	Symbol     string
+	Side       Side
+	Type       OrderType
+	Price      decimal.Decimal
+	Qty        decimal.Decimal
+	QuoteQty   decimal.Decimal
+	ReduceOnly bool
+
+	Filled decimal.Decimal
+	// FilledQuote is the sum of price * quantity of the fills
+	FilledQuote decimal.Decimal
+	Fee         decimal.Decimal
+	FeeAsset    string
+
+	Status       Status
+	RejectReason string
+	CreateTime   time.Time
+	UpdateTime   time.Time
+}
+
+// IsOpen reports whether the order may still be filled.
+func (o *Order) IsOpen() bool {
+	return o.Status == New || o.Status == PartiallyFilled
+}
+
+// Remaining returns the quantity left to fill.
+func (o *Order) Remaining() decimal.Decimal {
+	return o.Qty.Sub(o.Filled)
+}
+
+// AvgPrice returns the average fill price rounded to places digits, zero without fill.
+func (o *Order) AvgPrice(places int32) decimal.Decimal {
+	if o.Filled.IsZero() {
+		return decimal.Zero
+	}
+	return o.FilledQuote.Div(o.Filled, places)
+}
+
+type Fill struct {
+	OrderID  string
+	ClientID string
+	Symbol   string
+	Side     Side
+	Price    decimal.Decimal
+	Qty      decimal.Decimal
+	Fee      decimal.Decimal
+	FeeAsset string
+	Maker    bool
+	Time     time.Time
+}
+
+type Balance struct {
+	Asset  string
+	Free   decimal.Decimal
+	Locked decimal.Decimal
+}
+
+func (b *Balance) Total() decimal.Decimal {
+	return b.Free.Add(b.Locked)
+}
+
+// A Position of a Linear instrument, Qty is positive when long and negative when short.
+type Position struct {
+	Symbol        string
+	Qty           decimal.Decimal
+	EntryPrice    decimal.Decimal
+	MarkPrice     decimal.Decimal
+	RealizedPnL   decimal.Decimal
+	UnrealizedPnL decimal.Decimal
+}
+
+// Fees are rates of the traded value, a negative maker fee is a rebate.
+type Fees struct {
+	Maker decimal.Decimal
+	Taker decimal.Decimal
+}
+
+// A Trade printed by the venue, Side is the taker side.
+type Trade struct {
+	Price decimal.Decimal
+	Qty   decimal.Decimal
+	Side  Side
+	Time  time.Time
+}
+
+// A MarketState is the latest market data of an instrument.
+type MarketState struct {
+	Symbol string
+	Book   *book.Book
+	// Trade is set when the update is a trade, nil for a book update
+	Trade *Trade
+	Time  time.Time
+
+	// used is the liquidity of the book levels already filling resting orders
+	used map[string]levelUse
+	// traded is the quantity of Trade already filling resting orders
+	traded decimal.Decimal
+}
+
+// Mark returns the mid price of the book, or the last trade price when a side of the book is empty.
+func (m *MarketState) Mark() (decimal.Decimal, bool) {
+	if m.Book != nil {
+		if mid, ok := m.Book.Mid(); ok {
+			return mid, true
+		}
+	}
+	if m.Trade != nil {
+		return m.Trade.Price, true
+	}
+	return decimal.Zero, false
+}
@@ -0,0 +1,515 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tickdata
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/rluisr/nexapi/utils/book"
+	"github.com/rluisr/nexapi/utils/decimal"
+)
+
+type Format string
+
+const (
+	// JSONL writes one JSON object per line
+	JSONL Format = "jsonl"
+	// Binary writes length prefixed records with varint encoded numbers and
+	// delta encoded sequences and times
+	Binary Format = "bin"
+)
+
+// binaryMagic starts every binary file, it is followed by the format version.
+var binaryMagic = []byte("NXTK")
+
+const binaryVersion = 1
+
+const (
+	flagExchangeTime = 1 << iota
+	flagSnapshot
+)
+
+var ErrCorrupted = errors.New("tickdata: corrupted record")
+
+// An encoder writes the ticks of one file. Blocks are decoded independently,
+// so reset drops every state carried from one tick to the next.
+type encoder interface {
+	header(w io.Writer) error
+	reset(w io.Writer) error
+	encode(w io.Writer, t *Tick) error
+}
+
+type decoder interface {
+	// header is only read at the start of a file, not when starting at a block
+	header(r *bufio.Reader) error
+	// decode returns io.EOF at the end of the stream
+	decode(r *bufio.Reader) (*Tick, error)
+}
+
+func newEncoder(f Format) (encoder, error) {
+	switch f {
+	case JSONL:
+		return jsonEncoder{}, nil
+	case Binary:
+		return &binaryEncoder{}, nil
+	}
+	return nil, fmt.Errorf("tickdata: unknown format %q", f)
+}
+
+func newDecoder(f Format) (decoder, error) {
+	switch f {
+	case JSONL:
+		return jsonDecoder{}, nil
+	case Binary:
+		return &binaryDecoder{}, nil
+	}
+	return nil, fmt.Errorf("tickdata: unknown format %q", f)
+}
+
+type jsonTick struct {
+	Seq    uint64 `json:"seq"`
+	Kind   Kind   `json:"kind"`
+	Venue  string `json:"venue"`
+	Symbol string `json:"symbol,omitempty"`
+	// times in unix nanoseconds
+	Recv     int64                `json:"recv"`
+	Exch     int64                `json:"exch,omitempty"`
+	Price    *decimal.Decimal     `json:"price,omitempty"`
+	Qty      *decimal.Decimal     `json:"qty,omitempty"`
+	Side     Side                 `json:"side,omitempty"`
+	Bid      *decimal.Decimal     `json:"bid,omitempty"`
+	BidSize  *decimal.Decimal     `json:"bidSize,omitempty"`
+	Ask      *decimal.Decimal     `json:"ask,omitempty"`
+	AskSize  *decimal.Decimal     `json:"askSize,omitempty"`
+	Bids     [][2]decimal.Decimal `json:"bids,omitempty"`
+	Asks     [][2]decimal.Decimal `json:"asks,omitempty"`
+	Snapshot bool                 `json:"snapshot,omitempty"`
+	Version  int64                `json:"version,omitempty"`
+}
+
+func optional(d decimal.Decimal) *decimal.Decimal {
+	if d.IsZero() {
+		return nil
+	}
+	return &d
+}
+
+func value(d *decimal.Decimal) decimal.Decimal {
+	if d == nil {
+		return decimal.Zero
+	}
+	return *d
+}
+
+func jsonLevels(levels []book.PriceLevel) [][2]decimal.Decimal {
+	var res [][2]decimal.Decimal
+	for _, l := range levels {
+		res = append(res, [2]decimal.Decimal{l.Price, l.Size})
+	}
+	return res
+}
+
+// bookLevels decodes the zero prices and sizes as decimal.Zero, like the binary format
+func bookLevels(levels [][2]decimal.Decimal) []book.PriceLevel {
+	var res []book.PriceLevel
+	for _, l := range levels {
+		res = append(res, book.PriceLevel{Price: value(optional(l[0])), Size: value(optional(l[1]))})
+	}
+	return res
+}
+
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func fromUnixNano(ns int64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
+
+type jsonEncoder struct{}
+
+func (jsonEncoder) header(io.Writer) error { return nil }
+
+func (jsonEncoder) reset(io.Writer) error { return nil }
+
+func (jsonEncoder) encode(w io.Writer, t *Tick) error {
+	data, err := json.Marshal(&jsonTick{
+		Seq:      t.Seq,
+		Kind:     t.Kind,
+		Venue:    t.Venue,
+		Symbol:   t.Symbol,
+		Recv:     unixNano(t.ReceiveTime),
+		Exch:     unixNano(t.ExchangeTime),
+		Price:    optional(t.Price),
+		Qty:      optional(t.Qty),
+		Side:     t.Side,
+		Bid:      optional(t.Bid),
+		BidSize:  optional(t.BidSize),
+		Ask:      optional(t.Ask),
+		AskSize:  optional(t.AskSize),
+		Bids:     jsonLevels(t.Bids),
+		Asks:     jsonLevels(t.Asks),
+		Snapshot: t.Snapshot,
+		Version:  t.Version,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+type jsonDecoder struct{}
+
+func (jsonDecoder) header(*bufio.Reader) error { return nil }
+
+func (jsonDecoder) decode(r *bufio.Reader) (*Tick, error) {
+	line, err := r.ReadBytes('\n')
+	if err == io.EOF && len(line) > 0 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var v jsonTick
+	if err := json.Unmarshal(line, &v); err != nil {
+		return nil, err
+	}
+
+	t := &Tick{
+		Seq:          v.Seq,
+		Kind:         v.Kind,
+		Venue:        v.Venue,
+		Symbol:       v.Symbol,
+		ReceiveTime:  fromUnixNano(v.Recv),
+		ExchangeTime: fromUnixNano(v.Exch),
+		Price:        value(v.Price),
+		Qty:          value(v.Qty),
+		Bid:          value(v.Bid),
+		BidSize:      value(v.BidSize),
+		Ask:          value(v.Ask),
+		AskSize:      value(v.AskSize),
+		Bids:         bookLevels(v.Bids),
+		Asks:         bookLevels(v.Asks),
+		Side:         v.Side,
+		Snapshot:     v.Snapshot,
+		Version:      v.Version,
+	}
+
+	return t, nil
+}
+
+// binaryEncoder writes a record as its uvarint length followed by
+//
+//	kind, flags, seq delta, receive time delta, [exchange - receive time],
+//	venue, symbol, then the fields of the kind
+//
+// Strings are interned: a known string is written as its id, a new one as the
+// next id followed by the string. A zero length record resets the ids and the
+// deltas, it starts every block.
+type binaryEncoder struct {
+	buf     []byte
+	strings map[string]uint64
+	seq     uint64
+	recv    int64
+}
+
+func (e *binaryEncoder) header(w io.Writer) error {
+	_, err := w.Write(append(append([]byte{}, binaryMagic...), binaryVersion))
+	return err
+}
+
+func (e *binaryEncoder) reset(w io.Writer) error {
+	e.strings = make(map[string]uint64)
+	e.seq, e.recv = 0, 0
+
+	_, err := w.Write([]byte{0})
+	return err
+}
+
+func (e *binaryEncoder) encode(w io.Writer, t *Tick) error {
+	var flags byte
+	if !t.ExchangeTime.IsZero() {
+		flags |= flagExchangeTime
+	}
+	if t.Snapshot {
+		flags |= flagSnapshot
+	}
+
+	b := append(e.buf[:0], byte(t.Kind), flags)
+	b = binary.AppendUvarint(b, t.Seq-e.seq)
+	recv := unixNano(t.ReceiveTime)
+	b = binary.AppendVarint(b, recv-e.recv)
+	if flags&flagExchangeTime != 0 {
+		b = binary.AppendVarint(b, t.ExchangeTime.UnixNano()-recv)
+	}
+	e.seq, e.recv = t.Seq, recv
+
+	b = e.appendString(b, t.Venue)
+	b = e.appendString(b, t.Symbol)
+
+	switch t.Kind {
+	case Trade:
+		b = appendDecimals(b, t.Price, t.Qty)
+		b = append(b, byte(t.Side))
+	case BookUpdate:
+		b = binary.AppendVarint(b, t.Version)
+		b = appendLevels(b, t.Bids)
+		b = appendLevels(b, t.Asks)
+	case Ticker:
+		b = appendDecimals(b, t.Price, t.Bid, t.BidSize, t.Ask, t.AskSize)
+	}
+
+	e.buf = b
+
+	if _, err := w.Write(binary.AppendUvarint(nil, uint64(len(b)))); err != nil {
+		return err
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+func (e *binaryEncoder) appendString(b []byte, s string) []byte {
+	if id, ok := e.strings[s]; ok {
+		return binary.AppendUvarint(b, id)
+	}
+
+	id := uint64(len(e.strings))
+	e.strings[s] = id
+
+	b = binary.AppendUvarint(b, id)
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+// decimals are written as their string, which keeps them exact and is shorter
+// than a big.Int for the usual prices and quantities
+func appendDecimals(b []byte, values ...decimal.Decimal) []byte {
+	for _, d := range values {
+		var s string
+		if !d.IsZero() {
+			s = d.String()
+		}
+		b = binary.AppendUvarint(b, uint64(len(s)))
+		b = append(b, s...)
+	}
+	return b
+}
+
+func appendLevels(b []byte, levels []book.PriceLevel) []byte {
+	b = binary.AppendUvarint(b, uint64(len(levels)))
+	for _, l := range levels {
+		b = appendDecimals(b, l.Price, l.Size)
+	}
+	return b
+}
+
+type binaryDecoder struct {
+	buf     []byte
+	strings []string
+	seq     uint64
+	recv    int64
+}
+
+func (d *binaryDecoder) header(r *bufio.Reader) error {
+	h := make([]byte, len(binaryMagic)+1)
+	if _, err := io.ReadFull(r, h); err != nil {
+		return err
+	}
+	if string(h[:len(binaryMagic)]) != string(binaryMagic) {
+		return errors.New("tickdata: not a binary tick file")
+	}
+	if h[len(binaryMagic)] != binaryVersion {
+		return fmt.Errorf("tickdata: unsupported binary version %d", h[len(binaryMagic)])
+	}
+	return nil
+}
+
+func (d *binaryDecoder) decode(r *bufio.Reader) (*Tick, error) {
+	for {
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			if err == io.EOF {
+				return nil, err
+			}
+			return nil, io.ErrUnexpectedEOF
+		}
+
+		if n == 0 {
+			d.strings = d.strings[:0]
+			d.seq, d.recv = 0, 0
+			continue
+		}
+
+		if cap(d.buf) < int(n) {
+			d.buf = make([]byte, n)
+		}
+		d.buf = d.buf[:n]
+		if _, err := io.ReadFull(r, d.buf); err != nil {
+			return nil, io.ErrUnexpectedEOF
+		}
+
+		return d.record(&recordReader{b: d.buf})
+	}
+}
+
+func (d *binaryDecoder) record(r *recordReader) (*Tick, error) {
+	t := &Tick{Kind: Kind(r.byte())}
+	flags := r.byte()
+
+	d.seq += r.uvarint()
+	d.recv += r.varint()
+	t.Seq = d.seq
+	t.ReceiveTime = fromUnixNano(d.recv)
+	if flags&flagExchangeTime != 0 {
+		t.ExchangeTime = time.Unix(0, d.recv+r.varint())
+	}
+	t.Snapshot = flags&flagSnapshot != 0
+
+	t.Venue = d.string(r)
+	t.Symbol = d.string(r)
+
+	switch t.Kind {
+	case Trade:
+		t.Price, t.Qty = r.decimal(), r.decimal()
+		t.Side = Side(r.byte())
+	case BookUpdate:
+		t.Version = r.varint()
+		t.Bids = r.levels()
+		t.Asks = r.levels()
+	case Ticker:
+		t.Price = r.decimal()
+		t.Bid, t.BidSize = r.decimal(), r.decimal()
+		t.Ask, t.AskSize = r.decimal(), r.decimal()
+	case Reconnect:
+	default:
+		return nil, fmt.Errorf("tickdata: unknown kind %d", t.Kind)
+	}
+
+	if r.err != nil || len(r.b) != 0 {
+		return nil, ErrCorrupted
+	}
+
+	return t, nil
+}
+
+func (d *binaryDecoder) string(r *recordReader) string {
+	id := r.uvarint()
+	if id < uint64(len(d.strings)) {
+		return d.strings[id]
+	}
+	if id != uint64(len(d.strings)) {
+		r.err = ErrCorrupted
+		return ""
+	}
+
+	s := string(r.bytes(r.uvarint()))
+	if r.err == nil {
+		d.strings = append(d.strings, s)
+	}
+	return s
+}
+
+// recordReader decodes the fields of one record, the first error is kept and
+// the following reads return zero values.
+type recordReader struct {
+	b   []byte
+	err error
+}
+
+func (r *recordReader) byte() byte {
+	if r.err != nil || len(r.b) == 0 {
+		r.err = ErrCorrupted
+		return 0
+	}
+	c := r.b[0]
+	r.b = r.b[1:]
+	return c
+}
+
+func (r *recordReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.b)
+	if r.err != nil || n <= 0 {
+		r.err = ErrCorrupted
+		return 0
+	}
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *recordReader) varint() int64 {
+	v, n := binary.Varint(r.b)
+	if r.err != nil || n <= 0 {
+		r.err = ErrCorrupted
+		return 0
+	}
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *recordReader) bytes(n uint64) []byte {
+	if r.err != nil || n > uint64(len(r.b)) {
+		r.err = ErrCorrupted
+		return nil
+	}
+	v := r.b[:n]
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *recordReader) decimal() decimal.Decimal {
+	s := r.bytes(r.uvarint())
+	if len(s) == 0 {
+		return decimal.Zero
+	}
+
+	v, err := decimal.NewFromString(string(s))
+	if err != nil && r.err == nil {
+		r.err = ErrCorrupted
+	}
+	return v
+}
+
+func (r *recordReader) levels() []book.PriceLevel {
+	n := r.uvarint()
+	// a level takes at least two bytes
+	if n > uint64(len(r.b))/2 {
+		r.err = ErrCorrupted
+		return nil
+	}
+
+	var levels []book.PriceLevel
+	for i := uint64(0); i < n; i++ {
+		levels = append(levels, book.PriceLevel{Price: r.decimal(), Size: r.decimal()})
+	}
+	return levels
+}
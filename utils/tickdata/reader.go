@@ -0,0 +1,230 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tickdata
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// A Reader reads the ticks of one file.
+type Reader struct {
+	file *os.File
+	gz   *gzip.Reader
+	r    *bufio.Reader
+	dec  decoder
+	from time.Time
+}
+
+// Open opens a file written by a Writer, the format is given by the file name.
+// Files still being written can be read up to the last flushed tick.
+func Open(path string) (*Reader, error) {
+	return open(path, 0, time.Time{})
+}
+
+// OpenFrom opens a closed file and starts at the first tick received at or after
+// from, the index is used to start at the block holding from. The following ticks
+// are returned in Seq order, including the ones stamped slightly before from.
+func OpenFrom(path string, from time.Time) (*Reader, error) {
+	idx, err := ReadIndex(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var offset int64
+	for _, b := range idx.Blocks {
+		if b.Start.After(from) {
+			break
+		}
+		offset = b.Offset
+	}
+
+	return open(path, offset, from)
+}
+
+func open(path string, offset int64, from time.Time) (*Reader, error) {
+	name := strings.TrimSuffix(filepath.Base(path), partSuffix)
+	compressed := strings.HasSuffix(name, gzipSuffix)
+	format := Format(strings.TrimPrefix(filepath.Ext(strings.TrimSuffix(name, gzipSuffix)), "."))
+
+	dec, err := newDecoder(format)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	rd := &Reader{file: f, dec: dec, from: from}
+
+	var src io.Reader = f
+	if compressed {
+		rd.gz, err = gzip.NewReader(bufio.NewReader(f))
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		src = rd.gz
+	}
+	rd.r = bufio.NewReader(src)
+
+	if offset == 0 {
+		if err := dec.header(rd.r); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+
+	return rd, nil
+}
+
+// Next returns the next tick in Seq order, or io.EOF at the end of the file.
+func (r *Reader) Next() (*Tick, error) {
+	for {
+		t, err := r.dec.decode(r.r)
+		if err != nil {
+			return nil, err
+		}
+		if t.ReceiveTime.Before(r.from) {
+			continue
+		}
+		r.from = time.Time{}
+		return t, nil
+	}
+}
+
+func (r *Reader) Close() error {
+	if r.gz != nil {
+		r.gz.Close()
+	}
+	return r.file.Close()
+}
+
+// Files lists the closed files of a Writer in the order they were written.
+func Files(dir, prefix string, format Format) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix+"-") {
+			continue
+		}
+		if strings.HasSuffix(name, "."+string(format)) || strings.HasSuffix(name, "."+string(format)+gzipSuffix) {
+			files = append(files, filepath.Join(dir, name))
+		}
+	}
+
+	// the names start with the receive time of the first tick
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// A DirReader reads the closed files of a Writer one after the other.
+type DirReader struct {
+	files []string
+	from  time.Time
+	cur   *Reader
+}
+
+// OpenDir reads the ticks of the files of prefix in Seq order, starting at the
+// first tick received at or after from. The files ending before from are skipped
+// using their index.
+func OpenDir(dir, prefix string, format Format, from time.Time) (*DirReader, error) {
+	files, err := Files(dir, prefix, format)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("tickdata: no %s files of %s in %s", format, prefix, dir)
+	}
+
+	for len(files) > 1 {
+		idx, err := ReadIndex(files[0])
+		if err != nil {
+			return nil, err
+		}
+		if !idx.End.Before(from) {
+			break
+		}
+		files = files[1:]
+	}
+
+	return &DirReader{files: files, from: from}, nil
+}
+
+// Next returns the next tick, or io.EOF after the last file.
+func (d *DirReader) Next() (*Tick, error) {
+	for {
+		if d.cur == nil {
+			if len(d.files) == 0 {
+				return nil, io.EOF
+			}
+
+			var err error
+			if d.from.IsZero() {
+				d.cur, err = Open(d.files[0])
+			} else {
+				d.cur, err = OpenFrom(d.files[0], d.from)
+			}
+			if err != nil {
+				return nil, err
+			}
+			d.files = d.files[1:]
+		}
+
+		t, err := d.cur.Next()
+		if err == nil {
+			// the next files are read from their start
+			d.from = time.Time{}
+		}
+		if !errors.Is(err, io.EOF) {
+			return t, err
+		}
+
+		if err := d.cur.Close(); err != nil {
+			return nil, err
+		}
+		d.cur = nil
+	}
+}
+
+func (d *DirReader) Close() error {
+	if d.cur == nil {
+		return nil
+	}
+	return d.cur.Close()
+}
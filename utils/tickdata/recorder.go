@@ -0,0 +1,221 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tickdata
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const defaultFlushInterval = time.Second
+
+// A Source feeds the ticks of a venue to the recorder until ctx is done. It
+// calls Recorder.Reconnected whenever its connection is restored.
+type Source interface {
+	Run(ctx context.Context, rec *Recorder) error
+}
+
+type RecorderConfig struct {
+	// Writers of the recorded ticks, e.g. one JSONL and one Binary writer
+	Writers []WriterConfig
+	// FlushInterval is the max delay of a tick before it reaches the files
+	// while Run is running, the default is 1 second
+	FlushInterval time.Duration
+}
+
+// Stats counts the ticks of a Recorder.
+type Stats struct {
+	Recorded int
+	// Dropped book updates whose version was already recorded, e.g. replayed after a reconnect
+	Dropped    int
+	Reconnects int
+}
+
+// A Recorder numbers the ticks of its sources in the order they are received
+// and writes them to every writer. It is safe for concurrent use.
+type Recorder struct {
+	flushInterval time.Duration
+
+	mu       sync.Mutex
+	writers  []*Writer
+	seq      uint64
+	versions map[streamKey]int64
+	stats    Stats
+	err      error
+}
+
+func NewRecorder(cfg RecorderConfig) (*Recorder, error) {
+	if len(cfg.Writers) == 0 {
+		return nil, errors.New("tickdata: at least one writer is required")
+	}
+
+	r := &Recorder{
+		flushInterval: cfg.FlushInterval,
+		versions:      make(map[streamKey]int64),
+	}
+	if r.flushInterval <= 0 {
+		r.flushInterval = defaultFlushInterval
+	}
+
+	for _, c := range cfg.Writers {
+		w, err := NewWriter(c)
+		if err != nil {
+			return nil, err
+		}
+		r.writers = append(r.writers, w)
+	}
+
+	return r, nil
+}
+
+// Record writes a copy of t numbered with the next Seq, t is not modified. A
+// zero ReceiveTime is set to now. The ReceiveTime is kept as given, sources
+// stamping their ticks concurrently may record them slightly out of time order,
+// the Seq is the order of the files. Book updates with a version already
+// recorded are dropped.
+//
+// The first write error is returned by every following call.
+func (r *Recorder) Record(t *Tick) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return r.err
+	}
+
+	if t.Kind == BookUpdate && t.Version != 0 {
+		key := streamKey{venue: t.Venue, symbol: t.Symbol, kind: t.Kind}
+		if last, ok := r.versions[key]; ok && !t.Snapshot && t.Version <= last {
+			r.stats.Dropped++
+			return nil
+		}
+		r.versions[key] = t.Version
+	}
+
+	tick := *t
+	if tick.ReceiveTime.IsZero() {
+		tick.ReceiveTime = time.Now()
+	}
+
+	r.seq++
+	tick.Seq = r.seq
+
+	for _, w := range r.writers {
+		if err := w.Write(&tick); err != nil {
+			r.err = err
+			return err
+		}
+	}
+
+	r.stats.Recorded++
+	if t.Kind == Reconnect {
+		r.stats.Reconnects++
+	}
+
+	return nil
+}
+
+// Reconnected records a Reconnect marker for venue.
+func (r *Recorder) Reconnected(venue string) error {
+	return r.Record(&Tick{Kind: Reconnect, Venue: venue})
+}
+
+func (r *Recorder) Stats() Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.stats
+}
+
+func (r *Recorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, w := range r.writers {
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the files of every writer and writes their index.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var err error
+	for _, w := range r.writers {
+		if cerr := w.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// Run runs the sources and flushes the writers periodically until ctx is done
+// or a source fails. It returns the error of the first failing source.
+func (r *Recorder) Run(ctx context.Context, sources ...Source) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make(chan error, len(sources))
+	var wg sync.WaitGroup
+	for _, src := range sources {
+		wg.Add(1)
+		go func(src Source) {
+			defer wg.Done()
+			if err := src.Run(ctx, r); err != nil && ctx.Err() == nil {
+				errs <- err
+				cancel()
+			}
+		}(src)
+	}
+
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	var err error
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-ticker.C:
+			if err = r.Flush(); err != nil {
+				cancel()
+				break loop
+			}
+		}
+	}
+
+	wg.Wait()
+
+	select {
+	case serr := <-errs:
+		return serr
+	default:
+	}
+
+	if err == nil {
+		err = r.Flush()
+	}
+	return err
+}
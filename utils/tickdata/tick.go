@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tickdata records normalized market data ticks to disk and reads them back.
+//
+// A Recorder numbers the trades, book updates and tickers of its sources and
+// writes them to rotating files, either as JSON Lines or in a compact binary
+// format, optionally gzip compressed. Every closed file gets an index listing
+// its streams and the offsets of its blocks, so a reader can start in the
+// middle of a file.
+package tickdata
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rluisr/nexapi/utils/book"
+	"github.com/rluisr/nexapi/utils/decimal"
+)
+
+type Kind uint8
+
+const (
+	Trade Kind = iota + 1
+	// BookUpdate carries the changed levels of a book, or the whole book when Snapshot is set
+	BookUpdate
+	Ticker
+	// Reconnect marks the restored connection of a venue, the books must be
+	// rebuilt from the next snapshot
+	Reconnect
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Trade:
+		return "trade"
+	case BookUpdate:
+		return "book"
+	case Ticker:
+		return "ticker"
+	case Reconnect:
+		return "reconnect"
+	}
+	return "unknown"
+}
+
+func (k Kind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+func (k *Kind) UnmarshalText(text []byte) error {
+	for v := Trade; v <= Reconnect; v++ {
+		if v.String() == string(text) {
+			*k = v
+			return nil
+		}
+	}
+	return fmt.Errorf("tickdata: unknown kind %q", text)
+}
+
+// Side is the taker side of a trade.
+type Side uint8
+
+const (
+	Buy Side = iota + 1
+	Sell
+)
+
+func (s Side) String() string {
+	switch s {
+	case Buy:
+		return "buy"
+	case Sell:
+		return "sell"
+	}
+	return ""
+}
+
+func (s Side) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Side) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "buy":
+		*s = Buy
+	case "sell":
+		*s = Sell
+	case "":
+		*s = 0
+	default:
+		return fmt.Errorf("tickdata: unknown side %q", text)
+	}
+	return nil
+}
+
+// A Tick is one market data event of a venue.
+type Tick struct {
+	// Seq is set by the Recorder, it increases by one for every recorded tick
+	Seq    uint64
+	Kind   Kind
+	Venue  string
+	Symbol string
+	// ReceiveTime is the local time the tick was received, ExchangeTime is the
+	// time reported by the venue and is zero when the venue does not report it
+	ReceiveTime  time.Time
+	ExchangeTime time.Time
+
+	// Price and Qty of a trade, Price is the last price of a ticker
+	Price decimal.Decimal
+	Qty   decimal.Decimal
+	Side  Side
+
+	// Best bid and ask of a ticker
+	Bid     decimal.Decimal
+	BidSize decimal.Decimal
+	Ask     decimal.Decimal
+	AskSize decimal.Decimal
+
+	// Levels of a book update, a zero size removes the level
+	Bids     []book.PriceLevel
+	Asks     []book.PriceLevel
+	Snapshot bool
+	// Version is the update id of the venue, zero when the venue does not report it
+	Version int64
+}
+
+// Time is the exchange time when reported, else the receive time.
+func (t *Tick) Time() time.Time {
+	if t.ExchangeTime.IsZero() {
+		return t.ReceiveTime
+	}
+	return t.ExchangeTime
+}
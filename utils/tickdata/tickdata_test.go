@@ -0,0 +1,315 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tickdata
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/rluisr/nexapi/utils/book"
+	"github.com/rluisr/nexapi/utils/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var testStart = time.Unix(0, time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC).UnixNano())
+
+func dec(s string) decimal.Decimal {
+	return decimal.MustFromString(s)
+}
+
+func testTicks() []*Tick {
+	at := func(ms int) time.Time { return testStart.Add(time.Duration(ms) * time.Millisecond) }
+
+	return []*Tick{
+		{Seq: 1, Kind: Trade, Venue: "mexc", Symbol: "BTC_USDT", ReceiveTime: at(5), ExchangeTime: at(2),
+			Price: dec("42000.5"), Qty: dec("0.01"), Side: Buy},
+		{Seq: 2, Kind: BookUpdate, Venue: "mexc", Symbol: "BTC_USDT", ReceiveTime: at(7), Version: 100, Snapshot: true,
+			Bids: []book.PriceLevel{{Price: dec("42000"), Size: dec("1.5")}},
+			Asks: []book.PriceLevel{{Price: dec("42001"), Size: dec("2")}, {Price: dec("42002"), Size: decimal.Zero}}},
+		{Seq: 3, Kind: Ticker, Venue: "okx", Symbol: "ETH-USDT", ReceiveTime: at(7), ExchangeTime: at(9),
+			Price: dec("2200.1"), Bid: dec("2200"), BidSize: dec("3"), Ask: dec("2200.2"), AskSize: dec("4")},
+		{Seq: 4, Kind: Reconnect, Venue: "mexc", ReceiveTime: at(1000)},
+		{Seq: 5, Kind: Trade, Venue: "mexc", Symbol: "BTC_USDT", ReceiveTime: at(1001), Price: dec("41999"), Qty: dec("1"), Side: Sell},
+	}
+}
+
+func readAll(t *testing.T, r interface {
+	Next() (*Tick, error)
+	Close() error
+}) []*Tick {
+	defer r.Close()
+
+	var ticks []*Tick
+	for {
+		tick, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return ticks
+		}
+		if !assert.Nil(t, err) {
+			return ticks
+		}
+		ticks = append(ticks, tick)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []Format{JSONL, Binary} {
+		for _, compress := range []bool{false, true} {
+			dir := t.TempDir()
+			w, err := NewWriter(WriterConfig{Dir: dir, Prefix: "ticks", Format: format, Compress: compress, BlockSize: 2})
+			assert.Nil(t, err)
+
+			want := testTicks()
+			for _, tick := range want {
+				assert.Nil(t, w.Write(tick))
+			}
+			assert.Nil(t, w.Close())
+
+			files, err := Files(dir, "ticks", format)
+			assert.Nil(t, err)
+			if !assert.Len(t, files, 1) {
+				continue
+			}
+
+			r, err := Open(files[0])
+			assert.Nil(t, err)
+			assert.Equal(t, want, readAll(t, r), "%s compress=%v", format, compress)
+
+			idx, err := ReadIndex(files[0])
+			assert.Nil(t, err)
+			assert.Equal(t, 5, idx.Count)
+			assert.Equal(t, []uint64{1, 5}, []uint64{idx.FirstSeq, idx.LastSeq})
+			assert.Len(t, idx.Blocks, 3)
+			assert.Len(t, idx.Streams, 4)
+			assert.Equal(t, 2, idx.Streams[0].Count)
+			assert.True(t, idx.Streams[0].FirstTime.Equal(want[0].ExchangeTime))
+
+			// starts at the third block, then skips the ticks received before from
+			r, err = OpenFrom(files[0], want[4].ReceiveTime)
+			assert.Nil(t, err)
+			assert.Equal(t, want[4:], readAll(t, r), "%s compress=%v", format, compress)
+		}
+	}
+}
+
+func TestSeqOrder(t *testing.T) {
+	at := func(ms int) time.Time { return testStart.Add(time.Duration(ms) * time.Millisecond) }
+	trade := func(seq uint64, ms int) *Tick {
+		return &Tick{Seq: seq, Kind: Trade, Venue: "mexc", Symbol: "BTC_USDT", ReceiveTime: at(ms), Price: dec("1"), Qty: dec("1")}
+	}
+
+	for _, format := range []Format{JSONL, Binary} {
+		dir := t.TempDir()
+		w, err := NewWriter(WriterConfig{Dir: dir, Prefix: "ticks", Format: format})
+		assert.Nil(t, err)
+
+		// the second and the fourth ticks were stamped before the previous ones
+		want := []*Tick{trade(1, 10), trade(2, 8), trade(3, 12), trade(4, 11)}
+		for _, tick := range want {
+			assert.Nil(t, w.Write(tick))
+		}
+		assert.Nil(t, w.Close())
+
+		r, err := OpenDir(dir, "ticks", format, time.Time{})
+		assert.Nil(t, err)
+		assert.Equal(t, want, readAll(t, r), format)
+
+		// the ticks following the first one received since from are all returned
+		r, err = OpenDir(dir, "ticks", format, at(10))
+		assert.Nil(t, err)
+		assert.Equal(t, want, readAll(t, r), format)
+
+		r, err = OpenDir(dir, "ticks", format, at(11))
+		assert.Nil(t, err)
+		assert.Equal(t, want[2:], readAll(t, r), format)
+	}
+}
+
+func TestBinaryIsCompact(t *testing.T) {
+	sizes := map[Format]int64{}
+	for _, format := range []Format{JSONL, Binary} {
+		dir := t.TempDir()
+		w, _ := NewWriter(WriterConfig{Dir: dir, Prefix: "ticks", Format: format})
+		for i := 0; i < 100; i++ {
+			for _, tick := range testTicks() {
+				assert.Nil(t, w.Write(tick))
+			}
+		}
+		assert.Nil(t, w.Close())
+
+		files, _ := Files(dir, "ticks", format)
+		idx, err := ReadIndex(files[0])
+		assert.Nil(t, err)
+		assert.Equal(t, 500, idx.Count)
+		sizes[format] = w.size.n
+	}
+
+	assert.Less(t, sizes[Binary]*3, sizes[JSONL])
+}
+
+func TestRotation(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWriter(WriterConfig{Dir: dir, Prefix: "ticks", Format: Binary, Compress: true, Interval: time.Hour})
+	assert.Nil(t, err)
+
+	var ticks []*Tick
+	for i := 0; i < 6; i++ {
+		tick := &Tick{Seq: uint64(i + 1), Kind: Trade, Venue: "mexc", Symbol: "BTC_USDT", Price: dec("1"), Qty: dec("1"),
+			ReceiveTime: testStart.Add(time.Duration(i) * 25 * time.Minute)}
+		ticks = append(ticks, tick)
+		assert.Nil(t, w.Write(tick))
+	}
+
+	// the open file is not listed until it is closed
+	files, err := Files(dir, "ticks", Binary)
+	assert.Nil(t, err)
+	assert.Len(t, files, 2)
+	assert.Nil(t, w.Close())
+
+	files, err = Files(dir, "ticks", Binary)
+	assert.Nil(t, err)
+	assert.Len(t, files, 3)
+	assert.Contains(t, files[1], "ticks-20231201T011500Z-000001.bin.gz")
+
+	r, err := OpenDir(dir, "ticks", Binary, time.Time{})
+	assert.Nil(t, err)
+	assert.Equal(t, ticks, readAll(t, r))
+
+	// the first file ends before from and is skipped
+	r, err = OpenDir(dir, "ticks", Binary, ticks[3].ReceiveTime)
+	assert.Nil(t, err)
+	assert.Equal(t, ticks[3:], readAll(t, r))
+	assert.Len(t, r.files, 0)
+}
+
+type testSource struct {
+	ticks []*Tick
+}
+
+func (s *testSource) Run(ctx context.Context, rec *Recorder) error {
+	for _, t := range s.ticks {
+		if t.Kind == Reconnect {
+			if err := rec.Reconnected(t.Venue); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := rec.Record(t); err != nil {
+			return err
+		}
+	}
+
+	<-ctx.Done()
+	return nil
+}
+
+func TestRecorder(t *testing.T) {
+	dir := t.TempDir()
+	rec, err := NewRecorder(RecorderConfig{
+		Writers: []WriterConfig{
+			{Dir: dir, Prefix: "ticks", Format: JSONL, Compress: true},
+			{Dir: dir, Prefix: "ticks", Format: Binary},
+		},
+		FlushInterval: 10 * time.Millisecond,
+	})
+	assert.Nil(t, err)
+
+	update := func(version int64, snapshot bool, recv int) *Tick {
+		return &Tick{Kind: BookUpdate, Venue: "mexc", Symbol: "BTC_USDT", Version: version, Snapshot: snapshot,
+			ReceiveTime: testStart.Add(time.Duration(recv) * time.Second)}
+	}
+
+	src := &testSource{ticks: []*Tick{
+		update(10, true, 1),
+		update(11, false, 2),
+		{Kind: Reconnect, Venue: "mexc"},
+		// replayed after the reconnect
+		update(11, false, 3),
+		update(12, false, 4),
+		// the venue restarted its versions
+		update(1, true, 5),
+	}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- rec.Run(ctx, src) }()
+
+	assert.Eventually(t, func() bool { return rec.Stats().Recorded == 5 }, time.Second, time.Millisecond)
+	cancel()
+	assert.Nil(t, <-done)
+	assert.Nil(t, rec.Close())
+	assert.Equal(t, Stats{Recorded: 5, Dropped: 1, Reconnects: 1}, rec.Stats())
+
+	for _, format := range []Format{JSONL, Binary} {
+		r, err := OpenDir(dir, "ticks", format, time.Time{})
+		assert.Nil(t, err)
+
+		ticks := readAll(t, r)
+		if !assert.Len(t, ticks, 5) {
+			continue
+		}
+
+		var versions []int64
+		for i, tick := range ticks {
+			assert.Equal(t, uint64(i+1), tick.Seq)
+			versions = append(versions, tick.Version)
+		}
+		assert.Equal(t, []int64{10, 11, 0, 12, 1}, versions)
+		assert.Equal(t, Reconnect, ticks[2].Kind)
+		assert.False(t, ticks[2].ReceiveTime.IsZero())
+		// the receive times are kept even when they go back
+		assert.True(t, ticks[3].ReceiveTime.Equal(testStart.Add(4*time.Second)))
+		assert.True(t, ticks[4].ReceiveTime.Equal(testStart.Add(5*time.Second)))
+	}
+
+	// the ticks of the source are not modified
+	for _, tick := range src.ticks {
+		assert.Zero(t, tick.Seq)
+	}
+	assert.True(t, src.ticks[2].ReceiveTime.IsZero())
+}
+
+func TestCorrupted(t *testing.T) {
+	dir := t.TempDir()
+	w, _ := NewWriter(WriterConfig{Dir: dir, Prefix: "ticks", Format: Binary})
+	for _, tick := range testTicks() {
+		assert.Nil(t, w.Write(tick))
+	}
+	assert.Nil(t, w.Flush())
+
+	// the file being written is readable up to the last flushed tick
+	r, err := Open(w.path + partSuffix)
+	assert.Nil(t, err)
+	assert.Len(t, readAll(t, r), 5)
+
+	_, err = w.file.Write([]byte{10, 1, 2})
+	assert.Nil(t, err)
+
+	r, err = Open(w.path + partSuffix)
+	assert.Nil(t, err)
+	defer r.Close()
+	for i := 0; i < 5; i++ {
+		_, err = r.Next()
+		assert.Nil(t, err)
+	}
+	_, err = r.Next()
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
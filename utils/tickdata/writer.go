@@ -0,0 +1,337 @@
+/*
+ * Copyright (c) 2023, LinstoHu
+ * All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tickdata
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	defaultMaxSize   = 256 << 20
+	defaultBlockSize = 10000
+
+	// partSuffix is the suffix of the file being written, it is removed once the file is closed
+	partSuffix  = ".part"
+	indexSuffix = ".idx"
+	gzipSuffix  = ".gz"
+)
+
+type WriterConfig struct {
+	Dir string
+	// Prefix of the file names, the files are named <prefix>-<first receive time>-<n>.<format>[.gz]
+	Prefix string
+	Format Format
+	// Compress writes gzip files, every block is a separate gzip member
+	Compress bool
+	// MaxSize rotates the file once this many uncompressed bytes are written, the default is 256MB
+	MaxSize int64
+	// Interval rotates the files on the multiples of the interval, e.g. 1h for
+	// hourly files. Zero rotates on the size only
+	Interval time.Duration
+	// BlockSize is the number of ticks of a block, the default is 10000
+	BlockSize int
+}
+
+// An Index describes a closed file, it is written next to the file with the .idx suffix.
+type Index struct {
+	File       string `json:"file"`
+	Format     Format `json:"format"`
+	Compressed bool   `json:"compressed"`
+	Count      int    `json:"count"`
+	FirstSeq   uint64 `json:"firstSeq"`
+	LastSeq    uint64 `json:"lastSeq"`
+	// Start and End are the receive times of the first and the last tick
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Streams []*Stream `json:"streams"`
+	Blocks  []*Block  `json:"blocks"`
+}
+
+// A Stream summarizes the ticks of one kind of a venue symbol.
+type Stream struct {
+	Venue  string `json:"venue"`
+	Symbol string `json:"symbol,omitempty"`
+	Kind   Kind   `json:"kind"`
+	Count  int    `json:"count"`
+	// FirstTime and LastTime are the exchange times, or the receive times when not reported
+	FirstTime time.Time `json:"firstTime"`
+	LastTime  time.Time `json:"lastTime"`
+}
+
+// A Block is a part of a file which can be decoded on its own.
+type Block struct {
+	// Offset in the file, compressed files start a gzip member at every block
+	Offset int64     `json:"offset"`
+	Seq    uint64    `json:"seq"`
+	Start  time.Time `json:"start"`
+	Count  int       `json:"count"`
+}
+
+// A Writer writes ticks to rotating files. It is not safe for concurrent use.
+type Writer struct {
+	cfg WriterConfig
+
+	file  *os.File
+	path  string
+	buf   *bufio.Writer
+	gz    *gzip.Writer
+	out   io.Writer
+	enc   encoder
+	until time.Time
+
+	// written counts the bytes of the file, size the uncompressed bytes
+	written countingWriter
+	size    countingWriter
+
+	index   *Index
+	streams map[streamKey]*Stream
+}
+
+type streamKey struct {
+	venue, symbol string
+	kind          Kind
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func NewWriter(cfg WriterConfig) (*Writer, error) {
+	if cfg.Dir == "" || cfg.Prefix == "" {
+		return nil, errors.New("tickdata: dir and prefix are required")
+	}
+	if _, err := newEncoder(cfg.Format); err != nil {
+		return nil, err
+	}
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = defaultMaxSize
+	}
+	if cfg.BlockSize <= 0 {
+		cfg.BlockSize = defaultBlockSize
+	}
+
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &Writer{cfg: cfg}, nil
+}
+
+// Write appends t to the current file, the file is rotated first when it is
+// full or when t is received past its interval.
+func (w *Writer) Write(t *Tick) error {
+	if w.file != nil && (w.size.n >= w.cfg.MaxSize || !w.until.IsZero() && !t.ReceiveTime.Before(w.until)) {
+		if err := w.closeFile(); err != nil {
+			return err
+		}
+	}
+
+	if w.file == nil {
+		if err := w.open(t.ReceiveTime); err != nil {
+			return err
+		}
+	}
+
+	blocks := w.index.Blocks
+	if len(blocks) == 0 || blocks[len(blocks)-1].Count >= w.cfg.BlockSize {
+		if err := w.startBlock(t); err != nil {
+			return err
+		}
+	}
+
+	if err := w.enc.encode(&w.size, t); err != nil {
+		return err
+	}
+
+	w.add(t)
+
+	return nil
+}
+
+// Flush writes the buffered ticks to the file, they are readable by a reader
+// which does not need the index.
+func (w *Writer) Flush() error {
+	if w.file == nil {
+		return nil
+	}
+	if w.gz != nil {
+		if err := w.gz.Flush(); err != nil {
+			return err
+		}
+	}
+	return w.buf.Flush()
+}
+
+// Close closes the current file and writes its index.
+func (w *Writer) Close() error {
+	if w.file == nil {
+		return nil
+	}
+	return w.closeFile()
+}
+
+func (w *Writer) open(start time.Time) error {
+	ext := "." + string(w.cfg.Format)
+	if w.cfg.Compress {
+		ext += gzipSuffix
+	}
+
+	// the counter only matters for the files started in the same second
+	for n := 1; ; n++ {
+		name := fmt.Sprintf("%s-%s-%06d%s", w.cfg.Prefix, start.UTC().Format("20060102T150405Z"), n, ext)
+		path := filepath.Join(w.cfg.Dir, name)
+
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+
+		f, err := os.OpenFile(path+partSuffix, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if os.IsExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		w.file, w.path = f, path
+		w.index = &Index{File: name, Format: w.cfg.Format, Compressed: w.cfg.Compress}
+		break
+	}
+
+	w.streams = make(map[streamKey]*Stream)
+	w.buf = bufio.NewWriter(w.file)
+	w.written = countingWriter{w: w.buf}
+	w.out = &w.written
+	if w.cfg.Compress {
+		w.gz = gzip.NewWriter(&w.written)
+		w.out = w.gz
+	}
+	w.size = countingWriter{w: w.out}
+	w.enc, _ = newEncoder(w.cfg.Format)
+
+	w.until = time.Time{}
+	if w.cfg.Interval > 0 {
+		w.until = start.Truncate(w.cfg.Interval).Add(w.cfg.Interval)
+	}
+
+	return w.enc.header(&w.size)
+}
+
+func (w *Writer) startBlock(t *Tick) error {
+	var offset int64
+	if len(w.index.Blocks) > 0 {
+		if w.gz != nil {
+			if err := w.gz.Close(); err != nil {
+				return err
+			}
+			w.gz.Reset(&w.written)
+		}
+		offset = w.written.n
+	}
+
+	w.index.Blocks = append(w.index.Blocks, &Block{Offset: offset, Seq: t.Seq, Start: t.ReceiveTime})
+
+	return w.enc.reset(&w.size)
+}
+
+func (w *Writer) add(t *Tick) {
+	idx := w.index
+	if idx.Count == 0 {
+		idx.FirstSeq, idx.Start = t.Seq, t.ReceiveTime
+	}
+	idx.Count++
+	idx.LastSeq, idx.End = t.Seq, t.ReceiveTime
+	idx.Blocks[len(idx.Blocks)-1].Count++
+
+	key := streamKey{venue: t.Venue, symbol: t.Symbol, kind: t.Kind}
+	s, ok := w.streams[key]
+	if !ok {
+		s = &Stream{Venue: t.Venue, Symbol: t.Symbol, Kind: t.Kind, FirstTime: t.Time()}
+		w.streams[key] = s
+		idx.Streams = append(idx.Streams, s)
+	}
+	s.Count++
+	s.LastTime = t.Time()
+}
+
+func (w *Writer) closeFile() error {
+	f := w.file
+	w.file = nil
+
+	err := func() error {
+		if w.gz != nil {
+			if err := w.gz.Close(); err != nil {
+				return err
+			}
+			w.gz = nil
+		}
+		if err := w.buf.Flush(); err != nil {
+			return err
+		}
+		return f.Sync()
+	}()
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := writeIndex(w.path+indexSuffix, w.index); err != nil {
+		return err
+	}
+
+	return os.Rename(w.path+partSuffix, w.path)
+}
+
+func writeIndex(path string, idx *Index) error {
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// ReadIndex reads the index of a closed file.
+func ReadIndex(path string) (*Index, error) {
+	data, err := os.ReadFile(path + indexSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	var idx Index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, err
+	}
+	return &idx, nil
+}